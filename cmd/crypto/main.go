@@ -7,11 +7,18 @@ import (
 
 	"simple-securities/config"
 	crypto "simple-securities/gen/crypto/v1"
+	"simple-securities/internal/crypto/application/algo"
 	"simple-securities/internal/crypto/application/service"
+	"simple-securities/internal/crypto/domain/model"
 	grpcHandler "simple-securities/internal/crypto/handler/grpc"
+	"simple-securities/internal/crypto/infras/feed"
+	"simple-securities/internal/crypto/infras/gateway"
+	"simple-securities/internal/crypto/infras/repo"
 	"simple-securities/internal/crypto/middleware"
 	"simple-securities/pkg/conv"
+	"simple-securities/pkg/db/cache"
 	"simple-securities/pkg/db/sqlite"
+	"simple-securities/pkg/inbox"
 	"simple-securities/pkg/kafka"
 	"simple-securities/pkg/logger"
 	"simple-securities/pkg/server"
@@ -34,45 +41,146 @@ func main() {
 		zap.String("port", conv.ConvertUInt32ToString(config.GlobalConfig.GrpcServer.Port)),
 		zap.String("env", string(config.GlobalConfig.Env)))
 
-	db, err := sqlite.NewSQLiteClient()
+	db, err := sqlite.NewSQLiteClientWithDSN(config.GlobalConfig.SQLite.DSN)
 	if err != nil {
 		log.Fatalf("Failed to connect to SQLite: %v", err)
 	}
 	defer db.Close(ctx)
+	db.MigrateFiles(
+		"migrations/sqlite/000002_init_algo_orders.up.sql",
+		"migrations/sqlite/000007_init_inbox.up.sql",
+		"migrations/sqlite/000015_add_algo_child_order_fees.up.sql",
+	)
 
-	// Kafka brokers
-	brokers := []string{"localhost:9092", "localhost:9093", "localhost:9094"}
+	rdb, err := cache.NewRedisClient(cache.DefaultRedisConfig())
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer rdb.Close()
+
+	// Kafka brokers, the declared topics are created or checked before use
+	kafkaConfig := config.GlobalConfig.Kafka
+	brokers := kafkaConfig.Brokers
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(ctx, 30*time.Second)
+	if err := kafka.NewAdmin(brokers, logger.Logger).EnsureTopics(bootstrapCtx, kafkaConfig.Topics...); err != nil {
+		logger.Logger.Warn("kafka topics are not as declared", zap.Error(err))
+	}
+	cancelBootstrap()
 
 	// Create Kafka Manager
-	mgr := kafka.NewManager(brokers, logger.Logger)
+	producerConfig := config.GlobalConfig.Producer
+	producer, err := kafka.NewProducerWithConfig(brokers, kafka.ProducerConfig{
+		Async:       producerConfig.Async,
+		BatchSize:   producerConfig.BatchSize,
+		BatchBytes:  producerConfig.BatchBytes,
+		Linger:      config.GetDuration(producerConfig.Linger),
+		Compression: producerConfig.Compression,
+		Acks:        producerConfig.Acks,
+		Idempotent:  producerConfig.Idempotent,
+		MaxAttempts: producerConfig.MaxAttempts,
+	}, logger.Logger)
+	if err != nil {
+		log.Fatalf("Failed to create Kafka producer: %v", err)
+	}
+	mgr := kafka.NewManagerWithProducer(brokers, producer, logger.Logger)
 	defer mgr.Close()
 
+	// Failed messages go through the retry topics to the dead-letter topic, the inbox skips redeliveries
+	inboxStore := inbox.NewStore(db.DB)
+	go purgeInbox(ctx, inboxStore, config.GetDuration(config.GlobalConfig.Consumer.DedupRetention))
+	consumerOptions := kafka.ConsumerOptions{
+		Retry: kafka.RetryPolicy{
+			Attempts:   config.GlobalConfig.Consumer.RetryAttempts,
+			Backoff:    config.GetDuration(config.GlobalConfig.Consumer.RetryBackoff),
+			MaxBackoff: config.GetDuration(config.GlobalConfig.Consumer.MaxBackoff),
+		},
+		Dedup:          inboxStore,
+		Workers:        config.GlobalConfig.Consumer.Workers,
+		CommitBatch:    config.GlobalConfig.Consumer.CommitBatch,
+		CommitInterval: config.GetDuration(config.GlobalConfig.Consumer.CommitInterval),
+		DrainTimeout:   config.GetDuration(config.GlobalConfig.Consumer.DrainTimeout),
+	}
+
 	// Register consumers
-	if err := mgr.AddConsumer("metrics", "group-metrics", func(ctx context.Context, key, value []byte) error {
+	if err := mgr.AddConsumerWithOptions("metrics", "group-metrics", func(ctx context.Context, msg *kafka.Message) error {
 		logger.Logger.Warn("🧹 consumer metrics",
-			zap.String("key", string(key)),
-			// zap.String("value", string(value)),
+			zap.String("key", string(msg.Key)),
+			zap.String("event_type", msg.EventType()),
+			zap.String("request_id", kafka.RequestIDFrom(ctx)),
+			// zap.String("value", string(msg.Value)),
 		)
 		return nil
-	}); err != nil {
+	}, consumerOptions); err != nil {
 		logger.Logger.Error("failed to add metrics consumer", zap.Error(err))
 	}
 
-	if err := mgr.AddConsumer("audit", "group-audit", func(ctx context.Context, key, value []byte) error {
+	if err := mgr.AddConsumerWithOptions("audit", "group-audit", func(ctx context.Context, msg *kafka.Message) error {
 		logger.Logger.Warn("🧹 consumer audit",
-			zap.String("key", string(key)),
-			// zap.String("value", string(value)),
+			zap.String("key", string(msg.Key)),
+			zap.String("event_type", msg.EventType()),
+			zap.String("request_id", kafka.RequestIDFrom(ctx)),
+			// zap.String("value", string(msg.Value)),
 		)
 		return nil
-	}); err != nil {
+	}, consumerOptions); err != nil {
 		logger.Logger.Error("failed to add audit consumer", zap.Error(err))
 	}
 
 	mgr.StartAllConsumers(ctx)
 
+	// Live prices published by cmd/worker
+	priceFeed := feed.NewRedisPriceFeed(rdb.Client, logger.Logger)
+	go func() {
+		if err := priceFeed.Start(ctx); err != nil {
+			logger.Logger.Error("price feed stopped with error", zap.Error(err))
+		}
+	}()
+
 	getServerTimeSvc := service.NewGetServerTimeSvc()
 	getKlinesSvc := service.NewGetKlinesSvc()
-	cryptoHandler := grpcHandler.NewCryptoGrpcHandler(getKlinesSvc, getServerTimeSvc)
+
+	algoOrderRepo := repo.NewAlgoOrderRepo(db.DB)
+	orderGateway := gateway.NewPaperOrderGateway(priceFeed)
+	if fees := config.GlobalConfig.Fees; fees != nil {
+		if err := fees.Validate(); err != nil {
+			log.Fatalf("Failed to load fee schedule: %v", err)
+		}
+		logger.Logger.Info("💸 fee schedule loaded", zap.String("schedule", fees.Name), zap.Int("tiers", len(fees.Tiers)))
+	}
+	scheduler := algo.NewScheduler(
+		algo.SchedulerConfig{
+			ServiceName:  config.GlobalConfig.App.Name,
+			Topic:        config.GlobalConfig.Algo.Topic,
+			TickInterval: config.GetDuration(config.GlobalConfig.Algo.TickInterval),
+			Fees:         config.GlobalConfig.Fees,
+		},
+		algoOrderRepo,
+		orderGateway,
+		map[model.AlgoType]algo.Strategy{
+			model.AlgoTypeOCO:          algo.NewOCOStrategy(priceFeed),
+			model.AlgoTypeTrailingStop: algo.NewTrailingStopStrategy(priceFeed),
+			model.AlgoTypeTWAP:         algo.NewTWAPStrategy(),
+			model.AlgoTypeVWAP:         algo.NewVWAPStrategy(getKlinesSvc),
+		},
+		mgr,
+		logger.Logger,
+	)
+	go func() {
+		if err := scheduler.Start(ctx); err != nil {
+			logger.Logger.Error("algo scheduler stopped with error", zap.Error(err))
+		}
+	}()
+
+	placeAlgoOrderSvc := service.NewPlaceAlgoOrderSvc(scheduler)
+	getAlgoOrderSvc := service.NewGetAlgoOrderSvc(algoOrderRepo)
+	cancelAlgoOrderSvc := service.NewCancelAlgoOrderSvc(scheduler)
+	cryptoHandler := grpcHandler.NewCryptoGrpcHandler(
+		getKlinesSvc,
+		getServerTimeSvc,
+		placeAlgoOrderSvc,
+		getAlgoOrderSvc,
+		cancelAlgoOrderSvc,
+	)
 
 	// Create the gRPC server
 	grpcServer, err := grpc.NewGrpcServer(
@@ -106,3 +214,26 @@ func main() {
 	// Add shutdown hook to trigger closer resources of service
 	server.AddShutdownHook(grpcServer, db.DB)
 }
+
+// purgeInbox forgets the processed message ids past the retention period once an hour
+func purgeInbox(ctx context.Context, store *inbox.Store, retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := store.Purge(ctx, time.Now().Add(-retention))
+			if err != nil {
+				logger.Logger.Error("failed to purge inbox", zap.Error(err))
+				continue
+			}
+			logger.Logger.Debug("purged inbox", zap.Int64("count", purged))
+		}
+	}
+}
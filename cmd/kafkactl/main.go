@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"simple-securities/config"
+	"simple-securities/pkg/kafka"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const usage = `Usage: kafkactl [-config <service>] [-brokers <host:port,...>] <command>
+
+Commands:
+  topics list                          list the topics with their partitions and configs
+  topics create                        create the topics declared in the service config
+  topics create <topic> [flags]        create a topic, see kafkactl topics create -h
+  groups lag <group> <topic>           show the lag of a consumer group
+  groups reset <group> <topic> -to <earliest|latest|RFC3339 time>
+                                       move an inactive consumer group
+
+Flags:
+`
+
+func main() {
+	flags := flag.NewFlagSet("kafkactl", flag.ExitOnError)
+	service := flags.String("config", "crypto", "service whose config lists the brokers and topics")
+	brokerList := flags.String("brokers", "", "comma separated brokers, overriding the config")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout of the command")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	args := flags.Args()
+	if len(args) < 2 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	var kafkaConfig config.KafkaConfig
+	if conf, err := config.Load("./config", *service); err == nil && conf.Kafka != nil {
+		kafkaConfig = *conf.Kafka
+	} else if *brokerList == "" {
+		fail(fmt.Errorf("no kafka config for %s, set -brokers: %v", *service, err))
+	}
+	if *brokerList != "" {
+		kafkaConfig.Brokers = strings.Split(*brokerList, ",")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	admin := kafka.NewAdmin(kafkaConfig.Brokers, zap.NewNop())
+
+	var err error
+	switch args[0] + " " + args[1] {
+	case "topics list":
+		err = listTopics(ctx, admin)
+	case "topics create":
+		err = createTopics(ctx, admin, kafkaConfig.Topics, args[2:])
+	case "groups lag":
+		err = showLag(ctx, admin, args[2:])
+	case "groups reset":
+		err = resetOffsets(ctx, admin, args[2:])
+	default:
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "kafkactl:", err)
+	os.Exit(1)
+}
+
+func listTopics(ctx context.Context, admin *kafka.Admin) error {
+	topics, err := admin.Topics(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "TOPIC\tPARTITIONS\tREPLICATION\tRETENTION\tCLEANUP")
+	for _, t := range topics {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", t.Name, t.Partitions, t.ReplicationFactor,
+			retention(t.Configs["retention.ms"]), t.Configs["cleanup.policy"])
+	}
+	return w.Flush()
+}
+
+func retention(ms string) string {
+	if ms == "-1" {
+		return "forever"
+	}
+	d, err := time.ParseDuration(ms + "ms")
+	if err != nil {
+		return ms
+	}
+	return d.String()
+}
+
+func createTopics(ctx context.Context, admin *kafka.Admin, declared []kafka.TopicSpec, args []string) error {
+	specs := declared
+	if len(args) > 0 {
+		spec := kafka.TopicSpec{Name: args[0]}
+		flags := flag.NewFlagSet("topics create", flag.ExitOnError)
+		flags.IntVar(&spec.Partitions, "partitions", 0, "number of partitions, the broker default when unset")
+		flags.IntVar(&spec.ReplicationFactor, "replication", 0, "replication factor, the broker default when unset")
+		flags.DurationVar(&spec.Retention, "retention", 0, "retention, negative to keep messages forever")
+		flags.BoolVar(&spec.Compact, "compact", false, "compact the topic by key")
+		flags.IntVar(&spec.RetryTopics, "retry-topics", 0, "also create this many retry topics and the dead-letter topic")
+		_ = flags.Parse(args[1:])
+		specs = []kafka.TopicSpec{spec}
+	}
+	if len(specs) == 0 {
+		return errors.New("no topic to create")
+	}
+
+	created, err := admin.CreateTopics(ctx, specs...)
+	for _, name := range created {
+		fmt.Println("created", name)
+	}
+	if err != nil {
+		return err
+	}
+	if len(created) == 0 {
+		fmt.Println("all topics exist")
+	}
+	return nil
+}
+
+func showLag(ctx context.Context, admin *kafka.Admin, args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: kafkactl groups lag <group> <topic>")
+	}
+	lag, err := admin.Lag(ctx, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PARTITION\tCOMMITTED\tEND\tLAG")
+	for _, p := range lag.Partitions {
+		committed := fmt.Sprint(p.Committed)
+		if p.Committed < 0 {
+			committed = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\n", p.Partition, committed, p.End, p.Lag)
+	}
+	fmt.Fprintf(w, "total\t\t\t%d\n", lag.Total)
+	return w.Flush()
+}
+
+func resetOffsets(ctx context.Context, admin *kafka.Admin, args []string) error {
+	if len(args) < 2 {
+		return errors.New("usage: kafkactl groups reset <group> <topic> -to <earliest|latest|time>")
+	}
+	flags := flag.NewFlagSet("groups reset", flag.ExitOnError)
+	to := flags.String("to", "", "earliest, latest or an RFC3339 time")
+	_ = flags.Parse(args[2:])
+
+	var timestamp int64
+	switch *to {
+	case "earliest":
+		timestamp = kafkago.FirstOffset
+	case "latest":
+		timestamp = kafkago.LastOffset
+	case "":
+		return errors.New("-to is required")
+	default:
+		at, err := time.Parse(time.RFC3339, *to)
+		if err != nil {
+			return fmt.Errorf("invalid -to %q: %w", *to, err)
+		}
+		timestamp = at.UnixMilli()
+	}
+
+	offsets, err := admin.ResetOffsets(ctx, args[0], args[1], timestamp)
+	if err != nil {
+		return err
+	}
+	partitions := make([]int, 0, len(offsets))
+	for p := range offsets {
+		partitions = append(partitions, p)
+	}
+	sort.Ints(partitions)
+	for _, p := range partitions {
+		fmt.Printf("%s/%d -> %d\n", args[1], p, offsets[p])
+	}
+	return nil
+}
@@ -7,15 +7,27 @@ import (
 
 	"simple-securities/config"
 	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/notification/application/broadcast"
+	"simple-securities/internal/notification/application/delivery"
+	"simple-securities/internal/notification/application/digest"
 	"simple-securities/internal/notification/application/service"
+	"simple-securities/internal/notification/application/templates"
+	"simple-securities/internal/notification/domain/model"
+	domainRepo "simple-securities/internal/notification/domain/repo"
 	grpcHandler "simple-securities/internal/notification/handler/grpc"
+	"simple-securities/internal/notification/infras/channel"
+	"simple-securities/internal/notification/infras/client"
 	"simple-securities/internal/notification/infras/repo"
 	"simple-securities/internal/notification/middleware"
+	"simple-securities/pkg/cdc"
 	"simple-securities/pkg/conv"
 	"simple-securities/pkg/db/cache"
 	"simple-securities/pkg/db/sqlite"
+	"simple-securities/pkg/inbox"
 	"simple-securities/pkg/kafka"
+	"simple-securities/pkg/kafka/schema"
 	"simple-securities/pkg/logger"
+	"simple-securities/pkg/outbox"
 	"simple-securities/pkg/server"
 	"simple-securities/pkg/server/grpc"
 	"simple-securities/pkg/uuid"
@@ -37,13 +49,24 @@ func main() {
 		zap.String("port", conv.ConvertUInt32ToString(config.GlobalConfig.GrpcServer.Port)),
 		zap.String("env", string(config.GlobalConfig.Env)))
 
-	// Kafka brokers
-	brokers := []string{"localhost:9092"}
+	// Kafka brokers, the declared topics are created or checked before use
+	kafkaConfig := config.GlobalConfig.Kafka
+	brokers := kafkaConfig.Brokers
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(ctx, 30*time.Second)
+	if err := kafka.NewAdmin(brokers, logger.Logger).EnsureTopics(bootstrapCtx, kafkaConfig.Topics...); err != nil {
+		logger.Logger.Warn("kafka topics are not as declared", zap.Error(err))
+	}
+	cancelBootstrap()
 
 	// Create Kafka Manager
 	mgr := kafka.NewManager(brokers, logger.Logger)
 	defer mgr.Close()
 
+	// The notification events are produced and consumed by this service only, so the schemas
+	// of their typed values are kept in a registry of the process
+	jsonSerializer := schema.NewJSONSerializer()
+	mgr.SetCodec(kafka.NewCodec(schema.NewMemoryRegistry(schema.CompatibilityBackward, jsonSerializer), jsonSerializer))
+
 	// -----------------------------
 	// Producer loop
 	// -----------------------------
@@ -79,12 +102,13 @@ func main() {
 		}
 	}()
 
-	db, err := sqlite.NewSQLiteClient()
+	// Digests and deliveries in progress survive restarts in a database file, without one the
+	// service runs on a seeded in-memory database
+	db, err := newSQLiteClient(config.GlobalConfig.SQLite)
 	if err != nil {
 		log.Fatalf("Failed to connect to SQLite: %v", err)
 	}
 	defer db.Close(ctx)
-	db.AutoMigrate()
 
 	rdb, err := cache.NewRedisClient(cache.DefaultRedisConfig())
 	if err != nil {
@@ -92,12 +116,195 @@ func main() {
 	}
 	defer rdb.Close()
 
-	notiRepo := repo.NewNotificationRepo(db.DB)
-	notiCacheRepo := repo.NewNotificationCacheRepo(rdb.Client)
-	sendNotiSvc := service.NewSendNotiSvc(notiRepo, notiCacheRepo)
+	// Notification events are written to the outbox with the notification, the relay publishes
+	// them as typed values read by the delivery dispatcher
+	notiRepo := repo.NewNotificationRepo(db.DB, repo.OutboxConfig{
+		ServiceName: config.GlobalConfig.App.Name,
+		Topic:       config.GlobalConfig.Events.Topic,
+	})
+	eventPublisher := outbox.NewTypedPublisher[model.NotificationEvent](mgr, config.GlobalConfig.Events.Topic)
+	relay := outbox.NewRelay(db.DB, eventPublisher, outbox.RelayConfig{
+		Interval:      config.GetDuration(config.GlobalConfig.Outbox.Interval),
+		BatchSize:     config.GlobalConfig.Outbox.BatchSize,
+		Retention:     config.GetDuration(config.GlobalConfig.Outbox.Retention),
+		StatsInterval: config.GetDuration(config.GlobalConfig.Outbox.StatsInterval),
+	}, logger.Logger)
+	go func() {
+		if err := relay.Start(ctx); err != nil {
+			logger.Logger.Error("outbox relay stopped with error", zap.Error(err))
+		}
+	}()
+
+	// Row changes of the tracked tables are captured by triggers and published by the tailer
+	if cdcConfig := config.GlobalConfig.CDC; cdcConfig != nil && cdcConfig.Enabled {
+		if err := cdc.InstallTriggers(ctx, db.DB, cdcConfig.Tables...); err != nil {
+			log.Fatalf("Failed to install CDC triggers: %v", err)
+		}
+		tailer, err := cdc.NewTailer(db.DB, mgr, cdc.TailerConfig{
+			Name:        config.GlobalConfig.App.Name,
+			TopicPrefix: cdcConfig.TopicPrefix,
+			Interval:    config.GetDuration(cdcConfig.Interval),
+			BatchSize:   cdcConfig.BatchSize,
+			Retention:   config.GetDuration(cdcConfig.Retention),
+		}, logger.Logger)
+		if err != nil {
+			log.Fatalf("Failed to create CDC tailer: %v", err)
+		}
+		go func() {
+			if err := tailer.Start(ctx); err != nil {
+				logger.Logger.Error("cdc tailer stopped with error", zap.Error(err))
+			}
+		}()
+	}
+
+	// Reads go through the cache, writes invalidate it
+	var cacheConfig repo.CacheConfig
+	if cacheConf := config.GlobalConfig.Cache; cacheConf != nil {
+		cacheConfig = repo.CacheConfig{
+			TTL:         config.GetDuration(cacheConf.TTL),
+			NegativeTTL: config.GetDuration(cacheConf.NegativeTTL),
+			PageTTL:     config.GetDuration(cacheConf.PageTTL),
+		}
+	}
+	notiCacheRepo := repo.NewNotificationCacheRepo(rdb.Client, cacheConfig)
+	templateRegistry, err := newTemplateRegistry(config.GlobalConfig.Templates)
+	if err != nil {
+		log.Fatalf("Failed to load notification templates: %v", err)
+	}
+	userChannelRepo := repo.NewUserChannelRepo(db.DB)
+	deliveryRepo := repo.NewDeliveryRepo(db.DB)
+	preferencesRepo := repo.NewPreferencesRepo(db.DB)
+
+	// Low-priority notifications wait in the digests of the users, the scheduler emits them
+	digestRepo := repo.NewDigestRepo(db.DB, repo.OutboxConfig{
+		ServiceName: config.GlobalConfig.App.Name,
+		Topic:       config.GlobalConfig.Events.Topic,
+	})
+	var digestScheduler *digest.Scheduler
+	if digestConfig := config.GlobalConfig.Digest; digestConfig != nil && digestConfig.Enabled {
+		digestScheduler = digest.NewScheduler(digestRepo, preferencesRepo, notiCacheRepo, digest.Config{
+			Types:     digestConfig.Types,
+			Interval:  config.GetDuration(digestConfig.Interval),
+			BatchSize: digestConfig.BatchSize,
+			ItemLink:  digestConfig.ItemLink,
+			MaxItems:  digestConfig.MaxItems,
+		}, logger.Logger)
+		go func() {
+			if err := digestScheduler.Start(ctx); err != nil {
+				logger.Logger.Error("digest scheduler stopped with error", zap.Error(err))
+			}
+		}()
+	}
+	sendNotiSvc := service.NewSendNotiSvc(notiRepo, notiCacheRepo, templateRegistry, digestScheduler)
+
+	// Broadcast jobs fan out from the database, the holders of a symbol come from the stock service
+	var stockClient domainRepo.IStockClient
+	if clientsConfig := config.GlobalConfig.Clients; clientsConfig != nil && clientsConfig.Stock != "" {
+		c, err := client.NewStockClient(clientsConfig.Stock)
+		if err != nil {
+			log.Fatalf("Failed to create stock client: %v", err)
+		}
+		defer c.Close()
+		stockClient = c
+	}
+	broadcastRepo := repo.NewBroadcastRepo(db.DB)
+	if broadcastConfig := config.GlobalConfig.Broadcast; broadcastConfig != nil && broadcastConfig.Enabled {
+		runner := broadcast.NewRunner(broadcastRepo, stockClient, sendNotiSvc, broadcast.Config{
+			Interval:  config.GetDuration(broadcastConfig.Interval),
+			BatchSize: broadcastConfig.BatchSize,
+			Rate:      broadcastConfig.Rate,
+			Lease:     config.GetDuration(broadcastConfig.Lease),
+		}, logger.Logger)
+		go func() {
+			if err := runner.Start(ctx); err != nil {
+				logger.Logger.Error("broadcast runner stopped with error", zap.Error(err))
+			}
+		}()
+	}
 	getNotiSvc := service.NewGetNotiSvc(notiRepo, notiCacheRepo)
 	getNotiByUserIdSvc := service.NewGetNotiByUserIdSvc(notiRepo, notiCacheRepo)
-	notiHandler := grpcHandler.NewNotificationGrpcHandler(sendNotiSvc, getNotiSvc, getNotiByUserIdSvc)
+	markNotiReadSvc := service.NewMarkNotiReadSvc(notiRepo, notiCacheRepo)
+	markNotiViewedSvc := service.NewMarkNotiViewedSvc(notiRepo, notiCacheRepo)
+	markAllNotiReadSvc := service.NewMarkAllNotiReadSvc(notiRepo, notiCacheRepo)
+	getUnreadNotiCountSvc := service.NewGetUnreadNotiCountSvc(notiRepo)
+	deleteNotiSvc := service.NewDeleteNotiSvc(notiRepo, notiCacheRepo)
+
+	// Notifications are delivered to the channels of their user from the notification events
+	if deliveryConfig := config.GlobalConfig.Delivery; deliveryConfig != nil && deliveryConfig.Enabled {
+		dispatcher := delivery.NewDispatcher(
+			notiRepo,
+			userChannelRepo,
+			deliveryRepo,
+			preferencesRepo,
+			newChannels(deliveryConfig),
+			delivery.Config{
+				CriticalTypes:   deliveryConfig.CriticalTypes,
+				ResumeInterval:  config.GetDuration(deliveryConfig.ResumeInterval),
+				ResumeBatchSize: deliveryConfig.ResumeBatchSize,
+			},
+			logger.Logger,
+		)
+		// The deliveries deferred by the quiet hours of their users are resumed once due
+		go func() {
+			if err := dispatcher.Start(ctx); err != nil {
+				logger.Logger.Error("delivery resumer stopped with error", zap.Error(err))
+			}
+		}()
+		// Failed events go through the retry topics, the inbox skips the events delivered again
+		// and the delivery rows keep a retry from sending the channels already delivered
+		inboxStore := inbox.NewStore(db.DB)
+		go purgeInbox(ctx, inboxStore, config.GetDuration(config.GlobalConfig.Consumer.DedupRetention))
+		consumerOptions := kafka.ConsumerOptions{
+			Retry: kafka.RetryPolicy{
+				Attempts:   config.GlobalConfig.Consumer.RetryAttempts,
+				Backoff:    config.GetDuration(config.GlobalConfig.Consumer.RetryBackoff),
+				MaxBackoff: config.GetDuration(config.GlobalConfig.Consumer.MaxBackoff),
+			},
+			Dedup:          inboxStore,
+			Workers:        config.GlobalConfig.Consumer.Workers,
+			CommitBatch:    config.GlobalConfig.Consumer.CommitBatch,
+			CommitInterval: config.GetDuration(config.GlobalConfig.Consumer.CommitInterval),
+			DrainTimeout:   config.GetDuration(config.GlobalConfig.Consumer.DrainTimeout),
+		}
+		if err := kafka.Subscribe(mgr, config.GlobalConfig.Events.Topic, deliveryConfig.Group, dispatcher.Handle, consumerOptions); err != nil {
+			logger.Logger.Error("failed to add delivery consumer", zap.Error(err))
+		}
+		mgr.StartAllConsumers(ctx)
+	}
+	setChannelSvc := service.NewSetNotiChannelSvc(userChannelRepo)
+	getChannelsSvc := service.NewGetNotiChannelsSvc(userChannelRepo)
+	getDeliveriesSvc := service.NewGetNotiDeliveriesSvc(notiRepo, deliveryRepo)
+	getPreferencesSvc := service.NewGetNotiPreferencesSvc(preferencesRepo)
+	updatePreferencesSvc := service.NewUpdateNotiPreferencesSvc(preferencesRepo)
+	getTemplatesSvc := service.NewGetNotiTemplatesSvc(templateRegistry)
+	previewTemplateSvc := service.NewPreviewNotiTemplateSvc(templateRegistry)
+	validateTemplateSvc := service.NewValidateNotiTemplateSvc(templateRegistry)
+	getDigestSvc := service.NewGetNotiDigestSvc(digestRepo)
+	broadcastSvc := service.NewBroadcastNotiSvc(broadcastRepo, stockClient, templateRegistry)
+	getBroadcastJobSvc := service.NewGetBroadcastJobSvc(broadcastRepo)
+	cancelBroadcastSvc := service.NewCancelBroadcastJobSvc(broadcastRepo)
+	notiHandler := grpcHandler.NewNotificationGrpcHandler(
+		sendNotiSvc,
+		getNotiSvc,
+		getNotiByUserIdSvc,
+		markNotiReadSvc,
+		markNotiViewedSvc,
+		markAllNotiReadSvc,
+		getUnreadNotiCountSvc,
+		deleteNotiSvc,
+		setChannelSvc,
+		getChannelsSvc,
+		getDeliveriesSvc,
+		getPreferencesSvc,
+		updatePreferencesSvc,
+		getTemplatesSvc,
+		previewTemplateSvc,
+		validateTemplateSvc,
+		getDigestSvc,
+		broadcastSvc,
+		getBroadcastJobSvc,
+		cancelBroadcastSvc,
+	)
 
 	// Create the gRPC server
 	grpcServer, err := grpc.NewGrpcServer(
@@ -131,3 +338,118 @@ func main() {
 	// Add shutdown hook to trigger closer resources of service
 	server.AddShutdownHook(grpcServer, db.DB)
 }
+
+// newChannels creates the channel adapters, webhooks and Discord need no setup while email and
+// push are left out until configured
+func newChannels(deliveryConfig *config.DeliveryConfig) []delivery.Channel {
+	channels := []delivery.Channel{
+		channel.NewWebhookChannel(channel.WebhookConfig{
+			Secret:  deliveryConfig.Webhook.Secret,
+			Timeout: config.GetDuration(deliveryConfig.Webhook.Timeout),
+		}),
+		channel.NewDiscordChannel(channel.DiscordConfig{
+			UserName:  deliveryConfig.Discord.UserName,
+			AvatarUrl: deliveryConfig.Discord.AvatarUrl,
+		}),
+	}
+	if emailConfig := deliveryConfig.Email; emailConfig != nil && emailConfig.Host != "" {
+		email, err := channel.NewEmailChannel(channel.EmailConfig{
+			Host:     emailConfig.Host,
+			Port:     emailConfig.Port,
+			Username: emailConfig.Username,
+			Password: emailConfig.Password,
+			From:     emailConfig.From,
+		})
+		if err != nil {
+			log.Fatalf("Failed to create email channel: %v", err)
+		}
+		channels = append(channels, email)
+	}
+	if pushConfig := deliveryConfig.Push; pushConfig != nil && pushConfig.VAPIDPrivateKey != "" {
+		push, err := channel.NewPushChannel(channel.PushConfig{
+			VAPIDPrivateKey: pushConfig.VAPIDPrivateKey,
+			Subject:         pushConfig.Subject,
+			TTL:             config.GetDuration(pushConfig.TTL),
+			Timeout:         config.GetDuration(pushConfig.Timeout),
+		})
+		if err != nil {
+			log.Fatalf("Failed to create push channel: %v", err)
+		}
+		channels = append(channels, push)
+	}
+	return channels
+}
+
+// purgeInbox forgets the processed message ids past the retention period once an hour
+func purgeInbox(ctx context.Context, store *inbox.Store, retention time.Duration) {
+	if retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := store.Purge(ctx, time.Now().Add(-retention))
+			if err != nil {
+				logger.Logger.Error("failed to purge inbox", zap.Error(err))
+				continue
+			}
+			logger.Logger.Debug("purged inbox", zap.Int64("count", purged))
+		}
+	}
+}
+
+// newSQLiteClient opens and migrates the database file of the DSN, or a seeded in-memory
+// database when none is configured
+func newSQLiteClient(sqliteConfig *config.SQLiteConfig) (*sqlite.SQLiteClient, error) {
+	if sqliteConfig == nil || sqliteConfig.DSN == "" {
+		db, err := sqlite.NewSQLiteClient()
+		if err != nil {
+			return nil, err
+		}
+		db.AutoMigrate()
+		return db, nil
+	}
+	db, err := sqlite.NewSQLiteClientWithDSN(sqliteConfig.DSN)
+	if err != nil {
+		return nil, err
+	}
+	db.Migrate()
+	return db, nil
+}
+
+// newTemplateRegistry compiles the configured templates, English is the default locale when
+// none is configured
+func newTemplateRegistry(templatesConfig *config.TemplatesConfig) (*templates.Registry, error) {
+	if templatesConfig == nil {
+		return templates.NewRegistry("en")
+	}
+	defaultLocale := templatesConfig.DefaultLocale
+	if defaultLocale == "" {
+		defaultLocale = "en"
+	}
+	definitions := make([]*model.Template, 0, len(templatesConfig.Definitions))
+	for _, d := range templatesConfig.Definitions {
+		t := &model.Template{
+			ID:      d.ID,
+			Format:  model.TemplateFormat(d.Format),
+			Locales: make(map[string]model.TemplateContent, len(d.Locales)),
+		}
+		for _, v := range d.Variables {
+			t.Variables = append(t.Variables, model.TemplateVariable{
+				Name:     v.Name,
+				Type:     model.VariableType(v.Type),
+				Required: v.Required,
+			})
+		}
+		for locale, content := range d.Locales {
+			t.Locales[locale] = model.TemplateContent{Title: content.Title, Body: content.Body}
+		}
+		definitions = append(definitions, t)
+	}
+	return templates.NewRegistry(defaultLocale, definitions...)
+}
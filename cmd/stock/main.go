@@ -2,44 +2,369 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
-	"net"
 	"os"
+	"time"
 
+	"simple-securities/config"
 	stock "simple-securities/gen/stock/v1"
+	"simple-securities/internal/stock/application/corporate"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/application/market"
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/application/service"
+	"simple-securities/internal/stock/application/statement"
+	grpcHandler "simple-securities/internal/stock/handler/grpc"
+	"simple-securities/internal/stock/infras/client"
+	"simple-securities/internal/stock/infras/repo"
+	"simple-securities/internal/stock/middleware"
+	"simple-securities/pkg/calendar"
+	"simple-securities/pkg/cdc"
+	"simple-securities/pkg/conv"
+	"simple-securities/pkg/db/sqlite"
+	"simple-securities/pkg/eventstore"
+	"simple-securities/pkg/kafka"
+	"simple-securities/pkg/logger"
+	"simple-securities/pkg/server"
+	"simple-securities/pkg/server/grpc"
 
-	"google.golang.org/grpc"
+	"go.uber.org/zap"
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
-type StockService struct {
-	stock.UnimplementedStockServiceServer
-}
+func main() {
+	ctx := context.Background()
 
-func NewStockService() *StockService {
-	return &StockService{}
-}
+	config.Init("./config", "stock")
 
-// Ping implements the StockService.Ping RPC
-func (s *StockService) Ping(ctx context.Context, req *stock.PingRequest) (*stock.PingResponse, error) {
-	return &stock.PingResponse{Message: "pong 🏓 from Stock Service"}, nil
-}
+	logger.Init()
+	logger.Logger.Info("🚀 Application starting",
+		zap.String("service", config.GlobalConfig.App.Name),
+		zap.String("version", config.GlobalConfig.App.Version),
+		zap.String("port", conv.ConvertUInt32ToString(config.GlobalConfig.GrpcServer.Port)),
+		zap.String("env", string(config.GlobalConfig.Env)))
 
-func main() {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "50054" // default port for stock-service
+	db, err := sqlite.NewSQLiteClientWithDSN(config.GlobalConfig.SQLite.DSN)
+	if err != nil {
+		log.Fatalf("Failed to connect to SQLite: %v", err)
 	}
+	defer db.Close(ctx)
+	db.MigrateFiles(
+		"migrations/sqlite/000003_init_stockdb.up.sql",
+		"migrations/sqlite/000004_init_statements.up.sql",
+		"migrations/sqlite/000005_init_stock_events.up.sql",
+		"migrations/sqlite/000008_init_changelog.up.sql",
+		"migrations/sqlite/000014_add_stock_order_sessions.up.sql",
+		"migrations/sqlite/000016_add_stock_fill_fees.up.sql",
+		"migrations/sqlite/000017_init_stock_snapshots.up.sql",
+	)
 
-	lis, err := net.Listen("tcp", ":"+port)
+	notificationClient, err := client.NewNotificationClient(config.GlobalConfig.Clients.Notification)
 	if err != nil {
-		log.Fatalf("❌ Failed to listen: %v", err)
+		log.Fatalf("Failed to create notification client: %v", err)
 	}
+	defer notificationClient.Close()
 
-	grpcServer := grpc.NewServer()
-	stock.RegisterStockServiceServer(grpcServer, NewStockService())
+	corporateActionRepo := repo.NewCorporateActionRepo(db.DB)
+	importCorporateActionsSvc := service.NewImportCorporateActionsSvc(corporateActionRepo)
+	listCorporateActionsSvc := service.NewListCorporateActionsSvc(corporateActionRepo)
 
-	log.Printf("🚀 Stock Service running on port %s", port)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("❌ Failed to serve: %v", err)
+	// Load the corporate action calendar, entries already known are skipped
+	if file := config.GlobalConfig.CorporateActions.File; file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			logger.Logger.Warn("corporate action calendar not loaded", zap.String("file", file), zap.Error(err))
+		} else {
+			result, err := importCorporateActionsSvc.Handle(ctx, &dto.ImportCorporateActionsReq{
+				Format:  mapper.FormatFromPath(file),
+				Content: content,
+			})
+			if err != nil {
+				log.Fatalf("Failed to import corporate actions from %s: %v", file, err)
+			}
+			logger.Logger.Info("🗓 corporate action calendar loaded",
+				zap.String("file", file),
+				zap.Uint32("imported", result.Imported),
+				zap.Uint32("skipped", result.Skipped))
+		}
+	}
+
+	location, err := time.LoadLocation(config.GlobalConfig.CorporateActions.TimeZone)
+	if err != nil {
+		log.Fatalf("Invalid corporate actions time zone: %v", err)
+	}
+	tradingCalendar, err := newTradingCalendar(config.GlobalConfig.Calendar)
+	if err != nil {
+		log.Fatalf("Failed to load trading calendar: %v", err)
+	}
+	exchange, err := tradingCalendar.Exchange(config.GlobalConfig.Calendar.Exchange)
+	if err != nil {
+		log.Fatalf("Failed to load trading calendar: %v", err)
+	}
+	logger.Logger.Info("🕘 trading calendar loaded",
+		zap.String("exchange", exchange.Code),
+		zap.String("phase", string(exchange.Phase(time.Now()))),
+		zap.Time("next_open", exchange.NextOpen(time.Now())),
+		zap.Time("next_close", exchange.NextClose(time.Now())))
+
+	feeSchedule := config.GlobalConfig.Fees
+	if feeSchedule == nil {
+		log.Fatalf("Failed to load fee schedule: fees config is missing")
+	}
+	if err := feeSchedule.Validate(); err != nil {
+		log.Fatalf("Failed to load fee schedule: %v", err)
+	}
+	logger.Logger.Info("💸 fee schedule loaded",
+		zap.String("schedule", feeSchedule.Name),
+		zap.Int("tiers", len(feeSchedule.Tiers)),
+		zap.Int("pass_through", len(feeSchedule.PassThrough)))
+
+	// Kafka brokers, the declared topics are created or checked before use
+	kafkaConfig := config.GlobalConfig.Kafka
+	brokers := kafkaConfig.Brokers
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(ctx, 30*time.Second)
+	if err := kafka.NewAdmin(brokers, logger.Logger).EnsureTopics(bootstrapCtx, kafkaConfig.Topics...); err != nil {
+		logger.Logger.Warn("kafka topics are not as declared", zap.Error(err))
+	}
+	cancelBootstrap()
+
+	// Create Kafka Manager
+	mgr := kafka.NewManager(brokers, logger.Logger)
+	defer mgr.Close()
+
+	// Row changes of the tracked tables are captured by triggers and published by the tailer
+	if cdcConfig := config.GlobalConfig.CDC; cdcConfig != nil && cdcConfig.Enabled {
+		if err := cdc.InstallTriggers(ctx, db.DB, cdcConfig.Tables...); err != nil {
+			log.Fatalf("Failed to install CDC triggers: %v", err)
+		}
+		tailer, err := cdc.NewTailer(db.DB, mgr, cdc.TailerConfig{
+			Name:        config.GlobalConfig.App.Name,
+			TopicPrefix: cdcConfig.TopicPrefix,
+			Interval:    config.GetDuration(cdcConfig.Interval),
+			BatchSize:   cdcConfig.BatchSize,
+			Retention:   config.GetDuration(cdcConfig.Retention),
+		}, logger.Logger)
+		if err != nil {
+			log.Fatalf("Failed to create CDC tailer: %v", err)
+		}
+		go func() {
+			if err := tailer.Start(ctx); err != nil {
+				logger.Logger.Error("cdc tailer stopped with error", zap.Error(err))
+			}
+		}()
+	}
+
+	// Orders are event sourced, the projector keeps the query tables in line with the event store
+	projectInterval := config.GetDuration(config.GlobalConfig.Events.ProjectInterval)
+	eventStore, err := eventstore.NewSQLStore(db.DB, eventstore.Config{
+		EventsTable:    "stock_events",
+		SnapshotsTable: "stock_snapshots",
+		PollInterval:   projectInterval,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create event store: %v", err)
+	}
+	eventRepo := repo.NewEventRepo(eventStore)
+	orderStore := order.NewStore(
+		order.StoreConfig{
+			ServiceName: config.GlobalConfig.App.Name,
+			Topic:       config.GlobalConfig.Events.Topic,
+			Snapshots:   eventstore.SnapshotPolicy{Every: config.GlobalConfig.Events.SnapshotEvery},
+		},
+		eventRepo,
+		mgr,
+		logger.Logger,
+	)
+	readModelRepo := repo.NewReadModelRepo(db.DB)
+	projector := order.NewProjector(
+		projectInterval,
+		eventRepo,
+		readModelRepo,
+		logger.Logger,
+	)
+	go func() {
+		if err := projector.Start(ctx); err != nil {
+			logger.Logger.Error("order projector stopped with error", zap.Error(err))
+		}
+	}()
+
+	stockOrderRepo := repo.NewStockOrderRepo(db.DB)
+	fillRepo := repo.NewFillRepo(db.DB)
+	barRepo := repo.NewBarRepo(db.DB)
+	barAggregator, err := market.NewBarAggregator(
+		exchange,
+		barRepo,
+		config.GlobalConfig.Bars.SourceInterval,
+		config.GlobalConfig.Bars.Intervals,
+	)
+	if err != nil {
+		log.Fatalf("Failed to create bar aggregator: %v", err)
+	}
+	sessionCloser := market.NewSessionCloser(exchange, stockOrderRepo, orderStore, barAggregator, logger.Logger)
+	go func() {
+		if err := sessionCloser.Start(ctx); err != nil {
+			logger.Logger.Error("session closer stopped with error", zap.Error(err))
+		}
+	}()
+
+	placeOrderSvc := service.NewPlaceOrderSvc(market.NewOrderValidator(exchange), orderStore)
+	cancelOrderSvc := service.NewCancelOrderSvc(orderStore)
+	reportExecutionSvc := service.NewReportExecutionSvc(orderStore, market.NewFeePricer(feeSchedule, fillRepo))
+	getOrderSvc := service.NewGetOrderSvc(stockOrderRepo, fillRepo)
+	listOrdersSvc := service.NewListOrdersSvc(stockOrderRepo)
+	getFeeScheduleSvc := service.NewGetFeeScheduleSvc(feeSchedule, fillRepo)
+
+	statementRepo := repo.NewStatementRepo(db.DB)
+	accountRepo := repo.NewAccountRepo(db.DB)
+	statementGenerator := statement.NewGenerator(
+		exchange,
+		accountRepo,
+		statementRepo,
+		notificationClient,
+		logger.Logger,
+	)
+	statementScheduler := statement.NewScheduler(
+		config.GetDuration(config.GlobalConfig.Statements.GenerateInterval),
+		exchange,
+		statementGenerator,
+		logger.Logger,
+	)
+	go func() {
+		if err := statementScheduler.Start(ctx); err != nil {
+			logger.Logger.Error("statement scheduler stopped with error", zap.Error(err))
+		}
+	}()
+	listStatementsSvc := service.NewListStatementsSvc(statementRepo)
+	getStatementSvc := service.NewGetStatementSvc(statementRepo)
+	listHoldersSvc := service.NewListHoldersSvc(accountRepo)
+
+	// Corporate actions are booked on the event store once the read models caught up with it
+	processor := corporate.NewProcessor(
+		config.GetDuration(config.GlobalConfig.CorporateActions.ProcessInterval),
+		location,
+		corporateActionRepo,
+		accountRepo,
+		stockOrderRepo,
+		eventRepo,
+		readModelRepo,
+		orderStore,
+		notificationClient,
+		logger.Logger,
+	)
+	go func() {
+		if err := processor.Start(ctx); err != nil {
+			logger.Logger.Error("corporate action processor stopped with error", zap.Error(err))
+		}
+	}()
+
+	stockHandler := grpcHandler.NewStockGrpcHandler(
+		importCorporateActionsSvc,
+		listCorporateActionsSvc,
+		listStatementsSvc,
+		getStatementSvc,
+		placeOrderSvc,
+		cancelOrderSvc,
+		reportExecutionSvc,
+		getOrderSvc,
+		listOrdersSvc,
+		getFeeScheduleSvc,
+		listHoldersSvc,
+	)
+
+	// Create the gRPC server
+	grpcServer, err := grpc.NewGrpcServer(
+		grpc.GrpcServerConfig{
+			Port: config.GlobalConfig.GrpcServer.Port,
+			KeepaliveParams: keepalive.ServerParameters{
+				MaxConnectionIdle:     time.Duration(config.GlobalConfig.GrpcServer.MaxConnectionIdle),
+				MaxConnectionAge:      time.Duration(config.GlobalConfig.GrpcServer.MaxConnectionAge),
+				MaxConnectionAgeGrace: time.Duration(config.GlobalConfig.GrpcServer.MaxConnectionAgeGrace),
+				Time:                  time.Duration(config.GlobalConfig.GrpcServer.Time),
+				Timeout:               time.Duration(config.GlobalConfig.GrpcServer.Timeout),
+			},
+			KeepalivePolicy: keepalive.EnforcementPolicy{
+				MinTime:             time.Duration(config.GlobalConfig.GrpcServer.MinTime),
+				PermitWithoutStream: config.GlobalConfig.GrpcServer.PermitWithoutStream,
+			},
+			UnaryInterceptor: middleware.LoggingInterceptor,
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to new grpc server err=%s\n", err.Error())
+	}
+
+	// Start the gRPC server
+	go grpcServer.Start(
+		func(server *googleGrpc.Server) {
+			stock.RegisterStockServiceServer(server, stockHandler)
+		},
+	)
+
+	// Add shutdown hook to trigger closer resources of service
+	server.AddShutdownHook(grpcServer, db.DB)
+}
+
+// newTradingCalendar builds the exchanges of the calendar config
+func newTradingCalendar(conf *config.CalendarConfig) (*calendar.Calendar, error) {
+	if conf == nil {
+		return nil, fmt.Errorf("calendar config is missing")
+	}
+
+	exchanges := make([]*calendar.Exchange, 0, len(conf.Exchanges))
+	for _, ec := range conf.Exchanges {
+		location, err := time.LoadLocation(ec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("exchange %s: %w", ec.Code, err)
+		}
+		regular, err := parseSession(ec.Regular)
+		if err != nil {
+			return nil, fmt.Errorf("exchange %s regular session: %w", ec.Code, err)
+		}
+		if regular.IsZero() {
+			return nil, fmt.Errorf("exchange %s has no regular session", ec.Code)
+		}
+
+		exchange := calendar.NewExchange(ec.Code, location, regular)
+		if exchange.PreMarket, err = parseSession(ec.PreMarket); err != nil {
+			return nil, fmt.Errorf("exchange %s pre-market: %w", ec.Code, err)
+		}
+		if exchange.PostMarket, err = parseSession(ec.PostMarket); err != nil {
+			return nil, fmt.Errorf("exchange %s post-market: %w", ec.Code, err)
+		}
+		for _, date := range ec.Holidays {
+			if err := exchange.AddHoliday(date); err != nil {
+				return nil, fmt.Errorf("exchange %s: %w", ec.Code, err)
+			}
+		}
+		for _, hd := range ec.HalfDays {
+			early, err := calendar.ParseClock(hd.Close)
+			if err != nil {
+				return nil, fmt.Errorf("exchange %s half day %s: %w", ec.Code, hd.Date, err)
+			}
+			if err := exchange.AddHalfDay(hd.Date, early); err != nil {
+				return nil, fmt.Errorf("exchange %s: %w", ec.Code, err)
+			}
+		}
+		exchanges = append(exchanges, exchange)
+	}
+	return calendar.New(exchanges...), nil
+}
+
+// parseSession reads a session config, a missing one is the disabled session
+func parseSession(conf *config.SessionConfig) (calendar.Session, error) {
+	if conf == nil || conf.Open == "" || conf.Close == "" {
+		return calendar.Session{}, nil
+	}
+	open, err := calendar.ParseClock(conf.Open)
+	if err != nil {
+		return calendar.Session{}, err
+	}
+	end, err := calendar.ParseClock(conf.Close)
+	if err != nil {
+		return calendar.Session{}, err
 	}
+	return calendar.Session{Open: open, Close: end}, nil
 }
@@ -3,6 +3,8 @@ package config
 import (
 	"flag"
 	"os"
+	"simple-securities/pkg/fee"
+	"simple-securities/pkg/kafka"
 	"strconv"
 	"strings"
 	"sync"
@@ -36,17 +38,36 @@ func GetLastConfigChangeTime() time.Time {
 }
 
 type Config struct {
-	Env           Env               `yaml:"env" mapstructure:"env"`
-	App           *AppConfig        `yaml:"app" mapstructure:"app"`
-	GrpcServer    *GrpcServerConfig `yaml:"grpc_server" mapstructure:"grpc_server"`
-	HTTPServer    *HttpServerConfig `yaml:"http_server" mapstructure:"http_server"`
-	MetricsServer *MetricsConfig    `yaml:"metrics_server" mapstructure:"metrics_server"`
-	Log           *LogConfig        `yaml:"log" mapstructure:"log"`
-	MySQL         *MySQLConfig      `yaml:"mysql" mapstructure:"mysql"`
-	Redis         *RedisConfig      `yaml:"redis" mapstructure:"redis"`
-	Postgre       *PostgreSQLConfig `yaml:"postgres" mapstructure:"postgres"`
-	MongoDB       *MongoDBConfig    `yaml:"mongodb" mapstructure:"mongodb"`
-	MigrationDir  string            `yaml:"migration_dir" mapstructure:"migration_dir"`
+	Env              Env                     `yaml:"env" mapstructure:"env"`
+	App              *AppConfig              `yaml:"app" mapstructure:"app"`
+	GrpcServer       *GrpcServerConfig       `yaml:"grpc_server" mapstructure:"grpc_server"`
+	HTTPServer       *HttpServerConfig       `yaml:"http_server" mapstructure:"http_server"`
+	MetricsServer    *MetricsConfig          `yaml:"metrics_server" mapstructure:"metrics_server"`
+	Log              *LogConfig              `yaml:"log" mapstructure:"log"`
+	MySQL            *MySQLConfig            `yaml:"mysql" mapstructure:"mysql"`
+	Redis            *RedisConfig            `yaml:"redis" mapstructure:"redis"`
+	Cache            *CacheConfig            `yaml:"cache" mapstructure:"cache"`
+	Postgre          *PostgreSQLConfig       `yaml:"postgres" mapstructure:"postgres"`
+	SQLite           *SQLiteConfig           `yaml:"sqlite" mapstructure:"sqlite"`
+	MongoDB          *MongoDBConfig          `yaml:"mongodb" mapstructure:"mongodb"`
+	Algo             *AlgoConfig             `yaml:"algo" mapstructure:"algo"`
+	CorporateActions *CorporateActionsConfig `yaml:"corporate_actions" mapstructure:"corporate_actions"`
+	Calendar         *CalendarConfig         `yaml:"calendar" mapstructure:"calendar"`
+	Bars             *BarsConfig             `yaml:"bars" mapstructure:"bars"`
+	Statements       *StatementsConfig       `yaml:"statements" mapstructure:"statements"`
+	Fees             *fee.Schedule           `yaml:"fees" mapstructure:"fees"`
+	Events           *EventsConfig           `yaml:"events" mapstructure:"events"`
+	Outbox           *OutboxConfig           `yaml:"outbox" mapstructure:"outbox"`
+	CDC              *CDCConfig              `yaml:"cdc" mapstructure:"cdc"`
+	Delivery         *DeliveryConfig         `yaml:"delivery" mapstructure:"delivery"`
+	Templates        *TemplatesConfig        `yaml:"templates" mapstructure:"templates"`
+	Digest           *DigestConfig           `yaml:"digest" mapstructure:"digest"`
+	Broadcast        *BroadcastConfig        `yaml:"broadcast" mapstructure:"broadcast"`
+	Consumer         *ConsumerConfig         `yaml:"consumer" mapstructure:"consumer"`
+	Producer         *ProducerConfig         `yaml:"producer" mapstructure:"producer"`
+	Kafka            *KafkaConfig            `yaml:"kafka" mapstructure:"kafka"`
+	Clients          *ClientsConfig          `yaml:"clients" mapstructure:"clients"`
+	MigrationDir     string                  `yaml:"migration_dir" mapstructure:"migration_dir"`
 }
 
 type AppConfig struct {
@@ -136,6 +157,18 @@ type RedisConfig struct {
 	MinIdleConns int    `yaml:"minIdleConns" mapstructure:"minIdleConns"`
 }
 
+// CacheConfig sets how long cached entries live in Redis: entities, the markers of missing
+// entities and listing pages
+type CacheConfig struct {
+	TTL         string `yaml:"ttl" mapstructure:"ttl"`
+	NegativeTTL string `yaml:"negative_ttl" mapstructure:"negative_ttl"`
+	PageTTL     string `yaml:"page_ttl" mapstructure:"page_ttl"`
+}
+
+type SQLiteConfig struct {
+	DSN string `yaml:"dsn" mapstructure:"dsn"`
+}
+
 type MongoDBConfig struct {
 	Host        string `yaml:"host" mapstructure:"host"`
 	Port        int    `yaml:"port" mapstructure:"port"`
@@ -149,6 +182,208 @@ type MongoDBConfig struct {
 	IdleTimeout int    `yaml:"idle_timeout" mapstructure:"idle_timeout"`
 }
 
+type AlgoConfig struct {
+	TickInterval string `yaml:"tick_interval" mapstructure:"tick_interval"`
+	Topic        string `yaml:"topic" mapstructure:"topic"`
+}
+
+type CorporateActionsConfig struct {
+	File            string `yaml:"file" mapstructure:"file"`
+	ProcessInterval string `yaml:"process_interval" mapstructure:"process_interval"`
+	TimeZone        string `yaml:"time_zone" mapstructure:"time_zone"`
+}
+
+// CalendarConfig lists the trading hours of the exchanges, Exchange is the one stock orders route to
+type CalendarConfig struct {
+	Exchange  string            `yaml:"exchange" mapstructure:"exchange"`
+	Exchanges []*ExchangeConfig `yaml:"exchanges" mapstructure:"exchanges"`
+}
+
+// ExchangeConfig holds the sessions of one exchange as "15:04" wall clocks in its time zone,
+// pre and post-market are disabled when left empty
+type ExchangeConfig struct {
+	Code       string           `yaml:"code" mapstructure:"code"`
+	TimeZone   string           `yaml:"time_zone" mapstructure:"time_zone"`
+	PreMarket  *SessionConfig   `yaml:"pre_market" mapstructure:"pre_market"`
+	Regular    *SessionConfig   `yaml:"regular" mapstructure:"regular"`
+	PostMarket *SessionConfig   `yaml:"post_market" mapstructure:"post_market"`
+	Holidays   []string         `yaml:"holidays" mapstructure:"holidays"`
+	HalfDays   []*HalfDayConfig `yaml:"half_days" mapstructure:"half_days"`
+}
+
+type SessionConfig struct {
+	Open  string `yaml:"open" mapstructure:"open"`
+	Close string `yaml:"close" mapstructure:"close"`
+}
+
+type HalfDayConfig struct {
+	Date  string `yaml:"date" mapstructure:"date"`
+	Close string `yaml:"close" mapstructure:"close"`
+}
+
+// BarsConfig sets the bar interval fed by the market data and the intervals rolled up from it at the close
+type BarsConfig struct {
+	SourceInterval string   `yaml:"source_interval" mapstructure:"source_interval"`
+	Intervals      []string `yaml:"intervals" mapstructure:"intervals"`
+}
+
+type StatementsConfig struct {
+	GenerateInterval string `yaml:"generate_interval" mapstructure:"generate_interval"`
+}
+
+// EventsConfig sets the Kafka topic the domain events are published on, how often the read
+// models poll the event store and how many events of a stream are replayed between snapshots
+type EventsConfig struct {
+	Topic           string `yaml:"topic" mapstructure:"topic"`
+	ProjectInterval string `yaml:"project_interval" mapstructure:"project_interval"`
+	SnapshotEvery   uint64 `yaml:"snapshot_every" mapstructure:"snapshot_every"`
+}
+
+// OutboxConfig sets how the outbox relay polls, how long dispatched messages are kept and
+// how often the relay lag is reported
+type OutboxConfig struct {
+	Interval      string `yaml:"interval" mapstructure:"interval"`
+	BatchSize     int    `yaml:"batch_size" mapstructure:"batch_size"`
+	Retention     string `yaml:"retention" mapstructure:"retention"`
+	StatsInterval string `yaml:"stats_interval" mapstructure:"stats_interval"`
+}
+
+// CDCConfig sets which tables have their row changes published, the topics they go to and
+// how the changelog is polled and purged
+type CDCConfig struct {
+	Enabled     bool     `yaml:"enabled" mapstructure:"enabled"`
+	TopicPrefix string   `yaml:"topic_prefix" mapstructure:"topic_prefix"`
+	Tables      []string `yaml:"tables" mapstructure:"tables"`
+	Interval    string   `yaml:"interval" mapstructure:"interval"`
+	BatchSize   int      `yaml:"batch_size" mapstructure:"batch_size"`
+	Retention   string   `yaml:"retention" mapstructure:"retention"`
+}
+
+// DeliveryConfig sets the consumer group delivering notifications to the channels of the
+// users and the channel adapters. Email and push are only delivered once configured.
+type DeliveryConfig struct {
+	Enabled bool   `yaml:"enabled" mapstructure:"enabled"`
+	Group   string `yaml:"group" mapstructure:"group"`
+	// CriticalTypes are the notification types delivered whatever the preferences of the user
+	CriticalTypes []string `yaml:"critical_types" mapstructure:"critical_types"`
+	// ResumeInterval is how often the deliveries deferred by quiet hours are resumed once due
+	ResumeInterval  string                `yaml:"resume_interval" mapstructure:"resume_interval"`
+	ResumeBatchSize int                   `yaml:"resume_batch_size" mapstructure:"resume_batch_size"`
+	Email           *EmailDeliveryConfig  `yaml:"email" mapstructure:"email"`
+	Webhook         WebhookDeliveryConfig `yaml:"webhook" mapstructure:"webhook"`
+	Discord         DiscordDeliveryConfig `yaml:"discord" mapstructure:"discord"`
+	Push            *PushDeliveryConfig   `yaml:"push" mapstructure:"push"`
+}
+
+type EmailDeliveryConfig struct {
+	Host     string `yaml:"host" mapstructure:"host"`
+	Port     int    `yaml:"port" mapstructure:"port"`
+	Username string `yaml:"username" mapstructure:"username"`
+	Password string `yaml:"password" mapstructure:"password"`
+	From     string `yaml:"from" mapstructure:"from"`
+}
+
+type WebhookDeliveryConfig struct {
+	Secret  string `yaml:"secret" mapstructure:"secret"`
+	Timeout string `yaml:"timeout" mapstructure:"timeout"`
+}
+
+type DiscordDeliveryConfig struct {
+	UserName  string `yaml:"user_name" mapstructure:"user_name"`
+	AvatarUrl string `yaml:"avatar_url" mapstructure:"avatar_url"`
+}
+
+type PushDeliveryConfig struct {
+	VAPIDPrivateKey string `yaml:"vapid_private_key" mapstructure:"vapid_private_key"`
+	Subject         string `yaml:"subject" mapstructure:"subject"`
+	TTL             string `yaml:"ttl" mapstructure:"ttl"`
+	Timeout         string `yaml:"timeout" mapstructure:"timeout"`
+}
+
+// TemplatesConfig declares the notification templates by notification type, with their
+// variables and their content by locale. Every template has the default locale.
+type TemplatesConfig struct {
+	DefaultLocale string           `yaml:"default_locale" mapstructure:"default_locale"`
+	Definitions   []TemplateConfig `yaml:"definitions" mapstructure:"definitions"`
+}
+
+type TemplateConfig struct {
+	ID        string                           `yaml:"id" mapstructure:"id"`
+	Format    string                           `yaml:"format" mapstructure:"format"`
+	Variables []TemplateVariableConfig         `yaml:"variables" mapstructure:"variables"`
+	Locales   map[string]TemplateContentConfig `yaml:"locales" mapstructure:"locales"`
+}
+
+type TemplateVariableConfig struct {
+	Name     string `yaml:"name" mapstructure:"name"`
+	Type     string `yaml:"type" mapstructure:"type"`
+	Required bool   `yaml:"required" mapstructure:"required"`
+}
+
+type TemplateContentConfig struct {
+	Title string `yaml:"title" mapstructure:"title"`
+	Body  string `yaml:"body" mapstructure:"body"`
+}
+
+// DigestConfig sets the low-priority notification types held in the digests of the users
+// who asked for them, how often due digests are emitted and the link of each summary item
+type DigestConfig struct {
+	Enabled   bool     `yaml:"enabled" mapstructure:"enabled"`
+	Types     []string `yaml:"types" mapstructure:"types"`
+	Interval  string   `yaml:"interval" mapstructure:"interval"`
+	BatchSize int      `yaml:"batch_size" mapstructure:"batch_size"`
+	ItemLink  string   `yaml:"item_link" mapstructure:"item_link"`
+	MaxItems  int      `yaml:"max_items" mapstructure:"max_items"`
+}
+
+// BroadcastConfig sets how often the broadcast runner looks for jobs, the batches they fan
+// out in, the notifications sent per second and the lease of a job on its runner
+type BroadcastConfig struct {
+	Enabled   bool   `yaml:"enabled" mapstructure:"enabled"`
+	Interval  string `yaml:"interval" mapstructure:"interval"`
+	BatchSize int    `yaml:"batch_size" mapstructure:"batch_size"`
+	Rate      int    `yaml:"rate" mapstructure:"rate"`
+	Lease     string `yaml:"lease" mapstructure:"lease"`
+}
+
+// ConsumerConfig sets how many retry topics a failed Kafka message goes through before the
+// dead-letter topic, the backoff between them and how long processed message ids are kept.
+// With more than one worker messages are handled concurrently, in order per key.
+type ConsumerConfig struct {
+	RetryAttempts  int    `yaml:"retry_attempts" mapstructure:"retry_attempts"`
+	RetryBackoff   string `yaml:"retry_backoff" mapstructure:"retry_backoff"`
+	MaxBackoff     string `yaml:"max_backoff" mapstructure:"max_backoff"`
+	DedupRetention string `yaml:"dedup_retention" mapstructure:"dedup_retention"`
+	Workers        int    `yaml:"workers" mapstructure:"workers"`
+	CommitBatch    int    `yaml:"commit_batch" mapstructure:"commit_batch"`
+	CommitInterval string `yaml:"commit_interval" mapstructure:"commit_interval"`
+	DrainTimeout   string `yaml:"drain_timeout" mapstructure:"drain_timeout"`
+}
+
+// KafkaConfig lists the brokers and the topics declared at startup
+type KafkaConfig struct {
+	Brokers []string          `yaml:"brokers" mapstructure:"brokers"`
+	Topics  []kafka.TopicSpec `yaml:"topics" mapstructure:"topics"`
+}
+
+// ProducerConfig sets how the Kafka producer batches, compresses and acknowledges its writes
+type ProducerConfig struct {
+	Async       bool   `yaml:"async" mapstructure:"async"`
+	BatchSize   int    `yaml:"batch_size" mapstructure:"batch_size"`
+	BatchBytes  int64  `yaml:"batch_bytes" mapstructure:"batch_bytes"`
+	Linger      string `yaml:"linger" mapstructure:"linger"`
+	Compression string `yaml:"compression" mapstructure:"compression"`
+	Acks        string `yaml:"acks" mapstructure:"acks"`
+	Idempotent  bool   `yaml:"idempotent" mapstructure:"idempotent"`
+	MaxAttempts int    `yaml:"max_attempts" mapstructure:"max_attempts"`
+}
+
+// ClientsConfig holds the gRPC addresses of the other services
+type ClientsConfig struct {
+	Notification string `yaml:"notification" mapstructure:"notification"`
+	Stock        string `yaml:"stock" mapstructure:"stock"`
+}
+
 func Load(configPath string, configFile string) (*Config, error) {
 	var conf *Config
 	vip := viper.New()
@@ -202,6 +437,7 @@ func applyEnvOverrides(conf *Config) {
 	applyMySQLEnvOverrides(conf)
 	applyPostgresEnvOverrides(conf)
 	applyRedisEnvOverrides(conf)
+	applySQLiteEnvOverrides(conf)
 	applyMongoDBEnvOverrides(conf)
 	applyLogEnvOverrides(conf)
 
@@ -414,6 +650,16 @@ func applyRedisEnvOverrides(conf *Config) {
 	}
 }
 
+// applySQLiteEnvOverrides applies SQLite related environment variables
+func applySQLiteEnvOverrides(conf *Config) {
+	if dsn := os.Getenv("APP_SQLITE_DSN"); dsn != "" {
+		if conf.SQLite == nil {
+			conf.SQLite = &SQLiteConfig{}
+		}
+		conf.SQLite.DSN = dsn
+	}
+}
+
 // applyMongoDBEnvOverrides applies MongoDB related environment variables
 func applyMongoDBEnvOverrides(conf *Config) {
 	if conf.MongoDB == nil {
@@ -330,6 +330,686 @@ func (x *Kline) GetTakerBuyQuoteAssetVolume() string {
 	return ""
 }
 
+type PlaceAlgoOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"` // e.g., "BTCUSDT"
+	Side          string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`     // BUY, SELL
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`     // OCO, TRAILING_STOP, TWAP, VWAP
+	Quantity      float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	LimitPrice    float64                `protobuf:"fixed64,6,opt,name=limit_price,json=limitPrice,proto3" json:"limit_price,omitempty"`       // OCO take-profit leg
+	StopPrice     float64                `protobuf:"fixed64,7,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`          // OCO stop-loss leg
+	TrailAmount   float64                `protobuf:"fixed64,8,opt,name=trail_amount,json=trailAmount,proto3" json:"trail_amount,omitempty"`    // TRAILING_STOP, absolute distance
+	TrailPercent  float64                `protobuf:"fixed64,9,opt,name=trail_percent,json=trailPercent,proto3" json:"trail_percent,omitempty"` // TRAILING_STOP, distance in percent
+	StartTime     int64                  `protobuf:"varint,10,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`          // TWAP/VWAP, unix seconds, defaults to now
+	EndTime       int64                  `protobuf:"varint,11,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`                // TWAP/VWAP, unix seconds
+	Slices        uint32                 `protobuf:"varint,12,opt,name=slices,proto3" json:"slices,omitempty"`                                 // TWAP/VWAP
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceAlgoOrderRequest) Reset() {
+	*x = PlaceAlgoOrderRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceAlgoOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceAlgoOrderRequest) ProtoMessage() {}
+
+func (x *PlaceAlgoOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceAlgoOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceAlgoOrderRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PlaceAlgoOrderRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PlaceAlgoOrderRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *PlaceAlgoOrderRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PlaceAlgoOrderRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetLimitPrice() float64 {
+	if x != nil {
+		return x.LimitPrice
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetTrailAmount() float64 {
+	if x != nil {
+		return x.TrailAmount
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetTrailPercent() float64 {
+	if x != nil {
+		return x.TrailPercent
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *PlaceAlgoOrderRequest) GetSlices() uint32 {
+	if x != nil {
+		return x.Slices
+	}
+	return 0
+}
+
+type PlaceAlgoOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlgoOrder     *AlgoOrder             `protobuf:"bytes,1,opt,name=algo_order,json=algoOrder,proto3" json:"algo_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceAlgoOrderResponse) Reset() {
+	*x = PlaceAlgoOrderResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceAlgoOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceAlgoOrderResponse) ProtoMessage() {}
+
+func (x *PlaceAlgoOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceAlgoOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceAlgoOrderResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlaceAlgoOrderResponse) GetAlgoOrder() *AlgoOrder {
+	if x != nil {
+		return x.AlgoOrder
+	}
+	return nil
+}
+
+type GetAlgoOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlgoOrderRequest) Reset() {
+	*x = GetAlgoOrderRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlgoOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlgoOrderRequest) ProtoMessage() {}
+
+func (x *GetAlgoOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlgoOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetAlgoOrderRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAlgoOrderRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type GetAlgoOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlgoOrder     *AlgoOrder             `protobuf:"bytes,1,opt,name=algo_order,json=algoOrder,proto3" json:"algo_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAlgoOrderResponse) Reset() {
+	*x = GetAlgoOrderResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAlgoOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAlgoOrderResponse) ProtoMessage() {}
+
+func (x *GetAlgoOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAlgoOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetAlgoOrderResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetAlgoOrderResponse) GetAlgoOrder() *AlgoOrder {
+	if x != nil {
+		return x.AlgoOrder
+	}
+	return nil
+}
+
+type CancelAlgoOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAlgoOrderRequest) Reset() {
+	*x = CancelAlgoOrderRequest{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAlgoOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAlgoOrderRequest) ProtoMessage() {}
+
+func (x *CancelAlgoOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAlgoOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelAlgoOrderRequest) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CancelAlgoOrderRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CancelAlgoOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AlgoOrder     *AlgoOrder             `protobuf:"bytes,1,opt,name=algo_order,json=algoOrder,proto3" json:"algo_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelAlgoOrderResponse) Reset() {
+	*x = CancelAlgoOrderResponse{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelAlgoOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelAlgoOrderResponse) ProtoMessage() {}
+
+func (x *CancelAlgoOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelAlgoOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelAlgoOrderResponse) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CancelAlgoOrderResponse) GetAlgoOrder() *AlgoOrder {
+	if x != nil {
+		return x.AlgoOrder
+	}
+	return nil
+}
+
+type AlgoOrder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid           string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	UserId         uint64                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side           string                 `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	Type           string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // WORKING, COMPLETED, CANCELLED, FAILED
+	Quantity       float64                `protobuf:"fixed64,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQuantity float64                `protobuf:"fixed64,9,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	LimitPrice     float64                `protobuf:"fixed64,10,opt,name=limit_price,json=limitPrice,proto3" json:"limit_price,omitempty"`
+	StopPrice      float64                `protobuf:"fixed64,11,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	TrailAmount    float64                `protobuf:"fixed64,12,opt,name=trail_amount,json=trailAmount,proto3" json:"trail_amount,omitempty"`
+	TrailPercent   float64                `protobuf:"fixed64,13,opt,name=trail_percent,json=trailPercent,proto3" json:"trail_percent,omitempty"`
+	StartTime      int64                  `protobuf:"varint,14,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime        int64                  `protobuf:"varint,15,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Slices         uint32                 `protobuf:"varint,16,opt,name=slices,proto3" json:"slices,omitempty"`
+	Reason         string                 `protobuf:"bytes,17,opt,name=reason,proto3" json:"reason,omitempty"`
+	Children       []*ChildOrder          `protobuf:"bytes,18,rep,name=children,proto3" json:"children,omitempty"`
+	CreatedAt      uint64                 `protobuf:"varint,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      uint64                 `protobuf:"varint,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AlgoOrder) Reset() {
+	*x = AlgoOrder{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AlgoOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AlgoOrder) ProtoMessage() {}
+
+func (x *AlgoOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AlgoOrder.ProtoReflect.Descriptor instead.
+func (*AlgoOrder) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AlgoOrder) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetFilledQuantity() float64 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetLimitPrice() float64 {
+	if x != nil {
+		return x.LimitPrice
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetTrailAmount() float64 {
+	if x != nil {
+		return x.TrailAmount
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetTrailPercent() float64 {
+	if x != nil {
+		return x.TrailPercent
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetSlices() uint32 {
+	if x != nil {
+		return x.Slices
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AlgoOrder) GetChildren() []*ChildOrder {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *AlgoOrder) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *AlgoOrder) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ChildOrder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid           string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Seq            uint32                 `protobuf:"varint,3,opt,name=seq,proto3" json:"seq,omitempty"`
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`     // MARKET, LIMIT, STOP
+	Price          float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"` // limit price, or trigger price for STOP
+	Quantity       float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQuantity float64                `protobuf:"fixed64,7,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	AvgFillPrice   float64                `protobuf:"fixed64,8,opt,name=avg_fill_price,json=avgFillPrice,proto3" json:"avg_fill_price,omitempty"`
+	Status         string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"` // NEW, PARTIALLY_FILLED, FILLED, CANCELLED, REJECTED
+	CreatedAt      uint64                 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      uint64                 `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Fee            float64                `protobuf:"fixed64,12,opt,name=fee,proto3" json:"fee,omitempty"` // commission charged on the fills, in the quote asset
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChildOrder) Reset() {
+	*x = ChildOrder{}
+	mi := &file_crypto_v1_crypto_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChildOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChildOrder) ProtoMessage() {}
+
+func (x *ChildOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_crypto_v1_crypto_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChildOrder.ProtoReflect.Descriptor instead.
+func (*ChildOrder) Descriptor() ([]byte, []int) {
+	return file_crypto_v1_crypto_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ChildOrder) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ChildOrder) GetSeq() uint32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ChildOrder) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetFilledQuantity() float64 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetAvgFillPrice() float64 {
+	if x != nil {
+		return x.AvgFillPrice
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChildOrder) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *ChildOrder) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
 var File_crypto_v1_crypto_proto protoreflect.FileDescriptor
 
 const file_crypto_v1_crypto_proto_rawDesc = "" +
@@ -358,10 +1038,87 @@ const file_crypto_v1_crypto_proto_rawDesc = "" +
 	"\x10number_of_trades\x18\t \x01(\x05R\x0enumberOfTrades\x12<\n" +
 	"\x1btaker_buy_base_asset_volume\x18\n" +
 	" \x01(\tR\x17takerBuyBaseAssetVolume\x12>\n" +
-	"\x1ctaker_buy_quote_asset_volume\x18\v \x01(\tR\x18takerBuyQuoteAssetVolume2\xee\x01\n" +
+	"\x1ctaker_buy_quote_asset_volume\x18\v \x01(\tR\x18takerBuyQuoteAssetVolume\"\xe6\x02\n" +
+	"\x15PlaceAlgoOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x03 \x01(\tR\x04side\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x01R\bquantity\x12\x1f\n" +
+	"\vlimit_price\x18\x06 \x01(\x01R\n" +
+	"limitPrice\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\a \x01(\x01R\tstopPrice\x12!\n" +
+	"\ftrail_amount\x18\b \x01(\x01R\vtrailAmount\x12#\n" +
+	"\rtrail_percent\x18\t \x01(\x01R\ftrailPercent\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\n" +
+	" \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\v \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06slices\x18\f \x01(\rR\x06slices\"M\n" +
+	"\x16PlaceAlgoOrderResponse\x123\n" +
+	"\n" +
+	"algo_order\x18\x01 \x01(\v2\x14.crypto.v1.AlgoOrderR\talgoOrder\"%\n" +
+	"\x13GetAlgoOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"K\n" +
+	"\x14GetAlgoOrderResponse\x123\n" +
+	"\n" +
+	"algo_order\x18\x01 \x01(\v2\x14.crypto.v1.AlgoOrderR\talgoOrder\"(\n" +
+	"\x16CancelAlgoOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\"N\n" +
+	"\x17CancelAlgoOrderResponse\x123\n" +
+	"\n" +
+	"algo_order\x18\x01 \x01(\v2\x14.crypto.v1.AlgoOrderR\talgoOrder\"\xc8\x04\n" +
+	"\tAlgoOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x05 \x01(\tR\x04side\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x01R\bquantity\x12'\n" +
+	"\x0ffilled_quantity\x18\t \x01(\x01R\x0efilledQuantity\x12\x1f\n" +
+	"\vlimit_price\x18\n" +
+	" \x01(\x01R\n" +
+	"limitPrice\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\v \x01(\x01R\tstopPrice\x12!\n" +
+	"\ftrail_amount\x18\f \x01(\x01R\vtrailAmount\x12#\n" +
+	"\rtrail_percent\x18\r \x01(\x01R\ftrailPercent\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x0e \x01(\x03R\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x0f \x01(\x03R\aendTime\x12\x16\n" +
+	"\x06slices\x18\x10 \x01(\rR\x06slices\x12\x16\n" +
+	"\x06reason\x18\x11 \x01(\tR\x06reason\x121\n" +
+	"\bchildren\x18\x12 \x03(\v2\x15.crypto.v1.ChildOrderR\bchildren\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\x04R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x14 \x01(\x04R\tupdatedAt\"\xbf\x02\n" +
+	"\n" +
+	"ChildOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x10\n" +
+	"\x03seq\x18\x03 \x01(\rR\x03seq\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x01R\bquantity\x12'\n" +
+	"\x0ffilled_quantity\x18\a \x01(\x01R\x0efilledQuantity\x12$\n" +
+	"\x0eavg_fill_price\x18\b \x01(\x01R\favgFillPrice\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x04R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\x04R\tupdatedAt\x12\x10\n" +
+	"\x03fee\x18\f \x01(\x01R\x03fee2\xf1\x04\n" +
 	"\rCryptoService\x12v\n" +
 	"\rGetServerTime\x12\x1f.crypto.v1.GetServerTimeRequest\x1a .crypto.v1.GetServerTimeResponse\"\"\x82\xd3\xe4\x93\x02\x1c\x12\x1a/api/v1/crypto/server-time\x12e\n" +
-	"\tGetKlines\x12\x1b.crypto.v1.GetKlinesRequest\x1a\x1c.crypto.v1.GetKlinesResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/crypto/klinesBt\n" +
+	"\tGetKlines\x12\x1b.crypto.v1.GetKlinesRequest\x1a\x1c.crypto.v1.GetKlinesResponse\"\x1d\x82\xd3\xe4\x93\x02\x17\x12\x15/api/v1/crypto/klines\x12|\n" +
+	"\x0ePlaceAlgoOrder\x12 .crypto.v1.PlaceAlgoOrderRequest\x1a!.crypto.v1.PlaceAlgoOrderResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/crypto/algo-orders\x12x\n" +
+	"\fGetAlgoOrder\x12\x1e.crypto.v1.GetAlgoOrderRequest\x1a\x1f.crypto.v1.GetAlgoOrderResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/crypto/algo-orders/{id}\x12\x88\x01\n" +
+	"\x0fCancelAlgoOrder\x12!.crypto.v1.CancelAlgoOrderRequest\x1a\".crypto.v1.CancelAlgoOrderResponse\".\x82\xd3\xe4\x93\x02(\"&/api/v1/crypto/algo-orders/{id}/cancelBt\n" +
 	"\rcom.crypto.v1B\vCryptoProtoP\x01Z\x11/gen/go/crypto/v1\xa2\x02\x03CXX\xaa\x02\tCrypto.V1\xca\x02\tCrypto\\V1\xe2\x02\x15Crypto\\V1\\GPBMetadata\xea\x02\n" +
 	"Crypto::V1b\x06proto3"
 
@@ -377,25 +1134,43 @@ func file_crypto_v1_crypto_proto_rawDescGZIP() []byte {
 	return file_crypto_v1_crypto_proto_rawDescData
 }
 
-var file_crypto_v1_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_crypto_v1_crypto_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_crypto_v1_crypto_proto_goTypes = []any{
-	(*GetServerTimeRequest)(nil),  // 0: crypto.v1.GetServerTimeRequest
-	(*GetServerTimeResponse)(nil), // 1: crypto.v1.GetServerTimeResponse
-	(*GetKlinesRequest)(nil),      // 2: crypto.v1.GetKlinesRequest
-	(*GetKlinesResponse)(nil),     // 3: crypto.v1.GetKlinesResponse
-	(*Kline)(nil),                 // 4: crypto.v1.Kline
+	(*GetServerTimeRequest)(nil),    // 0: crypto.v1.GetServerTimeRequest
+	(*GetServerTimeResponse)(nil),   // 1: crypto.v1.GetServerTimeResponse
+	(*GetKlinesRequest)(nil),        // 2: crypto.v1.GetKlinesRequest
+	(*GetKlinesResponse)(nil),       // 3: crypto.v1.GetKlinesResponse
+	(*Kline)(nil),                   // 4: crypto.v1.Kline
+	(*PlaceAlgoOrderRequest)(nil),   // 5: crypto.v1.PlaceAlgoOrderRequest
+	(*PlaceAlgoOrderResponse)(nil),  // 6: crypto.v1.PlaceAlgoOrderResponse
+	(*GetAlgoOrderRequest)(nil),     // 7: crypto.v1.GetAlgoOrderRequest
+	(*GetAlgoOrderResponse)(nil),    // 8: crypto.v1.GetAlgoOrderResponse
+	(*CancelAlgoOrderRequest)(nil),  // 9: crypto.v1.CancelAlgoOrderRequest
+	(*CancelAlgoOrderResponse)(nil), // 10: crypto.v1.CancelAlgoOrderResponse
+	(*AlgoOrder)(nil),               // 11: crypto.v1.AlgoOrder
+	(*ChildOrder)(nil),              // 12: crypto.v1.ChildOrder
 }
 var file_crypto_v1_crypto_proto_depIdxs = []int32{
-	4, // 0: crypto.v1.GetKlinesResponse.klines:type_name -> crypto.v1.Kline
-	0, // 1: crypto.v1.CryptoService.GetServerTime:input_type -> crypto.v1.GetServerTimeRequest
-	2, // 2: crypto.v1.CryptoService.GetKlines:input_type -> crypto.v1.GetKlinesRequest
-	1, // 3: crypto.v1.CryptoService.GetServerTime:output_type -> crypto.v1.GetServerTimeResponse
-	3, // 4: crypto.v1.CryptoService.GetKlines:output_type -> crypto.v1.GetKlinesResponse
-	3, // [3:5] is the sub-list for method output_type
-	1, // [1:3] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	4,  // 0: crypto.v1.GetKlinesResponse.klines:type_name -> crypto.v1.Kline
+	11, // 1: crypto.v1.PlaceAlgoOrderResponse.algo_order:type_name -> crypto.v1.AlgoOrder
+	11, // 2: crypto.v1.GetAlgoOrderResponse.algo_order:type_name -> crypto.v1.AlgoOrder
+	11, // 3: crypto.v1.CancelAlgoOrderResponse.algo_order:type_name -> crypto.v1.AlgoOrder
+	12, // 4: crypto.v1.AlgoOrder.children:type_name -> crypto.v1.ChildOrder
+	0,  // 5: crypto.v1.CryptoService.GetServerTime:input_type -> crypto.v1.GetServerTimeRequest
+	2,  // 6: crypto.v1.CryptoService.GetKlines:input_type -> crypto.v1.GetKlinesRequest
+	5,  // 7: crypto.v1.CryptoService.PlaceAlgoOrder:input_type -> crypto.v1.PlaceAlgoOrderRequest
+	7,  // 8: crypto.v1.CryptoService.GetAlgoOrder:input_type -> crypto.v1.GetAlgoOrderRequest
+	9,  // 9: crypto.v1.CryptoService.CancelAlgoOrder:input_type -> crypto.v1.CancelAlgoOrderRequest
+	1,  // 10: crypto.v1.CryptoService.GetServerTime:output_type -> crypto.v1.GetServerTimeResponse
+	3,  // 11: crypto.v1.CryptoService.GetKlines:output_type -> crypto.v1.GetKlinesResponse
+	6,  // 12: crypto.v1.CryptoService.PlaceAlgoOrder:output_type -> crypto.v1.PlaceAlgoOrderResponse
+	8,  // 13: crypto.v1.CryptoService.GetAlgoOrder:output_type -> crypto.v1.GetAlgoOrderResponse
+	10, // 14: crypto.v1.CryptoService.CancelAlgoOrder:output_type -> crypto.v1.CancelAlgoOrderResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_crypto_v1_crypto_proto_init() }
@@ -409,7 +1184,7 @@ func file_crypto_v1_crypto_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_crypto_v1_crypto_proto_rawDesc), len(file_crypto_v1_crypto_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CryptoService_GetServerTime_FullMethodName = "/crypto.v1.CryptoService/GetServerTime"
-	CryptoService_GetKlines_FullMethodName     = "/crypto.v1.CryptoService/GetKlines"
+	CryptoService_GetServerTime_FullMethodName   = "/crypto.v1.CryptoService/GetServerTime"
+	CryptoService_GetKlines_FullMethodName       = "/crypto.v1.CryptoService/GetKlines"
+	CryptoService_PlaceAlgoOrder_FullMethodName  = "/crypto.v1.CryptoService/PlaceAlgoOrder"
+	CryptoService_GetAlgoOrder_FullMethodName    = "/crypto.v1.CryptoService/GetAlgoOrder"
+	CryptoService_CancelAlgoOrder_FullMethodName = "/crypto.v1.CryptoService/CancelAlgoOrder"
 )
 
 // CryptoServiceClient is the client API for CryptoService service.
@@ -29,6 +32,9 @@ const (
 type CryptoServiceClient interface {
 	GetServerTime(ctx context.Context, in *GetServerTimeRequest, opts ...grpc.CallOption) (*GetServerTimeResponse, error)
 	GetKlines(ctx context.Context, in *GetKlinesRequest, opts ...grpc.CallOption) (*GetKlinesResponse, error)
+	PlaceAlgoOrder(ctx context.Context, in *PlaceAlgoOrderRequest, opts ...grpc.CallOption) (*PlaceAlgoOrderResponse, error)
+	GetAlgoOrder(ctx context.Context, in *GetAlgoOrderRequest, opts ...grpc.CallOption) (*GetAlgoOrderResponse, error)
+	CancelAlgoOrder(ctx context.Context, in *CancelAlgoOrderRequest, opts ...grpc.CallOption) (*CancelAlgoOrderResponse, error)
 }
 
 type cryptoServiceClient struct {
@@ -59,12 +65,45 @@ func (c *cryptoServiceClient) GetKlines(ctx context.Context, in *GetKlinesReques
 	return out, nil
 }
 
+func (c *cryptoServiceClient) PlaceAlgoOrder(ctx context.Context, in *PlaceAlgoOrderRequest, opts ...grpc.CallOption) (*PlaceAlgoOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlaceAlgoOrderResponse)
+	err := c.cc.Invoke(ctx, CryptoService_PlaceAlgoOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) GetAlgoOrder(ctx context.Context, in *GetAlgoOrderRequest, opts ...grpc.CallOption) (*GetAlgoOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAlgoOrderResponse)
+	err := c.cc.Invoke(ctx, CryptoService_GetAlgoOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cryptoServiceClient) CancelAlgoOrder(ctx context.Context, in *CancelAlgoOrderRequest, opts ...grpc.CallOption) (*CancelAlgoOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelAlgoOrderResponse)
+	err := c.cc.Invoke(ctx, CryptoService_CancelAlgoOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CryptoServiceServer is the server API for CryptoService service.
 // All implementations must embed UnimplementedCryptoServiceServer
 // for forward compatibility.
 type CryptoServiceServer interface {
 	GetServerTime(context.Context, *GetServerTimeRequest) (*GetServerTimeResponse, error)
 	GetKlines(context.Context, *GetKlinesRequest) (*GetKlinesResponse, error)
+	PlaceAlgoOrder(context.Context, *PlaceAlgoOrderRequest) (*PlaceAlgoOrderResponse, error)
+	GetAlgoOrder(context.Context, *GetAlgoOrderRequest) (*GetAlgoOrderResponse, error)
+	CancelAlgoOrder(context.Context, *CancelAlgoOrderRequest) (*CancelAlgoOrderResponse, error)
 	mustEmbedUnimplementedCryptoServiceServer()
 }
 
@@ -81,6 +120,15 @@ func (UnimplementedCryptoServiceServer) GetServerTime(context.Context, *GetServe
 func (UnimplementedCryptoServiceServer) GetKlines(context.Context, *GetKlinesRequest) (*GetKlinesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetKlines not implemented")
 }
+func (UnimplementedCryptoServiceServer) PlaceAlgoOrder(context.Context, *PlaceAlgoOrderRequest) (*PlaceAlgoOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceAlgoOrder not implemented")
+}
+func (UnimplementedCryptoServiceServer) GetAlgoOrder(context.Context, *GetAlgoOrderRequest) (*GetAlgoOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlgoOrder not implemented")
+}
+func (UnimplementedCryptoServiceServer) CancelAlgoOrder(context.Context, *CancelAlgoOrderRequest) (*CancelAlgoOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelAlgoOrder not implemented")
+}
 func (UnimplementedCryptoServiceServer) mustEmbedUnimplementedCryptoServiceServer() {}
 func (UnimplementedCryptoServiceServer) testEmbeddedByValue()                       {}
 
@@ -138,6 +186,60 @@ func _CryptoService_GetKlines_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CryptoService_PlaceAlgoOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceAlgoOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).PlaceAlgoOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_PlaceAlgoOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).PlaceAlgoOrder(ctx, req.(*PlaceAlgoOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_GetAlgoOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlgoOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).GetAlgoOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_GetAlgoOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).GetAlgoOrder(ctx, req.(*GetAlgoOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CryptoService_CancelAlgoOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelAlgoOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CryptoServiceServer).CancelAlgoOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CryptoService_CancelAlgoOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CryptoServiceServer).CancelAlgoOrder(ctx, req.(*CancelAlgoOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CryptoService_ServiceDesc is the grpc.ServiceDesc for CryptoService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +255,18 @@ var CryptoService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetKlines",
 			Handler:    _CryptoService_GetKlines_Handler,
 		},
+		{
+			MethodName: "PlaceAlgoOrder",
+			Handler:    _CryptoService_PlaceAlgoOrder_Handler,
+		},
+		{
+			MethodName: "GetAlgoOrder",
+			Handler:    _CryptoService_GetAlgoOrder_Handler,
+		},
+		{
+			MethodName: "CancelAlgoOrder",
+			Handler:    _CryptoService_CancelAlgoOrder_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "crypto/v1/crypto.proto",
@@ -102,6 +102,2203 @@ func (x *PingResponse) GetMessage() string {
 	return ""
 }
 
+type ImportCorporateActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"` // csv | json
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCorporateActionsRequest) Reset() {
+	*x = ImportCorporateActionsRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCorporateActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCorporateActionsRequest) ProtoMessage() {}
+
+func (x *ImportCorporateActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCorporateActionsRequest.ProtoReflect.Descriptor instead.
+func (*ImportCorporateActionsRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ImportCorporateActionsRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *ImportCorporateActionsRequest) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type ImportCorporateActionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Imported      uint32                 `protobuf:"varint,1,opt,name=imported,proto3" json:"imported,omitempty"`
+	Skipped       uint32                 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportCorporateActionsResponse) Reset() {
+	*x = ImportCorporateActionsResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportCorporateActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportCorporateActionsResponse) ProtoMessage() {}
+
+func (x *ImportCorporateActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportCorporateActionsResponse.ProtoReflect.Descriptor instead.
+func (*ImportCorporateActionsResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ImportCorporateActionsResponse) GetImported() uint32 {
+	if x != nil {
+		return x.Imported
+	}
+	return 0
+}
+
+func (x *ImportCorporateActionsResponse) GetSkipped() uint32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type ListCorporateActionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Limit         uint32                 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        uint32                 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCorporateActionsRequest) Reset() {
+	*x = ListCorporateActionsRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCorporateActionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCorporateActionsRequest) ProtoMessage() {}
+
+func (x *ListCorporateActionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCorporateActionsRequest.ProtoReflect.Descriptor instead.
+func (*ListCorporateActionsRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCorporateActionsRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListCorporateActionsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCorporateActionsRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListCorporateActionsResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CorporateActions []*CorporateAction     `protobuf:"bytes,1,rep,name=corporate_actions,json=corporateActions,proto3" json:"corporate_actions,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListCorporateActionsResponse) Reset() {
+	*x = ListCorporateActionsResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCorporateActionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCorporateActionsResponse) ProtoMessage() {}
+
+func (x *ListCorporateActionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCorporateActionsResponse.ProtoReflect.Descriptor instead.
+func (*ListCorporateActionsResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCorporateActionsResponse) GetCorporateActions() []*CorporateAction {
+	if x != nil {
+		return x.CorporateActions
+	}
+	return nil
+}
+
+type CorporateAction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid          string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	ExDate        string                 `protobuf:"bytes,5,opt,name=ex_date,json=exDate,proto3" json:"ex_date,omitempty"`
+	PayDate       string                 `protobuf:"bytes,6,opt,name=pay_date,json=payDate,proto3" json:"pay_date,omitempty"`
+	RatioFrom     float64                `protobuf:"fixed64,7,opt,name=ratio_from,json=ratioFrom,proto3" json:"ratio_from,omitempty"`
+	RatioTo       float64                `protobuf:"fixed64,8,opt,name=ratio_to,json=ratioTo,proto3" json:"ratio_to,omitempty"`
+	CashAmount    float64                `protobuf:"fixed64,9,opt,name=cash_amount,json=cashAmount,proto3" json:"cash_amount,omitempty"`
+	NewSymbol     string                 `protobuf:"bytes,10,opt,name=new_symbol,json=newSymbol,proto3" json:"new_symbol,omitempty"`
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,12,opt,name=reason,proto3" json:"reason,omitempty"`
+	ProcessedAt   uint64                 `protobuf:"varint,13,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
+	CreatedAt     uint64                 `protobuf:"varint,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     uint64                 `protobuf:"varint,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CorporateAction) Reset() {
+	*x = CorporateAction{}
+	mi := &file_stock_v1_stock_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CorporateAction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorporateAction) ProtoMessage() {}
+
+func (x *CorporateAction) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorporateAction.ProtoReflect.Descriptor instead.
+func (*CorporateAction) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CorporateAction) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetExDate() string {
+	if x != nil {
+		return x.ExDate
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetPayDate() string {
+	if x != nil {
+		return x.PayDate
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetRatioFrom() float64 {
+	if x != nil {
+		return x.RatioFrom
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetRatioTo() float64 {
+	if x != nil {
+		return x.RatioTo
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetCashAmount() float64 {
+	if x != nil {
+		return x.CashAmount
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetNewSymbol() string {
+	if x != nil {
+		return x.NewSymbol
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CorporateAction) GetProcessedAt() uint64 {
+	if x != nil {
+		return x.ProcessedAt
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *CorporateAction) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type ListStatementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // CONFIRMATION | MONTHLY, empty for both
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        uint32                 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatementsRequest) Reset() {
+	*x = ListStatementsRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatementsRequest) ProtoMessage() {}
+
+func (x *ListStatementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStatementsRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListStatementsRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListStatementsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListStatementsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListStatementsRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListStatementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statements    []*Statement           `protobuf:"bytes,1,rep,name=statements,proto3" json:"statements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatementsResponse) Reset() {
+	*x = ListStatementsResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatementsResponse) ProtoMessage() {}
+
+func (x *ListStatementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStatementsResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListStatementsResponse) GetStatements() []*Statement {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
+type GetStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // html | csv
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatementRequest) Reset() {
+	*x = GetStatementRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementRequest) ProtoMessage() {}
+
+func (x *GetStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementRequest.ProtoReflect.Descriptor instead.
+func (*GetStatementRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetStatementRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *GetStatementRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type GetStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statement     *Statement             `protobuf:"bytes,1,opt,name=statement,proto3" json:"statement,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatementResponse) Reset() {
+	*x = GetStatementResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementResponse) ProtoMessage() {}
+
+func (x *GetStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementResponse.ProtoReflect.Descriptor instead.
+func (*GetStatementResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetStatementResponse) GetStatement() *Statement {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+func (x *GetStatementResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetStatementResponse) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type Statement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid          string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	UserId        uint64                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	PeriodStart   string                 `protobuf:"bytes,5,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd     string                 `protobuf:"bytes,6,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	CreatedAt     uint64                 `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Statement) Reset() {
+	*x = Statement{}
+	mi := &file_stock_v1_stock_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statement) ProtoMessage() {}
+
+func (x *Statement) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statement.ProtoReflect.Descriptor instead.
+func (*Statement) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Statement) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Statement) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Statement) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Statement) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Statement) GetPeriodStart() string {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return ""
+}
+
+func (x *Statement) GetPeriodEnd() string {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return ""
+}
+
+func (x *Statement) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type GetFeeScheduleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional, sets the tier from the 30-day volume of the user
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`           // BUY | SELL
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // optional, previews the fee of a fill of quantity at price
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Liquidity     string                 `protobuf:"bytes,6,opt,name=liquidity,proto3" json:"liquidity,omitempty"` // MAKER | TAKER, TAKER by default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeScheduleRequest) Reset() {
+	*x = GetFeeScheduleRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeScheduleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeScheduleRequest) ProtoMessage() {}
+
+func (x *GetFeeScheduleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeScheduleRequest.ProtoReflect.Descriptor instead.
+func (*GetFeeScheduleRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetFeeScheduleRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetFeeScheduleRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetFeeScheduleRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *GetFeeScheduleRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *GetFeeScheduleRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *GetFeeScheduleRequest) GetLiquidity() string {
+	if x != nil {
+		return x.Liquidity
+	}
+	return ""
+}
+
+type GetFeeScheduleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedule      *FeeSchedule           `protobuf:"bytes,1,opt,name=schedule,proto3" json:"schedule,omitempty"`
+	Volume_30D    float64                `protobuf:"fixed64,2,opt,name=volume_30d,json=volume30d,proto3" json:"volume_30d,omitempty"`
+	Tier          string                 `protobuf:"bytes,3,opt,name=tier,proto3" json:"tier,omitempty"`
+	Preview       *FeePreview            `protobuf:"bytes,4,opt,name=preview,proto3" json:"preview,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeeScheduleResponse) Reset() {
+	*x = GetFeeScheduleResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeeScheduleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeeScheduleResponse) ProtoMessage() {}
+
+func (x *GetFeeScheduleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeeScheduleResponse.ProtoReflect.Descriptor instead.
+func (*GetFeeScheduleResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetFeeScheduleResponse) GetSchedule() *FeeSchedule {
+	if x != nil {
+		return x.Schedule
+	}
+	return nil
+}
+
+func (x *GetFeeScheduleResponse) GetVolume_30D() float64 {
+	if x != nil {
+		return x.Volume_30D
+	}
+	return 0
+}
+
+func (x *GetFeeScheduleResponse) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *GetFeeScheduleResponse) GetPreview() *FeePreview {
+	if x != nil {
+		return x.Preview
+	}
+	return nil
+}
+
+type FeeSchedule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Discount      float64                `protobuf:"fixed64,2,opt,name=discount,proto3" json:"discount,omitempty"`
+	Tiers         []*FeeTier             `protobuf:"bytes,3,rep,name=tiers,proto3" json:"tiers,omitempty"`
+	Minimums      []*FeeMinimum          `protobuf:"bytes,4,rep,name=minimums,proto3" json:"minimums,omitempty"`
+	PassThrough   []*PassThroughFee      `protobuf:"bytes,5,rep,name=pass_through,json=passThrough,proto3" json:"pass_through,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeSchedule) Reset() {
+	*x = FeeSchedule{}
+	mi := &file_stock_v1_stock_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSchedule) ProtoMessage() {}
+
+func (x *FeeSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeSchedule.ProtoReflect.Descriptor instead.
+func (*FeeSchedule) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *FeeSchedule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetTiers() []*FeeTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+func (x *FeeSchedule) GetMinimums() []*FeeMinimum {
+	if x != nil {
+		return x.Minimums
+	}
+	return nil
+}
+
+func (x *FeeSchedule) GetPassThrough() []*PassThroughFee {
+	if x != nil {
+		return x.PassThrough
+	}
+	return nil
+}
+
+type FeeTier struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MinVolume     float64                `protobuf:"fixed64,2,opt,name=min_volume,json=minVolume,proto3" json:"min_volume,omitempty"`
+	MakerRate     float64                `protobuf:"fixed64,3,opt,name=maker_rate,json=makerRate,proto3" json:"maker_rate,omitempty"`
+	TakerRate     float64                `protobuf:"fixed64,4,opt,name=taker_rate,json=takerRate,proto3" json:"taker_rate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeTier) Reset() {
+	*x = FeeTier{}
+	mi := &file_stock_v1_stock_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeTier) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeTier) ProtoMessage() {}
+
+func (x *FeeTier) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeTier.ProtoReflect.Descriptor instead.
+func (*FeeTier) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FeeTier) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeeTier) GetMinVolume() float64 {
+	if x != nil {
+		return x.MinVolume
+	}
+	return 0
+}
+
+func (x *FeeTier) GetMakerRate() float64 {
+	if x != nil {
+		return x.MakerRate
+	}
+	return 0
+}
+
+func (x *FeeTier) GetTakerRate() float64 {
+	if x != nil {
+		return x.TakerRate
+	}
+	return 0
+}
+
+type FeeMinimum struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeMinimum) Reset() {
+	*x = FeeMinimum{}
+	mi := &file_stock_v1_stock_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeMinimum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeMinimum) ProtoMessage() {}
+
+func (x *FeeMinimum) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeMinimum.ProtoReflect.Descriptor instead.
+func (*FeeMinimum) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FeeMinimum) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *FeeMinimum) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type PassThroughFee struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Side          string                 `protobuf:"bytes,2,opt,name=side,proto3" json:"side,omitempty"`
+	Rate          float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	PerUnit       float64                `protobuf:"fixed64,4,opt,name=per_unit,json=perUnit,proto3" json:"per_unit,omitempty"`
+	Max           float64                `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PassThroughFee) Reset() {
+	*x = PassThroughFee{}
+	mi := &file_stock_v1_stock_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PassThroughFee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PassThroughFee) ProtoMessage() {}
+
+func (x *PassThroughFee) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PassThroughFee.ProtoReflect.Descriptor instead.
+func (*PassThroughFee) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PassThroughFee) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PassThroughFee) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *PassThroughFee) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *PassThroughFee) GetPerUnit() float64 {
+	if x != nil {
+		return x.PerUnit
+	}
+	return 0
+}
+
+func (x *PassThroughFee) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type FeePreview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Notional      float64                `protobuf:"fixed64,1,opt,name=notional,proto3" json:"notional,omitempty"`
+	Rate          float64                `protobuf:"fixed64,2,opt,name=rate,proto3" json:"rate,omitempty"`
+	Commission    float64                `protobuf:"fixed64,3,opt,name=commission,proto3" json:"commission,omitempty"`
+	Discount      float64                `protobuf:"fixed64,4,opt,name=discount,proto3" json:"discount,omitempty"`
+	PassThrough   float64                `protobuf:"fixed64,5,opt,name=pass_through,json=passThrough,proto3" json:"pass_through,omitempty"`
+	Total         float64                `protobuf:"fixed64,6,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeePreview) Reset() {
+	*x = FeePreview{}
+	mi := &file_stock_v1_stock_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeePreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeePreview) ProtoMessage() {}
+
+func (x *FeePreview) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeePreview.ProtoReflect.Descriptor instead.
+func (*FeePreview) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *FeePreview) GetNotional() float64 {
+	if x != nil {
+		return x.Notional
+	}
+	return 0
+}
+
+func (x *FeePreview) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *FeePreview) GetCommission() float64 {
+	if x != nil {
+		return x.Commission
+	}
+	return 0
+}
+
+func (x *FeePreview) GetDiscount() float64 {
+	if x != nil {
+		return x.Discount
+	}
+	return 0
+}
+
+func (x *FeePreview) GetPassThrough() float64 {
+	if x != nil {
+		return x.PassThrough
+	}
+	return 0
+}
+
+func (x *FeePreview) GetTotal() float64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type PlaceOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          string                 `protobuf:"bytes,3,opt,name=side,proto3" json:"side,omitempty"`                                    // BUY | SELL
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`                                    // MARKET | LIMIT | STOP | STOP_LIMIT
+	TimeInForce   string                 `protobuf:"bytes,5,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"` // DAY | GTC, DAY by default
+	ExtendedHours bool                   `protobuf:"varint,6,opt,name=extended_hours,json=extendedHours,proto3" json:"extended_hours,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"` // limit price
+	StopPrice     float64                `protobuf:"fixed64,9,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PlaceOrderRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetExtendedHours() bool {
+	if x != nil {
+		return x.ExtendedHours
+	}
+	return false
+}
+
+func (x *PlaceOrderRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+type PlaceOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *StockOrder            `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PlaceOrderResponse) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CancelOrderRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *StockOrder            `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CancelOrderResponse) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type ReportExecutionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"` // order uuid
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	Liquidity     string                 `protobuf:"bytes,4,opt,name=liquidity,proto3" json:"liquidity,omitempty"`                      // MAKER | TAKER, TAKER by default
+	ExecutedAt    uint64                 `protobuf:"varint,5,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"` // unix seconds, now by default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportExecutionRequest) Reset() {
+	*x = ReportExecutionRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportExecutionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportExecutionRequest) ProtoMessage() {}
+
+func (x *ReportExecutionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportExecutionRequest.ProtoReflect.Descriptor instead.
+func (*ReportExecutionRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReportExecutionRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *ReportExecutionRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReportExecutionRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ReportExecutionRequest) GetLiquidity() string {
+	if x != nil {
+		return x.Liquidity
+	}
+	return ""
+}
+
+func (x *ReportExecutionRequest) GetExecutedAt() uint64 {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return 0
+}
+
+type ReportExecutionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *StockOrder            `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Fill          *Fill                  `protobuf:"bytes,2,opt,name=fill,proto3" json:"fill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportExecutionResponse) Reset() {
+	*x = ReportExecutionResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportExecutionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportExecutionResponse) ProtoMessage() {}
+
+func (x *ReportExecutionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportExecutionResponse.ProtoReflect.Descriptor instead.
+func (*ReportExecutionResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ReportExecutionResponse) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *ReportExecutionResponse) GetFill() *Fill {
+	if x != nil {
+		return x.Fill
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uuid          string                 `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetOrderRequest) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *StockOrder            `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Fills         []*Fill                `protobuf:"bytes,2,rep,name=fills,proto3" json:"fills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOrderResponse) GetOrder() *StockOrder {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *GetOrderResponse) GetFills() []*Fill {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        uint64                 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // empty for every status
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        uint32                 `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListOrdersRequest) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetOffset() uint32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*StockOrder          `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*StockOrder {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+type StockOrder struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid           string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	UserId         uint64                 `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side           string                 `protobuf:"bytes,5,opt,name=side,proto3" json:"side,omitempty"`
+	Type           string                 `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	TimeInForce    string                 `protobuf:"bytes,7,opt,name=time_in_force,json=timeInForce,proto3" json:"time_in_force,omitempty"`
+	ExtendedHours  bool                   `protobuf:"varint,8,opt,name=extended_hours,json=extendedHours,proto3" json:"extended_hours,omitempty"`
+	Price          float64                `protobuf:"fixed64,9,opt,name=price,proto3" json:"price,omitempty"`
+	StopPrice      float64                `protobuf:"fixed64,10,opt,name=stop_price,json=stopPrice,proto3" json:"stop_price,omitempty"`
+	Quantity       float64                `protobuf:"fixed64,11,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQuantity float64                `protobuf:"fixed64,12,opt,name=filled_quantity,json=filledQuantity,proto3" json:"filled_quantity,omitempty"`
+	Status         string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt      uint64                 `protobuf:"varint,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt      uint64                 `protobuf:"varint,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      uint64                 `protobuf:"varint,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StockOrder) Reset() {
+	*x = StockOrder{}
+	mi := &file_stock_v1_stock_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockOrder) ProtoMessage() {}
+
+func (x *StockOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockOrder.ProtoReflect.Descriptor instead.
+func (*StockOrder) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *StockOrder) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockOrder) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *StockOrder) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StockOrder) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *StockOrder) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *StockOrder) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *StockOrder) GetTimeInForce() string {
+	if x != nil {
+		return x.TimeInForce
+	}
+	return ""
+}
+
+func (x *StockOrder) GetExtendedHours() bool {
+	if x != nil {
+		return x.ExtendedHours
+	}
+	return false
+}
+
+func (x *StockOrder) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *StockOrder) GetStopPrice() float64 {
+	if x != nil {
+		return x.StopPrice
+	}
+	return 0
+}
+
+func (x *StockOrder) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StockOrder) GetFilledQuantity() float64 {
+	if x != nil {
+		return x.FilledQuantity
+	}
+	return 0
+}
+
+func (x *StockOrder) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StockOrder) GetExpiresAt() uint64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *StockOrder) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *StockOrder) GetUpdatedAt() uint64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type Fill struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             uint64                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uuid           string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	OrderUuid      string                 `protobuf:"bytes,3,opt,name=order_uuid,json=orderUuid,proto3" json:"order_uuid,omitempty"`
+	UserId         uint64                 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol         string                 `protobuf:"bytes,5,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side           string                 `protobuf:"bytes,6,opt,name=side,proto3" json:"side,omitempty"`
+	Quantity       float64                `protobuf:"fixed64,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price          float64                `protobuf:"fixed64,8,opt,name=price,proto3" json:"price,omitempty"`
+	Liquidity      string                 `protobuf:"bytes,9,opt,name=liquidity,proto3" json:"liquidity,omitempty"`
+	Commission     float64                `protobuf:"fixed64,10,opt,name=commission,proto3" json:"commission,omitempty"`
+	PassThroughFee float64                `protobuf:"fixed64,11,opt,name=pass_through_fee,json=passThroughFee,proto3" json:"pass_through_fee,omitempty"`
+	Fee            float64                `protobuf:"fixed64,12,opt,name=fee,proto3" json:"fee,omitempty"`
+	RealizedPnl    float64                `protobuf:"fixed64,13,opt,name=realized_pnl,json=realizedPnl,proto3" json:"realized_pnl,omitempty"`
+	ExecutedAt     uint64                 `protobuf:"varint,14,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Fill) Reset() {
+	*x = Fill{}
+	mi := &file_stock_v1_stock_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fill) ProtoMessage() {}
+
+func (x *Fill) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fill.ProtoReflect.Descriptor instead.
+func (*Fill) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Fill) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Fill) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *Fill) GetOrderUuid() string {
+	if x != nil {
+		return x.OrderUuid
+	}
+	return ""
+}
+
+func (x *Fill) GetUserId() uint64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Fill) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Fill) GetSide() string {
+	if x != nil {
+		return x.Side
+	}
+	return ""
+}
+
+func (x *Fill) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Fill) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Fill) GetLiquidity() string {
+	if x != nil {
+		return x.Liquidity
+	}
+	return ""
+}
+
+func (x *Fill) GetCommission() float64 {
+	if x != nil {
+		return x.Commission
+	}
+	return 0
+}
+
+func (x *Fill) GetPassThroughFee() float64 {
+	if x != nil {
+		return x.PassThroughFee
+	}
+	return 0
+}
+
+func (x *Fill) GetFee() float64 {
+	if x != nil {
+		return x.Fee
+	}
+	return 0
+}
+
+func (x *Fill) GetRealizedPnl() float64 {
+	if x != nil {
+		return x.RealizedPnl
+	}
+	return 0
+}
+
+func (x *Fill) GetExecutedAt() uint64 {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return 0
+}
+
+// ListHoldersRequest pages through the users holding a position in the symbol, by user id
+type ListHoldersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	AfterUserId   uint64                 `protobuf:"varint,2,opt,name=after_user_id,json=afterUserId,proto3" json:"after_user_id,omitempty"`
+	Limit         uint32                 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldersRequest) Reset() {
+	*x = ListHoldersRequest{}
+	mi := &file_stock_v1_stock_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldersRequest) ProtoMessage() {}
+
+func (x *ListHoldersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldersRequest.ProtoReflect.Descriptor instead.
+func (*ListHoldersRequest) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListHoldersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListHoldersRequest) GetAfterUserId() uint64 {
+	if x != nil {
+		return x.AfterUserId
+	}
+	return 0
+}
+
+func (x *ListHoldersRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListHoldersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserIds       []uint64               `protobuf:"varint,1,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Total         uint64                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // holders of the symbol
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHoldersResponse) Reset() {
+	*x = ListHoldersResponse{}
+	mi := &file_stock_v1_stock_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHoldersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHoldersResponse) ProtoMessage() {}
+
+func (x *ListHoldersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_stock_v1_stock_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHoldersResponse.ProtoReflect.Descriptor instead.
+func (*ListHoldersResponse) Descriptor() ([]byte, []int) {
+	return file_stock_v1_stock_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListHoldersResponse) GetUserIds() []uint64 {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *ListHoldersResponse) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
 var File_stock_v1_stock_proto protoreflect.FileDescriptor
 
 const file_stock_v1_stock_proto_rawDesc = "" +
@@ -109,9 +2306,219 @@ const file_stock_v1_stock_proto_rawDesc = "" +
 	"\x14stock/v1/stock.proto\x12\bstock.v1\x1a\x1cgoogle/api/annotations.proto\"\r\n" +
 	"\vPingRequest\"(\n" +
 	"\fPingResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2]\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"Q\n" +
+	"\x1dImportCorporateActionsRequest\x12\x16\n" +
+	"\x06format\x18\x01 \x01(\tR\x06format\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent\"V\n" +
+	"\x1eImportCorporateActionsResponse\x12\x1a\n" +
+	"\bimported\x18\x01 \x01(\rR\bimported\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\rR\askipped\"c\n" +
+	"\x1bListCorporateActionsRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\rR\x06offset\"f\n" +
+	"\x1cListCorporateActionsResponse\x12F\n" +
+	"\x11corporate_actions\x18\x01 \x03(\v2\x19.stock.v1.CorporateActionR\x10corporateActions\"\xa0\x03\n" +
+	"\x0fCorporateAction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x17\n" +
+	"\aex_date\x18\x05 \x01(\tR\x06exDate\x12\x19\n" +
+	"\bpay_date\x18\x06 \x01(\tR\apayDate\x12\x1d\n" +
+	"\n" +
+	"ratio_from\x18\a \x01(\x01R\tratioFrom\x12\x19\n" +
+	"\bratio_to\x18\b \x01(\x01R\aratioTo\x12\x1f\n" +
+	"\vcash_amount\x18\t \x01(\x01R\n" +
+	"cashAmount\x12\x1d\n" +
+	"\n" +
+	"new_symbol\x18\n" +
+	" \x01(\tR\tnewSymbol\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\f \x01(\tR\x06reason\x12!\n" +
+	"\fprocessed_at\x18\r \x01(\x04R\vprocessedAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\x04R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\x04R\tupdatedAt\"r\n" +
+	"\x15ListStatementsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\rR\x06offset\"M\n" +
+	"\x16ListStatementsResponse\x123\n" +
+	"\n" +
+	"statements\x18\x01 \x03(\v2\x13.stock.v1.StatementR\n" +
+	"statements\"A\n" +
+	"\x13GetStatementRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\x86\x01\n" +
+	"\x14GetStatementResponse\x121\n" +
+	"\tstatement\x18\x01 \x01(\v2\x13.stock.v1.StatementR\tstatement\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\fR\acontent\"\xbd\x01\n" +
+	"\tStatement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12!\n" +
+	"\fperiod_start\x18\x05 \x01(\tR\vperiodStart\x12\x1d\n" +
+	"\n" +
+	"period_end\x18\x06 \x01(\tR\tperiodEnd\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x04R\tcreatedAt\"\xac\x01\n" +
+	"\x15GetFeeScheduleRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x03 \x01(\tR\x04side\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1c\n" +
+	"\tliquidity\x18\x06 \x01(\tR\tliquidity\"\xae\x01\n" +
+	"\x16GetFeeScheduleResponse\x121\n" +
+	"\bschedule\x18\x01 \x01(\v2\x15.stock.v1.FeeScheduleR\bschedule\x12\x1d\n" +
+	"\n" +
+	"volume_30d\x18\x02 \x01(\x01R\tvolume30d\x12\x12\n" +
+	"\x04tier\x18\x03 \x01(\tR\x04tier\x12.\n" +
+	"\apreview\x18\x04 \x01(\v2\x14.stock.v1.FeePreviewR\apreview\"\xd5\x01\n" +
+	"\vFeeSchedule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bdiscount\x18\x02 \x01(\x01R\bdiscount\x12'\n" +
+	"\x05tiers\x18\x03 \x03(\v2\x11.stock.v1.FeeTierR\x05tiers\x120\n" +
+	"\bminimums\x18\x04 \x03(\v2\x14.stock.v1.FeeMinimumR\bminimums\x12;\n" +
+	"\fpass_through\x18\x05 \x03(\v2\x18.stock.v1.PassThroughFeeR\vpassThrough\"z\n" +
+	"\aFeeTier\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"min_volume\x18\x02 \x01(\x01R\tminVolume\x12\x1d\n" +
+	"\n" +
+	"maker_rate\x18\x03 \x01(\x01R\tmakerRate\x12\x1d\n" +
+	"\n" +
+	"taker_rate\x18\x04 \x01(\x01R\ttakerRate\":\n" +
+	"\n" +
+	"FeeMinimum\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"y\n" +
+	"\x0ePassThroughFee\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04side\x18\x02 \x01(\tR\x04side\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\x12\x19\n" +
+	"\bper_unit\x18\x04 \x01(\x01R\aperUnit\x12\x10\n" +
+	"\x03max\x18\x05 \x01(\x01R\x03max\"\xb1\x01\n" +
+	"\n" +
+	"FeePreview\x12\x1a\n" +
+	"\bnotional\x18\x01 \x01(\x01R\bnotional\x12\x12\n" +
+	"\x04rate\x18\x02 \x01(\x01R\x04rate\x12\x1e\n" +
+	"\n" +
+	"commission\x18\x03 \x01(\x01R\n" +
+	"commission\x12\x1a\n" +
+	"\bdiscount\x18\x04 \x01(\x01R\bdiscount\x12!\n" +
+	"\fpass_through\x18\x05 \x01(\x01R\vpassThrough\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x01R\x05total\"\x88\x02\n" +
+	"\x11PlaceOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x03 \x01(\tR\x04side\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\"\n" +
+	"\rtime_in_force\x18\x05 \x01(\tR\vtimeInForce\x12%\n" +
+	"\x0eextended_hours\x18\x06 \x01(\bR\rextendedHours\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\b \x01(\x01R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\t \x01(\x01R\tstopPrice\"@\n" +
+	"\x12PlaceOrderResponse\x12*\n" +
+	"\x05order\x18\x01 \x01(\v2\x14.stock.v1.StockOrderR\x05order\"@\n" +
+	"\x12CancelOrderRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"A\n" +
+	"\x13CancelOrderResponse\x12*\n" +
+	"\x05order\x18\x01 \x01(\v2\x14.stock.v1.StockOrderR\x05order\"\x9d\x01\n" +
+	"\x16ReportExecutionRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x03 \x01(\x01R\x05price\x12\x1c\n" +
+	"\tliquidity\x18\x04 \x01(\tR\tliquidity\x12\x1f\n" +
+	"\vexecuted_at\x18\x05 \x01(\x04R\n" +
+	"executedAt\"i\n" +
+	"\x17ReportExecutionResponse\x12*\n" +
+	"\x05order\x18\x01 \x01(\v2\x14.stock.v1.StockOrderR\x05order\x12\"\n" +
+	"\x04fill\x18\x02 \x01(\v2\x0e.stock.v1.FillR\x04fill\"%\n" +
+	"\x0fGetOrderRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"d\n" +
+	"\x10GetOrderResponse\x12*\n" +
+	"\x05order\x18\x01 \x01(\v2\x14.stock.v1.StockOrderR\x05order\x12$\n" +
+	"\x05fills\x18\x02 \x03(\v2\x0e.stock.v1.FillR\x05fills\"r\n" +
+	"\x11ListOrdersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\rR\x06offset\"B\n" +
+	"\x12ListOrdersResponse\x12,\n" +
+	"\x06orders\x18\x01 \x03(\v2\x14.stock.v1.StockOrderR\x06orders\"\xc3\x03\n" +
+	"\n" +
+	"StockOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x05 \x01(\tR\x04side\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\"\n" +
+	"\rtime_in_force\x18\a \x01(\tR\vtimeInForce\x12%\n" +
+	"\x0eextended_hours\x18\b \x01(\bR\rextendedHours\x12\x14\n" +
+	"\x05price\x18\t \x01(\x01R\x05price\x12\x1d\n" +
+	"\n" +
+	"stop_price\x18\n" +
+	" \x01(\x01R\tstopPrice\x12\x1a\n" +
+	"\bquantity\x18\v \x01(\x01R\bquantity\x12'\n" +
+	"\x0ffilled_quantity\x18\f \x01(\x01R\x0efilledQuantity\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\x04R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\x04R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\x04R\tupdatedAt\"\xfe\x02\n" +
+	"\x04Fill\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\x12\x1d\n" +
+	"\n" +
+	"order_uuid\x18\x03 \x01(\tR\torderUuid\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x04R\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x05 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04side\x18\x06 \x01(\tR\x04side\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\b \x01(\x01R\x05price\x12\x1c\n" +
+	"\tliquidity\x18\t \x01(\tR\tliquidity\x12\x1e\n" +
+	"\n" +
+	"commission\x18\n" +
+	" \x01(\x01R\n" +
+	"commission\x12(\n" +
+	"\x10pass_through_fee\x18\v \x01(\x01R\x0epassThroughFee\x12\x10\n" +
+	"\x03fee\x18\f \x01(\x01R\x03fee\x12!\n" +
+	"\frealized_pnl\x18\r \x01(\x01R\vrealizedPnl\x12\x1f\n" +
+	"\vexecuted_at\x18\x0e \x01(\x04R\n" +
+	"executedAt\"f\n" +
+	"\x12ListHoldersRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\"\n" +
+	"\rafter_user_id\x18\x02 \x01(\x04R\vafterUserId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\rR\x05limit\"F\n" +
+	"\x13ListHoldersResponse\x12\x19\n" +
+	"\buser_ids\x18\x01 \x03(\x04R\auserIds\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x04R\x05total2\xa3\v\n" +
 	"\fStockService\x12M\n" +
-	"\x04Ping\x12\x15.stock.v1.PingRequest\x1a\x16.stock.v1.PingResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/stock/v1/pingBm\n" +
+	"\x04Ping\x12\x15.stock.v1.PingRequest\x1a\x16.stock.v1.PingResponse\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/stock/v1/ping\x12\x9e\x01\n" +
+	"\x16ImportCorporateActions\x12'.stock.v1.ImportCorporateActionsRequest\x1a(.stock.v1.ImportCorporateActionsResponse\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/api/v1/stock/corporate-actions/import\x12\x8e\x01\n" +
+	"\x14ListCorporateActions\x12%.stock.v1.ListCorporateActionsRequest\x1a&.stock.v1.ListCorporateActionsResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/stock/corporate-actions\x12u\n" +
+	"\x0eListStatements\x12\x1f.stock.v1.ListStatementsRequest\x1a .stock.v1.ListStatementsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/api/v1/stock/statements\x12v\n" +
+	"\fGetStatement\x12\x1d.stock.v1.GetStatementRequest\x1a\x1e.stock.v1.GetStatementResponse\"'\x82\xd3\xe4\x93\x02!\x12\x1f/api/v1/stock/statements/{uuid}\x12h\n" +
+	"\n" +
+	"PlaceOrder\x12\x1b.stock.v1.PlaceOrderRequest\x1a\x1c.stock.v1.PlaceOrderResponse\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/api/v1/stock/orders\x12y\n" +
+	"\vCancelOrder\x12\x1c.stock.v1.CancelOrderRequest\x1a\x1d.stock.v1.CancelOrderResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/v1/stock/orders/{uuid}/cancel\x12\x89\x01\n" +
+	"\x0fReportExecution\x12 .stock.v1.ReportExecutionRequest\x1a!.stock.v1.ReportExecutionResponse\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/api/v1/stock/orders/{uuid}/executions\x12f\n" +
+	"\bGetOrder\x12\x19.stock.v1.GetOrderRequest\x1a\x1a.stock.v1.GetOrderResponse\"#\x82\xd3\xe4\x93\x02\x1d\x12\x1b/api/v1/stock/orders/{uuid}\x12e\n" +
+	"\n" +
+	"ListOrders\x12\x1b.stock.v1.ListOrdersRequest\x1a\x1c.stock.v1.ListOrdersResponse\"\x1c\x82\xd3\xe4\x93\x02\x16\x12\x14/api/v1/stock/orders\x12o\n" +
+	"\x0eGetFeeSchedule\x12\x1f.stock.v1.GetFeeScheduleRequest\x1a .stock.v1.GetFeeScheduleResponse\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/api/v1/stock/fees\x12r\n" +
+	"\vListHolders\x12\x1c.stock.v1.ListHoldersRequest\x1a\x1d.stock.v1.ListHoldersResponse\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/stock/holders/{symbol}Bm\n" +
 	"\fcom.stock.v1B\n" +
 	"StockProtoP\x01Z\x10/gen/go/stock/v1\xa2\x02\x03SXX\xaa\x02\bStock.V1\xca\x02\bStock\\V1\xe2\x02\x14Stock\\V1\\GPBMetadata\xea\x02\tStock::V1b\x06proto3"
 
@@ -127,19 +2534,87 @@ func file_stock_v1_stock_proto_rawDescGZIP() []byte {
 	return file_stock_v1_stock_proto_rawDescData
 }
 
-var file_stock_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_stock_v1_stock_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
 var file_stock_v1_stock_proto_goTypes = []any{
-	(*PingRequest)(nil),  // 0: stock.v1.PingRequest
-	(*PingResponse)(nil), // 1: stock.v1.PingResponse
+	(*PingRequest)(nil),                    // 0: stock.v1.PingRequest
+	(*PingResponse)(nil),                   // 1: stock.v1.PingResponse
+	(*ImportCorporateActionsRequest)(nil),  // 2: stock.v1.ImportCorporateActionsRequest
+	(*ImportCorporateActionsResponse)(nil), // 3: stock.v1.ImportCorporateActionsResponse
+	(*ListCorporateActionsRequest)(nil),    // 4: stock.v1.ListCorporateActionsRequest
+	(*ListCorporateActionsResponse)(nil),   // 5: stock.v1.ListCorporateActionsResponse
+	(*CorporateAction)(nil),                // 6: stock.v1.CorporateAction
+	(*ListStatementsRequest)(nil),          // 7: stock.v1.ListStatementsRequest
+	(*ListStatementsResponse)(nil),         // 8: stock.v1.ListStatementsResponse
+	(*GetStatementRequest)(nil),            // 9: stock.v1.GetStatementRequest
+	(*GetStatementResponse)(nil),           // 10: stock.v1.GetStatementResponse
+	(*Statement)(nil),                      // 11: stock.v1.Statement
+	(*GetFeeScheduleRequest)(nil),          // 12: stock.v1.GetFeeScheduleRequest
+	(*GetFeeScheduleResponse)(nil),         // 13: stock.v1.GetFeeScheduleResponse
+	(*FeeSchedule)(nil),                    // 14: stock.v1.FeeSchedule
+	(*FeeTier)(nil),                        // 15: stock.v1.FeeTier
+	(*FeeMinimum)(nil),                     // 16: stock.v1.FeeMinimum
+	(*PassThroughFee)(nil),                 // 17: stock.v1.PassThroughFee
+	(*FeePreview)(nil),                     // 18: stock.v1.FeePreview
+	(*PlaceOrderRequest)(nil),              // 19: stock.v1.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),             // 20: stock.v1.PlaceOrderResponse
+	(*CancelOrderRequest)(nil),             // 21: stock.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),            // 22: stock.v1.CancelOrderResponse
+	(*ReportExecutionRequest)(nil),         // 23: stock.v1.ReportExecutionRequest
+	(*ReportExecutionResponse)(nil),        // 24: stock.v1.ReportExecutionResponse
+	(*GetOrderRequest)(nil),                // 25: stock.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),               // 26: stock.v1.GetOrderResponse
+	(*ListOrdersRequest)(nil),              // 27: stock.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),             // 28: stock.v1.ListOrdersResponse
+	(*StockOrder)(nil),                     // 29: stock.v1.StockOrder
+	(*Fill)(nil),                           // 30: stock.v1.Fill
+	(*ListHoldersRequest)(nil),             // 31: stock.v1.ListHoldersRequest
+	(*ListHoldersResponse)(nil),            // 32: stock.v1.ListHoldersResponse
 }
 var file_stock_v1_stock_proto_depIdxs = []int32{
-	0, // 0: stock.v1.StockService.Ping:input_type -> stock.v1.PingRequest
-	1, // 1: stock.v1.StockService.Ping:output_type -> stock.v1.PingResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	6,  // 0: stock.v1.ListCorporateActionsResponse.corporate_actions:type_name -> stock.v1.CorporateAction
+	11, // 1: stock.v1.ListStatementsResponse.statements:type_name -> stock.v1.Statement
+	11, // 2: stock.v1.GetStatementResponse.statement:type_name -> stock.v1.Statement
+	14, // 3: stock.v1.GetFeeScheduleResponse.schedule:type_name -> stock.v1.FeeSchedule
+	18, // 4: stock.v1.GetFeeScheduleResponse.preview:type_name -> stock.v1.FeePreview
+	15, // 5: stock.v1.FeeSchedule.tiers:type_name -> stock.v1.FeeTier
+	16, // 6: stock.v1.FeeSchedule.minimums:type_name -> stock.v1.FeeMinimum
+	17, // 7: stock.v1.FeeSchedule.pass_through:type_name -> stock.v1.PassThroughFee
+	29, // 8: stock.v1.PlaceOrderResponse.order:type_name -> stock.v1.StockOrder
+	29, // 9: stock.v1.CancelOrderResponse.order:type_name -> stock.v1.StockOrder
+	29, // 10: stock.v1.ReportExecutionResponse.order:type_name -> stock.v1.StockOrder
+	30, // 11: stock.v1.ReportExecutionResponse.fill:type_name -> stock.v1.Fill
+	29, // 12: stock.v1.GetOrderResponse.order:type_name -> stock.v1.StockOrder
+	30, // 13: stock.v1.GetOrderResponse.fills:type_name -> stock.v1.Fill
+	29, // 14: stock.v1.ListOrdersResponse.orders:type_name -> stock.v1.StockOrder
+	0,  // 15: stock.v1.StockService.Ping:input_type -> stock.v1.PingRequest
+	2,  // 16: stock.v1.StockService.ImportCorporateActions:input_type -> stock.v1.ImportCorporateActionsRequest
+	4,  // 17: stock.v1.StockService.ListCorporateActions:input_type -> stock.v1.ListCorporateActionsRequest
+	7,  // 18: stock.v1.StockService.ListStatements:input_type -> stock.v1.ListStatementsRequest
+	9,  // 19: stock.v1.StockService.GetStatement:input_type -> stock.v1.GetStatementRequest
+	19, // 20: stock.v1.StockService.PlaceOrder:input_type -> stock.v1.PlaceOrderRequest
+	21, // 21: stock.v1.StockService.CancelOrder:input_type -> stock.v1.CancelOrderRequest
+	23, // 22: stock.v1.StockService.ReportExecution:input_type -> stock.v1.ReportExecutionRequest
+	25, // 23: stock.v1.StockService.GetOrder:input_type -> stock.v1.GetOrderRequest
+	27, // 24: stock.v1.StockService.ListOrders:input_type -> stock.v1.ListOrdersRequest
+	12, // 25: stock.v1.StockService.GetFeeSchedule:input_type -> stock.v1.GetFeeScheduleRequest
+	31, // 26: stock.v1.StockService.ListHolders:input_type -> stock.v1.ListHoldersRequest
+	1,  // 27: stock.v1.StockService.Ping:output_type -> stock.v1.PingResponse
+	3,  // 28: stock.v1.StockService.ImportCorporateActions:output_type -> stock.v1.ImportCorporateActionsResponse
+	5,  // 29: stock.v1.StockService.ListCorporateActions:output_type -> stock.v1.ListCorporateActionsResponse
+	8,  // 30: stock.v1.StockService.ListStatements:output_type -> stock.v1.ListStatementsResponse
+	10, // 31: stock.v1.StockService.GetStatement:output_type -> stock.v1.GetStatementResponse
+	20, // 32: stock.v1.StockService.PlaceOrder:output_type -> stock.v1.PlaceOrderResponse
+	22, // 33: stock.v1.StockService.CancelOrder:output_type -> stock.v1.CancelOrderResponse
+	24, // 34: stock.v1.StockService.ReportExecution:output_type -> stock.v1.ReportExecutionResponse
+	26, // 35: stock.v1.StockService.GetOrder:output_type -> stock.v1.GetOrderResponse
+	28, // 36: stock.v1.StockService.ListOrders:output_type -> stock.v1.ListOrdersResponse
+	13, // 37: stock.v1.StockService.GetFeeSchedule:output_type -> stock.v1.GetFeeScheduleResponse
+	32, // 38: stock.v1.StockService.ListHolders:output_type -> stock.v1.ListHoldersResponse
+	27, // [27:39] is the sub-list for method output_type
+	15, // [15:27] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_stock_v1_stock_proto_init() }
@@ -153,7 +2628,7 @@ func file_stock_v1_stock_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_stock_v1_stock_proto_rawDesc), len(file_stock_v1_stock_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   33,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
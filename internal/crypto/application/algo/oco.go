@@ -0,0 +1,84 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/internal/crypto/domain/repo"
+	"time"
+)
+
+// ocoStrategy works a take-profit limit leg and a stop-loss leg, the first leg to execute cancels
+// the other. Only one leg is ever at the venue: the limit leg rests there while the stop price is
+// watched, and once it is touched the limit leg is pulled and the stop leg is sent as a market
+// order for what the limit leg left unfilled, so the two legs cannot both fill the parent.
+type ocoStrategy struct {
+	prices repo.IPriceFeed
+}
+
+func NewOCOStrategy(prices repo.IPriceFeed) Strategy {
+	return &ocoStrategy{prices: prices}
+}
+
+func (s *ocoStrategy) Init(ctx context.Context, order *model.AlgoOrder) error {
+	p := order.Params
+	if p.LimitPrice <= 0 || p.StopPrice <= 0 {
+		return fmt.Errorf("limit_price and stop_price are required")
+	}
+	// A sell bracket takes profit above and stops out below the market, a buy bracket the other way round
+	if order.Side == model.SideSell && p.LimitPrice <= p.StopPrice {
+		return fmt.Errorf("limit_price must be above stop_price for a sell OCO")
+	}
+	if order.Side == model.SideBuy && p.LimitPrice >= p.StopPrice {
+		return fmt.Errorf("limit_price must be below stop_price for a buy OCO")
+	}
+	return nil
+}
+
+func (s *ocoStrategy) Tick(
+	ctx context.Context,
+	now time.Time,
+	order *model.AlgoOrder,
+	children []*model.ChildOrder,
+) (Decision, error) {
+	if len(children) == 0 {
+		return Decision{
+			Release: []*model.ChildOrder{order.NewChild(model.ChildTypeLimit, order.Params.LimitPrice, order.Quantity)},
+		}, nil
+	}
+
+	open := openChildren(children)
+	st := &order.State
+	if !st.StopTriggered {
+		price, ok := s.prices.LastPrice(order.Symbol)
+		if !ok || !s.stopTouched(order, price) {
+			// A limit leg that is no longer working leaves nothing to protect
+			return Decision{Done: len(open) == 0}, nil
+		}
+		st.StopTriggered = true
+	}
+
+	// The stop leg waits until the venue confirms the limit leg is off the book
+	if len(open) > 0 {
+		if len(children) > 1 {
+			return Decision{Done: true}, nil
+		}
+		return Decision{Cancel: open}, nil
+	}
+	if len(children) == 1 && order.RemainingQuantity() > epsilon {
+		return Decision{
+			Release: []*model.ChildOrder{order.NewChild(model.ChildTypeMarket, 0, order.RemainingQuantity())},
+			Done:    true,
+		}, nil
+	}
+	return Decision{Done: true}, nil
+}
+
+// stopTouched reports whether the price reached the stop-loss, below the market for a sell
+// bracket and above it for a buy one
+func (s *ocoStrategy) stopTouched(order *model.AlgoOrder, price float64) bool {
+	if order.Side == model.SideSell {
+		return price <= order.Params.StopPrice
+	}
+	return price >= order.Params.StopPrice
+}
@@ -0,0 +1,376 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/crypto/application/dto"
+	"simple-securities/internal/crypto/application/mapper"
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/internal/crypto/domain/repo"
+	"simple-securities/pkg/errors"
+	"simple-securities/pkg/fee"
+	"simple-securities/pkg/kafka"
+	"simple-securities/pkg/uuid"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Event types published on the algo order topic
+const (
+	EventAlgoOrderAccepted  = "algo_order.accepted"
+	EventAlgoOrderUpdated   = "algo_order.updated"
+	EventAlgoOrderCompleted = "algo_order.completed"
+	EventAlgoOrderCancelled = "algo_order.cancelled"
+	EventAlgoOrderFailed    = "algo_order.failed"
+	EventChildReleased      = "algo_order.child_released"
+	EventChildUpdated       = "algo_order.child_updated"
+)
+
+// EventPublisher is the part of kafka.MessageBus the scheduler needs
+type EventPublisher interface {
+	SendMessage(ctx context.Context, topic string, key string, partition int, event kafka.Event) error
+}
+
+type SchedulerConfig struct {
+	ServiceName  string
+	Topic        string
+	TickInterval time.Duration
+	// Fees prices the child order fills, no fee is charged when nil
+	Fees *fee.Schedule
+}
+
+// Scheduler owns the parent orders: it releases child orders according to the
+// strategy of every working order, tracks their executions and persists the
+// progress so that a restart resumes where it stopped.
+type Scheduler struct {
+	config     SchedulerConfig
+	repo       repo.IAlgoOrderRepo
+	gateway    repo.IOrderGateway
+	strategies map[model.AlgoType]Strategy
+	publisher  EventPublisher
+	logger     *zap.Logger
+	mu         sync.Mutex
+}
+
+func NewScheduler(
+	config SchedulerConfig,
+	algoOrderRepo repo.IAlgoOrderRepo,
+	gateway repo.IOrderGateway,
+	strategies map[model.AlgoType]Strategy,
+	publisher EventPublisher,
+	logger *zap.Logger,
+) *Scheduler {
+	if config.TickInterval <= 0 {
+		config.TickInterval = time.Second
+	}
+	return &Scheduler{
+		config:     config,
+		repo:       algoOrderRepo,
+		gateway:    gateway,
+		strategies: strategies,
+		publisher:  publisher,
+		logger:     logger,
+	}
+}
+
+// Start runs the scheduling loop until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.logger.Info("🛠 algo scheduler started", zap.Duration("tick_interval", s.config.TickInterval))
+
+	ticker := time.NewTicker(s.config.TickInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("algo scheduler stopped")
+			return nil
+		case now := <-ticker.C:
+			s.tick(ctx, now)
+		}
+	}
+}
+
+// Accept validates a new parent order and hands it over to the scheduler
+func (s *Scheduler) Accept(ctx context.Context, order *model.AlgoOrder) (*model.AlgoOrder, error) {
+	strategy, ok := s.strategies[order.Type]
+	if !ok {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported algo type %q", order.Type), nil)
+	}
+	if err := strategy.Init(ctx, order); err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	created, err := s.repo.Create(ctx, order)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to create algo order", err)
+	}
+	s.publish(ctx, EventAlgoOrderAccepted, created, nil)
+	return created, nil
+}
+
+// Cancel stops a working parent order and pulls its open child orders
+func (s *Scheduler) Cancel(ctx context.Context, id uint64) (*model.AlgoOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	order, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get algo order", err)
+	}
+	if order == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("algo order %d not found", id), nil)
+	}
+	if order.Status.IsTerminal() {
+		return nil, errors.NewBusinessError(fmt.Sprintf("algo order %d is already %s", id, order.Status), nil)
+	}
+
+	if err := s.finish(ctx, order, model.AlgoStatusCancelled, "cancelled by user"); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (s *Scheduler) tick(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	orders, err := s.repo.GetWorking(ctx)
+	if err != nil {
+		s.logger.Error("failed to load working algo orders", zap.Error(err))
+		return
+	}
+
+	for _, order := range orders {
+		if err := s.process(ctx, now, order); err != nil {
+			s.logger.Error("failed to process algo order",
+				zap.Uint64("algo_order_id", order.ID),
+				zap.String("type", string(order.Type)),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (s *Scheduler) process(ctx context.Context, now time.Time, order *model.AlgoOrder) error {
+	children, err := s.sync(ctx, order)
+	if err != nil {
+		return err
+	}
+
+	strategy, ok := s.strategies[order.Type]
+	if !ok {
+		return s.finish(ctx, order, model.AlgoStatusFailed, fmt.Sprintf("unsupported algo type %q", order.Type))
+	}
+
+	decision, err := strategy.Tick(ctx, now, order, children)
+	if err != nil {
+		return s.finish(ctx, order, model.AlgoStatusFailed, err.Error())
+	}
+
+	for _, child := range decision.Cancel {
+		if err := s.gateway.Cancel(ctx, child); err != nil {
+			s.logger.Warn("failed to cancel child order", zap.String("child_uuid", child.Uuid), zap.Error(err))
+		}
+	}
+
+	// Persist the strategy state before releasing, a crash in between is caught up on the next tick
+	if err := s.repo.Update(ctx, order); err != nil {
+		return err
+	}
+
+	for _, child := range decision.Release {
+		if err := s.release(ctx, order, child); err != nil {
+			return err
+		}
+		children = append(children, child)
+	}
+
+	if order.RemainingQuantity() <= epsilon {
+		return s.finish(ctx, order, model.AlgoStatusCompleted, "")
+	}
+	if decision.Done && len(openChildren(children)) == 0 {
+		return s.finish(ctx, order, model.AlgoStatusCompleted,
+			fmt.Sprintf("schedule finished with %g unfilled", order.RemainingQuantity()))
+	}
+	return nil
+}
+
+// sync refreshes the open child orders from the gateway and rolls their fills up to the parent
+func (s *Scheduler) sync(ctx context.Context, order *model.AlgoOrder) ([]*model.ChildOrder, error) {
+	children, err := s.repo.GetChildren(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var filled float64
+	for _, child := range children {
+		if child.Status.IsOpen() {
+			update, known, err := s.gateway.Poll(ctx, child)
+			if err != nil {
+				return nil, err
+			}
+			if !known {
+				// The venue lost the order (e.g. paper venue restarted), treat the rest as cancelled
+				update = model.ChildUpdate{
+					Status:         model.ChildStatusCancelled,
+					FilledQuantity: child.FilledQuantity,
+					AvgFillPrice:   child.AvgFillPrice,
+				}
+			}
+
+			if update.Status != child.Status || update.FilledQuantity != child.FilledQuantity {
+				charged, err := s.chargeFee(ctx, order, child, update)
+				if err != nil {
+					return nil, err
+				}
+				child.Fee += charged
+				child.Status = update.Status
+				child.FilledQuantity = update.FilledQuantity
+				child.AvgFillPrice = update.AvgFillPrice
+				if err := s.repo.UpdateChild(ctx, child); err != nil {
+					return nil, err
+				}
+				s.publish(ctx, EventChildUpdated, order, child)
+			}
+		}
+		filled += child.FilledQuantity
+	}
+
+	if filled != order.FilledQuantity {
+		order.FilledQuantity = filled
+		s.publish(ctx, EventAlgoOrderUpdated, order, nil)
+	}
+	return children, nil
+}
+
+// chargeFee prices the quantity filled since the last report, resting limit children add liquidity
+// and the others take it
+func (s *Scheduler) chargeFee(ctx context.Context, order *model.AlgoOrder, child *model.ChildOrder, update model.ChildUpdate) (float64, error) {
+	filled := update.FilledQuantity - child.FilledQuantity
+	if s.config.Fees == nil || filled <= epsilon {
+		return 0, nil
+	}
+
+	volume, err := s.repo.GetVolume(ctx, order.UserID, time.Now().Add(-fee.VolumeWindow))
+	if err != nil {
+		return 0, err
+	}
+
+	liquidity := fee.LiquidityTaker
+	if child.Type == model.ChildTypeLimit {
+		liquidity = fee.LiquidityMaker
+	}
+	notional := update.FilledQuantity*update.AvgFillPrice - child.FilledQuantity*child.AvgFillPrice
+	breakdown := s.config.Fees.Compute(fee.Trade{
+		Asset:     order.Symbol,
+		Side:      string(order.Side),
+		Liquidity: liquidity,
+		Quantity:  filled,
+		Price:     notional / filled,
+		Volume30d: volume,
+		Discount:  s.config.Fees.DiscountFor(order.UserID),
+	})
+	return breakdown.Total, nil
+}
+
+func (s *Scheduler) release(ctx context.Context, order *model.AlgoOrder, child *model.ChildOrder) error {
+	if _, err := s.repo.CreateChild(ctx, child); err != nil {
+		return err
+	}
+
+	if err := s.gateway.Submit(ctx, child); err != nil {
+		s.logger.Warn("child order rejected", zap.String("child_uuid", child.Uuid), zap.Error(err))
+		child.Status = model.ChildStatusRejected
+		if err := s.repo.UpdateChild(ctx, child); err != nil {
+			return err
+		}
+	}
+
+	s.publish(ctx, EventChildReleased, order, child)
+	return nil
+}
+
+// finish moves the parent order to a terminal status and cancels what is still working. The
+// children are polled before and after they are cancelled so that the fills since the last tick
+// are rolled up and charged; a cancel the venue rejects leaves the order and the child working.
+func (s *Scheduler) finish(ctx context.Context, order *model.AlgoOrder, status model.AlgoStatus, reason string) error {
+	children, err := s.sync(ctx, order)
+	if err != nil {
+		return err
+	}
+	open := openChildren(children)
+	if len(open) > 0 {
+		for _, child := range open {
+			if err := s.gateway.Cancel(ctx, child); err != nil {
+				return fmt.Errorf("failed to cancel child order %s: %w", child.Uuid, err)
+			}
+		}
+		if children, err = s.sync(ctx, order); err != nil {
+			return err
+		}
+	}
+	// The venue accepted the cancels, the children it still reports working are done with
+	for _, child := range openChildren(children) {
+		child.Status = model.ChildStatusCancelled
+		if err := s.repo.UpdateChild(ctx, child); err != nil {
+			return err
+		}
+		s.publish(ctx, EventChildUpdated, order, child)
+	}
+
+	order.Status = status
+	order.Reason = reason
+	if err := s.repo.Update(ctx, order); err != nil {
+		return err
+	}
+
+	eventType := EventAlgoOrderCompleted
+	switch status {
+	case model.AlgoStatusCancelled:
+		eventType = EventAlgoOrderCancelled
+	case model.AlgoStatusFailed:
+		eventType = EventAlgoOrderFailed
+	}
+	s.publish(ctx, eventType, order, nil)
+
+	s.logger.Info("✅ algo order finished",
+		zap.Uint64("algo_order_id", order.ID),
+		zap.String("status", string(status)),
+		zap.Float64("filled_quantity", order.FilledQuantity),
+		zap.String("reason", reason),
+	)
+	return nil
+}
+
+func (s *Scheduler) publish(ctx context.Context, eventType string, order *model.AlgoOrder, child *model.ChildOrder) {
+	if s.publisher == nil {
+		return
+	}
+
+	now := time.Now()
+	event := kafka.Event{
+		Meta: kafka.Meta{
+			ServiceName: s.config.ServiceName,
+			RequestID:   uuid.NewGoogleUUID(),
+			Code:        200,
+			Message:     eventType,
+			Timestamp:   now.Unix(),
+			Datetime:    now.Format("2006-01-02 15:04:05"),
+		},
+		Data: &dto.AlgoOrderEventDto{
+			EventType: eventType,
+			AlgoOrder: mapper.ToAlgoOrderDto(order, nil),
+			Child:     mapper.ToChildOrderDto(child),
+		},
+	}
+
+	if err := s.publisher.SendMessage(ctx, s.config.Topic, order.Uuid, -1, event); err != nil {
+		s.logger.Error("failed to publish algo order event", zap.String("event_type", eventType), zap.Error(err))
+	}
+}
@@ -0,0 +1,204 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/internal/crypto/domain/repo"
+	"simple-securities/internal/crypto/infras/gateway"
+	infrasRepo "simple-securities/internal/crypto/infras/repo"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// fakePrices is a price feed set by the tests
+type fakePrices map[string]float64
+
+func (p fakePrices) LastPrice(symbol string) (float64, bool) {
+	price, ok := p[symbol]
+	return price, ok
+}
+
+// rejectingGateway is a venue refusing to cancel orders
+type rejectingGateway struct {
+	repo.IOrderGateway
+}
+
+func (g rejectingGateway) Cancel(ctx context.Context, child *model.ChildOrder) error {
+	return errors.New("venue unavailable")
+}
+
+func newTestScheduler(t *testing.T, prices fakePrices) (*Scheduler, repo.IAlgoOrderRepo) {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "crypto.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000002_init_algo_orders",
+		"000015_add_algo_child_order_fees",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+
+	algoOrderRepo := infrasRepo.NewAlgoOrderRepo(db)
+	scheduler := NewScheduler(SchedulerConfig{}, algoOrderRepo, gateway.NewPaperOrderGateway(prices),
+		map[model.AlgoType]Strategy{
+			model.AlgoTypeOCO:  NewOCOStrategy(prices),
+			model.AlgoTypeTWAP: NewTWAPStrategy(),
+		}, nil, zap.NewNop())
+	return scheduler, algoOrderRepo
+}
+
+// childQuantities lists the quantities of the children of the order in release order
+func childQuantities(t *testing.T, algoOrderRepo repo.IAlgoOrderRepo, id uint64) string {
+	t.Helper()
+	children, err := algoOrderRepo.GetChildren(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var quantities []string
+	for _, child := range children {
+		quantities = append(quantities, fmt.Sprintf("%s %g %s", child.Type, child.Quantity, child.Status))
+	}
+	return fmt.Sprint(quantities)
+}
+
+func getOrder(t *testing.T, algoOrderRepo repo.IAlgoOrderRepo, id uint64) *model.AlgoOrder {
+	t.Helper()
+	order, err := algoOrderRepo.GetByID(context.Background(), id)
+	if err != nil || order == nil {
+		t.Fatalf("GetByID(%d) = %+v, %v", id, order, err)
+	}
+	return order
+}
+
+func TestTWAPReleasesDueSlices(t *testing.T) {
+	scheduler, algoOrderRepo := newTestScheduler(t, fakePrices{"BTCUSDT": 100})
+	ctx := context.Background()
+	start := time.Now().Truncate(time.Second)
+	order, err := scheduler.Accept(ctx, model.NewAlgoOrder(1, "BTCUSDT", model.SideBuy, model.AlgoTypeTWAP, 10,
+		model.AlgoParams{StartTime: start.Unix(), EndTime: start.Add(40 * time.Second).Unix(), Slices: 4}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scheduler.tick(ctx, start)
+	// The slices due while the scheduler was away are caught up in one child order
+	scheduler.tick(ctx, start.Add(25*time.Second))
+	scheduler.tick(ctx, start.Add(45*time.Second))
+	if order := getOrder(t, algoOrderRepo, order.ID); order.Status != model.AlgoStatusWorking {
+		t.Fatalf("order is %s before its last slice is filled", order.Status)
+	}
+	scheduler.tick(ctx, start.Add(46*time.Second))
+
+	if got := childQuantities(t, algoOrderRepo, order.ID); got != "[MARKET 2.5 FILLED MARKET 5 FILLED MARKET 2.5 FILLED]" {
+		t.Errorf("children = %s", got)
+	}
+	if order := getOrder(t, algoOrderRepo, order.ID); order.Status != model.AlgoStatusCompleted || order.FilledQuantity != 10 {
+		t.Errorf("order = %s with %g filled, want completed with 10", order.Status, order.FilledQuantity)
+	}
+}
+
+func TestValidateWindowBoundsSlices(t *testing.T) {
+	now := time.Now()
+	p := model.AlgoParams{StartTime: now.Unix(), EndTime: now.Unix() + 10, Slices: 11}
+	if err := validateWindow(now, &p); err == nil {
+		t.Error("validateWindow() accepted more slices than seconds in the window")
+	}
+	p.Slices = 10
+	if err := validateWindow(now, &p); err != nil {
+		t.Errorf("validateWindow() = %v", err)
+	}
+
+	// An order stored with more slices than nanoseconds in its window does not divide by zero
+	p = model.AlgoParams{StartTime: now.Unix(), EndTime: now.Unix() + 1, Slices: 2_000_000_000}
+	if idx, ok := dueSlice(time.Unix(p.StartTime, 0).Add(time.Hour), p); !ok || idx != p.Slices-1 {
+		t.Errorf("dueSlice() = %d, %t, want the last slice", idx, ok)
+	}
+}
+
+func TestOCOSendsOneLegAtATime(t *testing.T) {
+	prices := fakePrices{"BTCUSDT": 100}
+	scheduler, algoOrderRepo := newTestScheduler(t, prices)
+	ctx := context.Background()
+	order, err := scheduler.Accept(ctx, model.NewAlgoOrder(1, "BTCUSDT", model.SideSell, model.AlgoTypeOCO, 10,
+		model.AlgoParams{LimitPrice: 110, StopPrice: 90}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	scheduler.tick(ctx, now)
+	if got := childQuantities(t, algoOrderRepo, order.ID); got != "[LIMIT 10 NEW]" {
+		t.Fatalf("children = %s, want the limit leg only", got)
+	}
+
+	// The stop is touched: the limit leg is pulled before the stop leg is sent, so that a
+	// rebound through the limit price cannot fill both
+	prices["BTCUSDT"] = 85
+	scheduler.tick(ctx, now.Add(time.Second))
+	prices["BTCUSDT"] = 115
+	scheduler.tick(ctx, now.Add(2*time.Second))
+	scheduler.tick(ctx, now.Add(3*time.Second))
+
+	if got := childQuantities(t, algoOrderRepo, order.ID); got != "[LIMIT 10 CANCELLED MARKET 10 FILLED]" {
+		t.Errorf("children = %s", got)
+	}
+	if order := getOrder(t, algoOrderRepo, order.ID); order.Status != model.AlgoStatusCompleted || order.FilledQuantity != 10 {
+		t.Errorf("order = %s with %g filled, want completed with 10", order.Status, order.FilledQuantity)
+	}
+}
+
+func TestCancelRollsUpFillsSinceLastTick(t *testing.T) {
+	prices := fakePrices{"BTCUSDT": 100}
+	scheduler, algoOrderRepo := newTestScheduler(t, prices)
+	ctx := context.Background()
+	order, err := scheduler.Accept(ctx, model.NewAlgoOrder(1, "BTCUSDT", model.SideSell, model.AlgoTypeOCO, 10,
+		model.AlgoParams{LimitPrice: 110, StopPrice: 90}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	scheduler.tick(ctx, time.Now())
+
+	// The venue refuses the cancel, the limit leg stays working
+	gateway := scheduler.gateway
+	scheduler.gateway = rejectingGateway{gateway}
+	if _, err := scheduler.Cancel(ctx, order.ID); err == nil {
+		t.Fatal("Cancel() with a rejected child cancel succeeded")
+	}
+	if got := childQuantities(t, algoOrderRepo, order.ID); got != "[LIMIT 10 NEW]" {
+		t.Errorf("children = %s, want the limit leg still working", got)
+	}
+	if order := getOrder(t, algoOrderRepo, order.ID); order.Status != model.AlgoStatusWorking {
+		t.Errorf("order is %s after a rejected cancel, want working", order.Status)
+	}
+
+	// The limit leg fills before the next tick, the cancel reports it
+	scheduler.gateway = gateway
+	prices["BTCUSDT"] = 115
+	cancelled, err := scheduler.Cancel(ctx, order.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cancelled.FilledQuantity != 10 {
+		t.Errorf("cancelled order filled %g, want 10", cancelled.FilledQuantity)
+	}
+	if got := childQuantities(t, algoOrderRepo, order.ID); got != "[LIMIT 10 FILLED]" {
+		t.Errorf("children = %s, want the limit leg filled", got)
+	}
+}
@@ -0,0 +1,98 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/crypto/domain/model"
+	"time"
+)
+
+// epsilon absorbs float rounding when comparing quantities
+const epsilon = 1e-9
+
+// Decision is what a strategy wants the scheduler to do on a tick.
+type Decision struct {
+	// Release are new child orders to send to the venue
+	Release []*model.ChildOrder
+	// Cancel are open child orders to pull from the venue
+	Cancel []*model.ChildOrder
+	// Done means the strategy will not release any more child orders
+	Done bool
+}
+
+// Strategy drives the child orders of one algo type.
+type Strategy interface {
+	// Init validates the params and seeds the state of a new parent order
+	Init(ctx context.Context, order *model.AlgoOrder) error
+	// Tick is called periodically with the up to date children of a working parent order
+	Tick(ctx context.Context, now time.Time, order *model.AlgoOrder, children []*model.ChildOrder) (Decision, error)
+}
+
+// committedQuantity is the quantity that is either filled or still working at the venue
+func committedQuantity(children []*model.ChildOrder) float64 {
+	var qty float64
+	for _, c := range children {
+		if c.Status.IsOpen() {
+			qty += c.Quantity
+		} else {
+			qty += c.FilledQuantity
+		}
+	}
+	return qty
+}
+
+func hasFill(children []*model.ChildOrder) bool {
+	for _, c := range children {
+		if c.FilledQuantity > epsilon {
+			return true
+		}
+	}
+	return false
+}
+
+func openChildren(children []*model.ChildOrder) []*model.ChildOrder {
+	var open []*model.ChildOrder
+	for _, c := range children {
+		if c.Status.IsOpen() {
+			open = append(open, c)
+		}
+	}
+	return open
+}
+
+func validateWindow(now time.Time, p *model.AlgoParams) error {
+	if p.Slices == 0 {
+		return fmt.Errorf("slices must be greater than 0")
+	}
+	if p.StartTime == 0 {
+		p.StartTime = now.Unix()
+	}
+	if p.EndTime <= p.StartTime {
+		return fmt.Errorf("end_time must be after start_time")
+	}
+	if p.EndTime <= now.Unix() {
+		return fmt.Errorf("end_time must be in the future")
+	}
+	// Slices are released on the ticks of the scheduler, at most one per second
+	if int64(p.Slices) > p.EndTime-p.StartTime {
+		return fmt.Errorf("slices must not exceed the seconds between start_time and end_time")
+	}
+	return nil
+}
+
+// sliceInterval is the time between two consecutive slices of a scheduled order
+func sliceInterval(p model.AlgoParams) time.Duration {
+	// Orders accepted before slices were bounded by the window still get a usable interval
+	return max(time.Duration(p.EndTime-p.StartTime)*time.Second/time.Duration(p.Slices), time.Nanosecond)
+}
+
+// dueSlice returns the last slice index whose release time has passed, ok is false before the start.
+func dueSlice(now time.Time, p model.AlgoParams) (uint32, bool) {
+	start := time.Unix(p.StartTime, 0)
+	if now.Before(start) {
+		return 0, false
+	}
+	// Capped before the conversion, long past the end the index does not fit in 32 bits
+	idx := min(int64(now.Sub(start)/sliceInterval(p)), int64(p.Slices)-1)
+	return uint32(idx), true
+}
@@ -0,0 +1,82 @@
+package algo
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/internal/crypto/domain/repo"
+	"time"
+)
+
+// trailingStopStrategy follows the best price seen and sends a market order once the price retraces by the trail.
+type trailingStopStrategy struct {
+	prices repo.IPriceFeed
+}
+
+func NewTrailingStopStrategy(prices repo.IPriceFeed) Strategy {
+	return &trailingStopStrategy{prices: prices}
+}
+
+func (s *trailingStopStrategy) Init(ctx context.Context, order *model.AlgoOrder) error {
+	p := order.Params
+	if (p.TrailAmount > 0) == (p.TrailPercent > 0) {
+		return fmt.Errorf("exactly one of trail_amount or trail_percent is required")
+	}
+	if p.TrailPercent >= 100 {
+		return fmt.Errorf("trail_percent must be below 100")
+	}
+	if price, ok := s.prices.LastPrice(order.Symbol); ok {
+		order.State.Watermark = price
+	}
+	return nil
+}
+
+func (s *trailingStopStrategy) Tick(
+	ctx context.Context,
+	now time.Time,
+	order *model.AlgoOrder,
+	children []*model.ChildOrder,
+) (Decision, error) {
+	if len(children) > 0 {
+		return Decision{Done: true}, nil
+	}
+
+	price, ok := s.prices.LastPrice(order.Symbol)
+	if !ok {
+		return Decision{}, nil
+	}
+
+	st := &order.State
+	if st.Watermark == 0 {
+		st.Watermark = price
+	}
+
+	// Sell stops trail the high below the market, buy stops trail the low above it
+	if order.Side == model.SideSell {
+		if price > st.Watermark {
+			st.Watermark = price
+		}
+		if price > st.Watermark-s.trail(order) {
+			return Decision{}, nil
+		}
+	} else {
+		if price < st.Watermark {
+			st.Watermark = price
+		}
+		if price < st.Watermark+s.trail(order) {
+			return Decision{}, nil
+		}
+	}
+
+	return Decision{
+		Release: []*model.ChildOrder{order.NewChild(model.ChildTypeMarket, 0, order.RemainingQuantity())},
+		Done:    true,
+	}, nil
+}
+
+func (s *trailingStopStrategy) trail(order *model.AlgoOrder) float64 {
+	if order.Params.TrailAmount > 0 {
+		return order.Params.TrailAmount
+	}
+	return order.State.Watermark * order.Params.TrailPercent / 100
+}
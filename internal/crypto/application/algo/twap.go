@@ -0,0 +1,78 @@
+package algo
+
+import (
+	"context"
+	"simple-securities/internal/crypto/domain/model"
+	"time"
+)
+
+// scheduleStrategy releases market slices between start_time and end_time so that the
+// cumulative quantity sent follows the slice weights. Slices missed while the service
+// was down are caught up in a single child order.
+type scheduleStrategy struct {
+	weights func(ctx context.Context, order *model.AlgoOrder) ([]float64, error)
+}
+
+// NewTWAPStrategy spreads the quantity evenly over the slices.
+func NewTWAPStrategy() Strategy {
+	return &scheduleStrategy{}
+}
+
+func (s *scheduleStrategy) Init(ctx context.Context, order *model.AlgoOrder) error {
+	if err := validateWindow(time.Now(), &order.Params); err != nil {
+		return err
+	}
+	if s.weights == nil {
+		return nil
+	}
+
+	weights, err := s.weights(ctx, order)
+	if err != nil {
+		return err
+	}
+	order.State.Weights = weights
+	return nil
+}
+
+func (s *scheduleStrategy) Tick(
+	ctx context.Context,
+	now time.Time,
+	order *model.AlgoOrder,
+	children []*model.ChildOrder,
+) (Decision, error) {
+	st := &order.State
+	p := order.Params
+
+	idx, started := dueSlice(now, p)
+	if !started || st.NextSlice > idx {
+		return Decision{Done: st.NextSlice >= p.Slices}, nil
+	}
+
+	var d Decision
+	qty := order.Quantity*cumulativeWeight(order, idx) - committedQuantity(children)
+	if qty > epsilon {
+		d.Release = []*model.ChildOrder{order.NewChild(model.ChildTypeMarket, 0, qty)}
+	}
+	st.NextSlice = idx + 1
+	d.Done = st.NextSlice >= p.Slices
+	return d, nil
+}
+
+// cumulativeWeight is the share of the parent quantity due once slice idx is released
+func cumulativeWeight(order *model.AlgoOrder, idx uint32) float64 {
+	slices := order.Params.Slices
+	if idx+1 >= slices {
+		return 1
+	}
+
+	weights := order.State.Weights
+	if len(weights) != int(slices) {
+		return float64(idx+1) / float64(slices)
+	}
+
+	var sum float64
+	for _, w := range weights[:idx+1] {
+		sum += w
+	}
+	return sum
+}
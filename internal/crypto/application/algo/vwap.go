@@ -0,0 +1,92 @@
+package algo
+
+import (
+	"context"
+	"simple-securities/internal/crypto/application/dto"
+	"simple-securities/internal/crypto/application/service"
+	"simple-securities/internal/crypto/domain/model"
+	"strconv"
+	"time"
+)
+
+// klineIntervals are the Binance kline intervals, smallest first
+var klineIntervals = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"1m", time.Minute},
+	{"3m", 3 * time.Minute},
+	{"5m", 5 * time.Minute},
+	{"15m", 15 * time.Minute},
+	{"30m", 30 * time.Minute},
+	{"1h", time.Hour},
+	{"2h", 2 * time.Hour},
+	{"4h", 4 * time.Hour},
+	{"6h", 6 * time.Hour},
+	{"8h", 8 * time.Hour},
+	{"12h", 12 * time.Hour},
+	{"1d", 24 * time.Hour},
+}
+
+// maxKlines is the largest page the klines endpoint returns
+const maxKlines = 1000
+
+// NewVWAPStrategy weights every slice by the traded volume of the most recent klines
+// of the same length, falling back to even slices when no volume is available.
+func NewVWAPStrategy(getKlinesSvc service.GetKlinesSvc) Strategy {
+	return &scheduleStrategy{
+		weights: func(ctx context.Context, order *model.AlgoOrder) ([]float64, error) {
+			return volumeWeights(ctx, getKlinesSvc, order)
+		},
+	}
+}
+
+func volumeWeights(ctx context.Context, getKlinesSvc service.GetKlinesSvc, order *model.AlgoOrder) ([]float64, error) {
+	slices := order.Params.Slices
+	if slices > maxKlines {
+		return nil, nil
+	}
+
+	klines, err := getKlinesSvc.Handle(ctx, &dto.GetKlinesReq{
+		Symbol:   order.Symbol,
+		Interval: klineInterval(sliceInterval(order.Params)),
+		Limit:    int32(slices),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(klines) != int(slices) {
+		return nil, nil
+	}
+
+	var total float64
+	volumes := make([]float64, 0, len(klines))
+	for _, k := range klines {
+		v, err := strconv.ParseFloat(k.Volume, 64)
+		if err != nil || v < 0 {
+			v = 0
+		}
+		volumes = append(volumes, v)
+		total += v
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	for i := range volumes {
+		volumes[i] /= total
+	}
+	return volumes, nil
+}
+
+// klineInterval picks the largest kline interval not longer than the slice
+func klineInterval(slice time.Duration) string {
+	name := klineIntervals[0].name
+	for _, i := range klineIntervals {
+		if i.duration > slice {
+			break
+		}
+		name = i.name
+	}
+	return name
+}
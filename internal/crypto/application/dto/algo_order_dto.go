@@ -0,0 +1,62 @@
+package dto
+
+type AlgoOrderDto struct {
+	ID             uint64           `json:"id"`
+	Uuid           string           `json:"uuid"`
+	UserID         uint64           `json:"user_id"`
+	Symbol         string           `json:"symbol"`
+	Side           string           `json:"side"`
+	Type           string           `json:"type"`
+	Status         string           `json:"status"`
+	Quantity       float64          `json:"quantity"`
+	FilledQuantity float64          `json:"filled_quantity"`
+	LimitPrice     float64          `json:"limit_price,omitempty"`
+	StopPrice      float64          `json:"stop_price,omitempty"`
+	TrailAmount    float64          `json:"trail_amount,omitempty"`
+	TrailPercent   float64          `json:"trail_percent,omitempty"`
+	StartTime      int64            `json:"start_time,omitempty"`
+	EndTime        int64            `json:"end_time,omitempty"`
+	Slices         uint32           `json:"slices,omitempty"`
+	Reason         string           `json:"reason,omitempty"`
+	Children       []*ChildOrderDto `json:"children,omitempty"`
+	CreatedAt      uint64           `json:"created_at"`
+	UpdatedAt      uint64           `json:"updated_at"`
+}
+
+type ChildOrderDto struct {
+	ID             uint64  `json:"id"`
+	Uuid           string  `json:"uuid"`
+	AlgoOrderID    uint64  `json:"algo_order_id"`
+	Seq            uint32  `json:"seq"`
+	Type           string  `json:"type"`
+	Price          float64 `json:"price"`
+	Quantity       float64 `json:"quantity"`
+	FilledQuantity float64 `json:"filled_quantity"`
+	AvgFillPrice   float64 `json:"avg_fill_price"`
+	Fee            float64 `json:"fee"`
+	Status         string  `json:"status"`
+	CreatedAt      uint64  `json:"created_at"`
+	UpdatedAt      uint64  `json:"updated_at"`
+}
+
+type PlaceAlgoOrderReq struct {
+	UserID       uint64  `json:"user_id" validate:"required"`
+	Symbol       string  `json:"symbol" validate:"required"`
+	Side         string  `json:"side" validate:"required"`
+	Type         string  `json:"type" validate:"required"`
+	Quantity     float64 `json:"quantity" validate:"required"`
+	LimitPrice   float64 `json:"limit_price"`
+	StopPrice    float64 `json:"stop_price"`
+	TrailAmount  float64 `json:"trail_amount"`
+	TrailPercent float64 `json:"trail_percent"`
+	StartTime    int64   `json:"start_time"`
+	EndTime      int64   `json:"end_time"`
+	Slices       uint32  `json:"slices"`
+}
+
+// AlgoOrderEventDto is published to Kafka whenever a parent or child order changes
+type AlgoOrderEventDto struct {
+	EventType string         `json:"event_type"`
+	AlgoOrder *AlgoOrderDto  `json:"algo_order"`
+	Child     *ChildOrderDto `json:"child,omitempty"`
+}
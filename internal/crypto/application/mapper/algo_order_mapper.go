@@ -0,0 +1,93 @@
+package mapper
+
+import (
+	"simple-securities/internal/crypto/application/dto"
+	"simple-securities/internal/crypto/domain/model"
+	"time"
+)
+
+func unixOrZero(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.Unix())
+}
+
+func ToAlgoOrderDto(input *model.AlgoOrder, children []*model.ChildOrder) *dto.AlgoOrderDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.AlgoOrderDto{
+		ID:             input.ID,
+		Uuid:           input.Uuid,
+		UserID:         input.UserID,
+		Symbol:         input.Symbol,
+		Side:           string(input.Side),
+		Type:           string(input.Type),
+		Status:         string(input.Status),
+		Quantity:       input.Quantity,
+		FilledQuantity: input.FilledQuantity,
+		LimitPrice:     input.Params.LimitPrice,
+		StopPrice:      input.Params.StopPrice,
+		TrailAmount:    input.Params.TrailAmount,
+		TrailPercent:   input.Params.TrailPercent,
+		StartTime:      input.Params.StartTime,
+		EndTime:        input.Params.EndTime,
+		Slices:         input.Params.Slices,
+		Reason:         input.Reason,
+		Children:       ToChildOrderDtos(children),
+		CreatedAt:      unixOrZero(input.CreatedAt),
+		UpdatedAt:      unixOrZero(input.UpdatedAt),
+	}
+}
+
+func ToChildOrderDto(input *model.ChildOrder) *dto.ChildOrderDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.ChildOrderDto{
+		ID:             input.ID,
+		Uuid:           input.Uuid,
+		AlgoOrderID:    input.AlgoOrderID,
+		Seq:            input.Seq,
+		Type:           string(input.Type),
+		Price:          input.Price,
+		Quantity:       input.Quantity,
+		FilledQuantity: input.FilledQuantity,
+		AvgFillPrice:   input.AvgFillPrice,
+		Fee:            input.Fee,
+		Status:         string(input.Status),
+		CreatedAt:      unixOrZero(input.CreatedAt),
+		UpdatedAt:      unixOrZero(input.UpdatedAt),
+	}
+}
+
+func ToChildOrderDtos(inputs []*model.ChildOrder) []*dto.ChildOrderDto {
+	if inputs == nil {
+		return nil
+	}
+	dtos := make([]*dto.ChildOrderDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToChildOrderDto(input))
+	}
+	return dtos
+}
+
+func ToAlgoOrderModel(req *dto.PlaceAlgoOrderReq) *model.AlgoOrder {
+	return model.NewAlgoOrder(
+		req.UserID,
+		req.Symbol,
+		model.Side(req.Side),
+		model.AlgoType(req.Type),
+		req.Quantity,
+		model.AlgoParams{
+			LimitPrice:   req.LimitPrice,
+			StopPrice:    req.StopPrice,
+			TrailAmount:  req.TrailAmount,
+			TrailPercent: req.TrailPercent,
+			StartTime:    req.StartTime,
+			EndTime:      req.EndTime,
+			Slices:       req.Slices,
+		},
+	)
+}
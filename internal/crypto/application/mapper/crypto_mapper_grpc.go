@@ -34,3 +34,79 @@ func ToKlines(klineDtos []*dto.KlineDto) []*crypto.Kline {
 	}
 	return klines
 }
+
+func ToPlaceAlgoOrderReq(req *crypto.PlaceAlgoOrderRequest) *dto.PlaceAlgoOrderReq {
+	return &dto.PlaceAlgoOrderReq{
+		UserID:       req.UserId,
+		Symbol:       req.Symbol,
+		Side:         req.Side,
+		Type:         req.Type,
+		Quantity:     req.Quantity,
+		LimitPrice:   req.LimitPrice,
+		StopPrice:    req.StopPrice,
+		TrailAmount:  req.TrailAmount,
+		TrailPercent: req.TrailPercent,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Slices:       req.Slices,
+	}
+}
+
+func ToAlgoOrder(algoDto *dto.AlgoOrderDto) *crypto.AlgoOrder {
+	if algoDto == nil {
+		return nil
+	}
+	return &crypto.AlgoOrder{
+		Id:             algoDto.ID,
+		Uuid:           algoDto.Uuid,
+		UserId:         algoDto.UserID,
+		Symbol:         algoDto.Symbol,
+		Side:           algoDto.Side,
+		Type:           algoDto.Type,
+		Status:         algoDto.Status,
+		Quantity:       algoDto.Quantity,
+		FilledQuantity: algoDto.FilledQuantity,
+		LimitPrice:     algoDto.LimitPrice,
+		StopPrice:      algoDto.StopPrice,
+		TrailAmount:    algoDto.TrailAmount,
+		TrailPercent:   algoDto.TrailPercent,
+		StartTime:      algoDto.StartTime,
+		EndTime:        algoDto.EndTime,
+		Slices:         algoDto.Slices,
+		Reason:         algoDto.Reason,
+		Children:       ToChildOrders(algoDto.Children),
+		CreatedAt:      algoDto.CreatedAt,
+		UpdatedAt:      algoDto.UpdatedAt,
+	}
+}
+
+func ToChildOrder(childDto *dto.ChildOrderDto) *crypto.ChildOrder {
+	if childDto == nil {
+		return nil
+	}
+	return &crypto.ChildOrder{
+		Id:             childDto.ID,
+		Uuid:           childDto.Uuid,
+		Seq:            childDto.Seq,
+		Type:           childDto.Type,
+		Price:          childDto.Price,
+		Quantity:       childDto.Quantity,
+		FilledQuantity: childDto.FilledQuantity,
+		AvgFillPrice:   childDto.AvgFillPrice,
+		Fee:            childDto.Fee,
+		Status:         childDto.Status,
+		CreatedAt:      childDto.CreatedAt,
+		UpdatedAt:      childDto.UpdatedAt,
+	}
+}
+
+func ToChildOrders(childDtos []*dto.ChildOrderDto) []*crypto.ChildOrder {
+	if childDtos == nil {
+		return nil
+	}
+	children := make([]*crypto.ChildOrder, 0, len(childDtos))
+	for _, childDto := range childDtos {
+		children = append(children, ToChildOrder(childDto))
+	}
+	return children
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/crypto/application/dto"
+	"simple-securities/internal/crypto/application/mapper"
+)
+
+type CancelAlgoOrderSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.AlgoOrderDto, error)
+}
+
+type cancelAlgoOrderSvc struct {
+	scheduler AlgoOrderScheduler
+}
+
+func NewCancelAlgoOrderSvc(scheduler AlgoOrderScheduler) CancelAlgoOrderSvc {
+	return &cancelAlgoOrderSvc{
+		scheduler: scheduler,
+	}
+}
+
+func (s *cancelAlgoOrderSvc) Handle(ctx context.Context, id uint64) (*dto.AlgoOrderDto, error) {
+	order, err := s.scheduler.Cancel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToAlgoOrderDto(order, nil), nil
+}
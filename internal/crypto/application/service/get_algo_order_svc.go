@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/crypto/application/dto"
+	"simple-securities/internal/crypto/application/mapper"
+	"simple-securities/internal/crypto/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetAlgoOrderSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.AlgoOrderDto, error)
+}
+
+type getAlgoOrderSvc struct {
+	algoOrderRepo repo.IAlgoOrderRepo
+}
+
+func NewGetAlgoOrderSvc(algoOrderRepo repo.IAlgoOrderRepo) GetAlgoOrderSvc {
+	return &getAlgoOrderSvc{
+		algoOrderRepo: algoOrderRepo,
+	}
+}
+
+func (s *getAlgoOrderSvc) Handle(ctx context.Context, id uint64) (*dto.AlgoOrderDto, error) {
+	order, err := s.algoOrderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get algo order", err)
+	}
+	if order == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("algo order %d not found", id), nil)
+	}
+
+	children, err := s.algoOrderRepo.GetChildren(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get algo child orders", err)
+	}
+	return mapper.ToAlgoOrderDto(order, children), nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/crypto/application/dto"
+	"simple-securities/internal/crypto/application/mapper"
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/pkg/errors"
+	"strings"
+)
+
+// AlgoOrderScheduler is the algo scheduler that owns parent orders once accepted
+type AlgoOrderScheduler interface {
+	Accept(ctx context.Context, order *model.AlgoOrder) (*model.AlgoOrder, error)
+	Cancel(ctx context.Context, id uint64) (*model.AlgoOrder, error)
+}
+
+type PlaceAlgoOrderSvc interface {
+	Handle(ctx context.Context, req *dto.PlaceAlgoOrderReq) (*dto.AlgoOrderDto, error)
+}
+
+type placeAlgoOrderSvc struct {
+	scheduler AlgoOrderScheduler
+}
+
+func NewPlaceAlgoOrderSvc(scheduler AlgoOrderScheduler) PlaceAlgoOrderSvc {
+	return &placeAlgoOrderSvc{
+		scheduler: scheduler,
+	}
+}
+
+func (s *placeAlgoOrderSvc) Handle(ctx context.Context, req *dto.PlaceAlgoOrderReq) (*dto.AlgoOrderDto, error) {
+	req.Symbol = strings.ToUpper(req.Symbol)
+	req.Side = strings.ToUpper(req.Side)
+	req.Type = strings.ToUpper(req.Type)
+
+	if req.UserID == 0 || req.Symbol == "" {
+		return nil, errors.NewValidationError("user_id and symbol are required", nil)
+	}
+	if req.Side != string(model.SideBuy) && req.Side != string(model.SideSell) {
+		return nil, errors.NewValidationError("side must be BUY or SELL", nil)
+	}
+	if req.Quantity <= 0 {
+		return nil, errors.NewValidationError("quantity must be greater than 0", nil)
+	}
+
+	order, err := s.scheduler.Accept(ctx, mapper.ToAlgoOrderModel(req))
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToAlgoOrderDto(order, nil), nil
+}
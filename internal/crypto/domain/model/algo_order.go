@@ -0,0 +1,183 @@
+package model
+
+import (
+	"simple-securities/pkg/uuid"
+	"time"
+)
+
+type AlgoType string
+
+const (
+	AlgoTypeOCO          AlgoType = "OCO"
+	AlgoTypeTrailingStop AlgoType = "TRAILING_STOP"
+	AlgoTypeTWAP         AlgoType = "TWAP"
+	AlgoTypeVWAP         AlgoType = "VWAP"
+)
+
+type AlgoStatus string
+
+const (
+	AlgoStatusWorking   AlgoStatus = "WORKING"
+	AlgoStatusCompleted AlgoStatus = "COMPLETED"
+	AlgoStatusCancelled AlgoStatus = "CANCELLED"
+	AlgoStatusFailed    AlgoStatus = "FAILED"
+)
+
+// IsTerminal reports whether the scheduler is done with the order.
+func (s AlgoStatus) IsTerminal() bool {
+	return s == AlgoStatusCompleted || s == AlgoStatusCancelled || s == AlgoStatusFailed
+}
+
+type Side string
+
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+)
+
+// AlgoParams holds the type specific inputs of a parent order.
+type AlgoParams struct {
+	// OCO: take-profit limit leg and stop-loss leg, sent at market once the stop price is touched
+	LimitPrice float64 `json:"limit_price,omitempty"`
+	StopPrice  float64 `json:"stop_price,omitempty"`
+
+	// TRAILING_STOP: trail by an absolute amount or a percentage of the best price
+	TrailAmount  float64 `json:"trail_amount,omitempty"`
+	TrailPercent float64 `json:"trail_percent,omitempty"`
+
+	// TWAP/VWAP: schedule window split into slices
+	StartTime int64  `json:"start_time,omitempty"`
+	EndTime   int64  `json:"end_time,omitempty"`
+	Slices    uint32 `json:"slices,omitempty"`
+}
+
+// AlgoState is the progress the scheduler persists between ticks.
+type AlgoState struct {
+	// OCO: the stop price was touched, the limit leg is pulled for the stop leg
+	StopTriggered bool `json:"stop_triggered,omitempty"`
+	// TRAILING_STOP: best price seen since activation
+	Watermark float64 `json:"watermark,omitempty"`
+	// TWAP/VWAP: index of the next slice to release
+	NextSlice uint32 `json:"next_slice,omitempty"`
+	// VWAP: relative volume of every slice, sums to 1
+	Weights []float64 `json:"weights,omitempty"`
+	// Sequence number for the next child order
+	NextSeq uint32 `json:"next_seq,omitempty"`
+}
+
+type AlgoOrder struct {
+	ID             uint64     `db:"id"`
+	Uuid           string     `db:"uuid"`
+	UserID         uint64     `db:"user_id"`
+	Symbol         string     `db:"symbol"`
+	Side           Side       `db:"side"`
+	Type           AlgoType   `db:"type"`
+	Status         AlgoStatus `db:"status"`
+	Quantity       float64    `db:"quantity"`
+	FilledQuantity float64    `db:"filled_quantity"`
+	Params         AlgoParams `db:"-"`
+	State          AlgoState  `db:"-"`
+	Reason         string     `db:"reason"`
+	CreatedAt      time.Time  `db:"created_at"`
+	UpdatedAt      time.Time  `db:"updated_at"`
+}
+
+func (o AlgoOrder) TableName() string {
+	return "algo_orders"
+}
+
+// RemainingQuantity is the part of the parent order not filled yet.
+func (o *AlgoOrder) RemainingQuantity() float64 {
+	if rem := o.Quantity - o.FilledQuantity; rem > 0 {
+		return rem
+	}
+	return 0
+}
+
+func NewAlgoOrder(userID uint64, symbol string, side Side, algoType AlgoType, quantity float64, params AlgoParams) *AlgoOrder {
+	now := time.Now()
+	return &AlgoOrder{
+		Uuid:      uuid.NewGoogleUUID(),
+		UserID:    userID,
+		Symbol:    symbol,
+		Side:      side,
+		Type:      algoType,
+		Status:    AlgoStatusWorking,
+		Quantity:  quantity,
+		Params:    params,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+type ChildType string
+
+const (
+	ChildTypeMarket ChildType = "MARKET"
+	ChildTypeLimit  ChildType = "LIMIT"
+	ChildTypeStop   ChildType = "STOP"
+)
+
+type ChildStatus string
+
+const (
+	ChildStatusNew             ChildStatus = "NEW"
+	ChildStatusPartiallyFilled ChildStatus = "PARTIALLY_FILLED"
+	ChildStatusFilled          ChildStatus = "FILLED"
+	ChildStatusCancelled       ChildStatus = "CANCELLED"
+	ChildStatusRejected        ChildStatus = "REJECTED"
+)
+
+// IsOpen reports whether the child order can still receive fills.
+func (s ChildStatus) IsOpen() bool {
+	return s == ChildStatusNew || s == ChildStatusPartiallyFilled
+}
+
+type ChildOrder struct {
+	ID             uint64      `db:"id"`
+	Uuid           string      `db:"uuid"`
+	AlgoOrderID    uint64      `db:"algo_order_id"`
+	Seq            uint32      `db:"seq"`
+	Symbol         string      `db:"symbol"`
+	Side           Side        `db:"side"`
+	Type           ChildType   `db:"type"`
+	Price          float64     `db:"price"` // limit price, or trigger price for STOP
+	Quantity       float64     `db:"quantity"`
+	FilledQuantity float64     `db:"filled_quantity"`
+	AvgFillPrice   float64     `db:"avg_fill_price"`
+	Fee            float64     `db:"fee"` // commission charged on the fills so far
+	Status         ChildStatus `db:"status"`
+	CreatedAt      time.Time   `db:"created_at"`
+	UpdatedAt      time.Time   `db:"updated_at"`
+}
+
+func (c ChildOrder) TableName() string {
+	return "algo_child_orders"
+}
+
+// NewChild builds the next child order of the parent and advances its sequence.
+func (o *AlgoOrder) NewChild(childType ChildType, price, quantity float64) *ChildOrder {
+	now := time.Now()
+	child := &ChildOrder{
+		Uuid:        uuid.NewGoogleUUID(),
+		AlgoOrderID: o.ID,
+		Seq:         o.State.NextSeq,
+		Symbol:      o.Symbol,
+		Side:        o.Side,
+		Type:        childType,
+		Price:       price,
+		Quantity:    quantity,
+		Status:      ChildStatusNew,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	o.State.NextSeq++
+	return child
+}
+
+// ChildUpdate is the execution report of a child order returned by the order gateway.
+type ChildUpdate struct {
+	Status         ChildStatus
+	FilledQuantity float64
+	AvgFillPrice   float64
+}
@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/crypto/domain/model"
+	"time"
+)
+
+type IAlgoOrderRepo interface {
+	Create(ctx context.Context, order *model.AlgoOrder) (*model.AlgoOrder, error)
+	Update(ctx context.Context, order *model.AlgoOrder) error
+	GetByID(ctx context.Context, id uint64) (*model.AlgoOrder, error)
+	GetWorking(ctx context.Context) ([]*model.AlgoOrder, error)
+	CreateChild(ctx context.Context, child *model.ChildOrder) (*model.ChildOrder, error)
+	UpdateChild(ctx context.Context, child *model.ChildOrder) error
+	GetChildren(ctx context.Context, algoOrderID uint64) ([]*model.ChildOrder, error)
+	// GetVolume returns the notional filled by the child orders of a user updated since the given time
+	GetVolume(ctx context.Context, userID uint64, since time.Time) (float64, error)
+}
+
+// IOrderGateway routes child orders to the venue and reports their executions.
+type IOrderGateway interface {
+	Submit(ctx context.Context, child *model.ChildOrder) error
+	Cancel(ctx context.Context, child *model.ChildOrder) error
+	// Poll returns the latest execution report, ok is false when the venue does not know the order.
+	Poll(ctx context.Context, child *model.ChildOrder) (update model.ChildUpdate, ok bool, err error)
+}
+
+// IPriceFeed exposes the last traded price of a symbol.
+type IPriceFeed interface {
+	LastPrice(symbol string) (price float64, ok bool)
+}
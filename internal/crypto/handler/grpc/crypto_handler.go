@@ -10,17 +10,26 @@ import (
 
 type CryptoGrpcHandler struct {
 	crypto.UnimplementedCryptoServiceServer
-	getKlinesSvc     service.GetKlinesSvc
-	getServerTimeSvc service.GetServerTimeSvc
+	getKlinesSvc       service.GetKlinesSvc
+	getServerTimeSvc   service.GetServerTimeSvc
+	placeAlgoOrderSvc  service.PlaceAlgoOrderSvc
+	getAlgoOrderSvc    service.GetAlgoOrderSvc
+	cancelAlgoOrderSvc service.CancelAlgoOrderSvc
 }
 
 func NewCryptoGrpcHandler(
 	getKlinesSvc service.GetKlinesSvc,
 	getServerTimeSvc service.GetServerTimeSvc,
+	placeAlgoOrderSvc service.PlaceAlgoOrderSvc,
+	getAlgoOrderSvc service.GetAlgoOrderSvc,
+	cancelAlgoOrderSvc service.CancelAlgoOrderSvc,
 ) crypto.CryptoServiceServer {
 	return &CryptoGrpcHandler{
-		getKlinesSvc:     getKlinesSvc,
-		getServerTimeSvc: getServerTimeSvc,
+		getKlinesSvc:       getKlinesSvc,
+		getServerTimeSvc:   getServerTimeSvc,
+		placeAlgoOrderSvc:  placeAlgoOrderSvc,
+		getAlgoOrderSvc:    getAlgoOrderSvc,
+		cancelAlgoOrderSvc: cancelAlgoOrderSvc,
 	}
 }
 
@@ -47,3 +56,27 @@ func (h *CryptoGrpcHandler) GetKlines(ctx context.Context, req *crypto.GetKlines
 		Klines: mapper.ToKlines(result),
 	}, nil
 }
+
+func (h *CryptoGrpcHandler) PlaceAlgoOrder(ctx context.Context, req *crypto.PlaceAlgoOrderRequest) (*crypto.PlaceAlgoOrderResponse, error) {
+	result, err := h.placeAlgoOrderSvc.Handle(ctx, mapper.ToPlaceAlgoOrderReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &crypto.PlaceAlgoOrderResponse{AlgoOrder: mapper.ToAlgoOrder(result)}, nil
+}
+
+func (h *CryptoGrpcHandler) GetAlgoOrder(ctx context.Context, req *crypto.GetAlgoOrderRequest) (*crypto.GetAlgoOrderResponse, error) {
+	result, err := h.getAlgoOrderSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &crypto.GetAlgoOrderResponse{AlgoOrder: mapper.ToAlgoOrder(result)}, nil
+}
+
+func (h *CryptoGrpcHandler) CancelAlgoOrder(ctx context.Context, req *crypto.CancelAlgoOrderRequest) (*crypto.CancelAlgoOrderResponse, error) {
+	result, err := h.cancelAlgoOrderSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &crypto.CancelAlgoOrderResponse{AlgoOrder: mapper.ToAlgoOrder(result)}, nil
+}
@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// klineMessage is the subset of the Binance kline stream published by cmd/worker
+type klineMessage struct {
+	Data struct {
+		Symbol string `json:"s"`
+		Kline  struct {
+			ClosePrice string `json:"c"`
+		} `json:"k"`
+	} `json:"data"`
+}
+
+// RedisPriceFeed keeps the last price of every symbol published on Redis pub/sub.
+type RedisPriceFeed struct {
+	client *redis.Client
+	logger *zap.Logger
+	prices map[string]float64
+	mu     sync.RWMutex
+}
+
+func NewRedisPriceFeed(client *redis.Client, logger *zap.Logger) *RedisPriceFeed {
+	return &RedisPriceFeed{
+		client: client,
+		logger: logger,
+		prices: make(map[string]float64),
+	}
+}
+
+// Start listens to the symbol channels until ctx is cancelled
+func (f *RedisPriceFeed) Start(ctx context.Context) error {
+	sub := f.client.PSubscribe(ctx, "*")
+	defer sub.Close()
+
+	f.logger.Info("🔌 price feed subscribed to redis channels")
+
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			f.handle(msg)
+		}
+	}
+}
+
+func (f *RedisPriceFeed) handle(msg *redis.Message) {
+	var kline klineMessage
+	if err := json.Unmarshal([]byte(msg.Payload), &kline); err != nil {
+		return
+	}
+
+	price, err := strconv.ParseFloat(kline.Data.Kline.ClosePrice, 64)
+	if err != nil || price <= 0 {
+		return
+	}
+
+	f.mu.Lock()
+	f.prices[msg.Channel] = price
+	f.mu.Unlock()
+}
+
+// LastPrice returns the last close price received for the symbol
+func (f *RedisPriceFeed) LastPrice(symbol string) (float64, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	price, ok := f.prices[symbol]
+	return price, ok
+}
@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/internal/crypto/domain/repo"
+	"sync"
+)
+
+// PaperOrderGateway simulates a venue by filling child orders against the live price feed.
+// Orders only live in memory, so after a restart the scheduler sees them as unknown.
+type PaperOrderGateway struct {
+	prices repo.IPriceFeed
+	orders map[string]*model.ChildUpdate
+	mu     sync.Mutex
+}
+
+func NewPaperOrderGateway(prices repo.IPriceFeed) repo.IOrderGateway {
+	return &PaperOrderGateway{
+		prices: prices,
+		orders: make(map[string]*model.ChildUpdate),
+	}
+}
+
+// Submit accepts a child order
+func (g *PaperOrderGateway) Submit(ctx context.Context, child *model.ChildOrder) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.orders[child.Uuid] = &model.ChildUpdate{Status: model.ChildStatusNew}
+	return nil
+}
+
+// Cancel cancels a child order that has not been filled yet
+func (g *PaperOrderGateway) Cancel(ctx context.Context, child *model.ChildOrder) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if o, ok := g.orders[child.Uuid]; ok && o.Status.IsOpen() {
+		o.Status = model.ChildStatusCancelled
+	}
+	return nil
+}
+
+// Poll matches the child order against the last price and returns its execution report
+func (g *PaperOrderGateway) Poll(ctx context.Context, child *model.ChildOrder) (model.ChildUpdate, bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	o, ok := g.orders[child.Uuid]
+	if !ok {
+		return model.ChildUpdate{}, false, nil
+	}
+	if !o.Status.IsOpen() {
+		return *o, true, nil
+	}
+
+	price, ok := g.prices.LastPrice(child.Symbol)
+	if !ok {
+		return *o, true, nil
+	}
+
+	if fillPrice, ok := matchPrice(child, price); ok {
+		o.Status = model.ChildStatusFilled
+		o.FilledQuantity = child.Quantity
+		o.AvgFillPrice = fillPrice
+	}
+	return *o, true, nil
+}
+
+// matchPrice returns the execution price of the child order at the given market price
+func matchPrice(child *model.ChildOrder, price float64) (float64, bool) {
+	buy := child.Side == model.SideBuy
+
+	switch child.Type {
+	case model.ChildTypeMarket:
+		return price, true
+	case model.ChildTypeLimit:
+		if (buy && price <= child.Price) || (!buy && price >= child.Price) {
+			return child.Price, true
+		}
+	case model.ChildTypeStop:
+		if (buy && price >= child.Price) || (!buy && price <= child.Price) {
+			return price, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,222 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"simple-securities/internal/crypto/domain/model"
+	"simple-securities/internal/crypto/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type AlgoOrderRepo struct {
+	db *sqlx.DB
+}
+
+func NewAlgoOrderRepo(db *sqlx.DB) repo.IAlgoOrderRepo {
+	return &AlgoOrderRepo{db: db}
+}
+
+// algoOrderRow maps the JSON columns of algo_orders onto the domain model
+type algoOrderRow struct {
+	model.AlgoOrder
+	ParamsJSON string `db:"params"`
+	StateJSON  string `db:"state"`
+}
+
+func toAlgoOrderRow(o *model.AlgoOrder) (*algoOrderRow, error) {
+	params, err := json.Marshal(o.Params)
+	if err != nil {
+		return nil, err
+	}
+	state, err := json.Marshal(o.State)
+	if err != nil {
+		return nil, err
+	}
+	return &algoOrderRow{AlgoOrder: *o, ParamsJSON: string(params), StateJSON: string(state)}, nil
+}
+
+func (row *algoOrderRow) toModel() (*model.AlgoOrder, error) {
+	o := row.AlgoOrder
+	if err := json.Unmarshal([]byte(row.ParamsJSON), &o.Params); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(row.StateJSON), &o.State); err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+const algoOrderColumns = `
+	id, uuid, user_id, symbol, side, type, status,
+	quantity, filled_quantity, params, state, reason,
+	created_at, updated_at
+`
+
+const childOrderColumns = `
+	id, uuid, algo_order_id, seq, symbol, side, type,
+	price, quantity, filled_quantity, avg_fill_price, fee, status,
+	created_at, updated_at
+`
+
+// Create persists a new parent order
+func (r *AlgoOrderRepo) Create(ctx context.Context, order *model.AlgoOrder) (*model.AlgoOrder, error) {
+	row, err := toAlgoOrderRow(order)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		INSERT INTO algo_orders (
+			uuid, user_id, symbol, side, type, status,
+			quantity, filled_quantity, params, state, reason,
+			created_at, updated_at
+		) VALUES (
+			:uuid, :user_id, :symbol, :side, :type, :status,
+			:quantity, :filled_quantity, :params, :state, :reason,
+			:created_at, :updated_at
+		)
+		RETURNING id
+	`
+
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &order.ID, row); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Update saves the status and progress of a parent order
+func (r *AlgoOrderRepo) Update(ctx context.Context, order *model.AlgoOrder) error {
+	order.UpdatedAt = time.Now()
+	row, err := toAlgoOrderRow(order)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE algo_orders
+		SET
+			status          = :status,
+			filled_quantity = :filled_quantity,
+			state           = :state,
+			reason          = :reason,
+			updated_at      = :updated_at
+		WHERE id = :id
+	`
+	_, err = r.db.NamedExecContext(ctx, query, row)
+	return err
+}
+
+// GetByID fetches a parent order by ID
+func (r *AlgoOrderRepo) GetByID(ctx context.Context, id uint64) (*model.AlgoOrder, error) {
+	query := `SELECT ` + algoOrderColumns + ` FROM algo_orders WHERE id = $1`
+
+	var row algoOrderRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return row.toModel()
+}
+
+// GetWorking fetches every parent order the scheduler still owns
+func (r *AlgoOrderRepo) GetWorking(ctx context.Context) ([]*model.AlgoOrder, error) {
+	query := `SELECT ` + algoOrderColumns + ` FROM algo_orders WHERE status = $1 ORDER BY id`
+
+	var rows []*algoOrderRow
+	if err := r.db.SelectContext(ctx, &rows, query, model.AlgoStatusWorking); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*model.AlgoOrder, 0, len(rows))
+	for _, row := range rows {
+		o, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, o)
+	}
+	return orders, nil
+}
+
+// CreateChild persists a child order released by the scheduler
+func (r *AlgoOrderRepo) CreateChild(ctx context.Context, child *model.ChildOrder) (*model.ChildOrder, error) {
+	query := `
+		INSERT INTO algo_child_orders (
+			uuid, algo_order_id, seq, symbol, side, type,
+			price, quantity, filled_quantity, avg_fill_price, fee, status,
+			created_at, updated_at
+		) VALUES (
+			:uuid, :algo_order_id, :seq, :symbol, :side, :type,
+			:price, :quantity, :filled_quantity, :avg_fill_price, :fee, :status,
+			:created_at, :updated_at
+		)
+		RETURNING id
+	`
+
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &child.ID, child); err != nil {
+		return nil, err
+	}
+	return child, nil
+}
+
+// UpdateChild saves the execution progress of a child order
+func (r *AlgoOrderRepo) UpdateChild(ctx context.Context, child *model.ChildOrder) error {
+	child.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE algo_child_orders
+		SET
+			filled_quantity = :filled_quantity,
+			avg_fill_price  = :avg_fill_price,
+			fee             = :fee,
+			status          = :status,
+			updated_at      = :updated_at
+		WHERE id = :id
+	`
+	_, err := r.db.NamedExecContext(ctx, query, child)
+	return err
+}
+
+// GetChildren fetches the child orders of a parent order in release order
+func (r *AlgoOrderRepo) GetChildren(ctx context.Context, algoOrderID uint64) ([]*model.ChildOrder, error) {
+	query := `SELECT ` + childOrderColumns + ` FROM algo_child_orders WHERE algo_order_id = $1 ORDER BY seq`
+
+	var children []*model.ChildOrder
+	if err := r.db.SelectContext(ctx, &children, query, algoOrderID); err != nil {
+		return nil, err
+	}
+	return children, nil
+}
+
+// GetVolume sums the filled notional of the child orders of a user updated at or after since
+func (r *AlgoOrderRepo) GetVolume(ctx context.Context, userID uint64, since time.Time) (float64, error) {
+	query := `
+		SELECT COALESCE(SUM(c.filled_quantity * c.avg_fill_price), 0)
+		FROM algo_child_orders c
+		JOIN algo_orders o ON o.id = c.algo_order_id
+		WHERE o.user_id = $1 AND c.updated_at >= $2
+	`
+
+	var volume float64
+	if err := r.db.GetContext(ctx, &volume, query, userID, since); err != nil {
+		return 0, err
+	}
+	return volume, nil
+}
@@ -0,0 +1,247 @@
+// Package broadcast sends the broadcast jobs: one notification to every user of an audience,
+// all the users, a list of users or the holders of a symbol.
+//
+// Jobs live in the database. A runner leases the oldest job, fans it out in batches of users
+// ordered by id at a bounded rate, and saves the progress and the cursor after every batch,
+// so that a cancelled job stops at the next batch and a job whose runner died is resumed by
+// another after its lease, resending at most the batch in progress. Every notification goes
+// through the send service, with its templates, digests and preferences.
+package broadcast
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/service"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval  = 5 * time.Second
+	defaultBatchSize = 100
+	defaultRate      = 50
+	defaultLease     = time.Minute
+)
+
+// ErrNoStockClient is returned for the holders audience when the stock service is not configured
+var ErrNoStockClient = stderrors.New("holders audience needs the stock service")
+
+type Config struct {
+	// Interval is how often the runner looks for jobs to send
+	Interval  time.Duration
+	BatchSize int
+	// Rate bounds the notifications sent per second
+	Rate int
+	// Lease is how long a job is left to its runner without progress, it is stretched to
+	// cover two batches at the rate
+	Lease time.Duration
+}
+
+type Runner struct {
+	config        Config
+	broadcastRepo repo.IBroadcastRepo
+	stockClient   repo.IStockClient
+	sendNotiSvc   service.SendNotiSvc
+	logger        *zap.Logger
+}
+
+// NewRunner creates the runner of the broadcast jobs, stockClient is nil when the stock
+// service is not configured
+func NewRunner(
+	broadcastRepo repo.IBroadcastRepo,
+	stockClient repo.IStockClient,
+	sendNotiSvc service.SendNotiSvc,
+	config Config,
+	logger *zap.Logger,
+) *Runner {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.Rate <= 0 {
+		config.Rate = defaultRate
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaultLease
+	}
+	config.Lease = max(config.Lease, 2*time.Duration(config.BatchSize)*time.Second/time.Duration(config.Rate))
+	return &Runner{
+		config:        config,
+		broadcastRepo: broadcastRepo,
+		stockClient:   stockClient,
+		sendNotiSvc:   sendNotiSvc,
+		logger:        logger,
+	}
+}
+
+// Start sends the broadcast jobs until ctx is cancelled
+func (r *Runner) Start(ctx context.Context) error {
+	r.logger.Info("📣 broadcast runner started",
+		zap.Duration("interval", r.config.Interval),
+		zap.Int("rate", r.config.Rate))
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("broadcast runner stopped")
+			return nil
+		case <-ticker.C:
+			if _, err := r.RunPending(ctx); err != nil && ctx.Err() == nil {
+				r.logger.Error("failed to run broadcast jobs", zap.Error(err))
+			}
+		}
+	}
+}
+
+// RunPending sends the jobs waiting to be sent, one after the other, and returns how many
+// it ran. A job failing to reach its audience is left to be taken over after its lease.
+func (r *Runner) RunPending(ctx context.Context) (int, error) {
+	for ran := 0; ; ran++ {
+		job, err := r.broadcastRepo.Claim(ctx, time.Now().Add(r.config.Lease))
+		if err != nil || job == nil {
+			return ran, err
+		}
+		if err := r.run(ctx, job); err != nil {
+			return ran, fmt.Errorf("failed to run broadcast job %d: %w", job.ID, err)
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context, job *model.BroadcastJob) error {
+	logger := r.logger.With(zap.Uint64("job_id", job.ID), zap.String("audience", string(job.Audience)))
+
+	if job.Cursor == 0 && job.Sent == 0 && job.Failed == 0 {
+		total, err := r.count(ctx, job)
+		if err != nil {
+			return r.fail(ctx, job, err)
+		}
+		job.Total = total
+		logger.Info("broadcast job started", zap.Uint64("total", job.Total))
+	}
+
+	pace := time.NewTicker(time.Second / time.Duration(r.config.Rate))
+	defer pace.Stop()
+
+	for {
+		userIds, err := r.page(ctx, job)
+		if err != nil {
+			return r.fail(ctx, job, err)
+		}
+		if len(userIds) == 0 {
+			if err := r.broadcastRepo.Finish(ctx, job, model.BroadcastCompleted); err != nil {
+				return r.stopped(logger, job, err)
+			}
+			logger.Info("broadcast job completed",
+				zap.Uint64("sent", job.Sent),
+				zap.Uint64("failed", job.Failed))
+			return nil
+		}
+
+		for _, userId := range userIds {
+			select {
+			case <-ctx.Done():
+				// The next runner takes over from the last user sent to
+				err := r.broadcastRepo.SaveProgress(context.WithoutCancel(ctx), job, time.Now())
+				return r.stopped(logger, job, err)
+			case <-pace.C:
+			}
+			if err := r.sendNotiSvc.Handle(ctx, request(job, userId)); err != nil {
+				job.Failed++
+				job.LastError = fmt.Sprintf("user %d: %v", userId, err)
+			} else {
+				job.Sent++
+			}
+			job.Cursor = userId
+		}
+		if err := r.broadcastRepo.SaveProgress(ctx, job, time.Now().Add(r.config.Lease)); err != nil {
+			return r.stopped(logger, job, err)
+		}
+	}
+}
+
+// stopped ends a run whose progress could not be saved, a job cancelled or taken over by
+// another runner is not an error
+func (r *Runner) stopped(logger *zap.Logger, job *model.BroadcastJob, err error) error {
+	if !stderrors.Is(err, model.ErrBroadcastNotRunning) {
+		return err
+	}
+	if job.Status == model.BroadcastCancelled {
+		logger.Info("broadcast job cancelled", zap.Uint64("sent", job.Sent), zap.Uint64("failed", job.Failed))
+	} else {
+		logger.Warn("broadcast job taken over by another runner")
+	}
+	return nil
+}
+
+// fail closes the job when its audience cannot be resolved anymore, and leaves it to be
+// retried when the stock service is unreachable
+func (r *Runner) fail(ctx context.Context, job *model.BroadcastJob, err error) error {
+	if !stderrors.Is(err, ErrNoStockClient) {
+		return err
+	}
+	job.LastError = err.Error()
+	if err := r.broadcastRepo.Finish(ctx, job, model.BroadcastFailed); err != nil {
+		return r.stopped(r.logger, job, err)
+	}
+	r.logger.Error("broadcast job failed", zap.Uint64("job_id", job.ID), zap.Error(err))
+	return nil
+}
+
+// count is the number of users of the audience of the job
+func (r *Runner) count(ctx context.Context, job *model.BroadcastJob) (uint64, error) {
+	switch job.Audience {
+	case model.AudienceAll:
+		return r.broadcastRepo.CountUsers(ctx)
+	case model.AudienceUsers:
+		return uint64(len(job.UserIDs)), nil
+	case model.AudienceHolders:
+		if r.stockClient == nil {
+			return 0, ErrNoStockClient
+		}
+		_, total, err := r.stockClient.ListHolders(ctx, job.Symbol, 0, 1)
+		return total, err
+	}
+	return 0, fmt.Errorf("unknown audience %q", job.Audience)
+}
+
+// page is the next batch of users of the audience after the cursor of the job
+func (r *Runner) page(ctx context.Context, job *model.BroadcastJob) ([]uint64, error) {
+	switch job.Audience {
+	case model.AudienceAll:
+		return r.broadcastRepo.ListUsers(ctx, job.Cursor, r.config.BatchSize)
+	case model.AudienceUsers:
+		// The users of a job are sorted when it is created
+		start, _ := slices.BinarySearch(job.UserIDs, job.Cursor+1)
+		return job.UserIDs[start:min(start+r.config.BatchSize, len(job.UserIDs))], nil
+	case model.AudienceHolders:
+		if r.stockClient == nil {
+			return nil, ErrNoStockClient
+		}
+		userIds, _, err := r.stockClient.ListHolders(ctx, job.Symbol, job.Cursor, uint32(r.config.BatchSize))
+		return userIds, err
+	}
+	return nil, fmt.Errorf("unknown audience %q", job.Audience)
+}
+
+func request(job *model.BroadcastJob, userId uint64) *dto.NotificationCreateReq {
+	return &dto.NotificationCreateReq{
+		UserID:     userId,
+		Type:       job.Type,
+		Title:      job.Title,
+		Body:       job.Body,
+		TemplateID: job.TemplateID,
+		Locale:     job.Locale,
+		Variables:  job.Variables,
+	}
+}
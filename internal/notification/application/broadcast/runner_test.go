@@ -0,0 +1,220 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	infrasRepo "simple-securities/internal/notification/infras/repo"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// fakeSender records the users sent to, failing for the users of failFor
+type fakeSender struct {
+	sent    []uint64
+	failFor []uint64
+	onSend  func(userId uint64)
+}
+
+func (s *fakeSender) Handle(ctx context.Context, req *dto.NotificationCreateReq) error {
+	if s.onSend != nil {
+		s.onSend(req.UserID)
+	}
+	if slices.Contains(s.failFor, req.UserID) {
+		return errors.New("no such user")
+	}
+	s.sent = append(s.sent, req.UserID)
+	return nil
+}
+
+// fakeStock pages through holders
+type fakeStock struct {
+	holders []uint64
+}
+
+func (s *fakeStock) ListHolders(ctx context.Context, symbol string, afterUserId uint64, limit uint32) ([]uint64, uint64, error) {
+	start, _ := slices.BinarySearch(s.holders, afterUserId+1)
+	return s.holders[start:min(start+int(limit), len(s.holders))], uint64(len(s.holders)), nil
+}
+
+func newTestRepo(t *testing.T) (*sqlx.DB, repo.IBroadcastRepo) {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "broadcast.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000001_init_notificationdb",
+		"000010_init_notification_delivery",
+		"000011_init_notification_preferences",
+		"000013_init_notification_broadcasts",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+	return db, infrasRepo.NewBroadcastRepo(db)
+}
+
+func newTestRunner(broadcastRepo repo.IBroadcastRepo, stockClient repo.IStockClient, sender *fakeSender) *Runner {
+	return NewRunner(broadcastRepo, stockClient, sender, Config{BatchSize: 2, Rate: 1000}, zap.NewNop())
+}
+
+func createJob(t *testing.T, broadcastRepo repo.IBroadcastRepo, job *model.BroadcastJob) *model.BroadcastJob {
+	t.Helper()
+	job.Type, job.Title, job.Body = "maintenance", "Maintenance", "Trading pauses at 22:00"
+	job, err := broadcastRepo.Create(context.Background(), job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func getJob(t *testing.T, broadcastRepo repo.IBroadcastRepo, id uint64) *model.BroadcastJob {
+	t.Helper()
+	job, err := broadcastRepo.GetByID(context.Background(), id)
+	if err != nil || job == nil {
+		t.Fatalf("GetByID(%d) = %+v, %v", id, job, err)
+	}
+	return job
+}
+
+func TestRunSendsToListedUsers(t *testing.T) {
+	_, broadcastRepo := newTestRepo(t)
+	ctx := context.Background()
+	job := createJob(t, broadcastRepo, &model.BroadcastJob{Audience: model.AudienceUsers, UserIDs: []uint64{1, 2, 3, 4, 5}})
+	sender := &fakeSender{failFor: []uint64{3}}
+
+	runner := newTestRunner(broadcastRepo, nil, sender)
+	if ran, err := runner.RunPending(ctx); err != nil || ran != 1 {
+		t.Fatalf("RunPending() = %d, %v, want 1 job", ran, err)
+	}
+	if ran, err := runner.RunPending(ctx); err != nil || ran != 0 {
+		t.Fatalf("RunPending() once done = %d, %v, want none", ran, err)
+	}
+
+	if fmt.Sprint(sender.sent) != "[1 2 4 5]" {
+		t.Errorf("sent to %v", sender.sent)
+	}
+	job = getJob(t, broadcastRepo, job.ID)
+	if job.Status != model.BroadcastCompleted || job.Total != 5 || job.Sent != 4 || job.Failed != 1 || job.Cursor != 5 {
+		t.Errorf("job = %+v, want completed with 4 sent and 1 failed of 5", job)
+	}
+	if !strings.HasPrefix(job.LastError, "user 3:") || job.StartedAt == nil || job.FinishedAt == nil {
+		t.Errorf("job error %q, started at %v, finished at %v", job.LastError, job.StartedAt, job.FinishedAt)
+	}
+}
+
+func TestRunResolvesAudiences(t *testing.T) {
+	db, broadcastRepo := newTestRepo(t)
+	ctx := context.Background()
+
+	// The users known to the service have notifications, channels or preferences
+	db.MustExec(`INSERT INTO notifications (uuid, user_id, type, title, body, created_by, updated_by) VALUES ('a', 1, 'info', 't', 'b', 1, 1), ('b', 4, 'info', 't', 'b', 4, 4)`)
+	db.MustExec(`INSERT INTO notification_channels (user_id, channel, address) VALUES (2, 'webhook', 'https://example.com')`)
+	db.MustExec(`INSERT INTO notification_preferences (user_id) VALUES (4), (7)`)
+	all := createJob(t, broadcastRepo, &model.BroadcastJob{Audience: model.AudienceAll})
+	holders := createJob(t, broadcastRepo, &model.BroadcastJob{Audience: model.AudienceHolders, Symbol: "AAPL"})
+
+	sender := &fakeSender{}
+	runner := newTestRunner(broadcastRepo, &fakeStock{holders: []uint64{3, 9, 12}}, sender)
+	if _, err := runner.RunPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(sender.sent) != "[1 2 4 7 3 9 12]" {
+		t.Errorf("sent to %v, want the known users then the holders", sender.sent)
+	}
+	if job := getJob(t, broadcastRepo, all.ID); job.Total != 4 || job.Sent != 4 {
+		t.Errorf("all users job = %+v, want 4 sent of 4", job)
+	}
+	if job := getJob(t, broadcastRepo, holders.ID); job.Total != 3 || job.Sent != 3 {
+		t.Errorf("holders job = %+v, want 3 sent of 3", job)
+	}
+
+	// Without the stock service the holders cannot be listed
+	holders = createJob(t, broadcastRepo, &model.BroadcastJob{Audience: model.AudienceHolders, Symbol: "AAPL"})
+	if _, err := newTestRunner(broadcastRepo, nil, sender).RunPending(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if job := getJob(t, broadcastRepo, holders.ID); job.Status != model.BroadcastFailed || job.LastError == "" {
+		t.Errorf("holders job without stock service = %+v, want failed", job)
+	}
+}
+
+func TestCancelStopsJobAfterBatch(t *testing.T) {
+	_, broadcastRepo := newTestRepo(t)
+	ctx := context.Background()
+	job := createJob(t, broadcastRepo, &model.BroadcastJob{Audience: model.AudienceUsers, UserIDs: []uint64{1, 2, 3, 4, 5, 6}})
+
+	sender := &fakeSender{}
+	sender.onSend = func(userId uint64) {
+		if userId == 1 {
+			if cancelled, err := broadcastRepo.Cancel(ctx, job.ID); err != nil || !cancelled {
+				t.Errorf("Cancel() = %t, %v", cancelled, err)
+			}
+		}
+	}
+	if ran, err := newTestRunner(broadcastRepo, nil, sender).RunPending(ctx); err != nil || ran != 1 {
+		t.Fatalf("RunPending() = %d, %v", ran, err)
+	}
+
+	if fmt.Sprint(sender.sent) != "[1 2]" {
+		t.Errorf("sent to %v, want the batch in progress only", sender.sent)
+	}
+	job = getJob(t, broadcastRepo, job.ID)
+	if job.Status != model.BroadcastCancelled || job.Sent != 2 || job.Cursor != 2 || job.FinishedAt == nil {
+		t.Errorf("job = %+v, want cancelled with the batch in progress counted", job)
+	}
+	if cancelled, err := broadcastRepo.Cancel(ctx, job.ID); err != nil || cancelled {
+		t.Errorf("Cancel() of a cancelled job = %t, %v, want false", cancelled, err)
+	}
+}
+
+func TestExpiredLeaseIsTakenOver(t *testing.T) {
+	_, broadcastRepo := newTestRepo(t)
+	ctx := context.Background()
+	createJob(t, broadcastRepo, &model.BroadcastJob{Audience: model.AudienceUsers, UserIDs: []uint64{1, 2, 3, 4}})
+
+	// A runner that sent to the first two users and stalled past its lease
+	stalled, err := broadcastRepo.Claim(ctx, time.Now().Add(-time.Second))
+	if err != nil || stalled == nil {
+		t.Fatalf("Claim() = %+v, %v", stalled, err)
+	}
+	stalled.Total, stalled.Sent, stalled.Cursor = 4, 2, 2
+	if err := broadcastRepo.SaveProgress(ctx, stalled, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	sender := &fakeSender{}
+	if ran, err := newTestRunner(broadcastRepo, nil, sender).RunPending(ctx); err != nil || ran != 1 {
+		t.Fatalf("RunPending() = %d, %v", ran, err)
+	}
+	if fmt.Sprint(sender.sent) != "[3 4]" {
+		t.Errorf("sent to %v, want the users after the cursor", sender.sent)
+	}
+	if job := getJob(t, broadcastRepo, stalled.ID); job.Status != model.BroadcastCompleted || job.Sent != 4 {
+		t.Errorf("job = %+v, want completed with 4 sent", job)
+	}
+
+	// The stalled runner lost its claim
+	stalled.Sent, stalled.Cursor = 3, 3
+	if err := broadcastRepo.SaveProgress(ctx, stalled, time.Now().Add(time.Minute)); !errors.Is(err, model.ErrBroadcastNotRunning) {
+		t.Errorf("SaveProgress() of the stalled runner = %v, want %v", err, model.ErrBroadcastNotRunning)
+	}
+}
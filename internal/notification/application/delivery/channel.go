@@ -0,0 +1,35 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"simple-securities/internal/notification/domain/model"
+)
+
+// Channel delivers notifications to the address of a user channel
+type Channel interface {
+	Name() model.Channel
+	Send(ctx context.Context, to *model.UserChannel, notification *model.NotificationCreated) error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks a failure that trying again cannot fix, like an address the receiver
+// rejects, the delivery fails without retry
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
@@ -0,0 +1,261 @@
+// Package delivery sends the notifications of the inbox on the other channels of their users.
+//
+// The Dispatcher consumes the notification events the service publishes through its outbox,
+// so that delivery runs asynchronously from Send and survives restarts. The events are read
+// as model.NotificationEvent with kafka.Subscribe, under the schema the relay registered. Every notification
+// has a delivery per enabled channel of its user recording the status of the channel. A
+// failed channel fails the event which the consumer retries through its retry topics; the
+// channels delivered before are not sent again.
+//
+// The preferences of the user decide which notifications reach the channels: the deliveries
+// of muted types and of muted channels are skipped, the notification staying in the inbox.
+// The deliveries during quiet hours are deferred until the quiet hours end, Start resumes
+// them when they are due. Critical types, like margin calls, are delivered regardless.
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/kafka"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultResumeInterval  = time.Minute
+	defaultResumeBatchSize = 100
+)
+
+type Config struct {
+	// CriticalTypes are the notification types delivered whatever the preferences of the user
+	CriticalTypes []string
+	// ResumeInterval is how often the deferred deliveries that are due are resumed, a resumed
+	// delivery failing again is deferred by as much
+	ResumeInterval  time.Duration
+	ResumeBatchSize int
+}
+
+type Dispatcher struct {
+	config           Config
+	channels         map[model.Channel]Channel
+	critical         map[string]bool
+	notificationRepo repo.INotificationRepo
+	userChannelRepo  repo.IUserChannelRepo
+	deliveryRepo     repo.IDeliveryRepo
+	preferencesRepo  repo.IPreferencesRepo
+	logger           *zap.Logger
+	now              func() time.Time
+}
+
+func NewDispatcher(
+	notificationRepo repo.INotificationRepo,
+	userChannelRepo repo.IUserChannelRepo,
+	deliveryRepo repo.IDeliveryRepo,
+	preferencesRepo repo.IPreferencesRepo,
+	channels []Channel,
+	config Config,
+	logger *zap.Logger,
+) *Dispatcher {
+	if config.ResumeInterval <= 0 {
+		config.ResumeInterval = defaultResumeInterval
+	}
+	if config.ResumeBatchSize <= 0 {
+		config.ResumeBatchSize = defaultResumeBatchSize
+	}
+	byName := make(map[model.Channel]Channel, len(channels))
+	for _, channel := range channels {
+		byName[channel.Name()] = channel
+	}
+	critical := make(map[string]bool, len(config.CriticalTypes))
+	for _, notiType := range config.CriticalTypes {
+		critical[notiType] = true
+	}
+	return &Dispatcher{
+		config:           config,
+		channels:         byName,
+		critical:         critical,
+		notificationRepo: notificationRepo,
+		userChannelRepo:  userChannelRepo,
+		deliveryRepo:     deliveryRepo,
+		preferencesRepo:  preferencesRepo,
+		logger:           logger,
+		now:              time.Now,
+	}
+}
+
+// Handle is the kafka.Subscribe handler of the notification event topic
+func (d *Dispatcher) Handle(ctx context.Context, msg *kafka.Message, event model.NotificationEvent) error {
+	if event.Meta.Message != model.EventNotificationCreated {
+		return nil
+	}
+	if event.Data.ID == 0 {
+		d.logger.Error("dropping notification event without notification",
+			zap.String("request_id", event.Meta.RequestID))
+		return nil
+	}
+	return d.Dispatch(ctx, &event.Data)
+}
+
+// Dispatch sends the notification on the enabled channels of its user that have not
+// delivered it yet, it fails when a channel failed and may succeed later
+func (d *Dispatcher) Dispatch(ctx context.Context, notification *model.NotificationCreated) error {
+	userChannels, err := d.userChannelRepo.GetByUserId(ctx, notification.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get the channels of user %d: %w", notification.UserID, err)
+	}
+	preferences, err := d.preferences(ctx, notification)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, userChannel := range userChannels {
+		if !userChannel.Enabled {
+			continue
+		}
+		if err := d.deliver(ctx, userChannel, preferences, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Start resumes the deferred deliveries when they are due until ctx is cancelled
+func (d *Dispatcher) Start(ctx context.Context) error {
+	d.logger.Info("delivery resumer started", zap.Duration("interval", d.config.ResumeInterval))
+
+	ticker := time.NewTicker(d.config.ResumeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info("delivery resumer stopped")
+			return nil
+		case <-ticker.C:
+			if _, err := d.ResumeDue(ctx); err != nil && ctx.Err() == nil {
+				d.logger.Error("failed to resume deferred deliveries", zap.Error(err))
+			}
+		}
+	}
+}
+
+// ResumeDue delivers the deferred deliveries that are due and returns how many were resumed.
+// A delivery failing again is deferred by the resume interval rather than retried by the
+// consumer, the event that started it is long committed.
+func (d *Dispatcher) ResumeDue(ctx context.Context) (int, error) {
+	resumed := 0
+	for {
+		deliveries, err := d.deliveryRepo.GetDue(ctx, d.now(), d.config.ResumeBatchSize)
+		if err != nil {
+			return resumed, err
+		}
+		for _, delivery := range deliveries {
+			if err := d.resume(ctx, delivery); err != nil {
+				return resumed, err
+			}
+			resumed++
+		}
+		if len(deliveries) < d.config.ResumeBatchSize {
+			return resumed, nil
+		}
+	}
+}
+
+// resume delivers a due deferred delivery. A failed send defers it again, so it fails only
+// when the delivery could not be loaded or recorded and would be resumed again in the same run.
+func (d *Dispatcher) resume(ctx context.Context, delivery *model.Delivery) error {
+	n, err := d.notificationRepo.GetByID(ctx, delivery.NotificationID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification %d: %w", delivery.NotificationID, err)
+	}
+	if n == nil {
+		return d.deliveryRepo.Record(ctx, delivery.ID, model.DeliverySkipped, "notification deleted", d.now())
+	}
+	userChannels, err := d.userChannelRepo.GetByUserId(ctx, delivery.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get the channels of user %d: %w", delivery.UserID, err)
+	}
+	idx := slices.IndexFunc(userChannels, func(c *model.UserChannel) bool { return c.Channel == delivery.Channel })
+	if idx < 0 || !userChannels[idx].Enabled {
+		return d.deliveryRepo.Record(ctx, delivery.ID, model.DeliverySkipped, "channel disabled", d.now())
+	}
+
+	notification := model.NewNotificationCreated(n)
+	preferences, err := d.preferences(ctx, notification)
+	if err == nil {
+		err = d.deliver(ctx, userChannels[idx], preferences, notification)
+	}
+	if err == nil {
+		return nil
+	}
+	now := d.now()
+	return d.deliveryRepo.Defer(ctx, delivery.ID, now.Add(d.config.ResumeInterval), err.Error(), now)
+}
+
+// preferences are the preferences of the user applying to the notification, none for critical types
+func (d *Dispatcher) preferences(ctx context.Context, notification *model.NotificationCreated) (*model.Preferences, error) {
+	preferences, err := d.preferencesRepo.Get(ctx, notification.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the preferences of user %d: %w", notification.UserID, err)
+	}
+	if preferences == nil || d.critical[notification.Type] {
+		preferences = model.NewPreferences(notification.UserID)
+	}
+	return preferences, nil
+}
+
+func (d *Dispatcher) deliver(
+	ctx context.Context,
+	to *model.UserChannel,
+	preferences *model.Preferences,
+	notification *model.NotificationCreated,
+) error {
+	delivery, err := d.deliveryRepo.Start(ctx, notification.ID, notification.UserID, to.Channel)
+	if err != nil {
+		return fmt.Errorf("failed to start the %s delivery of notification %d: %w", to.Channel, notification.ID, err)
+	}
+	if delivery.Status == model.DeliverySent || delivery.Status == model.DeliverySkipped {
+		return nil
+	}
+	// A deferred delivery is left to ResumeDue until it is due
+	if delivery.Status == model.DeliveryDeferred && delivery.DeliverAfter != nil && d.now().Before(*delivery.DeliverAfter) {
+		return nil
+	}
+
+	switch reason := preferences.MuteReason(notification.Type, to.Channel, d.now()); reason {
+	case "":
+	case model.MuteReasonQuietHours:
+		return d.deliveryRepo.Defer(ctx, delivery.ID, preferences.QuietHours.EndAfter(d.now()), reason, d.now())
+	default:
+		return d.deliveryRepo.Record(ctx, delivery.ID, model.DeliverySkipped, reason, d.now())
+	}
+	channel, ok := d.channels[to.Channel]
+	if !ok {
+		return d.deliveryRepo.Record(ctx, delivery.ID, model.DeliverySkipped, "channel not configured", d.now())
+	}
+
+	sendErr := channel.Send(ctx, to, notification)
+	if sendErr == nil {
+		return d.deliveryRepo.Record(ctx, delivery.ID, model.DeliverySent, "", d.now())
+	}
+
+	d.logger.Warn("notification delivery failed",
+		zap.Uint64("notification_id", notification.ID),
+		zap.String("channel", string(to.Channel)),
+		zap.Uint32("attempt", delivery.Attempts+1),
+		zap.Bool("permanent", IsPermanent(sendErr)),
+		zap.Error(sendErr))
+	if err := d.deliveryRepo.Record(ctx, delivery.ID, model.DeliveryFailed, sendErr.Error(), d.now()); err != nil {
+		return err
+	}
+	if IsPermanent(sendErr) {
+		return nil
+	}
+	return fmt.Errorf("%s delivery of notification %d: %w", to.Channel, notification.ID, sendErr)
+}
@@ -0,0 +1,273 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	infrasRepo "simple-securities/internal/notification/infras/repo"
+	"simple-securities/pkg/kafka"
+	"simple-securities/pkg/kafka/schema"
+	"simple-securities/pkg/outbox"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// fakeChannel fails its first sends with err
+type fakeChannel struct {
+	name  model.Channel
+	err   error
+	fails int
+	sent  []uint64
+}
+
+func (c *fakeChannel) Name() model.Channel { return c.name }
+
+func (c *fakeChannel) Send(_ context.Context, _ *model.UserChannel, notification *model.NotificationCreated) error {
+	if c.fails > 0 {
+		c.fails--
+		return c.err
+	}
+	c.sent = append(c.sent, notification.ID)
+	return nil
+}
+
+func newTestRepos(t *testing.T) (repo.INotificationRepo, repo.IUserChannelRepo, repo.IDeliveryRepo, repo.IPreferencesRepo) {
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "delivery.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000001_init_notificationdb",
+		"000006_init_outbox",
+		"000010_init_notification_delivery",
+		"000011_init_notification_preferences",
+		"000018_add_notification_delivery_deferral",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+	return infrasRepo.NewNotificationRepo(db, infrasRepo.OutboxConfig{Topic: "notification-events"}),
+		infrasRepo.NewUserChannelRepo(db), infrasRepo.NewDeliveryRepo(db), infrasRepo.NewPreferencesRepo(db)
+}
+
+func addChannel(t *testing.T, userChannelRepo repo.IUserChannelRepo, channel model.Channel, enabled bool) {
+	t.Helper()
+	err := userChannelRepo.Upsert(context.Background(), &model.UserChannel{
+		UserID: 7, Channel: channel, Address: "to-" + string(channel), Enabled: enabled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func statuses(t *testing.T, deliveryRepo repo.IDeliveryRepo, id uint64) map[model.Channel]*model.Delivery {
+	t.Helper()
+	deliveries, err := deliveryRepo.GetByNotificationId(context.Background(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byChannel := make(map[model.Channel]*model.Delivery)
+	for _, d := range deliveries {
+		byChannel[d.Channel] = d
+	}
+	return byChannel
+}
+
+func TestDispatchRetriesOnlyFailedChannels(t *testing.T) {
+	notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo := newTestRepos(t)
+	addChannel(t, userChannelRepo, model.ChannelEmail, true)
+	addChannel(t, userChannelRepo, model.ChannelWebhook, true)
+	addChannel(t, userChannelRepo, model.ChannelDiscord, true)
+	addChannel(t, userChannelRepo, model.ChannelPush, false)
+
+	email := &fakeChannel{name: model.ChannelEmail}
+	webhook := &fakeChannel{name: model.ChannelWebhook, err: errors.New("connection refused"), fails: 1}
+	push := &fakeChannel{name: model.ChannelPush}
+	dispatcher := NewDispatcher(notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo,
+		[]Channel{email, webhook, push}, Config{}, zap.NewNop())
+	notification := &model.NotificationCreated{ID: 1, UserID: 7, Title: "Filled"}
+	ctx := context.Background()
+
+	if err := dispatcher.Dispatch(ctx, notification); err == nil {
+		t.Fatal("Dispatch() with a failed webhook succeeded, want an error to retry")
+	}
+	// The retry sends the webhook only
+	if err := dispatcher.Dispatch(ctx, notification); err != nil {
+		t.Fatalf("Dispatch() retry = %v", err)
+	}
+	if len(email.sent) != 1 || len(webhook.sent) != 1 || len(push.sent) != 0 {
+		t.Errorf("sent email %v, webhook %v, push %v, want one email and webhook", email.sent, webhook.sent, push.sent)
+	}
+
+	got := statuses(t, deliveryRepo, 1)
+	if d := got[model.ChannelWebhook]; d == nil || d.Status != model.DeliverySent || d.Attempts != 2 || d.DeliveredAt == nil {
+		t.Errorf("webhook delivery = %+v, want sent on the second attempt", d)
+	}
+	if d := got[model.ChannelEmail]; d == nil || d.Status != model.DeliverySent || d.Attempts != 1 {
+		t.Errorf("email delivery = %+v, want sent on the first attempt", d)
+	}
+	// Discord has no adapter, the disabled push channel no delivery
+	if d := got[model.ChannelDiscord]; d == nil || d.Status != model.DeliverySkipped {
+		t.Errorf("discord delivery = %+v, want skipped", d)
+	}
+	if d := got[model.ChannelPush]; d != nil {
+		t.Errorf("disabled push channel has delivery %+v", d)
+	}
+}
+
+func TestDispatchDoesNotRetryPermanentFailures(t *testing.T) {
+	notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo := newTestRepos(t)
+	addChannel(t, userChannelRepo, model.ChannelWebhook, true)
+	webhook := &fakeChannel{name: model.ChannelWebhook, err: Permanent(errors.New("410 Gone")), fails: 1}
+	dispatcher := NewDispatcher(notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo,
+		[]Channel{webhook}, Config{}, zap.NewNop())
+
+	if err := dispatcher.Dispatch(context.Background(), &model.NotificationCreated{ID: 1, UserID: 7}); err != nil {
+		t.Fatalf("Dispatch() with a permanent failure = %v, want nil", err)
+	}
+	if d := statuses(t, deliveryRepo, 1)[model.ChannelWebhook]; d == nil || d.Status != model.DeliveryFailed || d.LastError != "410 Gone" {
+		t.Errorf("webhook delivery = %+v, want failed with its error", d)
+	}
+}
+
+func TestHandleReadsTypedEvents(t *testing.T) {
+	notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo := newTestRepos(t)
+	addChannel(t, userChannelRepo, model.ChannelEmail, true)
+	email := &fakeChannel{name: model.ChannelEmail}
+	dispatcher := NewDispatcher(notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo,
+		[]Channel{email}, Config{}, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	bus := kafka.NewMemoryBus(1, zap.NewNop())
+	defer bus.Close()
+	jsonSerializer := schema.NewJSONSerializer()
+	bus.SetCodec(kafka.NewCodec(schema.NewMemoryRegistry(schema.CompatibilityBackward, jsonSerializer), jsonSerializer))
+	if err := kafka.Subscribe(bus, "notification-events", "delivery", dispatcher.Handle, kafka.ConsumerOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	bus.StartAllConsumers(ctx)
+
+	// The events are stored in the outbox as JSON and relayed as typed values
+	publisher := outbox.NewTypedPublisher[model.NotificationEvent](bus, "notification-events")
+	for _, event := range []kafka.Event{
+		{Meta: kafka.Meta{Message: "notification.deleted"}, Data: map[string]any{"id": 1, "user_id": 7}},
+		{Meta: kafka.Meta{Message: model.EventNotificationCreated}, Data: &model.NotificationCreated{ID: 2, UserID: 7}},
+	} {
+		value, err := json.Marshal(event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := publisher.WriteMessage(ctx, "notification-events", "7", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := bus.WaitCommitted(ctx, "delivery", "notification-events"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, msg := range bus.Messages("notification-events") {
+		if _, ok := msg.Header(kafka.HeaderSchemaID); !ok {
+			t.Errorf("message %s has no schema id", msg.Value)
+		}
+	}
+	if len(email.sent) != 1 || email.sent[0] != 2 {
+		t.Errorf("sent %v, want only the created notification 2", email.sent)
+	}
+}
+
+func TestDispatchFollowsPreferences(t *testing.T) {
+	notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo := newTestRepos(t)
+	addChannel(t, userChannelRepo, model.ChannelEmail, true)
+	addChannel(t, userChannelRepo, model.ChannelPush, true)
+	preferences := model.NewPreferences(7)
+	preferences.MutedTypes = []string{"order_filled"}
+	preferences.MutedChannels = []model.Channel{model.ChannelPush}
+	preferences.QuietHours = &model.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Paris"}
+	if err := preferencesRepo.Upsert(context.Background(), preferences); err != nil {
+		t.Fatal(err)
+	}
+
+	email := &fakeChannel{name: model.ChannelEmail}
+	push := &fakeChannel{name: model.ChannelPush}
+	dispatcher := NewDispatcher(notificationRepo, userChannelRepo, deliveryRepo, preferencesRepo,
+		[]Channel{email, push}, Config{CriticalTypes: []string{"margin_call"}}, zap.NewNop())
+	ctx := context.Background()
+	notifications := make(map[uint64]*model.NotificationCreated)
+	for _, notiType := range []string{"order_filled", "price_alert", "price_alert", "margin_call"} {
+		n, err := notificationRepo.Create(ctx, &model.Notification{UserID: 7, Type: notiType})
+		if err != nil {
+			t.Fatal(err)
+		}
+		notifications[n.ID] = model.NewNotificationCreated(n)
+	}
+	dispatch := func(ids ...uint64) {
+		t.Helper()
+		for _, id := range ids {
+			if err := dispatcher.Dispatch(ctx, notifications[id]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// 12:00 in Paris, outside the quiet hours
+	dispatcher.now = func() time.Time { return time.Date(2024, 3, 1, 11, 0, 0, 0, time.UTC) }
+	dispatch(1, 2)
+	// 23:30 in Paris, in the quiet hours
+	dispatcher.now = func() time.Time { return time.Date(2024, 3, 1, 22, 30, 0, 0, time.UTC) }
+	dispatch(3, 4)
+
+	// Critical notifications go through mutes and quiet hours
+	if len(email.sent) != 2 || email.sent[0] != 2 || email.sent[1] != 4 || len(push.sent) != 1 || push.sent[0] != 4 {
+		t.Errorf("sent email %v, push %v, want email 2 and 4, push 4", email.sent, push.sent)
+	}
+	for id, want := range map[uint64]map[model.Channel]string{
+		1: {model.ChannelEmail: model.MuteReasonType, model.ChannelPush: model.MuteReasonType},
+		2: {model.ChannelPush: model.MuteReasonChannel},
+		3: {model.ChannelPush: model.MuteReasonChannel},
+	} {
+		got := statuses(t, deliveryRepo, id)
+		for channel, reason := range want {
+			if d := got[channel]; d == nil || d.Status != model.DeliverySkipped || d.LastError != reason {
+				t.Errorf("notification %d %s delivery = %+v, want skipped for %s", id, channel, d, reason)
+			}
+		}
+	}
+
+	// The quiet hours defer the email of notification 3 until they end at 07:00 in Paris
+	end := time.Date(2024, 3, 2, 6, 0, 0, 0, time.UTC)
+	if d := statuses(t, deliveryRepo, 3)[model.ChannelEmail]; d == nil || d.Status != model.DeliveryDeferred ||
+		d.DeliverAfter == nil || !d.DeliverAfter.Equal(end) {
+		t.Fatalf("notification 3 email delivery = %+v, want deferred until %s", d, end)
+	}
+	// A redelivered event and a resume before the end leave it deferred
+	dispatcher.now = func() time.Time { return end.Add(-time.Minute) }
+	dispatch(3)
+	if resumed, err := dispatcher.ResumeDue(ctx); err != nil || resumed != 0 {
+		t.Fatalf("ResumeDue() in the quiet hours = %d, %v", resumed, err)
+	}
+	dispatcher.now = func() time.Time { return end }
+	if resumed, err := dispatcher.ResumeDue(ctx); err != nil || resumed != 1 {
+		t.Fatalf("ResumeDue() after the quiet hours = %d, %v, want 1", resumed, err)
+	}
+	if len(email.sent) != 3 || email.sent[2] != 3 {
+		t.Errorf("sent email %v, want notification 3 once the quiet hours ended", email.sent)
+	}
+	if d := statuses(t, deliveryRepo, 3)[model.ChannelEmail]; d == nil || d.Status != model.DeliverySent || d.Attempts != 1 {
+		t.Errorf("notification 3 email delivery = %+v, want sent on its first attempt", d)
+	}
+}
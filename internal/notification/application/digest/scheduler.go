@@ -0,0 +1,212 @@
+// Package digest holds the low-priority notifications of users who asked for digests out of
+// the inbox, and emits them per user as one summary notification every hour, day or week.
+//
+// Digests live in the database: the open digest of a user and its items survive restarts, and
+// a digest is closed in the transaction storing its summary notification and its event, so it
+// is emitted exactly once. The window of a digest follows the time zone of the user and ends
+// after the quiet hours. Only the configured types are collected, muted types stay in the inbox.
+package digest
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval  = time.Minute
+	defaultBatchSize = 100
+	defaultMaxItems  = 20
+)
+
+type Config struct {
+	// Types are the low-priority notification types collected in digests
+	Types []string
+	// Interval is how often the due digests are emitted
+	Interval  time.Duration
+	BatchSize int
+	// ItemLink is the link listed with each item of a summary, "{digest_id}" and "{item_id}"
+	// are replaced; items have no link when empty
+	ItemLink string
+	// MaxItems bounds the items listed in a summary, the others are counted
+	MaxItems int
+}
+
+type Scheduler struct {
+	config          Config
+	digestRepo      repo.IDigestRepo
+	preferencesRepo repo.IPreferencesRepo
+	notiCacheRepo   repo.INotificationCacheRepo
+	logger          *zap.Logger
+	now             func() time.Time
+}
+
+func NewScheduler(
+	digestRepo repo.IDigestRepo,
+	preferencesRepo repo.IPreferencesRepo,
+	notiCacheRepo repo.INotificationCacheRepo,
+	config Config,
+	logger *zap.Logger,
+) *Scheduler {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.MaxItems <= 0 {
+		config.MaxItems = defaultMaxItems
+	}
+	return &Scheduler{
+		config:          config,
+		digestRepo:      digestRepo,
+		preferencesRepo: preferencesRepo,
+		notiCacheRepo:   notiCacheRepo,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// Collect holds the notification in the digest of its user instead of the inbox when its type
+// is collected and the user gets digests. It returns false for the notifications to store.
+func (s *Scheduler) Collect(ctx context.Context, notification *model.Notification) (bool, error) {
+	if !slices.Contains(s.config.Types, notification.Type) {
+		return false, nil
+	}
+	preferences, err := s.preferencesRepo.Get(ctx, notification.UserID)
+	if err != nil {
+		return false, err
+	}
+	// Muted notifications stay in the inbox, a summary would bring them to the channels
+	if preferences == nil || preferences.DigestFrequency == model.DigestOff ||
+		slices.Contains(preferences.MutedTypes, notification.Type) {
+		return false, nil
+	}
+
+	now := s.now()
+	_, err = s.digestRepo.AddItem(ctx, preferences.DigestFrequency, preferences.DigestWindowEnd(now), &model.DigestItem{
+		UserID:    notification.UserID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Start emits the due digests until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.logger.Info("🗞️ digest scheduler started",
+		zap.Duration("interval", s.config.Interval),
+		zap.Strings("types", s.config.Types))
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("digest scheduler stopped")
+			return nil
+		case <-ticker.C:
+			if _, err := s.EmitDue(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Error("failed to emit digests", zap.Error(err))
+			}
+		}
+	}
+}
+
+// EmitDue emits the digests whose window ended and returns how many were emitted. A digest
+// that got an item while being emitted is left to the next run.
+func (s *Scheduler) EmitDue(ctx context.Context) (int, error) {
+	emitted := 0
+	for {
+		digests, err := s.digestRepo.GetDue(ctx, s.now(), s.config.BatchSize)
+		if err != nil {
+			return emitted, err
+		}
+		progressed := false
+		for _, digest := range digests {
+			ok, err := s.emit(ctx, digest)
+			if err != nil {
+				return emitted, err
+			}
+			if ok {
+				emitted++
+				progressed = true
+			}
+		}
+		if len(digests) < s.config.BatchSize || !progressed {
+			return emitted, nil
+		}
+	}
+}
+
+func (s *Scheduler) emit(ctx context.Context, digest *model.Digest) (bool, error) {
+	items, err := s.digestRepo.GetItems(ctx, digest.ID)
+	if err != nil {
+		return false, err
+	}
+	summary, err := s.digestRepo.Emit(ctx, digest, items, s.summary(digest, items))
+	if stderrors.Is(err, model.ErrDigestChanged) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to emit digest %d: %w", digest.ID, err)
+	}
+
+	// The summary is new in the inbox of the user
+	if err := s.notiCacheRepo.InvalidatePages(ctx, digest.UserID); err != nil {
+		s.logger.Warn("failed to invalidate notification cache",
+			zap.Uint64("user_id", digest.UserID), zap.Error(err))
+	}
+	s.logger.Info("digest emitted",
+		zap.Uint64("digest_id", digest.ID),
+		zap.Uint64("notification_id", summary.ID),
+		zap.Int("items", len(items)))
+	return true, nil
+}
+
+// summary is the notification of a digest, listing its first items with their links
+func (s *Scheduler) summary(digest *model.Digest, items []*model.DigestItem) *model.Notification {
+	noun := "notifications"
+	if len(items) == 1 {
+		noun = "notification"
+	}
+	title := fmt.Sprintf("Your %s digest: %d %s", digest.Frequency, len(items), noun)
+
+	var body strings.Builder
+	for i, item := range items {
+		if i == s.config.MaxItems {
+			fmt.Fprintf(&body, "…and %d more\n", len(items)-i)
+			break
+		}
+		fmt.Fprintf(&body, "- %s: %s", item.Title, item.Body)
+		if link := s.link(item); link != "" {
+			body.WriteString(" " + link)
+		}
+		body.WriteString("\n")
+	}
+	return model.NewNotification(digest.UserID, model.NotificationTypeDigest, title, strings.TrimSpace(body.String()))
+}
+
+func (s *Scheduler) link(item *model.DigestItem) string {
+	if s.config.ItemLink == "" {
+		return ""
+	}
+	return strings.NewReplacer(
+		"{digest_id}", strconv.FormatUint(item.DigestID, 10),
+		"{item_id}", strconv.FormatUint(item.ID, 10),
+	).Replace(s.config.ItemLink)
+}
@@ -0,0 +1,212 @@
+package digest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	infrasRepo "simple-securities/internal/notification/infras/repo"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// fakeCache counts the invalidated inbox pages
+type fakeCache struct {
+	repo.INotificationCacheRepo
+	invalidated int
+}
+
+func (c *fakeCache) InvalidatePages(ctx context.Context, userId uint64) error {
+	c.invalidated++
+	return nil
+}
+
+type testRepos struct {
+	digestRepo      repo.IDigestRepo
+	notiRepo        repo.INotificationRepo
+	preferencesRepo repo.IPreferencesRepo
+	cache           *fakeCache
+}
+
+func newTestScheduler(t *testing.T, now *time.Time) (*Scheduler, *testRepos) {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "digest.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000001_init_notificationdb",
+		"000006_init_outbox",
+		"000011_init_notification_preferences",
+		"000012_init_notification_digests",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+
+	outboxConfig := infrasRepo.OutboxConfig{ServiceName: "notification", Topic: "notification-events"}
+	repos := &testRepos{
+		digestRepo:      infrasRepo.NewDigestRepo(db, outboxConfig),
+		notiRepo:        infrasRepo.NewNotificationRepo(db, outboxConfig),
+		preferencesRepo: infrasRepo.NewPreferencesRepo(db),
+		cache:           &fakeCache{},
+	}
+	scheduler := NewScheduler(repos.digestRepo, repos.preferencesRepo, repos.cache, Config{
+		Types:    []string{"price_alert"},
+		ItemLink: "https://app.example.com/digests/{digest_id}#item-{item_id}",
+		MaxItems: 2,
+	}, zap.NewNop())
+	scheduler.now = func() time.Time { return *now }
+	return scheduler, repos
+}
+
+func setFrequency(t *testing.T, preferencesRepo repo.IPreferencesRepo, mutedTypes []string, frequency model.DigestFrequency) {
+	t.Helper()
+	preferences := model.NewPreferences(7)
+	preferences.MutedTypes = mutedTypes
+	preferences.DigestFrequency = frequency
+	if err := preferencesRepo.Upsert(context.Background(), preferences); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func inbox(t *testing.T, notiRepo repo.INotificationRepo) []*model.Notification {
+	t.Helper()
+	page, err := notiRepo.GetPageByUserId(context.Background(), 7, model.NotificationFilter{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return page.Notifications
+}
+
+func TestCollectOnlyDigestedTypes(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)
+	scheduler, repos := newTestScheduler(t, &now)
+	ctx := context.Background()
+	collect := func(notiType string) bool {
+		t.Helper()
+		collected, err := scheduler.Collect(ctx, model.NewNotification(7, notiType, "AAPL", "Above 200"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return collected
+	}
+
+	// Users without preferences or with digests off get every notification
+	if collect("price_alert") {
+		t.Error("collected a notification of a user without preferences")
+	}
+	setFrequency(t, repos.preferencesRepo, nil, model.DigestOff)
+	if collect("price_alert") {
+		t.Error("collected a notification of a user with digests off")
+	}
+
+	setFrequency(t, repos.preferencesRepo, []string{"info"}, model.DigestHourly)
+	if collect("order_filled") {
+		t.Error("collected a notification of a type not digested")
+	}
+	if !collect("price_alert") {
+		t.Error("price alert not collected for a user with hourly digests")
+	}
+	setFrequency(t, repos.preferencesRepo, []string{"price_alert"}, model.DigestHourly)
+	if collect("price_alert") {
+		t.Error("collected a notification of a muted type")
+	}
+}
+
+func TestEmitDueEmitsDigestsOnce(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)
+	scheduler, repos := newTestScheduler(t, &now)
+	ctx := context.Background()
+	setFrequency(t, repos.preferencesRepo, nil, model.DigestHourly)
+
+	for _, title := range []string{"AAPL", "MSFT", "NVDA"} {
+		if _, err := scheduler.Collect(ctx, model.NewNotification(7, "price_alert", title, "Above target")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The window of the digest ends at 11:00
+	if emitted, err := scheduler.EmitDue(ctx); err != nil || emitted != 0 {
+		t.Fatalf("EmitDue() before the window ends = %d, %v, want 0", emitted, err)
+	}
+	now = time.Date(2026, 3, 2, 11, 0, 0, 0, time.UTC)
+	if emitted, err := scheduler.EmitDue(ctx); err != nil || emitted != 1 {
+		t.Fatalf("EmitDue() = %d, %v, want 1", emitted, err)
+	}
+	// A restarted or concurrent scheduler finds nothing left to emit
+	if emitted, err := scheduler.EmitDue(ctx); err != nil || emitted != 0 {
+		t.Fatalf("EmitDue() after emitting = %d, %v, want 0", emitted, err)
+	}
+
+	notifications := inbox(t, repos.notiRepo)
+	if len(notifications) != 1 {
+		t.Fatalf("inbox has %d notifications, want the summary only", len(notifications))
+	}
+	summary := notifications[0]
+	if summary.Type != model.NotificationTypeDigest || summary.Title != "Your hourly digest: 3 notifications" {
+		t.Errorf("summary = %q %q", summary.Type, summary.Title)
+	}
+	wantBody := "- AAPL: Above target https://app.example.com/digests/1#item-1\n" +
+		"- MSFT: Above target https://app.example.com/digests/1#item-2\n" +
+		"…and 1 more"
+	if summary.Body != wantBody {
+		t.Errorf("summary body = %q, want %q", summary.Body, wantBody)
+	}
+	if repos.cache.invalidated != 1 {
+		t.Errorf("inbox pages invalidated %d times, want 1", repos.cache.invalidated)
+	}
+
+	digest, err := repos.digestRepo.GetByID(ctx, 1)
+	if err != nil || digest.Status != model.DigestEmitted || digest.NotificationID == nil || *digest.NotificationID != summary.ID {
+		t.Fatalf("GetByID() = %+v, %v, want the digest emitted with its summary", digest, err)
+	}
+
+	// Notifications after the emission open a new digest
+	if _, err := scheduler.Collect(ctx, model.NewNotification(7, "price_alert", "TSLA", "Below target")); err != nil {
+		t.Fatal(err)
+	}
+	now = time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	if emitted, err := scheduler.EmitDue(ctx); err != nil || emitted != 1 {
+		t.Fatalf("EmitDue() of the next window = %d, %v, want 1", emitted, err)
+	}
+	notifications = inbox(t, repos.notiRepo)
+	if len(notifications) != 2 || !strings.HasPrefix(notifications[0].Body, "- TSLA: Below target") {
+		t.Errorf("inbox after the next window = %d notifications, want a summary of TSLA first", len(notifications))
+	}
+}
+
+func TestDigestWindowEnd(t *testing.T) {
+	at := time.Date(2026, 3, 4, 21, 30, 0, 0, time.UTC) // a Wednesday
+	tests := []struct {
+		frequency  model.DigestFrequency
+		quietHours *model.QuietHours
+		want       time.Time
+	}{
+		{model.DigestHourly, nil, time.Date(2026, 3, 4, 22, 0, 0, 0, time.UTC)},
+		{model.DigestDaily, nil, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{model.DigestWeekly, nil, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		// Midnight falls in the quiet hours, the digest waits for their end
+		{model.DigestDaily, &model.QuietHours{Start: "22:00", End: "07:00", TimeZone: "UTC"}, time.Date(2026, 3, 5, 7, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		preferences := model.NewPreferences(7)
+		preferences.DigestFrequency = tt.frequency
+		preferences.QuietHours = tt.quietHours
+		if got := preferences.DigestWindowEnd(at); !got.Equal(tt.want) {
+			t.Errorf("DigestWindowEnd() of %s = %v, want %v", tt.frequency, got, tt.want)
+		}
+	}
+}
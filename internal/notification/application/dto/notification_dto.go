@@ -16,10 +16,13 @@ type NotificationDto struct {
 }
 
 type NotificationCreateReq struct {
-	UserID uint64 `json:"user_id" validate:"required"`
-	Type   string `json:"type" validate:"required"`
-	Title  string `json:"title" validate:"required"`
-	Body   string `json:"body" validate:"required"`
+	UserID     uint64         `json:"user_id" validate:"required"`
+	Type       string         `json:"type" validate:"required_without=TemplateID"`
+	Title      string         `json:"title" validate:"required_without=TemplateID"`
+	Body       string         `json:"body" validate:"required_without=TemplateID"`
+	TemplateID string         `json:"template_id"`
+	Locale     string         `json:"locale"`
+	Variables  map[string]any `json:"variables"`
 }
 
 type NotificationUpdateReq struct {
@@ -31,3 +34,172 @@ type NotificationUpdateReq struct {
 	Read   bool   `json:"read"`
 	Viewed bool   `json:"viewed"`
 }
+
+type GetNotiByUserIdReq struct {
+	UserID uint64 `json:"user_id" validate:"required"`
+	// Read and Viewed filter on the state when set
+	Read   *bool  `json:"read"`
+	Viewed *bool  `json:"viewed"`
+	Type   string `json:"type"`
+	Limit  uint32 `json:"limit"`
+	// Offset pages the legacy way, it is ignored with a page token
+	Offset    uint32 `json:"offset"`
+	PageToken string `json:"page_token"`
+}
+
+type NotificationPageDto struct {
+	Notifications []*NotificationDto `json:"notifications"`
+	NextPageToken string             `json:"next_page_token"`
+	Total         uint64             `json:"total"`
+	Unread        uint64             `json:"unread"`
+}
+
+type UnreadCountDto struct {
+	Unread   uint64 `json:"unread"`
+	Unviewed uint64 `json:"unviewed"`
+}
+
+type UserChannelDto struct {
+	UserID    uint64 `json:"user_id"`
+	Channel   string `json:"channel"`
+	Address   string `json:"address"`
+	Enabled   bool   `json:"enabled"`
+	HasSecret bool   `json:"has_secret"`
+	UpdatedAt uint64 `json:"updated_at"`
+}
+
+type SetChannelReq struct {
+	UserID  uint64 `json:"user_id" validate:"required"`
+	Channel string `json:"channel" validate:"required"`
+	Address string `json:"address" validate:"required"`
+	Secret  string `json:"secret"`
+	Enabled bool   `json:"enabled"`
+}
+
+type DeliveryDto struct {
+	Channel     string `json:"channel"`
+	Status      string `json:"status"`
+	Attempts    uint32 `json:"attempts"`
+	LastError   string `json:"last_error"`
+	DeliveredAt uint64 `json:"delivered_at"`
+	UpdatedAt   uint64 `json:"updated_at"`
+}
+
+type TemplateVariableDto struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+type TemplateContentDto struct {
+	Locale string `json:"locale"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+}
+
+type TemplateDto struct {
+	ID        string                 `json:"id"`
+	Format    string                 `json:"format"`
+	Variables []*TemplateVariableDto `json:"variables"`
+	Contents  []*TemplateContentDto  `json:"contents"`
+}
+
+type RenderTemplateReq struct {
+	TemplateID string         `json:"template_id" validate:"required"`
+	Locale     string         `json:"locale"`
+	Variables  map[string]any `json:"variables"`
+}
+
+type ValidateTemplateReq struct {
+	Template  *TemplateDto   `json:"template" validate:"required"`
+	Locale    string         `json:"locale"`
+	Variables map[string]any `json:"variables"`
+}
+
+type RenderedTemplateDto struct {
+	TemplateID string `json:"template_id"`
+	Locale     string `json:"locale"`
+	Format     string `json:"format"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+}
+
+type TemplateValidationDto struct {
+	Valid    bool                 `json:"valid"`
+	Errors   []string             `json:"errors"`
+	Rendered *RenderedTemplateDto `json:"rendered"`
+}
+
+type QuietHoursDto struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone"`
+}
+
+type PreferencesDto struct {
+	UserID          uint64         `json:"user_id"`
+	MutedTypes      []string       `json:"muted_types"`
+	MutedChannels   []string       `json:"muted_channels"`
+	QuietHours      *QuietHoursDto `json:"quiet_hours"`
+	DigestFrequency string         `json:"digest_frequency"`
+	UpdatedAt       uint64         `json:"updated_at"`
+}
+
+type UpdatePreferencesReq struct {
+	UserID          uint64         `json:"user_id" validate:"required"`
+	MutedTypes      []string       `json:"muted_types"`
+	MutedChannels   []string       `json:"muted_channels"`
+	QuietHours      *QuietHoursDto `json:"quiet_hours"`
+	DigestFrequency string         `json:"digest_frequency"`
+}
+
+type DigestItemDto struct {
+	ID        uint64 `json:"id"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	CreatedAt uint64 `json:"created_at"`
+}
+
+type DigestDto struct {
+	ID             uint64           `json:"id"`
+	UserID         uint64           `json:"user_id"`
+	Frequency      string           `json:"frequency"`
+	Status         string           `json:"status"`
+	WindowEnd      uint64           `json:"window_end"`
+	NotificationID uint64           `json:"notification_id"`
+	EmittedAt      uint64           `json:"emitted_at"`
+	Items          []*DigestItemDto `json:"items"`
+}
+
+type BroadcastReq struct {
+	Audience   string         `json:"audience" validate:"required"`
+	UserIDs    []uint64       `json:"user_ids"`
+	Symbol     string         `json:"symbol"`
+	Type       string         `json:"type" validate:"required_without=TemplateID"`
+	Title      string         `json:"title" validate:"required_without=TemplateID"`
+	Body       string         `json:"body" validate:"required_without=TemplateID"`
+	TemplateID string         `json:"template_id"`
+	Locale     string         `json:"locale"`
+	Variables  map[string]any `json:"variables"`
+}
+
+type BroadcastJobDto struct {
+	ID         uint64 `json:"id"`
+	Audience   string `json:"audience"`
+	UserCount  uint64 `json:"user_count"`
+	Symbol     string `json:"symbol"`
+	Type       string `json:"type"`
+	Title      string `json:"title"`
+	Body       string `json:"body"`
+	TemplateID string `json:"template_id"`
+	Locale     string `json:"locale"`
+	Status     string `json:"status"`
+	Total      uint64 `json:"total"`
+	Sent       uint64 `json:"sent"`
+	Failed     uint64 `json:"failed"`
+	LastError  string `json:"last_error"`
+	CreatedAt  uint64 `json:"created_at"`
+	StartedAt  uint64 `json:"started_at"`
+	FinishedAt uint64 `json:"finished_at"`
+}
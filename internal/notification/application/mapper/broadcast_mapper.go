@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+)
+
+func ToBroadcastJobDto(input *model.BroadcastJob) *dto.BroadcastJobDto {
+	if input == nil {
+		return nil
+	}
+	jobDto := &dto.BroadcastJobDto{
+		ID:         input.ID,
+		Audience:   string(input.Audience),
+		UserCount:  uint64(len(input.UserIDs)),
+		Symbol:     input.Symbol,
+		Type:       input.Type,
+		Title:      input.Title,
+		Body:       input.Body,
+		TemplateID: input.TemplateID,
+		Locale:     input.Locale,
+		Status:     string(input.Status),
+		Total:      input.Total,
+		Sent:       input.Sent,
+		Failed:     input.Failed,
+		LastError:  input.LastError,
+		CreatedAt:  uint64(input.CreatedAt.Unix()),
+	}
+	if input.StartedAt != nil {
+		jobDto.StartedAt = uint64(input.StartedAt.Unix())
+	}
+	if input.FinishedAt != nil {
+		jobDto.FinishedAt = uint64(input.FinishedAt.Unix())
+	}
+	return jobDto
+}
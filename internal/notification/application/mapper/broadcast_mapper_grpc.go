@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/notification/application/dto"
+)
+
+func ToBroadcastReq(req *noti.BroadcastRequest) *dto.BroadcastReq {
+	return &dto.BroadcastReq{
+		Audience:   req.Audience,
+		UserIDs:    req.UserIds,
+		Symbol:     req.Symbol,
+		Type:       req.Type,
+		Title:      req.Title,
+		Body:       req.Body,
+		TemplateID: req.TemplateId,
+		Locale:     req.Locale,
+		Variables:  ToVariables(req.Variables),
+	}
+}
+
+func ToBroadcastJob(jobDto *dto.BroadcastJobDto) *noti.BroadcastJob {
+	if jobDto == nil {
+		return nil
+	}
+	return &noti.BroadcastJob{
+		Id:         jobDto.ID,
+		Audience:   jobDto.Audience,
+		UserCount:  jobDto.UserCount,
+		Symbol:     jobDto.Symbol,
+		Type:       jobDto.Type,
+		Title:      jobDto.Title,
+		Body:       jobDto.Body,
+		TemplateId: jobDto.TemplateID,
+		Locale:     jobDto.Locale,
+		Status:     jobDto.Status,
+		Total:      jobDto.Total,
+		Sent:       jobDto.Sent,
+		Failed:     jobDto.Failed,
+		LastError:  jobDto.LastError,
+		CreatedAt:  jobDto.CreatedAt,
+		StartedAt:  jobDto.StartedAt,
+		FinishedAt: jobDto.FinishedAt,
+	}
+}
@@ -0,0 +1,61 @@
+package mapper
+
+import (
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+)
+
+func ToUserChannelDto(input *model.UserChannel) *dto.UserChannelDto {
+	if input == nil {
+		return nil
+	}
+	var updatedAt uint64
+	if !input.UpdatedAt.IsZero() {
+		updatedAt = uint64(input.UpdatedAt.Unix())
+	}
+	return &dto.UserChannelDto{
+		UserID:    input.UserID,
+		Channel:   string(input.Channel),
+		Address:   input.Address,
+		Enabled:   input.Enabled,
+		HasSecret: input.Secret != "",
+		UpdatedAt: updatedAt,
+	}
+}
+
+func ToUserChannelDtos(inputs []*model.UserChannel) []*dto.UserChannelDto {
+	dtos := make([]*dto.UserChannelDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToUserChannelDto(input))
+	}
+	return dtos
+}
+
+func ToDeliveryDto(input *model.Delivery) *dto.DeliveryDto {
+	if input == nil {
+		return nil
+	}
+	var deliveredAt, updatedAt uint64
+	if input.DeliveredAt != nil {
+		deliveredAt = uint64(input.DeliveredAt.Unix())
+	}
+	if !input.UpdatedAt.IsZero() {
+		updatedAt = uint64(input.UpdatedAt.Unix())
+	}
+	return &dto.DeliveryDto{
+		Channel:     string(input.Channel),
+		Status:      string(input.Status),
+		Attempts:    input.Attempts,
+		LastError:   input.LastError,
+		DeliveredAt: deliveredAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+func ToDeliveryDtos(inputs []*model.Delivery) []*dto.DeliveryDto {
+	dtos := make([]*dto.DeliveryDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToDeliveryDto(input))
+	}
+	return dtos
+}
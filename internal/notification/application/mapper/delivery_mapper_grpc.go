@@ -0,0 +1,53 @@
+package mapper
+
+import (
+	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/notification/application/dto"
+)
+
+func ToSetChannelReq(req *noti.SetChannelRequest) *dto.SetChannelReq {
+	return &dto.SetChannelReq{
+		UserID:  req.UserId,
+		Channel: req.Channel,
+		Address: req.Address,
+		Secret:  req.Secret,
+		Enabled: req.Enabled,
+	}
+}
+
+func ToUserChannel(channelDto *dto.UserChannelDto) *noti.UserChannel {
+	if channelDto == nil {
+		return nil
+	}
+	return &noti.UserChannel{
+		UserId:    channelDto.UserID,
+		Channel:   channelDto.Channel,
+		Address:   channelDto.Address,
+		Enabled:   channelDto.Enabled,
+		HasSecret: channelDto.HasSecret,
+		UpdatedAt: channelDto.UpdatedAt,
+	}
+}
+
+func ToUserChannels(channelDtos []*dto.UserChannelDto) []*noti.UserChannel {
+	channels := make([]*noti.UserChannel, 0, len(channelDtos))
+	for _, channelDto := range channelDtos {
+		channels = append(channels, ToUserChannel(channelDto))
+	}
+	return channels
+}
+
+func ToDeliveries(deliveryDtos []*dto.DeliveryDto) []*noti.Delivery {
+	deliveries := make([]*noti.Delivery, 0, len(deliveryDtos))
+	for _, deliveryDto := range deliveryDtos {
+		deliveries = append(deliveries, &noti.Delivery{
+			Channel:     deliveryDto.Channel,
+			Status:      deliveryDto.Status,
+			Attempts:    deliveryDto.Attempts,
+			LastError:   deliveryDto.LastError,
+			DeliveredAt: deliveryDto.DeliveredAt,
+			UpdatedAt:   deliveryDto.UpdatedAt,
+		})
+	}
+	return deliveries
+}
@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+)
+
+func ToDigestDto(input *model.Digest, items []*model.DigestItem) *dto.DigestDto {
+	if input == nil {
+		return nil
+	}
+	digestDto := &dto.DigestDto{
+		ID:        input.ID,
+		UserID:    input.UserID,
+		Frequency: string(input.Frequency),
+		Status:    string(input.Status),
+		WindowEnd: uint64(input.WindowEnd.Unix()),
+		Items:     make([]*dto.DigestItemDto, 0, len(items)),
+	}
+	if input.NotificationID != nil {
+		digestDto.NotificationID = *input.NotificationID
+	}
+	if input.EmittedAt != nil {
+		digestDto.EmittedAt = uint64(input.EmittedAt.Unix())
+	}
+	for _, item := range items {
+		digestDto.Items = append(digestDto.Items, &dto.DigestItemDto{
+			ID:        item.ID,
+			Type:      item.Type,
+			Title:     item.Title,
+			Body:      item.Body,
+			CreatedAt: uint64(item.CreatedAt.Unix()),
+		})
+	}
+	return digestDto
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/notification/application/dto"
+)
+
+func ToDigest(digestDto *dto.DigestDto) *noti.Digest {
+	if digestDto == nil {
+		return nil
+	}
+	digest := &noti.Digest{
+		Id:             digestDto.ID,
+		UserId:         digestDto.UserID,
+		Frequency:      digestDto.Frequency,
+		Status:         digestDto.Status,
+		WindowEnd:      digestDto.WindowEnd,
+		NotificationId: digestDto.NotificationID,
+		EmittedAt:      digestDto.EmittedAt,
+	}
+	for _, item := range digestDto.Items {
+		digest.Items = append(digest.Items, &noti.DigestItem{
+			Id:        item.ID,
+			Type:      item.Type,
+			Title:     item.Title,
+			Body:      item.Body,
+			CreatedAt: item.CreatedAt,
+		})
+	}
+	return digest
+}
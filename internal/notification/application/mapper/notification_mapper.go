@@ -48,3 +48,25 @@ func ToNotificationDtos(inputs []*model.Notification) []*dto.NotificationDto {
 	}
 	return dtos
 }
+
+func ToNotificationPageDto(input *model.NotificationPage) *dto.NotificationPageDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.NotificationPageDto{
+		Notifications: ToNotificationDtos(input.Notifications),
+		NextPageToken: ToPageToken(input.Next),
+		Total:         input.Total,
+		Unread:        input.Unread,
+	}
+}
+
+func ToUnreadCountDto(input *model.UnreadCount) *dto.UnreadCountDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.UnreadCountDto{
+		Unread:   input.Unread,
+		Unviewed: input.Unviewed,
+	}
+}
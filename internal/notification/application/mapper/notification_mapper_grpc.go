@@ -7,10 +7,25 @@ import (
 
 func ToCreateReq(req *noti.SendRequest) *dto.NotificationCreateReq {
 	return &dto.NotificationCreateReq{
-		UserID: req.UserId,
-		Type:   req.Type,
-		Title:  req.Title,
-		Body:   req.Body,
+		UserID:     req.UserId,
+		Type:       req.Type,
+		Title:      req.Title,
+		Body:       req.Body,
+		TemplateID: req.TemplateId,
+		Locale:     req.Locale,
+		Variables:  ToVariables(req.Variables),
+	}
+}
+
+func ToGetNotiByUserIdReq(req *noti.GetByUserIdRequest) *dto.GetNotiByUserIdReq {
+	return &dto.GetNotiByUserIdReq{
+		UserID:    req.UserId,
+		Read:      req.Read,
+		Viewed:    req.Viewed,
+		Type:      req.Type,
+		Limit:     req.Limit,
+		Offset:    req.Offset,
+		PageToken: req.PageToken,
 	}
 }
 
@@ -44,3 +59,25 @@ func ToNotifications(notiDtos []*dto.NotificationDto) []*noti.Notification {
 	}
 	return notis
 }
+
+func ToGetByUserIdResponse(pageDto *dto.NotificationPageDto) *noti.GetByUserIdResponse {
+	if pageDto == nil {
+		return &noti.GetByUserIdResponse{}
+	}
+	return &noti.GetByUserIdResponse{
+		Notifications: ToNotifications(pageDto.Notifications),
+		NextPageToken: pageDto.NextPageToken,
+		Total:         pageDto.Total,
+		Unread:        pageDto.Unread,
+	}
+}
+
+func ToUnreadCountResponse(countDto *dto.UnreadCountDto) *noti.GetUnreadCountResponse {
+	if countDto == nil {
+		return &noti.GetUnreadCountResponse{}
+	}
+	return &noti.GetUnreadCountResponse{
+		Unread:   countDto.Unread,
+		Unviewed: countDto.Unviewed,
+	}
+}
@@ -0,0 +1,43 @@
+package mapper
+
+import (
+	"encoding/base64"
+	"fmt"
+	"simple-securities/internal/notification/domain/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ToPageToken encodes the cursor into an opaque page token, an empty token for no cursor
+func ToPageToken(cursor *model.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := strconv.FormatInt(cursor.CreatedAt.UnixNano(), 10) + "." + strconv.FormatUint(cursor.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ToPageCursor decodes a page token, an empty token is the first page
+func ToPageCursor(token string) (*model.PageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	createdAt, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	nanos, err := strconv.ParseInt(createdAt, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	cursorID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token: %w", err)
+	}
+	return &model.PageCursor{CreatedAt: time.Unix(0, nanos).UTC(), ID: cursorID}, nil
+}
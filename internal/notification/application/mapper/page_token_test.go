@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/domain/model"
+)
+
+func TestPageTokenRoundTrip(t *testing.T) {
+	if token := ToPageToken(nil); token != "" {
+		t.Errorf("ToPageToken(nil) = %q, want empty", token)
+	}
+	if cursor, err := ToPageCursor(""); cursor != nil || err != nil {
+		t.Errorf("ToPageCursor(\"\") = %+v, %v, want the first page", cursor, err)
+	}
+
+	cursor := &model.PageCursor{
+		CreatedAt: time.Date(2024, 3, 1, 14, 0, 0, 123456789, time.FixedZone("CEST", 2*60*60)),
+		ID:        42,
+	}
+	got, err := ToPageCursor(ToPageToken(cursor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.CreatedAt.Equal(cursor.CreatedAt) || got.ID != cursor.ID {
+		t.Errorf("round trip = %+v, want %+v", got, cursor)
+	}
+}
+
+func TestPageCursorRejectsMalformedTokens(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+	for _, token := range []string{
+		"not base64!",
+		encode("1709301600000000000"),
+		encode("yesterday.42"),
+		encode("1709301600000000000.-1"),
+		encode("1709301600000000000.id"),
+	} {
+		if cursor, err := ToPageCursor(token); err == nil {
+			t.Errorf("ToPageCursor(%q) = %+v, want an error", token, cursor)
+		}
+	}
+}
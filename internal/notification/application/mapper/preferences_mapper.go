@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+)
+
+func ToPreferencesDto(input *model.Preferences) *dto.PreferencesDto {
+	if input == nil {
+		return nil
+	}
+	mutedChannels := make([]string, 0, len(input.MutedChannels))
+	for _, channel := range input.MutedChannels {
+		mutedChannels = append(mutedChannels, string(channel))
+	}
+	var quietHours *dto.QuietHoursDto
+	if q := input.QuietHours; q != nil {
+		quietHours = &dto.QuietHoursDto{Start: q.Start, End: q.End, TimeZone: q.TimeZone}
+	}
+	var updatedAt uint64
+	if !input.UpdatedAt.IsZero() {
+		updatedAt = uint64(input.UpdatedAt.Unix())
+	}
+	return &dto.PreferencesDto{
+		UserID:          input.UserID,
+		MutedTypes:      append([]string{}, input.MutedTypes...),
+		MutedChannels:   mutedChannels,
+		QuietHours:      quietHours,
+		DigestFrequency: string(input.DigestFrequency),
+		UpdatedAt:       updatedAt,
+	}
+}
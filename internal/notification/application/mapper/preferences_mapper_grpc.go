@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/notification/application/dto"
+)
+
+func ToUpdatePreferencesReq(req *noti.UpdatePreferencesRequest) *dto.UpdatePreferencesReq {
+	updateReq := &dto.UpdatePreferencesReq{
+		UserID:          req.UserId,
+		MutedTypes:      req.MutedTypes,
+		MutedChannels:   req.MutedChannels,
+		DigestFrequency: req.DigestFrequency,
+	}
+	if q := req.QuietHours; q != nil {
+		updateReq.QuietHours = &dto.QuietHoursDto{Start: q.Start, End: q.End, TimeZone: q.TimeZone}
+	}
+	return updateReq
+}
+
+func ToPreferences(preferencesDto *dto.PreferencesDto) *noti.Preferences {
+	if preferencesDto == nil {
+		return nil
+	}
+	preferences := &noti.Preferences{
+		UserId:          preferencesDto.UserID,
+		MutedTypes:      preferencesDto.MutedTypes,
+		MutedChannels:   preferencesDto.MutedChannels,
+		DigestFrequency: preferencesDto.DigestFrequency,
+		UpdatedAt:       preferencesDto.UpdatedAt,
+	}
+	if q := preferencesDto.QuietHours; q != nil {
+		preferences.QuietHours = &noti.QuietHours{Start: q.Start, End: q.End, TimeZone: q.TimeZone}
+	}
+	return preferences
+}
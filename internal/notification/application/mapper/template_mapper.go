@@ -0,0 +1,82 @@
+package mapper
+
+import (
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+	"sort"
+)
+
+func ToTemplateDto(input *model.Template) *dto.TemplateDto {
+	if input == nil {
+		return nil
+	}
+	variables := make([]*dto.TemplateVariableDto, 0, len(input.Variables))
+	for _, v := range input.Variables {
+		variables = append(variables, &dto.TemplateVariableDto{
+			Name:     v.Name,
+			Type:     string(v.Type),
+			Required: v.Required,
+		})
+	}
+	locales := make([]string, 0, len(input.Locales))
+	for locale := range input.Locales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	contents := make([]*dto.TemplateContentDto, 0, len(locales))
+	for _, locale := range locales {
+		contents = append(contents, &dto.TemplateContentDto{
+			Locale: locale,
+			Title:  input.Locales[locale].Title,
+			Body:   input.Locales[locale].Body,
+		})
+	}
+	return &dto.TemplateDto{
+		ID:        input.ID,
+		Format:    string(input.Format),
+		Variables: variables,
+		Contents:  contents,
+	}
+}
+
+func ToTemplateDtos(inputs []*model.Template) []*dto.TemplateDto {
+	dtos := make([]*dto.TemplateDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToTemplateDto(input))
+	}
+	return dtos
+}
+
+func ToTemplate(input *dto.TemplateDto) *model.Template {
+	variables := make([]model.TemplateVariable, 0, len(input.Variables))
+	for _, v := range input.Variables {
+		variables = append(variables, model.TemplateVariable{
+			Name:     v.Name,
+			Type:     model.VariableType(v.Type),
+			Required: v.Required,
+		})
+	}
+	locales := make(map[string]model.TemplateContent, len(input.Contents))
+	for _, c := range input.Contents {
+		locales[c.Locale] = model.TemplateContent{Title: c.Title, Body: c.Body}
+	}
+	return &model.Template{
+		ID:        input.ID,
+		Format:    model.TemplateFormat(input.Format),
+		Variables: variables,
+		Locales:   locales,
+	}
+}
+
+func ToRenderedTemplateDto(input *model.RenderedTemplate) *dto.RenderedTemplateDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.RenderedTemplateDto{
+		TemplateID: input.TemplateID,
+		Locale:     input.Locale,
+		Format:     string(input.Format),
+		Title:      input.Title,
+		Body:       input.Body,
+	}
+}
@@ -0,0 +1,90 @@
+package mapper
+
+import (
+	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/notification/application/dto"
+
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// ToVariables converts the JSON values of template variables, numbers become float64
+func ToVariables(variables map[string]*structpb.Value) map[string]any {
+	if len(variables) == 0 {
+		return nil
+	}
+	values := make(map[string]any, len(variables))
+	for name, value := range variables {
+		values[name] = value.AsInterface()
+	}
+	return values
+}
+
+func ToRenderTemplateReq(req *noti.PreviewTemplateRequest) *dto.RenderTemplateReq {
+	return &dto.RenderTemplateReq{
+		TemplateID: req.TemplateId,
+		Locale:     req.Locale,
+		Variables:  ToVariables(req.Variables),
+	}
+}
+
+func ToValidateTemplateReq(req *noti.ValidateTemplateRequest) *dto.ValidateTemplateReq {
+	validateReq := &dto.ValidateTemplateReq{
+		Locale:    req.Locale,
+		Variables: ToVariables(req.Variables),
+	}
+	if t := req.Template; t != nil {
+		templateDto := &dto.TemplateDto{ID: t.Id, Format: t.Format}
+		for _, v := range t.Variables {
+			templateDto.Variables = append(templateDto.Variables, &dto.TemplateVariableDto{
+				Name:     v.Name,
+				Type:     v.Type,
+				Required: v.Required,
+			})
+		}
+		for _, c := range t.Contents {
+			templateDto.Contents = append(templateDto.Contents, &dto.TemplateContentDto{
+				Locale: c.Locale,
+				Title:  c.Title,
+				Body:   c.Body,
+			})
+		}
+		validateReq.Template = templateDto
+	}
+	return validateReq
+}
+
+func ToTemplates(templateDtos []*dto.TemplateDto) []*noti.Template {
+	templates := make([]*noti.Template, 0, len(templateDtos))
+	for _, templateDto := range templateDtos {
+		t := &noti.Template{Id: templateDto.ID, Format: templateDto.Format}
+		for _, v := range templateDto.Variables {
+			t.Variables = append(t.Variables, &noti.TemplateVariable{
+				Name:     v.Name,
+				Type:     v.Type,
+				Required: v.Required,
+			})
+		}
+		for _, c := range templateDto.Contents {
+			t.Contents = append(t.Contents, &noti.TemplateContent{
+				Locale: c.Locale,
+				Title:  c.Title,
+				Body:   c.Body,
+			})
+		}
+		templates = append(templates, t)
+	}
+	return templates
+}
+
+func ToRenderedTemplate(renderedDto *dto.RenderedTemplateDto) *noti.RenderedTemplate {
+	if renderedDto == nil {
+		return nil
+	}
+	return &noti.RenderedTemplate{
+		TemplateId: renderedDto.TemplateID,
+		Locale:     renderedDto.Locale,
+		Format:     renderedDto.Format,
+		Title:      renderedDto.Title,
+		Body:       renderedDto.Body,
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/application/templates"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+	"slices"
+	"strings"
+)
+
+// maxBroadcastUsers bounds the users listed in a job, larger audiences are segments
+const maxBroadcastUsers = 100000
+
+type BroadcastNotiSvc interface {
+	Handle(ctx context.Context, req *dto.BroadcastReq) (*dto.BroadcastJobDto, error)
+}
+
+type broadcastNotiSvc struct {
+	broadcastRepo repo.IBroadcastRepo
+	stockClient   repo.IStockClient
+	templates     *templates.Registry
+}
+
+// NewBroadcastNotiSvc creates the service enqueuing broadcast jobs, stockClient is nil when
+// the stock service is not configured
+func NewBroadcastNotiSvc(
+	broadcastRepo repo.IBroadcastRepo,
+	stockClient repo.IStockClient,
+	templates *templates.Registry,
+) BroadcastNotiSvc {
+	return &broadcastNotiSvc{
+		broadcastRepo: broadcastRepo,
+		stockClient:   stockClient,
+		templates:     templates,
+	}
+}
+
+func (s *broadcastNotiSvc) Handle(ctx context.Context, req *dto.BroadcastReq) (*dto.BroadcastJobDto, error) {
+	job := &model.BroadcastJob{
+		Audience:   model.BroadcastAudience(req.Audience),
+		Type:       req.Type,
+		Title:      req.Title,
+		Body:       req.Body,
+		TemplateID: req.TemplateID,
+		Locale:     req.Locale,
+		Variables:  req.Variables,
+	}
+
+	switch job.Audience {
+	case model.AudienceAll:
+	case model.AudienceUsers:
+		if len(req.UserIDs) == 0 {
+			return nil, errors.NewValidationError("user_ids are required for the users audience", nil)
+		}
+		if len(req.UserIDs) > maxBroadcastUsers {
+			return nil, errors.NewValidationError(fmt.Sprintf("at most %d user_ids can be listed", maxBroadcastUsers), nil)
+		}
+		// The runner pages through the users by id
+		job.UserIDs = slices.Compact(slices.Sorted(slices.Values(req.UserIDs)))
+		if job.UserIDs[0] == 0 {
+			return nil, errors.NewValidationError("user_ids cannot be 0", nil)
+		}
+	case model.AudienceHolders:
+		job.Symbol = strings.ToUpper(strings.TrimSpace(req.Symbol))
+		if job.Symbol == "" {
+			return nil, errors.NewValidationError("symbol is required for the holders audience", nil)
+		}
+		if s.stockClient == nil {
+			return nil, errors.NewValidationError("holders audience needs the stock service", nil)
+		}
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported audience %q", req.Audience), nil)
+	}
+
+	// Every notification of the job is rendered alike, a template is checked once up front
+	if req.TemplateID != "" {
+		_, err := renderTemplate(s.templates, &dto.NotificationCreateReq{
+			TemplateID: req.TemplateID,
+			Locale:     req.Locale,
+			Variables:  req.Variables,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else if req.Type == "" || req.Title == "" || req.Body == "" {
+		return nil, errors.NewValidationError("type, title and body or a template are required", nil)
+	}
+
+	job, err := s.broadcastRepo.Create(ctx, job)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to create broadcast job", err)
+	}
+	return mapper.ToBroadcastJobDto(job), nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	infrasRepo "simple-securities/internal/notification/infras/repo"
+	"simple-securities/pkg/errors"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+)
+
+// countingRepo counts the reads reaching the database
+type countingRepo struct {
+	repo.INotificationRepo
+	gets  atomic.Int32
+	pages atomic.Int32
+}
+
+func (r *countingRepo) GetByID(ctx context.Context, id uint64) (*model.Notification, error) {
+	r.gets.Add(1)
+	return r.INotificationRepo.GetByID(ctx, id)
+}
+
+func (r *countingRepo) GetPageByUserId(
+	ctx context.Context,
+	userId uint64,
+	filter model.NotificationFilter,
+	after *model.PageCursor,
+	limit uint32,
+) (*model.NotificationPage, error) {
+	r.pages.Add(1)
+	return r.INotificationRepo.GetPageByUserId(ctx, userId, filter, after, limit)
+}
+
+func newTestRepos(t *testing.T) (*countingRepo, repo.INotificationCacheRepo) {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return &countingRepo{INotificationRepo: infrasRepo.NewNotificationInmemRepo()},
+		infrasRepo.NewNotificationCacheRepo(client, infrasRepo.CacheConfig{})
+}
+
+func isNotFound(err error) bool {
+	var appErr *errors.AppError
+	return stderrors.As(err, &appErr) && appErr.Type == errors.ErrorTypeNotFound
+}
+
+func TestGetNotiCachesNotifications(t *testing.T) {
+	notiRepo, notiCacheRepo := newTestRepos(t)
+	ctx := context.Background()
+	getNoti := NewGetNotiSvc(notiRepo, notiCacheRepo)
+
+	// A missing notification is cached as missing until it is sent
+	for i := 0; i < 2; i++ {
+		if _, err := getNoti.Handle(ctx, 1); !isNotFound(err) {
+			t.Fatalf("Handle() of a missing notification = %v, want not found", err)
+		}
+	}
+	if gets := notiRepo.gets.Load(); gets != 1 {
+		t.Fatalf("missing notification read %d times from the database, want 1", gets)
+	}
+
+	err := NewSendNotiSvc(notiRepo, notiCacheRepo, nil, nil).Handle(ctx, &dto.NotificationCreateReq{
+		UserID: 7, Type: "order", Title: "Filled", Body: "Your order is filled",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		n, err := getNoti.Handle(ctx, 1)
+		if err != nil || n.UserID != 7 || n.Read {
+			t.Fatalf("Handle() after send = %+v, %v", n, err)
+		}
+	}
+	if gets := notiRepo.gets.Load(); gets != 1 {
+		t.Errorf("sent notification read from the database %d more times, want 0", gets-1)
+	}
+
+	// Marking it read drops the cached copy
+	if _, err := NewMarkNotiReadSvc(notiRepo, notiCacheRepo).Handle(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := getNoti.Handle(ctx, 1); err != nil || !n.Read {
+		t.Errorf("Handle() after mark read = %+v, %v, want the read notification", n, err)
+	}
+}
+
+func TestGetNotiByUserIdCachesPages(t *testing.T) {
+	if testing.Short() {
+		t.Skip("reads from the database are slowed down by a second")
+	}
+	notiRepo, notiCacheRepo := newTestRepos(t)
+	ctx := context.Background()
+	sendNoti := NewSendNotiSvc(notiRepo, notiCacheRepo, nil, nil)
+	getPage := NewGetNotiByUserIdSvc(notiRepo, notiCacheRepo)
+	send := func() {
+		t.Helper()
+		err := sendNoti.Handle(ctx, &dto.NotificationCreateReq{UserID: 7, Type: "order", Title: "Filled", Body: "Filled"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Notifications created in the same instant are ordered by id
+		time.Sleep(time.Millisecond)
+	}
+
+	send()
+	req := &dto.GetNotiByUserIdReq{UserID: 7}
+	for i := 0; i < 2; i++ {
+		page, err := getPage.Handle(ctx, req)
+		if err != nil || page.Total != 1 || page.Unread != 1 {
+			t.Fatalf("Handle() = %+v, %v", page, err)
+		}
+	}
+	if pages := notiRepo.pages.Load(); pages != 1 {
+		t.Fatalf("page read %d times from the database, want 1", pages)
+	}
+
+	// Sending and marking read change the inbox, its pages are read again
+	send()
+	if page, err := getPage.Handle(ctx, req); err != nil || page.Total != 2 || page.Notifications[0].ID != 2 {
+		t.Fatalf("Handle() after send = %+v, %v", page, err)
+	}
+	if _, err := NewMarkNotiReadSvc(notiRepo, notiCacheRepo).Handle(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if page, err := getPage.Handle(ctx, req); err != nil || page.Unread != 1 || !page.Notifications[0].Read {
+		t.Fatalf("Handle() after mark read = %+v, %v", page, err)
+	}
+	if pages := notiRepo.pages.Load(); pages != 3 {
+		t.Errorf("page read %d times from the database, want 3", pages)
+	}
+}
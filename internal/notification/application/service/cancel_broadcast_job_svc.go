@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type CancelBroadcastJobSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.BroadcastJobDto, error)
+}
+
+type cancelBroadcastJobSvc struct {
+	broadcastRepo repo.IBroadcastRepo
+}
+
+func NewCancelBroadcastJobSvc(broadcastRepo repo.IBroadcastRepo) CancelBroadcastJobSvc {
+	return &cancelBroadcastJobSvc{broadcastRepo: broadcastRepo}
+}
+
+// Handle stops the job, the runner sending it stops after the batch in progress
+func (s *cancelBroadcastJobSvc) Handle(ctx context.Context, id uint64) (*dto.BroadcastJobDto, error) {
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+	cancelled, err := s.broadcastRepo.Cancel(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to cancel broadcast job", err)
+	}
+	job, err := s.broadcastRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get broadcast job", err)
+	}
+	if job == nil {
+		return nil, errors.NewNotFoundError("broadcast job not found", nil)
+	}
+	if !cancelled {
+		return nil, errors.NewBusinessError(fmt.Sprintf("broadcast job %d is already %s", id, job.Status), nil)
+	}
+	return mapper.ToBroadcastJobDto(job), nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type DeleteNotiSvc interface {
+	Handle(ctx context.Context, id uint64) error
+}
+
+type deleteNotiSvc struct {
+	notiRepo      repo.INotificationRepo
+	notiCacheRepo repo.INotificationCacheRepo
+}
+
+func NewDeleteNotiSvc(
+	notiRepo repo.INotificationRepo,
+	notiCacheRepo repo.INotificationCacheRepo,
+) DeleteNotiSvc {
+	return &deleteNotiSvc{
+		notiRepo:      notiRepo,
+		notiCacheRepo: notiCacheRepo,
+	}
+}
+
+func (s *deleteNotiSvc) Handle(
+	ctx context.Context,
+	id uint64,
+) error {
+	if id == 0 {
+		return errors.NewValidationError("id is required", nil)
+	}
+	// The owner is read first, its inbox pages are dropped with the notification
+	notification, err := s.notiRepo.GetByID(ctx, id)
+	if err != nil {
+		return errors.NewPersistenceError("failed to get notification", err)
+	}
+	if notification == nil {
+		return errors.NewNotFoundError("notification not found", nil)
+	}
+	if err := s.notiRepo.Delete(ctx, id); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewNotFoundError("notification not found", err)
+		}
+		return errors.NewPersistenceError("failed to delete notification", err)
+	}
+	if err := s.notiCacheRepo.Invalidate(ctx, id); err != nil {
+		return errors.NewSystemError("failed to invalidate notification cache", err)
+	}
+	if err := s.notiCacheRepo.InvalidatePages(ctx, notification.UserID); err != nil {
+		return errors.NewSystemError("failed to invalidate notification cache", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetBroadcastJobSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.BroadcastJobDto, error)
+}
+
+type getBroadcastJobSvc struct {
+	broadcastRepo repo.IBroadcastRepo
+}
+
+func NewGetBroadcastJobSvc(broadcastRepo repo.IBroadcastRepo) GetBroadcastJobSvc {
+	return &getBroadcastJobSvc{broadcastRepo: broadcastRepo}
+}
+
+func (s *getBroadcastJobSvc) Handle(ctx context.Context, id uint64) (*dto.BroadcastJobDto, error) {
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+	job, err := s.broadcastRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get broadcast job", err)
+	}
+	if job == nil {
+		return nil, errors.NewNotFoundError("broadcast job not found", nil)
+	}
+	return mapper.ToBroadcastJobDto(job), nil
+}
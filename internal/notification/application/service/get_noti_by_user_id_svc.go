@@ -2,14 +2,24 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"simple-securities/internal/notification/application/dto"
 	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/model"
 	"simple-securities/internal/notification/domain/repo"
-	"time"
+	"simple-securities/pkg/errors"
+	"strconv"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
 )
 
 type GetNotiByUserIdSvc interface {
-	Handle(ctx context.Context, userId uint64, limit, offset uint32) ([]*dto.NotificationDto, error)
+	// Handle pages with the page token of the request, or with its offset when it has none and
+	// the offset is set
+	Handle(ctx context.Context, req *dto.GetNotiByUserIdReq) (*dto.NotificationPageDto, error)
 }
 
 type getNotiByUserIdSvc struct {
@@ -29,14 +39,54 @@ func NewGetNotiByUserIdSvc(
 
 func (s *getNotiByUserIdSvc) Handle(
 	ctx context.Context,
-	userId uint64,
-	limit uint32,
-	offset uint32,
-) ([]*dto.NotificationDto, error) {
-	time.Sleep(time.Second * 1)
-	notifications, err := s.notiRepo.GetByUserId(ctx, userId, limit, offset)
+	req *dto.GetNotiByUserIdReq,
+) (*dto.NotificationPageDto, error) {
+	if req.UserID == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+	filter := model.NotificationFilter{Read: req.Read, Viewed: req.Viewed, Type: req.Type}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+	limit = min(limit, maxPageLimit)
+
+	if req.PageToken == "" && req.Offset > 0 {
+		notifications, err := s.notiRepo.GetByUserId(ctx, req.UserID, filter, limit, req.Offset)
+		if err != nil {
+			return nil, errors.NewPersistenceError("failed to get notifications", err)
+		}
+		return &dto.NotificationPageDto{Notifications: mapper.ToNotificationDtos(notifications)}, nil
+	}
+
+	after, err := mapper.ToPageCursor(req.PageToken)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewValidationError("invalid page_token", err)
+	}
+
+	// Pages are cached until they expire or the inbox of the user changes, a failing cache
+	// falls back to the database
+	key := pageCacheKey(filter, limit, req.PageToken)
+	if page, err := s.notiCacheRepo.GetPage(ctx, req.UserID, key); err == nil && page != nil {
+		return mapper.ToNotificationPageDto(page), nil
+	}
+
+	page, err := s.notiRepo.GetPageByUserId(ctx, req.UserID, filter, after, limit)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notifications", err)
+	}
+	_ = s.notiCacheRepo.SetPage(ctx, req.UserID, key, page)
+	return mapper.ToNotificationPageDto(page), nil
+}
+
+// pageCacheKey names a page of the inbox of a user by its filter, size and position
+func pageCacheKey(filter model.NotificationFilter, limit uint32, pageToken string) string {
+	flag := func(b *bool) string {
+		if b == nil {
+			return "any"
+		}
+		return strconv.FormatBool(*b)
 	}
-	return mapper.ToNotificationDtos(notifications), nil
+	return fmt.Sprintf("read:%s:viewed:%s:type:%s:limit:%d:token:%s",
+		flag(filter.Read), flag(filter.Viewed), filter.Type, limit, pageToken)
 }
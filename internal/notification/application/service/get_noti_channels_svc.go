@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetNotiChannelsSvc interface {
+	Handle(ctx context.Context, userId uint64) ([]*dto.UserChannelDto, error)
+}
+
+type getNotiChannelsSvc struct {
+	userChannelRepo repo.IUserChannelRepo
+}
+
+func NewGetNotiChannelsSvc(userChannelRepo repo.IUserChannelRepo) GetNotiChannelsSvc {
+	return &getNotiChannelsSvc{userChannelRepo: userChannelRepo}
+}
+
+func (s *getNotiChannelsSvc) Handle(
+	ctx context.Context,
+	userId uint64,
+) ([]*dto.UserChannelDto, error) {
+	if userId == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+	channels, err := s.userChannelRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification channels", err)
+	}
+	return mapper.ToUserChannelDtos(channels), nil
+}
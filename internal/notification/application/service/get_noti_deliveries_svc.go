@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetNotiDeliveriesSvc interface {
+	Handle(ctx context.Context, id uint64) ([]*dto.DeliveryDto, error)
+}
+
+type getNotiDeliveriesSvc struct {
+	notiRepo     repo.INotificationRepo
+	deliveryRepo repo.IDeliveryRepo
+}
+
+func NewGetNotiDeliveriesSvc(
+	notiRepo repo.INotificationRepo,
+	deliveryRepo repo.IDeliveryRepo,
+) GetNotiDeliveriesSvc {
+	return &getNotiDeliveriesSvc{
+		notiRepo:     notiRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+func (s *getNotiDeliveriesSvc) Handle(
+	ctx context.Context,
+	id uint64,
+) ([]*dto.DeliveryDto, error) {
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+	notification, err := s.notiRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification", err)
+	}
+	if notification == nil {
+		return nil, errors.NewNotFoundError("notification not found", nil)
+	}
+	deliveries, err := s.deliveryRepo.GetByNotificationId(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification deliveries", err)
+	}
+	return mapper.ToDeliveryDtos(deliveries), nil
+}
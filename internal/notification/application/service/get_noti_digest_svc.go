@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetNotiDigestSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.DigestDto, error)
+}
+
+type getNotiDigestSvc struct {
+	digestRepo repo.IDigestRepo
+}
+
+func NewGetNotiDigestSvc(digestRepo repo.IDigestRepo) GetNotiDigestSvc {
+	return &getNotiDigestSvc{digestRepo: digestRepo}
+}
+
+func (s *getNotiDigestSvc) Handle(ctx context.Context, id uint64) (*dto.DigestDto, error) {
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+	digest, err := s.digestRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification digest", err)
+	}
+	if digest == nil {
+		return nil, errors.NewNotFoundError("notification digest not found", nil)
+	}
+	items, err := s.digestRepo.GetItems(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification digest items", err)
+	}
+	return mapper.ToDigestDto(digest, items), nil
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetNotiPreferencesSvc interface {
+	Handle(ctx context.Context, userId uint64) (*dto.PreferencesDto, error)
+}
+
+type getNotiPreferencesSvc struct {
+	preferencesRepo repo.IPreferencesRepo
+}
+
+func NewGetNotiPreferencesSvc(preferencesRepo repo.IPreferencesRepo) GetNotiPreferencesSvc {
+	return &getNotiPreferencesSvc{preferencesRepo: preferencesRepo}
+}
+
+// Handle returns the preferences of the user, the defaults when the user never set any
+func (s *getNotiPreferencesSvc) Handle(ctx context.Context, userId uint64) (*dto.PreferencesDto, error) {
+	if userId == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+	preferences, err := s.preferencesRepo.Get(ctx, userId)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification preferences", err)
+	}
+	if preferences == nil {
+		preferences = model.NewPreferences(userId)
+	}
+	return mapper.ToPreferencesDto(preferences), nil
+}
@@ -5,6 +5,7 @@ import (
 	"simple-securities/internal/notification/application/dto"
 	"simple-securities/internal/notification/application/mapper"
 	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
 )
 
 type GetNotiSvc interface {
@@ -30,9 +31,27 @@ func (s *getNotiSvc) Handle(
 	ctx context.Context,
 	id uint64,
 ) (*dto.NotificationDto, error) {
-	notification, err := s.notiRepo.GetByID(ctx, id)
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+
+	// The cache only saves reads, a failing cache falls back to the database
+	notification, hit, err := s.notiCacheRepo.Get(ctx, id)
+	if err == nil && hit {
+		if notification == nil {
+			return nil, errors.NewNotFoundError("notification not found", nil)
+		}
+		return mapper.ToNotificationDto(notification), nil
+	}
+
+	notification, err = s.notiRepo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewPersistenceError("failed to get notification", err)
+	}
+	if notification == nil {
+		_ = s.notiCacheRepo.SetMissing(ctx, id)
+		return nil, errors.NewNotFoundError("notification not found", nil)
 	}
+	_ = s.notiCacheRepo.Set(ctx, notification.ID, notification)
 	return mapper.ToNotificationDto(notification), nil
 }
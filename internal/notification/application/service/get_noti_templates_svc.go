@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/application/templates"
+)
+
+type GetNotiTemplatesSvc interface {
+	Handle(ctx context.Context) ([]*dto.TemplateDto, error)
+}
+
+type getNotiTemplatesSvc struct {
+	templates *templates.Registry
+}
+
+func NewGetNotiTemplatesSvc(templates *templates.Registry) GetNotiTemplatesSvc {
+	return &getNotiTemplatesSvc{templates: templates}
+}
+
+func (s *getNotiTemplatesSvc) Handle(ctx context.Context) ([]*dto.TemplateDto, error) {
+	return mapper.ToTemplateDtos(s.templates.List()), nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetUnreadNotiCountSvc interface {
+	Handle(ctx context.Context, userId uint64) (*dto.UnreadCountDto, error)
+}
+
+type getUnreadNotiCountSvc struct {
+	notiRepo repo.INotificationRepo
+}
+
+func NewGetUnreadNotiCountSvc(notiRepo repo.INotificationRepo) GetUnreadNotiCountSvc {
+	return &getUnreadNotiCountSvc{notiRepo: notiRepo}
+}
+
+func (s *getUnreadNotiCountSvc) Handle(
+	ctx context.Context,
+	userId uint64,
+) (*dto.UnreadCountDto, error) {
+	if userId == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+	count, err := s.notiRepo.CountUnread(ctx, userId)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to count unread notifications", err)
+	}
+	return mapper.ToUnreadCountDto(count), nil
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+	"time"
+)
+
+type MarkAllNotiReadSvc interface {
+	// Handle marks the unread notifications of the user read and returns how many
+	Handle(ctx context.Context, userId uint64) (uint64, error)
+}
+
+type markAllNotiReadSvc struct {
+	notiRepo      repo.INotificationRepo
+	notiCacheRepo repo.INotificationCacheRepo
+}
+
+func NewMarkAllNotiReadSvc(
+	notiRepo repo.INotificationRepo,
+	notiCacheRepo repo.INotificationCacheRepo,
+) MarkAllNotiReadSvc {
+	return &markAllNotiReadSvc{
+		notiRepo:      notiRepo,
+		notiCacheRepo: notiCacheRepo,
+	}
+}
+
+func (s *markAllNotiReadSvc) Handle(
+	ctx context.Context,
+	userId uint64,
+) (uint64, error) {
+	if userId == 0 {
+		return 0, errors.NewValidationError("user_id is required", nil)
+	}
+	updated, err := s.notiRepo.MarkAllRead(ctx, userId, time.Now().UTC())
+	if err != nil {
+		return 0, errors.NewPersistenceError("failed to mark notifications read", err)
+	}
+	if updated > 0 {
+		if err := s.notiCacheRepo.InvalidateByUserId(ctx, userId); err != nil {
+			return updated, errors.NewSystemError("failed to invalidate notification cache", err)
+		}
+	}
+	return updated, nil
+}
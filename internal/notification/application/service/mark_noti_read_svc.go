@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+	"time"
+)
+
+type MarkNotiReadSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.NotificationDto, error)
+}
+
+type markNotiReadSvc struct {
+	notiRepo      repo.INotificationRepo
+	notiCacheRepo repo.INotificationCacheRepo
+}
+
+func NewMarkNotiReadSvc(
+	notiRepo repo.INotificationRepo,
+	notiCacheRepo repo.INotificationCacheRepo,
+) MarkNotiReadSvc {
+	return &markNotiReadSvc{
+		notiRepo:      notiRepo,
+		notiCacheRepo: notiCacheRepo,
+	}
+}
+
+func (s *markNotiReadSvc) Handle(
+	ctx context.Context,
+	id uint64,
+) (*dto.NotificationDto, error) {
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+	if err := s.notiRepo.MarkRead(ctx, id, time.Now().UTC()); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("notification not found", err)
+		}
+		return nil, errors.NewPersistenceError("failed to mark notification read", err)
+	}
+	return marked(ctx, s.notiRepo, s.notiCacheRepo, id)
+}
+
+// marked drops the cached copy and inbox pages of a notification whose state changed and
+// returns the stored one
+func marked(
+	ctx context.Context,
+	notiRepo repo.INotificationRepo,
+	notiCacheRepo repo.INotificationCacheRepo,
+	id uint64,
+) (*dto.NotificationDto, error) {
+	if err := notiCacheRepo.Invalidate(ctx, id); err != nil {
+		return nil, errors.NewSystemError("failed to invalidate notification cache", err)
+	}
+	notification, err := notiRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification", err)
+	}
+	if notification == nil {
+		return nil, errors.NewNotFoundError("notification not found", nil)
+	}
+	if err := notiCacheRepo.InvalidatePages(ctx, notification.UserID); err != nil {
+		return nil, errors.NewSystemError("failed to invalidate notification cache", err)
+	}
+	return mapper.ToNotificationDto(notification), nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+	"time"
+)
+
+type MarkNotiViewedSvc interface {
+	Handle(ctx context.Context, id uint64) (*dto.NotificationDto, error)
+}
+
+type markNotiViewedSvc struct {
+	notiRepo      repo.INotificationRepo
+	notiCacheRepo repo.INotificationCacheRepo
+}
+
+func NewMarkNotiViewedSvc(
+	notiRepo repo.INotificationRepo,
+	notiCacheRepo repo.INotificationCacheRepo,
+) MarkNotiViewedSvc {
+	return &markNotiViewedSvc{
+		notiRepo:      notiRepo,
+		notiCacheRepo: notiCacheRepo,
+	}
+}
+
+func (s *markNotiViewedSvc) Handle(
+	ctx context.Context,
+	id uint64,
+) (*dto.NotificationDto, error) {
+	if id == 0 {
+		return nil, errors.NewValidationError("id is required", nil)
+	}
+	if err := s.notiRepo.MarkViewed(ctx, id, time.Now().UTC()); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("notification not found", err)
+		}
+		return nil, errors.NewPersistenceError("failed to mark notification viewed", err)
+	}
+	return marked(ctx, s.notiRepo, s.notiCacheRepo, id)
+}
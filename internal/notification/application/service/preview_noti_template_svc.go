@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/application/templates"
+	"simple-securities/pkg/errors"
+)
+
+type PreviewNotiTemplateSvc interface {
+	Handle(ctx context.Context, req *dto.RenderTemplateReq) (*dto.RenderedTemplateDto, error)
+}
+
+type previewNotiTemplateSvc struct {
+	templates *templates.Registry
+}
+
+func NewPreviewNotiTemplateSvc(templates *templates.Registry) PreviewNotiTemplateSvc {
+	return &previewNotiTemplateSvc{templates: templates}
+}
+
+func (s *previewNotiTemplateSvc) Handle(
+	ctx context.Context,
+	req *dto.RenderTemplateReq,
+) (*dto.RenderedTemplateDto, error) {
+	if req.TemplateID == "" {
+		return nil, errors.NewValidationError("template_id is required", nil)
+	}
+	rendered, err := s.templates.Render(req.TemplateID, req.Locale, req.Variables)
+	if stderrors.Is(err, templates.ErrTemplateNotFound) {
+		return nil, errors.NewNotFoundError("notification template not found", err)
+	}
+	if err != nil {
+		return nil, variablesError(err)
+	}
+	return mapper.ToRenderedTemplateDto(rendered), nil
+}
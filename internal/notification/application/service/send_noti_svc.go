@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	stderrors "errors"
+	"simple-securities/internal/notification/application/digest"
 	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/templates"
 	"simple-securities/internal/notification/domain/model"
 	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+	"strings"
 )
 
 type SendNotiSvc interface {
@@ -14,15 +19,21 @@ type SendNotiSvc interface {
 type sendNotiSvc struct {
 	notiRepo      repo.INotificationRepo
 	notiCacheRepo repo.INotificationCacheRepo
+	templates     *templates.Registry
+	digests       *digest.Scheduler
 }
 
 func NewSendNotiSvc(
 	notiRepo repo.INotificationRepo,
 	notiCacheRepo repo.INotificationCacheRepo,
+	templates *templates.Registry,
+	digests *digest.Scheduler,
 ) SendNotiSvc {
 	return &sendNotiSvc{
 		notiRepo:      notiRepo,
 		notiCacheRepo: notiCacheRepo,
+		templates:     templates,
+		digests:       digests,
 	}
 }
 
@@ -30,9 +41,65 @@ func (s *sendNotiSvc) Handle(
 	ctx context.Context,
 	req *dto.NotificationCreateReq,
 ) error {
-	_, err := s.notiRepo.Create(ctx, model.NewNotification(req.UserID, req.Type, req.Title, req.Body))
+	notiType, title, body := req.Type, req.Title, req.Body
+	if req.TemplateID != "" {
+		rendered, err := renderTemplate(s.templates, req)
+		if err != nil {
+			return err
+		}
+		title, body = rendered.Title, rendered.Body
+		if notiType == "" {
+			notiType = req.TemplateID
+		}
+	}
+	if req.UserID == 0 || notiType == "" || title == "" || body == "" {
+		return errors.NewValidationError("user_id, type, title and body or a template are required", nil)
+	}
+
+	notification := model.NewNotification(req.UserID, notiType, title, body)
+	// Low-priority notifications of users getting digests wait in their digest
+	if s.digests != nil {
+		collected, err := s.digests.Collect(ctx, notification)
+		if err != nil {
+			return errors.NewPersistenceError("failed to collect notification in digest", err)
+		}
+		if collected {
+			return nil
+		}
+	}
+
+	notification, err := s.notiRepo.Create(ctx, notification)
 	if err != nil {
 		return err
 	}
+
+	// Caching the new notification replaces a missing marker of its id, and the inbox pages of
+	// the user no longer hold its first notifications and counts
+	if err := s.notiCacheRepo.Set(ctx, notification.ID, notification); err != nil {
+		return errors.NewSystemError("failed to cache notification", err)
+	}
+	if err := s.notiCacheRepo.InvalidatePages(ctx, notification.UserID); err != nil {
+		return errors.NewSystemError("failed to invalidate notification cache", err)
+	}
 	return nil
 }
+
+// renderTemplate renders the template of the request, its errors are validation errors
+func renderTemplate(registry *templates.Registry, req *dto.NotificationCreateReq) (*model.RenderedTemplate, error) {
+	if registry == nil {
+		return nil, errors.NewValidationError("notification templates are not configured", nil)
+	}
+	rendered, err := registry.Render(req.TemplateID, req.Locale, req.Variables)
+	if stderrors.Is(err, templates.ErrTemplateNotFound) {
+		return nil, errors.NewValidationError("unknown notification template "+req.TemplateID, err)
+	}
+	if err != nil {
+		return nil, variablesError(err)
+	}
+	return rendered, nil
+}
+
+// variablesError lists the problems of the variables of a template on one line
+func variablesError(err error) error {
+	return errors.NewValidationError("invalid template variables: "+strings.ReplaceAll(err.Error(), "\n", "; "), err)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type SetNotiChannelSvc interface {
+	Handle(ctx context.Context, req *dto.SetChannelReq) (*dto.UserChannelDto, error)
+}
+
+type setNotiChannelSvc struct {
+	userChannelRepo repo.IUserChannelRepo
+}
+
+func NewSetNotiChannelSvc(userChannelRepo repo.IUserChannelRepo) SetNotiChannelSvc {
+	return &setNotiChannelSvc{userChannelRepo: userChannelRepo}
+}
+
+func (s *setNotiChannelSvc) Handle(
+	ctx context.Context,
+	req *dto.SetChannelReq,
+) (*dto.UserChannelDto, error) {
+	channel := model.Channel(req.Channel)
+	if req.UserID == 0 || req.Address == "" {
+		return nil, errors.NewValidationError("user_id and address are required", nil)
+	}
+	if !channel.Valid() {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported channel %q", req.Channel), nil)
+	}
+
+	userChannel := &model.UserChannel{
+		UserID:  req.UserID,
+		Channel: channel,
+		Address: req.Address,
+		Secret:  req.Secret,
+		Enabled: req.Enabled,
+	}
+	if err := s.userChannelRepo.Upsert(ctx, userChannel); err != nil {
+		return nil, errors.NewPersistenceError("failed to save notification channel", err)
+	}
+	channels, err := s.userChannelRepo.GetByUserId(ctx, req.UserID)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to get notification channels", err)
+	}
+	for _, c := range channels {
+		if c.Channel == channel {
+			return mapper.ToUserChannelDto(c), nil
+		}
+	}
+	return mapper.ToUserChannelDto(userChannel), nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/errors"
+	"slices"
+	"strings"
+)
+
+type UpdateNotiPreferencesSvc interface {
+	Handle(ctx context.Context, req *dto.UpdatePreferencesReq) (*dto.PreferencesDto, error)
+}
+
+type updateNotiPreferencesSvc struct {
+	preferencesRepo repo.IPreferencesRepo
+}
+
+func NewUpdateNotiPreferencesSvc(preferencesRepo repo.IPreferencesRepo) UpdateNotiPreferencesSvc {
+	return &updateNotiPreferencesSvc{preferencesRepo: preferencesRepo}
+}
+
+func (s *updateNotiPreferencesSvc) Handle(
+	ctx context.Context,
+	req *dto.UpdatePreferencesReq,
+) (*dto.PreferencesDto, error) {
+	if req.UserID == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+	preferences := model.NewPreferences(req.UserID)
+
+	for _, notiType := range req.MutedTypes {
+		notiType = strings.TrimSpace(notiType)
+		if notiType == "" {
+			return nil, errors.NewValidationError("muted types cannot be empty", nil)
+		}
+		if !slices.Contains(preferences.MutedTypes, notiType) {
+			preferences.MutedTypes = append(preferences.MutedTypes, notiType)
+		}
+	}
+	for _, name := range req.MutedChannels {
+		channel := model.Channel(name)
+		if !channel.Valid() {
+			return nil, errors.NewValidationError(fmt.Sprintf("unsupported channel %q", name), nil)
+		}
+		if !slices.Contains(preferences.MutedChannels, channel) {
+			preferences.MutedChannels = append(preferences.MutedChannels, channel)
+		}
+	}
+	if q := req.QuietHours; q != nil {
+		preferences.QuietHours = &model.QuietHours{Start: q.Start, End: q.End, TimeZone: q.TimeZone}
+		if err := preferences.QuietHours.Validate(); err != nil {
+			return nil, errors.NewValidationError(err.Error(), err)
+		}
+	}
+	if req.DigestFrequency != "" {
+		preferences.DigestFrequency = model.DigestFrequency(req.DigestFrequency)
+		if !preferences.DigestFrequency.Valid() {
+			return nil, errors.NewValidationError(fmt.Sprintf("unsupported digest frequency %q", req.DigestFrequency), nil)
+		}
+	}
+
+	if err := s.preferencesRepo.Upsert(ctx, preferences); err != nil {
+		return nil, errors.NewPersistenceError("failed to save notification preferences", err)
+	}
+	return mapper.ToPreferencesDto(preferences), nil
+}
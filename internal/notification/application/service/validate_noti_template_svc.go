@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/notification/application/dto"
+	"simple-securities/internal/notification/application/mapper"
+	"simple-securities/internal/notification/application/templates"
+	"simple-securities/pkg/errors"
+)
+
+type ValidateNotiTemplateSvc interface {
+	Handle(ctx context.Context, req *dto.ValidateTemplateReq) (*dto.TemplateValidationDto, error)
+}
+
+type validateNotiTemplateSvc struct {
+	templates *templates.Registry
+}
+
+func NewValidateNotiTemplateSvc(templates *templates.Registry) ValidateNotiTemplateSvc {
+	return &validateNotiTemplateSvc{templates: templates}
+}
+
+// Handle reports the problems of a template instead of failing on them, a valid template is
+// rendered when variables are given
+func (s *validateNotiTemplateSvc) Handle(
+	ctx context.Context,
+	req *dto.ValidateTemplateReq,
+) (*dto.TemplateValidationDto, error) {
+	if req.Template == nil {
+		return nil, errors.NewValidationError("template is required", nil)
+	}
+	template := mapper.ToTemplate(req.Template)
+
+	validation := &dto.TemplateValidationDto{Errors: []string{}}
+	for _, err := range s.templates.Validate(template) {
+		validation.Errors = append(validation.Errors, err.Error())
+	}
+	if len(validation.Errors) == 0 && req.Variables != nil {
+		rendered, err := s.templates.Preview(template, req.Locale, req.Variables)
+		if err != nil {
+			validation.Errors = append(validation.Errors, err.Error())
+		}
+		validation.Rendered = mapper.ToRenderedTemplateDto(rendered)
+	}
+	validation.Valid = len(validation.Errors) == 0
+	return validation, nil
+}
@@ -0,0 +1,239 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"regexp"
+	"simple-securities/internal/notification/domain/model"
+	"sort"
+	"strings"
+	textTemplate "text/template"
+	"text/template/parse"
+	"time"
+)
+
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// funcs are the functions templates may call besides the builtins
+var funcs = map[string]any{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// fixed formats a number with the decimals, e.g. {{fixed 2 .price}}
+	"fixed": func(decimals int, value any) (string, error) {
+		switch v := value.(type) {
+		case float64:
+			return fmt.Sprintf("%.*f", decimals, v), nil
+		case int64:
+			return fmt.Sprintf("%.*f", decimals, float64(v)), nil
+		}
+		return "", fmt.Errorf("fixed of %T", value)
+	},
+	// date formats a time with a Go layout, e.g. {{date "2006-01-02" .filled_at}}
+	"date": func(layout string, value any) (string, error) {
+		t, ok := value.(time.Time)
+		if !ok {
+			return "", fmt.Errorf("date of %T", value)
+		}
+		return t.Format(layout), nil
+	},
+}
+
+// executor is a text or HTML template
+type executor interface {
+	Execute(w io.Writer, data any) error
+}
+
+type localized struct {
+	title executor
+	body  executor
+}
+
+type compiled struct {
+	template *model.Template
+	locales  map[string]*localized
+}
+
+// compile checks the template and parses its locales, it returns every problem found
+func compile(t *model.Template, defaultLocale string) (*compiled, []error) {
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if t.ID == "" {
+		fail("template has no id")
+	}
+	format := t.Format
+	if format == "" {
+		format = model.TemplateFormatText
+	}
+	if format != model.TemplateFormatText && format != model.TemplateFormatHTML {
+		fail("unsupported format %q", t.Format)
+	}
+
+	declared := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		switch {
+		case !variableName.MatchString(v.Name):
+			fail("invalid variable name %q", v.Name)
+		case declared[v.Name]:
+			fail("variable %q is declared twice", v.Name)
+		case !v.Type.Valid():
+			fail("variable %q has unsupported type %q", v.Name, v.Type)
+		}
+		declared[v.Name] = true
+	}
+
+	c := &compiled{
+		template: &model.Template{
+			ID:        t.ID,
+			Format:    format,
+			Variables: t.Variables,
+			Locales:   make(map[string]model.TemplateContent, len(t.Locales)),
+		},
+		locales: make(map[string]*localized, len(t.Locales)),
+	}
+	for _, locale := range sortedLocales(t.Locales) {
+		content := t.Locales[locale]
+		normalized := normalizeLocale(locale)
+		if _, ok := c.locales[normalized]; ok {
+			fail("locale %q is declared twice", locale)
+			continue
+		}
+		if strings.TrimSpace(content.Title) == "" || strings.TrimSpace(content.Body) == "" {
+			fail("locale %q needs a title and a body", locale)
+			continue
+		}
+
+		title, err := textTemplate.New("title").Funcs(funcs).Option("missingkey=error").Parse(content.Title)
+		if err != nil {
+			fail("locale %q: %v", locale, err)
+			continue
+		}
+		textBody, err := textTemplate.New("body").Funcs(funcs).Option("missingkey=error").Parse(content.Body)
+		if err != nil {
+			fail("locale %q: %v", locale, err)
+			continue
+		}
+		for _, name := range append(fields(title.Tree), fields(textBody.Tree)...) {
+			if !declared[name] {
+				fail("locale %q uses undeclared variable %q", locale, name)
+			}
+		}
+
+		var body executor = textBody
+		if format == model.TemplateFormatHTML {
+			htmlBody, err := htmlTemplate.New("body").Funcs(funcs).Option("missingkey=error").Parse(content.Body)
+			if err != nil {
+				fail("locale %q: %v", locale, err)
+				continue
+			}
+			body = htmlBody
+		}
+		c.locales[normalized] = &localized{title: title, body: body}
+		c.template.Locales[normalized] = content
+	}
+
+	if len(t.Locales) == 0 {
+		fail("template has no locale")
+	} else if _, ok := c.locales[defaultLocale]; !ok && len(errs) == 0 {
+		fail("template has no %q content for the default locale", defaultLocale)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	// Rendering sample variables catches the errors parsing misses, like functions called
+	// with the wrong type or HTML that cannot be escaped
+	for locale := range c.locales {
+		if _, err := c.render(defaultLocale, locale, samples(t.Variables)); err != nil {
+			fail("locale %q: %v", locale, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return c, nil
+}
+
+func (c *compiled) render(defaultLocale, locale string, variables map[string]any) (*model.RenderedTemplate, error) {
+	values, err := checkVariables(c.template.Variables, variables)
+	if err != nil {
+		return nil, err
+	}
+	resolved := resolveLocale(c.locales, defaultLocale, locale)
+	l := c.locales[resolved]
+
+	var title, body bytes.Buffer
+	if err := l.title.Execute(&title, values); err != nil {
+		return nil, err
+	}
+	if err := l.body.Execute(&body, values); err != nil {
+		return nil, err
+	}
+	return &model.RenderedTemplate{
+		TemplateID: c.template.ID,
+		Locale:     resolved,
+		Format:     c.template.Format,
+		Title:      strings.TrimSpace(title.String()),
+		Body:       strings.TrimSpace(body.String()),
+	}, nil
+}
+
+// fields lists the variables used with the dot of the template. The dot of range and with
+// blocks is another value, only their pipelines are walked.
+func fields(tree *parse.Tree) []string {
+	var names []string
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, cmd := range n.Cmds {
+				walk(cmd)
+			}
+		case *parse.CommandNode:
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.FieldNode:
+			names = append(names, n.Ident[0])
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			walk(n.Pipe)
+		}
+	}
+	walk(tree.Root)
+	return names
+}
+
+func sortedLocales(locales map[string]model.TemplateContent) []string {
+	keys := make([]string, 0, len(locales))
+	for locale := range locales {
+		keys = append(keys, locale)
+	}
+	sort.Strings(keys)
+	return keys
+}
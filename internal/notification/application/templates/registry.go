@@ -0,0 +1,114 @@
+// Package templates renders the title and body of notifications from templates keyed by the
+// notification type.
+//
+// Templates are Go templates, their body in text or HTML, written per locale. The variables a
+// template takes are declared with their type: the variables sent with a notification are
+// checked against them before rendering, and the templates may only use declared variables.
+// A locale missing from a template falls back to its language, then to the default locale
+// every template has.
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"simple-securities/internal/notification/domain/model"
+	"sort"
+	"strings"
+)
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+type Registry struct {
+	defaultLocale string
+	templates     map[string]*compiled
+}
+
+// NewRegistry compiles the templates, it fails on the first invalid template
+func NewRegistry(defaultLocale string, templates ...*model.Template) (*Registry, error) {
+	defaultLocale = normalizeLocale(defaultLocale)
+	if defaultLocale == "" {
+		return nil, errors.New("templates need a default locale")
+	}
+	r := &Registry{
+		defaultLocale: defaultLocale,
+		templates:     make(map[string]*compiled, len(templates)),
+	}
+	for _, t := range templates {
+		if _, ok := r.templates[t.ID]; ok {
+			return nil, fmt.Errorf("template %q is declared twice", t.ID)
+		}
+		c, errs := compile(t, defaultLocale)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("template %q: %w", t.ID, errors.Join(errs...))
+		}
+		r.templates[t.ID] = c
+	}
+	return r, nil
+}
+
+func (r *Registry) DefaultLocale() string {
+	return r.defaultLocale
+}
+
+func (r *Registry) Get(id string) (*model.Template, bool) {
+	c, ok := r.templates[id]
+	if !ok {
+		return nil, false
+	}
+	return c.template, true
+}
+
+// List returns the templates ordered by ID
+func (r *Registry) List() []*model.Template {
+	templates := make([]*model.Template, 0, len(r.templates))
+	for _, c := range r.templates {
+		templates = append(templates, c.template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].ID < templates[j].ID })
+	return templates
+}
+
+// Render renders the template in the locale, the variables must match its declaration
+func (r *Registry) Render(id, locale string, variables map[string]any) (*model.RenderedTemplate, error) {
+	c, ok := r.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrTemplateNotFound, id)
+	}
+	return c.render(r.defaultLocale, locale, variables)
+}
+
+// Validate returns the problems of a template that is not registered, e.g. a template being
+// written, as NewRegistry would find them
+func (r *Registry) Validate(t *model.Template) []error {
+	_, errs := compile(t, r.defaultLocale)
+	return errs
+}
+
+// Preview renders a template that is not registered
+func (r *Registry) Preview(t *model.Template, locale string, variables map[string]any) (*model.RenderedTemplate, error) {
+	c, errs := compile(t, r.defaultLocale)
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return c.render(r.defaultLocale, locale, variables)
+}
+
+// resolveLocale picks the locale of the template to render a requested locale with: the locale
+// itself, its language or the default locale
+func resolveLocale(locales map[string]*localized, defaultLocale, locale string) string {
+	locale = normalizeLocale(locale)
+	if _, ok := locales[locale]; ok {
+		return locale
+	}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		if _, ok := locales[language]; ok {
+			return language
+		}
+	}
+	return defaultLocale
+}
+
+// normalizeLocale writes locales like "fr_CA" and "fr-CA" alike
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,144 @@
+package templates
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"simple-securities/internal/notification/domain/model"
+)
+
+func orderFilled() *model.Template {
+	return &model.Template{
+		ID: "order_filled",
+		Variables: []model.TemplateVariable{
+			{Name: "symbol", Type: model.VariableString, Required: true},
+			{Name: "quantity", Type: model.VariableInteger, Required: true},
+			{Name: "price", Type: model.VariableNumber, Required: true},
+			{Name: "filled_at", Type: model.VariableTime},
+		},
+		Locales: map[string]model.TemplateContent{
+			"en": {
+				Title: "Order filled: {{.symbol}}",
+				Body:  `{{.quantity}} {{.symbol}} at {{fixed 2 .price}}{{if .filled_at}} on {{date "2006-01-02" .filled_at}}{{end}}`,
+			},
+			"fr": {Title: "Ordre exécuté : {{.symbol}}", Body: "{{.quantity}} {{.symbol}} à {{fixed 2 .price}}"},
+		},
+	}
+}
+
+func newTestRegistry(t *testing.T, templates ...*model.Template) *Registry {
+	t.Helper()
+	r, err := NewRegistry("en", templates...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestRenderFallsBackToLanguageAndDefaultLocale(t *testing.T) {
+	r := newTestRegistry(t, orderFilled())
+	variables := map[string]any{"symbol": "AAPL", "quantity": float64(10), "price": 189.5}
+
+	for locale, want := range map[string]string{
+		"":      "en",
+		"fr":    "fr",
+		"fr_CA": "fr",
+		"de-DE": "en",
+	} {
+		rendered, err := r.Render("order_filled", locale, variables)
+		if err != nil {
+			t.Fatalf("Render(%q) = %v", locale, err)
+		}
+		if rendered.Locale != want {
+			t.Errorf("Render(%q) used locale %q, want %q", locale, rendered.Locale, want)
+		}
+	}
+
+	variables["filled_at"] = "2024-03-01T14:30:00Z"
+	rendered, err := r.Render("order_filled", "en", variables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered.Title != "Order filled: AAPL" || rendered.Body != "10 AAPL at 189.50 on 2024-03-01" {
+		t.Errorf("Render() = %q, %q", rendered.Title, rendered.Body)
+	}
+
+	if _, err := r.Render("price_alert", "en", variables); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Render() of an unknown template = %v, want ErrTemplateNotFound", err)
+	}
+}
+
+func TestRenderChecksVariables(t *testing.T) {
+	r := newTestRegistry(t, orderFilled())
+
+	for name, tc := range map[string]struct {
+		variables map[string]any
+		want      string
+	}{
+		"missing":    {map[string]any{"symbol": "AAPL", "price": 1.0}, `"quantity" is required`},
+		"wrong type": {map[string]any{"symbol": 1.0, "quantity": 1.0, "price": 1.0}, `"symbol": 1 is not of type string`},
+		"fraction":   {map[string]any{"symbol": "AAPL", "quantity": 1.5, "price": 1.0}, `"quantity": 1.5 is not of type integer`},
+		"time":       {map[string]any{"symbol": "AAPL", "quantity": 1.0, "price": 1.0, "filled_at": "yesterday"}, "RFC 3339"},
+		"undeclared": {map[string]any{"symbol": "AAPL", "quantity": 1.0, "price": 1.0, "fee": 1.0}, `"fee" is not declared`},
+	} {
+		_, err := r.Render("order_filled", "en", tc.variables)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: Render() = %v, want an error containing %s", name, err, tc.want)
+		}
+	}
+}
+
+func TestHTMLTemplatesEscapeVariables(t *testing.T) {
+	r := newTestRegistry(t, &model.Template{
+		ID:        "message",
+		Format:    model.TemplateFormatHTML,
+		Variables: []model.TemplateVariable{{Name: "text", Type: model.VariableString, Required: true}},
+		Locales:   map[string]model.TemplateContent{"en": {Title: "From {{.text}}", Body: "<p>{{.text}}</p>"}},
+	})
+	rendered, err := r.Render("message", "en", map[string]any{"text": "<b>Tom & Jerry</b>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered.Body != "<p>&lt;b&gt;Tom &amp; Jerry&lt;/b&gt;</p>" || rendered.Title != "From <b>Tom & Jerry</b>" {
+		t.Errorf("Render() = %q, %q, want the body escaped", rendered.Title, rendered.Body)
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	r := newTestRegistry(t)
+
+	draft := orderFilled()
+	draft.Locales = map[string]model.TemplateContent{
+		"fr": {Title: "{{.symbol}}", Body: "{{.quantity}} {{.fee}}"},
+		"de": {Title: "{{.symbol", Body: "x"},
+	}
+	draft.Variables = append(draft.Variables, model.TemplateVariable{Name: "side", Type: "enum"})
+	errs := r.Validate(draft)
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	joined := strings.Join(messages, "\n")
+	for _, want := range []string{`unsupported type "enum"`, `undeclared variable "fee"`, `locale "de"`} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Validate() = %s, want a problem containing %s", joined, want)
+		}
+	}
+
+	// Errors only found rendering, here a number function given a string
+	draft = orderFilled()
+	draft.Locales["en"] = model.TemplateContent{Title: "{{.symbol}}", Body: "{{fixed 2 .symbol}}"}
+	if errs := r.Validate(draft); len(errs) != 1 || !strings.Contains(errs[0].Error(), "fixed of string") {
+		t.Errorf("Validate() = %v, want the failed rendering", errs)
+	}
+
+	delete(draft.Locales, "en")
+	if errs := r.Validate(draft); len(errs) != 1 || !strings.Contains(errs[0].Error(), `"en"`) {
+		t.Errorf("Validate() without the default locale = %v", errs)
+	}
+
+	if _, err := NewRegistry("en", orderFilled(), orderFilled()); err == nil {
+		t.Error("NewRegistry() with a template declared twice succeeded")
+	}
+}
@@ -0,0 +1,113 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"simple-securities/internal/notification/domain/model"
+	"sort"
+	"time"
+)
+
+// checkVariables checks the variables against their declaration and converts them to the
+// values the templates get: strings, float64 numbers, int64 integers, bools and times.
+// Optional variables left out are nil.
+func checkVariables(declared []model.TemplateVariable, variables map[string]any) (map[string]any, error) {
+	var errs []error
+	values := make(map[string]any, len(declared))
+	known := make(map[string]bool, len(declared))
+	for _, v := range declared {
+		known[v.Name] = true
+		raw, ok := variables[v.Name]
+		if !ok || raw == nil {
+			if v.Required {
+				errs = append(errs, fmt.Errorf("variable %q is required", v.Name))
+			}
+			values[v.Name] = nil
+			continue
+		}
+		value, err := convert(v.Type, raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("variable %q: %w", v.Name, err))
+			continue
+		}
+		values[v.Name] = value
+	}
+
+	var unknown []string
+	for name := range variables {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("variable %q is not declared", name))
+	}
+	return values, errors.Join(errs...)
+}
+
+func convert(typ model.VariableType, raw any) (any, error) {
+	switch typ {
+	case model.VariableString:
+		if s, ok := raw.(string); ok {
+			return s, nil
+		}
+	case model.VariableNumber:
+		switch v := raw.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		}
+	case model.VariableInteger:
+		switch v := raw.(type) {
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) <= 1<<53 {
+				return int64(v), nil
+			}
+		case int:
+			return int64(v), nil
+		case int64:
+			return v, nil
+		}
+	case model.VariableBool:
+		if b, ok := raw.(bool); ok {
+			return b, nil
+		}
+	case model.VariableTime:
+		switch v := raw.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an RFC 3339 time", v)
+			}
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%v is not of type %s", raw, typ)
+}
+
+// samples are variables of the declared types, to try the templates with
+func samples(declared []model.TemplateVariable) map[string]any {
+	values := make(map[string]any, len(declared))
+	for _, v := range declared {
+		switch v.Type {
+		case model.VariableString:
+			values[v.Name] = "sample"
+		case model.VariableNumber:
+			values[v.Name] = 1.5
+		case model.VariableInteger:
+			values[v.Name] = int64(1)
+		case model.VariableBool:
+			values[v.Name] = true
+		case model.VariableTime:
+			values[v.Name] = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+		}
+	}
+	return values
+}
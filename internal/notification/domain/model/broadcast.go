@@ -0,0 +1,79 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBroadcastNotRunning is returned when saving the progress of a job that was cancelled or
+// taken over by another runner
+var ErrBroadcastNotRunning = errors.New("broadcast job is not running")
+
+// BroadcastAudience is who a broadcast goes to
+type BroadcastAudience string
+
+const (
+	// AudienceAll is every user known to the notification service
+	AudienceAll BroadcastAudience = "all"
+	// AudienceUsers is the users listed in the job
+	AudienceUsers BroadcastAudience = "users"
+	// AudienceHolders is the users holding a position in the symbol of the job
+	AudienceHolders BroadcastAudience = "holders"
+)
+
+func (a BroadcastAudience) Valid() bool {
+	switch a {
+	case AudienceAll, AudienceUsers, AudienceHolders:
+		return true
+	}
+	return false
+}
+
+type BroadcastStatus string
+
+const (
+	BroadcastPending   BroadcastStatus = "pending"
+	BroadcastRunning   BroadcastStatus = "running"
+	BroadcastCompleted BroadcastStatus = "completed"
+	BroadcastCancelled BroadcastStatus = "cancelled"
+	// BroadcastFailed is a job whose audience cannot be resolved anymore
+	BroadcastFailed BroadcastStatus = "failed"
+)
+
+// Finished tells whether the job will send no more notifications
+func (s BroadcastStatus) Finished() bool {
+	return s == BroadcastCompleted || s == BroadcastCancelled || s == BroadcastFailed
+}
+
+// BroadcastJob sends one notification to every user of an audience. It fans out in batches
+// of users ordered by id, Cursor is the last user handled so that a restarted job resumes
+// after it.
+type BroadcastJob struct {
+	ID         uint64
+	Audience   BroadcastAudience
+	UserIDs    []uint64
+	Symbol     string
+	Type       string
+	Title      string
+	Body       string
+	TemplateID string
+	Locale     string
+	Variables  map[string]any
+	Status     BroadcastStatus
+	Total      uint64
+	Sent       uint64
+	Failed     uint64
+	Cursor     uint64
+	// LastError is the last failure to send to a user
+	LastError string
+	// LeaseID identifies the claim of the runner sending the job
+	LeaseID    uint64
+	CreatedAt  time.Time
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	UpdatedAt  time.Time
+}
+
+func (j BroadcastJob) TableName() string {
+	return "notification_broadcast_jobs"
+}
@@ -0,0 +1,74 @@
+package model
+
+import "time"
+
+// Channel is a way of delivering notifications outside the inbox
+type Channel string
+
+const (
+	ChannelEmail   Channel = "email"
+	ChannelWebhook Channel = "webhook"
+	ChannelDiscord Channel = "discord"
+	ChannelPush    Channel = "push"
+)
+
+// Channels lists the delivery channels in the order they are tried
+var Channels = []Channel{ChannelEmail, ChannelWebhook, ChannelDiscord, ChannelPush}
+
+func (c Channel) Valid() bool {
+	for _, channel := range Channels {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// UserChannel is the preference of a user for a channel. Address is where the channel
+// delivers: an email address, a webhook or Discord webhook URL, or the JSON of a push
+// subscription. Secret signs the webhook deliveries.
+type UserChannel struct {
+	UserID    uint64    `db:"user_id"`
+	Channel   Channel   `db:"channel"`
+	Address   string    `db:"address"`
+	Secret    string    `db:"secret"`
+	Enabled   bool      `db:"enabled"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (c UserChannel) TableName() string {
+	return "notification_channels"
+}
+
+// DeliveryStatus is the state of the delivery of a notification on a channel
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliveryDeferred is held back by the quiet hours of the user until DeliverAfter
+	DeliveryDeferred DeliveryStatus = "deferred"
+	DeliverySent     DeliveryStatus = "sent"
+	DeliveryFailed   DeliveryStatus = "failed"
+	// DeliverySkipped is a channel the service cannot deliver on, it is not configured
+	DeliverySkipped DeliveryStatus = "skipped"
+)
+
+// Delivery tracks a notification on one channel, there is one per notification and channel
+type Delivery struct {
+	ID             uint64         `db:"id"`
+	NotificationID uint64         `db:"notification_id"`
+	UserID         uint64         `db:"user_id"`
+	Channel        Channel        `db:"channel"`
+	Status         DeliveryStatus `db:"status"`
+	Attempts       uint32         `db:"attempts"`
+	LastError      string         `db:"last_error"`
+	DeliveredAt    *time.Time     `db:"delivered_at"`
+	DeliverAfter   *time.Time     `db:"deliver_after"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func (d Delivery) TableName() string {
+	return "notification_deliveries"
+}
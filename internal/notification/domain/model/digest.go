@@ -0,0 +1,94 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// NotificationTypeDigest is the type of the summary notifications of digests
+const NotificationTypeDigest = "digest"
+
+// ErrDigestChanged is returned when emitting a digest that got items since it was read
+var ErrDigestChanged = errors.New("digest changed while being emitted")
+
+type DigestStatus string
+
+const (
+	DigestOpen    DigestStatus = "open"
+	DigestEmitted DigestStatus = "emitted"
+)
+
+// Digest gathers the low-priority notifications of a user over a window. It collects items
+// while open, and is emitted once the window ends as one summary notification.
+type Digest struct {
+	ID             uint64          `db:"id"`
+	UserID         uint64          `db:"user_id"`
+	Frequency      DigestFrequency `db:"frequency"`
+	Status         DigestStatus    `db:"status"`
+	WindowEnd      time.Time       `db:"window_end"`
+	ItemCount      uint32          `db:"item_count"`
+	NotificationID *uint64         `db:"notification_id"`
+	EmittedAt      *time.Time      `db:"emitted_at"`
+	CreatedAt      time.Time       `db:"created_at"`
+	UpdatedAt      time.Time       `db:"updated_at"`
+}
+
+func (d Digest) TableName() string {
+	return "notification_digests"
+}
+
+// DigestItem is a notification held in a digest instead of the inbox
+type DigestItem struct {
+	ID        uint64    `db:"id"`
+	DigestID  uint64    `db:"digest_id"`
+	UserID    uint64    `db:"user_id"`
+	Type      string    `db:"type"`
+	Title     string    `db:"title"`
+	Body      string    `db:"body"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (i DigestItem) TableName() string {
+	return "notification_digest_items"
+}
+
+// WindowEnd is when a digest of the frequency opened at the instant is emitted: the next hour,
+// midnight or Monday midnight in the location. Off has no window.
+func (f DigestFrequency) WindowEnd(at time.Time, location *time.Location) time.Time {
+	local := at.In(location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	switch f {
+	case DigestHourly:
+		return time.Date(local.Year(), local.Month(), local.Day(), local.Hour()+1, 0, 0, 0, location)
+	case DigestDaily:
+		return midnight.AddDate(0, 0, 1)
+	case DigestWeekly:
+		daysToMonday := (8 - int(local.Weekday())) % 7
+		if daysToMonday == 0 {
+			daysToMonday = 7
+		}
+		return midnight.AddDate(0, 0, daysToMonday)
+	}
+	return time.Time{}
+}
+
+// Location is the time zone of the user, taken from the quiet hours, UTC without
+func (p *Preferences) Location() *time.Location {
+	if p.QuietHours != nil {
+		if location, err := time.LoadLocation(p.QuietHours.TimeZone); err == nil {
+			return location
+		}
+	}
+	return time.UTC
+}
+
+// DigestWindowEnd is when the digest of the user opened at the instant is emitted, postponed
+// to the end of the quiet hours when it falls in them
+func (p *Preferences) DigestWindowEnd(at time.Time) time.Time {
+	location := p.Location()
+	end := p.DigestFrequency.WindowEnd(at, location)
+	if p.QuietHours != nil && p.QuietHours.Contains(end) {
+		end = p.QuietHours.EndAfter(end)
+	}
+	return end
+}
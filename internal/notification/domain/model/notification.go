@@ -26,6 +26,40 @@ func (n Notification) TableName() string {
 	return "notifications"
 }
 
+// NotificationFilter narrows the notifications of a user, nil flags and an empty type match all
+type NotificationFilter struct {
+	Read   *bool
+	Viewed *bool
+	Type   string
+}
+
+// UnreadCount is the number of notifications of a user not read and not viewed yet
+type UnreadCount struct {
+	Unread   uint64 `db:"unread"`
+	Unviewed uint64 `db:"unviewed"`
+}
+
+// PageCursor is the position of the last notification of a page in the newest first order of
+// (CreatedAt, ID), the next page starts after it
+type PageCursor struct {
+	CreatedAt time.Time
+	ID        uint64
+}
+
+// NotificationPage is a page of the notifications of a user. Next is nil on the last page,
+// Total and Unread count every notification matching the filter.
+type NotificationPage struct {
+	Notifications []*Notification
+	Next          *PageCursor
+	Total         uint64
+	Unread        uint64
+}
+
+// Cursor is the position of the notification in the page order
+func (n Notification) Cursor() *PageCursor {
+	return &PageCursor{CreatedAt: n.CreatedAt, ID: n.ID}
+}
+
 func NewNotification(userID uint64, nType, title, body string) *Notification {
 	now := time.Now()
 	return &Notification{
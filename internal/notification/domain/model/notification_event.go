@@ -0,0 +1,38 @@
+package model
+
+import (
+	"simple-securities/pkg/kafka"
+	"time"
+)
+
+const EventNotificationCreated = "notification.created"
+
+// NotificationEvent is the typed envelope of the notification event topic, its schema is
+// registered for the topic by the codec of the message bus
+type NotificationEvent struct {
+	Meta kafka.Meta          `json:"meta"`
+	Data NotificationCreated `json:"data"`
+}
+
+// NotificationCreated is published on the notification event topic for every stored notification
+type NotificationCreated struct {
+	ID        uint64    `json:"id"`
+	Uuid      string    `json:"uuid"`
+	UserID    uint64    `json:"user_id"`
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewNotificationCreated(n *Notification) *NotificationCreated {
+	return &NotificationCreated{
+		ID:        n.ID,
+		Uuid:      n.Uuid,
+		UserID:    n.UserID,
+		Type:      n.Type,
+		Title:     n.Title,
+		Body:      n.Body,
+		CreatedAt: n.CreatedAt,
+	}
+}
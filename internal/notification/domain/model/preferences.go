@@ -0,0 +1,133 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// DigestFrequency is how often the notifications of a user are gathered in a digest, off
+// delivers each notification as it comes
+type DigestFrequency string
+
+const (
+	DigestOff    DigestFrequency = "off"
+	DigestHourly DigestFrequency = "hourly"
+	DigestDaily  DigestFrequency = "daily"
+	DigestWeekly DigestFrequency = "weekly"
+)
+
+func (f DigestFrequency) Valid() bool {
+	switch f {
+	case DigestOff, DigestHourly, DigestDaily, DigestWeekly:
+		return true
+	}
+	return false
+}
+
+// Reasons a delivery is skipped for the preferences of the user, or deferred until the end of
+// the quiet hours
+const (
+	MuteReasonType       = "type muted"
+	MuteReasonChannel    = "channel muted"
+	MuteReasonQuietHours = "quiet hours"
+)
+
+// QuietHours is a daily window, in the time zone of the user, when no notification is delivered
+// on the channels. Start and End are "15:04" clock times; a window like "22:00" to "07:00"
+// spans midnight.
+type QuietHours struct {
+	Start    string
+	End      string
+	TimeZone string
+}
+
+func (q *QuietHours) Validate() error {
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours start %q", q.Start)
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return fmt.Errorf("invalid quiet hours end %q", q.End)
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("quiet hours start and end at %s", q.Start)
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("invalid time zone %q", q.TimeZone)
+	}
+	return nil
+}
+
+// Contains tells whether the instant falls in the window, invalid quiet hours contain none
+func (q *QuietHours) Contains(at time.Time) bool {
+	if q.Validate() != nil {
+		return false
+	}
+	location, _ := time.LoadLocation(q.TimeZone)
+	start, _ := time.Parse("15:04", q.Start)
+	end, _ := time.Parse("15:04", q.End)
+
+	local := at.In(location)
+	minute := local.Hour()*60 + local.Minute()
+	from, to := start.Hour()*60+start.Minute(), end.Hour()*60+end.Minute()
+	if from < to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
+
+// EndAfter is the first end of the quiet hours after the instant
+func (q *QuietHours) EndAfter(at time.Time) time.Time {
+	location, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return at
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return at
+	}
+	local := at.In(location)
+	next := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, location)
+	if !next.After(local) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// Preferences are the choices of a user on which notifications reach the channels and when
+type Preferences struct {
+	UserID          uint64
+	MutedTypes      []string
+	MutedChannels   []Channel
+	QuietHours      *QuietHours
+	DigestFrequency DigestFrequency
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// NewPreferences returns the preferences of a user who never set any: nothing muted, every
+// notification delivered as it comes
+func NewPreferences(userId uint64) *Preferences {
+	return &Preferences{
+		UserID:          userId,
+		MutedTypes:      []string{},
+		MutedChannels:   []Channel{},
+		DigestFrequency: DigestOff,
+	}
+}
+
+// MuteReason tells why a notification of the type is not delivered on the channel at the
+// instant, it is empty when the notification is delivered
+func (p *Preferences) MuteReason(notiType string, channel Channel, at time.Time) string {
+	switch {
+	case slices.Contains(p.MutedTypes, notiType):
+		return MuteReasonType
+	case slices.Contains(p.MutedChannels, channel):
+		return MuteReasonChannel
+	case p.QuietHours != nil && p.QuietHours.Contains(at):
+		return MuteReasonQuietHours
+	}
+	return ""
+}
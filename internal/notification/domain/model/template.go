@@ -0,0 +1,60 @@
+package model
+
+// TemplateFormat is how the body of a template is rendered, HTML bodies escape their variables
+type TemplateFormat string
+
+const (
+	TemplateFormatText TemplateFormat = "text"
+	TemplateFormatHTML TemplateFormat = "html"
+)
+
+// VariableType is the type a template variable is checked against before rendering
+type VariableType string
+
+const (
+	VariableString  VariableType = "string"
+	VariableNumber  VariableType = "number"
+	VariableInteger VariableType = "integer"
+	VariableBool    VariableType = "bool"
+	// VariableTime takes RFC 3339 timestamps
+	VariableTime VariableType = "time"
+)
+
+func (t VariableType) Valid() bool {
+	switch t {
+	case VariableString, VariableNumber, VariableInteger, VariableBool, VariableTime:
+		return true
+	}
+	return false
+}
+
+type TemplateVariable struct {
+	Name     string
+	Type     VariableType
+	Required bool
+}
+
+// TemplateContent is the title and body of a template in one locale
+type TemplateContent struct {
+	Title string
+	Body  string
+}
+
+// Template builds the title and body of the notifications of a type from variables, its ID
+// is the notification type
+type Template struct {
+	ID        string
+	Format    TemplateFormat
+	Variables []TemplateVariable
+	// Locales holds the content by locale, e.g. "en" or "fr-ca"
+	Locales map[string]TemplateContent
+}
+
+// RenderedTemplate is a template rendered in the locale chosen for the requested one
+type RenderedTemplate struct {
+	TemplateID string
+	Locale     string
+	Format     TemplateFormat
+	Title      string
+	Body       string
+}
@@ -0,0 +1,35 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/notification/domain/model"
+	"time"
+)
+
+type IBroadcastRepo interface {
+	Create(ctx context.Context, job *model.BroadcastJob) (*model.BroadcastJob, error)
+	GetByID(ctx context.Context, id uint64) (*model.BroadcastJob, error)
+	// Claim starts the oldest pending job, or takes over a running one whose runner let its
+	// lease expire, and leases it until the instant. It returns nil when there is none.
+	Claim(ctx context.Context, lockedUntil time.Time) (*model.BroadcastJob, error)
+	// SaveProgress stores the counts and the cursor of a running job and extends its lease.
+	// It returns model.ErrBroadcastNotRunning when the job was taken over by another claim, or
+	// was cancelled, in which case the counts are still saved.
+	SaveProgress(ctx context.Context, job *model.BroadcastJob, lockedUntil time.Time) error
+	// Finish saves the progress of a running job and closes it with the status, it fails like
+	// SaveProgress
+	Finish(ctx context.Context, job *model.BroadcastJob, status model.BroadcastStatus) error
+	// Cancel stops a pending or running job, it returns false when the job already finished
+	Cancel(ctx context.Context, id uint64) (bool, error)
+	// ListUsers returns the users known to the notification service with an id above
+	// afterUserId, by id: the users with notifications, channels or preferences
+	ListUsers(ctx context.Context, afterUserId uint64, limit int) ([]uint64, error)
+	CountUsers(ctx context.Context) (uint64, error)
+}
+
+// IStockClient reads the holdings of the users from the stock service
+type IStockClient interface {
+	// ListHolders returns the users holding a position in symbol with an id above
+	// afterUserId, by id, and the number of holders
+	ListHolders(ctx context.Context, symbol string, afterUserId uint64, limit uint32) ([]uint64, uint64, error)
+}
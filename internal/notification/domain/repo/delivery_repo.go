@@ -0,0 +1,26 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/notification/domain/model"
+	"time"
+)
+
+type IUserChannelRepo interface {
+	// Upsert creates the channel of the user or replaces its address, secret and state
+	Upsert(ctx context.Context, channel *model.UserChannel) error
+	GetByUserId(ctx context.Context, userId uint64) ([]*model.UserChannel, error)
+}
+
+type IDeliveryRepo interface {
+	// Start returns the delivery of the notification on the channel, created pending on the
+	// first attempt
+	Start(ctx context.Context, notificationId, userId uint64, channel model.Channel) (*model.Delivery, error)
+	// Record stores the outcome of an attempt, counting it unless the channel was skipped
+	Record(ctx context.Context, id uint64, status model.DeliveryStatus, lastError string, at time.Time) error
+	// Defer holds the delivery back until the instant without counting an attempt
+	Defer(ctx context.Context, id uint64, until time.Time, lastError string, at time.Time) error
+	// GetDue fetches the deferred deliveries due at the instant
+	GetDue(ctx context.Context, at time.Time, limit int) ([]*model.Delivery, error)
+	GetByNotificationId(ctx context.Context, notificationId uint64) ([]*model.Delivery, error)
+}
@@ -0,0 +1,21 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/notification/domain/model"
+	"time"
+)
+
+type IDigestRepo interface {
+	// AddItem adds the item to the open digest of the user, opening one that ends at windowEnd
+	// when the user has none open at the time of the item
+	AddItem(ctx context.Context, frequency model.DigestFrequency, windowEnd time.Time, item *model.DigestItem) (*model.Digest, error)
+	// GetDue returns the open digests whose window ended at the instant, oldest first
+	GetDue(ctx context.Context, at time.Time, limit int) ([]*model.Digest, error)
+	GetByID(ctx context.Context, id uint64) (*model.Digest, error)
+	GetItems(ctx context.Context, digestId uint64) ([]*model.DigestItem, error)
+	// Emit stores the summary notification of the digest, with its event, and closes the digest
+	// at once. It returns model.ErrDigestChanged when the digest got items or was emitted since
+	// its items were read.
+	Emit(ctx context.Context, digest *model.Digest, items []*model.DigestItem, summary *model.Notification) (*model.Notification, error)
+}
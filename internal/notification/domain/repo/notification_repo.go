@@ -3,20 +3,41 @@ package repo
 import (
 	"context"
 	"simple-securities/internal/notification/domain/model"
+	"time"
 )
 
 type INotificationRepo interface {
 	Create(ctx context.Context, notification *model.Notification) (*model.Notification, error)
+	// Delete removes the notification, it returns sql.ErrNoRows for an unknown notification
 	Delete(ctx context.Context, id uint64) error
 	Update(ctx context.Context, notification *model.Notification) error
 	GetByID(ctx context.Context, id uint64) (*model.Notification, error)
-	GetByUserId(ctx context.Context, userId uint64, limit, offset uint32) ([]*model.Notification, error)
+	GetByUserId(ctx context.Context, userId uint64, filter model.NotificationFilter, limit, offset uint32) ([]*model.Notification, error)
+	// GetPageByUserId fetches the page of notifications after the cursor, newest first; a nil
+	// cursor fetches the first page. Pages stay stable when new notifications arrive.
+	GetPageByUserId(ctx context.Context, userId uint64, filter model.NotificationFilter, after *model.PageCursor, limit uint32) (*model.NotificationPage, error)
+	// MarkRead marks the notification read, and viewed as reading it shows it; the times of
+	// earlier reads and views are kept. It returns sql.ErrNoRows for an unknown notification.
+	MarkRead(ctx context.Context, id uint64, at time.Time) error
+	MarkViewed(ctx context.Context, id uint64, at time.Time) error
+	// MarkAllRead marks the unread notifications of the user read and returns how many
+	MarkAllRead(ctx context.Context, userId uint64, at time.Time) (uint64, error)
+	CountUnread(ctx context.Context, userId uint64) (*model.UnreadCount, error)
 }
 
 type INotificationCacheRepo interface {
 	Set(ctx context.Context, key uint64, notification *model.Notification) error
-	Get(ctx context.Context, id uint64) (*model.Notification, error)
+	// SetMissing caches that the notification does not exist, for a shorter time than a notification
+	SetMissing(ctx context.Context, id uint64) error
+	// Get returns hit false when nothing is cached for the notification; a hit with a nil
+	// notification is a notification cached as missing
+	Get(ctx context.Context, id uint64) (notification *model.Notification, hit bool, err error)
 	Invalidate(ctx context.Context, id uint64) error
 	InvalidateByUserId(ctx context.Context, userId uint64) error
+	// SetPage and GetPage cache a page of the notifications of a user under a key naming the
+	// filter and cursor of the page; InvalidatePages and InvalidateByUserId drop the pages of the user
+	SetPage(ctx context.Context, userId uint64, key string, page *model.NotificationPage) error
+	GetPage(ctx context.Context, userId uint64, key string) (*model.NotificationPage, error)
+	InvalidatePages(ctx context.Context, userId uint64) error
 	InvalidateAll(ctx context.Context) error
 }
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/notification/domain/model"
+)
+
+type IPreferencesRepo interface {
+	// Get returns the preferences of the user, nil when the user never set any
+	Get(ctx context.Context, userId uint64) (*model.Preferences, error)
+	// Upsert creates the preferences of the user or replaces them
+	Upsert(ctx context.Context, preferences *model.Preferences) error
+}
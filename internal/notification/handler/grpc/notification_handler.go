@@ -9,20 +9,71 @@ import (
 
 type NotificationGrpcHandler struct {
 	noti.UnimplementedNotificationServiceServer
-	sendNotiSvc        service.SendNotiSvc
-	getNotiSvc         service.GetNotiSvc
-	getNotiByUserIdSvc service.GetNotiByUserIdSvc
+	sendNotiSvc          service.SendNotiSvc
+	getNotiSvc           service.GetNotiSvc
+	getNotiByUserIdSvc   service.GetNotiByUserIdSvc
+	markNotiReadSvc      service.MarkNotiReadSvc
+	markNotiViewedSvc    service.MarkNotiViewedSvc
+	markAllNotiReadSvc   service.MarkAllNotiReadSvc
+	getUnreadCountSvc    service.GetUnreadNotiCountSvc
+	deleteNotiSvc        service.DeleteNotiSvc
+	setChannelSvc        service.SetNotiChannelSvc
+	getChannelsSvc       service.GetNotiChannelsSvc
+	getDeliveriesSvc     service.GetNotiDeliveriesSvc
+	getPreferencesSvc    service.GetNotiPreferencesSvc
+	updatePreferencesSvc service.UpdateNotiPreferencesSvc
+	getTemplatesSvc      service.GetNotiTemplatesSvc
+	previewTemplateSvc   service.PreviewNotiTemplateSvc
+	validateTemplateSvc  service.ValidateNotiTemplateSvc
+	getDigestSvc         service.GetNotiDigestSvc
+	broadcastSvc         service.BroadcastNotiSvc
+	getBroadcastJobSvc   service.GetBroadcastJobSvc
+	cancelBroadcastSvc   service.CancelBroadcastJobSvc
 }
 
 func NewNotificationGrpcHandler(
 	sendNotiSvc service.SendNotiSvc,
 	getNotiSvc service.GetNotiSvc,
 	getNotiByUserIdSvc service.GetNotiByUserIdSvc,
+	markNotiReadSvc service.MarkNotiReadSvc,
+	markNotiViewedSvc service.MarkNotiViewedSvc,
+	markAllNotiReadSvc service.MarkAllNotiReadSvc,
+	getUnreadCountSvc service.GetUnreadNotiCountSvc,
+	deleteNotiSvc service.DeleteNotiSvc,
+	setChannelSvc service.SetNotiChannelSvc,
+	getChannelsSvc service.GetNotiChannelsSvc,
+	getDeliveriesSvc service.GetNotiDeliveriesSvc,
+	getPreferencesSvc service.GetNotiPreferencesSvc,
+	updatePreferencesSvc service.UpdateNotiPreferencesSvc,
+	getTemplatesSvc service.GetNotiTemplatesSvc,
+	previewTemplateSvc service.PreviewNotiTemplateSvc,
+	validateTemplateSvc service.ValidateNotiTemplateSvc,
+	getDigestSvc service.GetNotiDigestSvc,
+	broadcastSvc service.BroadcastNotiSvc,
+	getBroadcastJobSvc service.GetBroadcastJobSvc,
+	cancelBroadcastSvc service.CancelBroadcastJobSvc,
 ) noti.NotificationServiceServer {
 	return &NotificationGrpcHandler{
-		sendNotiSvc:        sendNotiSvc,
-		getNotiSvc:         getNotiSvc,
-		getNotiByUserIdSvc: getNotiByUserIdSvc,
+		sendNotiSvc:          sendNotiSvc,
+		getNotiSvc:           getNotiSvc,
+		getNotiByUserIdSvc:   getNotiByUserIdSvc,
+		markNotiReadSvc:      markNotiReadSvc,
+		markNotiViewedSvc:    markNotiViewedSvc,
+		markAllNotiReadSvc:   markAllNotiReadSvc,
+		getUnreadCountSvc:    getUnreadCountSvc,
+		deleteNotiSvc:        deleteNotiSvc,
+		setChannelSvc:        setChannelSvc,
+		getChannelsSvc:       getChannelsSvc,
+		getDeliveriesSvc:     getDeliveriesSvc,
+		getPreferencesSvc:    getPreferencesSvc,
+		updatePreferencesSvc: updatePreferencesSvc,
+		getTemplatesSvc:      getTemplatesSvc,
+		previewTemplateSvc:   previewTemplateSvc,
+		validateTemplateSvc:  validateTemplateSvc,
+		getDigestSvc:         getDigestSvc,
+		broadcastSvc:         broadcastSvc,
+		getBroadcastJobSvc:   getBroadcastJobSvc,
+		cancelBroadcastSvc:   cancelBroadcastSvc,
 	}
 }
 
@@ -43,9 +94,149 @@ func (h *NotificationGrpcHandler) Get(ctx context.Context, req *noti.GetRequest)
 }
 
 func (h *NotificationGrpcHandler) GetByUserId(ctx context.Context, req *noti.GetByUserIdRequest) (*noti.GetByUserIdResponse, error) {
-	notiDtos, err := h.getNotiByUserIdSvc.Handle(ctx, req.UserId, req.Limit, req.Offset)
+	pageDto, err := h.getNotiByUserIdSvc.Handle(ctx, mapper.ToGetNotiByUserIdReq(req))
 	if err != nil {
 		return nil, err
 	}
-	return &noti.GetByUserIdResponse{Notifications: mapper.ToNotifications(notiDtos)}, nil
+	return mapper.ToGetByUserIdResponse(pageDto), nil
+}
+
+func (h *NotificationGrpcHandler) MarkRead(ctx context.Context, req *noti.MarkReadRequest) (*noti.MarkReadResponse, error) {
+	notiDto, err := h.markNotiReadSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.MarkReadResponse{Notification: mapper.ToNotification(notiDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) MarkViewed(ctx context.Context, req *noti.MarkViewedRequest) (*noti.MarkViewedResponse, error) {
+	notiDto, err := h.markNotiViewedSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.MarkViewedResponse{Notification: mapper.ToNotification(notiDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) MarkAllRead(ctx context.Context, req *noti.MarkAllReadRequest) (*noti.MarkAllReadResponse, error) {
+	updated, err := h.markAllNotiReadSvc.Handle(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.MarkAllReadResponse{Updated: updated}, nil
+}
+
+func (h *NotificationGrpcHandler) GetUnreadCount(ctx context.Context, req *noti.GetUnreadCountRequest) (*noti.GetUnreadCountResponse, error) {
+	countDto, err := h.getUnreadCountSvc.Handle(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToUnreadCountResponse(countDto), nil
+}
+
+func (h *NotificationGrpcHandler) Delete(ctx context.Context, req *noti.DeleteRequest) (*noti.DeleteResponse, error) {
+	err := h.deleteNotiSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return &noti.DeleteResponse{Success: false}, err
+	}
+	return &noti.DeleteResponse{Success: true}, nil
+}
+
+func (h *NotificationGrpcHandler) SetChannel(ctx context.Context, req *noti.SetChannelRequest) (*noti.SetChannelResponse, error) {
+	channelDto, err := h.setChannelSvc.Handle(ctx, mapper.ToSetChannelReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &noti.SetChannelResponse{Channel: mapper.ToUserChannel(channelDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) GetChannels(ctx context.Context, req *noti.GetChannelsRequest) (*noti.GetChannelsResponse, error) {
+	channelDtos, err := h.getChannelsSvc.Handle(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.GetChannelsResponse{Channels: mapper.ToUserChannels(channelDtos)}, nil
+}
+
+func (h *NotificationGrpcHandler) GetDeliveries(ctx context.Context, req *noti.GetDeliveriesRequest) (*noti.GetDeliveriesResponse, error) {
+	deliveryDtos, err := h.getDeliveriesSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.GetDeliveriesResponse{Deliveries: mapper.ToDeliveries(deliveryDtos)}, nil
+}
+
+func (h *NotificationGrpcHandler) GetPreferences(ctx context.Context, req *noti.GetPreferencesRequest) (*noti.GetPreferencesResponse, error) {
+	preferencesDto, err := h.getPreferencesSvc.Handle(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.GetPreferencesResponse{Preferences: mapper.ToPreferences(preferencesDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) UpdatePreferences(ctx context.Context, req *noti.UpdatePreferencesRequest) (*noti.UpdatePreferencesResponse, error) {
+	preferencesDto, err := h.updatePreferencesSvc.Handle(ctx, mapper.ToUpdatePreferencesReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &noti.UpdatePreferencesResponse{Preferences: mapper.ToPreferences(preferencesDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) GetDigest(ctx context.Context, req *noti.GetDigestRequest) (*noti.GetDigestResponse, error) {
+	digestDto, err := h.getDigestSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.GetDigestResponse{Digest: mapper.ToDigest(digestDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) Broadcast(ctx context.Context, req *noti.BroadcastRequest) (*noti.BroadcastResponse, error) {
+	jobDto, err := h.broadcastSvc.Handle(ctx, mapper.ToBroadcastReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &noti.BroadcastResponse{Job: mapper.ToBroadcastJob(jobDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) GetBroadcastJob(ctx context.Context, req *noti.GetBroadcastJobRequest) (*noti.GetBroadcastJobResponse, error) {
+	jobDto, err := h.getBroadcastJobSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.GetBroadcastJobResponse{Job: mapper.ToBroadcastJob(jobDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) CancelBroadcastJob(ctx context.Context, req *noti.CancelBroadcastJobRequest) (*noti.CancelBroadcastJobResponse, error) {
+	jobDto, err := h.cancelBroadcastSvc.Handle(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.CancelBroadcastJobResponse{Job: mapper.ToBroadcastJob(jobDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) GetTemplates(ctx context.Context, req *noti.GetTemplatesRequest) (*noti.GetTemplatesResponse, error) {
+	templateDtos, err := h.getTemplatesSvc.Handle(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &noti.GetTemplatesResponse{Templates: mapper.ToTemplates(templateDtos)}, nil
+}
+
+func (h *NotificationGrpcHandler) PreviewTemplate(ctx context.Context, req *noti.PreviewTemplateRequest) (*noti.PreviewTemplateResponse, error) {
+	renderedDto, err := h.previewTemplateSvc.Handle(ctx, mapper.ToRenderTemplateReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &noti.PreviewTemplateResponse{Rendered: mapper.ToRenderedTemplate(renderedDto)}, nil
+}
+
+func (h *NotificationGrpcHandler) ValidateTemplate(ctx context.Context, req *noti.ValidateTemplateRequest) (*noti.ValidateTemplateResponse, error) {
+	validationDto, err := h.validateTemplateSvc.Handle(ctx, mapper.ToValidateTemplateReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &noti.ValidateTemplateResponse{
+		Valid:    validationDto.Valid,
+		Errors:   validationDto.Errors,
+		Rendered: mapper.ToRenderedTemplate(validationDto.Rendered),
+	}, nil
 }
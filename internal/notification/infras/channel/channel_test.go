@@ -0,0 +1,120 @@
+package channel
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"simple-securities/internal/notification/application/delivery"
+	"simple-securities/internal/notification/domain/model"
+)
+
+func TestWebhookSignsRequests(t *testing.T) {
+	status := http.StatusNoContent
+	var verified bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(HeaderWebhookTimestamp), 10, 64)
+		verified = r.Header.Get(HeaderWebhookSignature) == Sign("user-secret", timestamp, body) &&
+			r.Header.Get(HeaderWebhookID) == "uuid-1"
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	webhook := NewWebhookChannel(WebhookConfig{Secret: "default-secret"})
+	to := &model.UserChannel{Channel: model.ChannelWebhook, Address: server.URL, Secret: "user-secret"}
+	notification := &model.NotificationCreated{ID: 1, Uuid: "uuid-1", UserID: 7, Title: "Filled"}
+
+	if err := webhook.Send(context.Background(), to, notification); err != nil || !verified {
+		t.Fatalf("Send() = %v, signature verified %t", err, verified)
+	}
+
+	// Receivers refusing the request are not retried, throttling and unavailable ones are
+	for code, permanent := range map[int]bool{
+		http.StatusGone:               true,
+		http.StatusTooManyRequests:    false,
+		http.StatusServiceUnavailable: false,
+	} {
+		status = code
+		err := webhook.Send(context.Background(), to, notification)
+		if err == nil || delivery.IsPermanent(err) != permanent {
+			t.Errorf("Send() answered %d = %v, want an error permanent %t", code, err, permanent)
+		}
+	}
+
+	to.Address = "ftp://example.com"
+	if err := webhook.Send(context.Background(), to, notification); !delivery.IsPermanent(err) {
+		t.Errorf("Send() to %s = %v, want a permanent error", to.Address, err)
+	}
+}
+
+// TestPushEncryptRoundTrip decrypts the payload the way the browser of the subscription does
+func TestPushEncryptRoundTrip(t *testing.T) {
+	userKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		t.Fatal(err)
+	}
+	subscription := &PushSubscription{Endpoint: "https://push.example.com/send/1"}
+	subscription.Keys.P256dh = base64.RawURLEncoding.EncodeToString(userKey.PublicKey().Bytes())
+	subscription.Keys.Auth = base64.URLEncoding.EncodeToString(authSecret)
+
+	payload := []byte(`{"title":"Filled"}`)
+	body, err := Encrypt(subscription, payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	salt, rs, keyLen := body[:16], binary.BigEndian.Uint32(body[16:20]), int(body[20])
+	if rs != recordSize || keyLen != 65 {
+		t.Fatalf("header record size %d, key id length %d", rs, keyLen)
+	}
+	serverPublic := body[21 : 21+keyLen]
+	serverKey, err := ecdh.P256().NewPublicKey(serverPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sharedSecret, err := userKey.ECDH(serverKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyInfo := "WebPush: info\x00" + string(userKey.PublicKey().Bytes()) + string(serverPublic)
+	ikm, _ := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	prk, _ := hkdf.Extract(sha256.New, ikm, salt)
+	contentKey, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	nonce, _ := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, body[21+keyLen:], nil)
+	if err != nil {
+		t.Fatalf("decrypt: %v", err)
+	}
+	if string(plaintext) != string(payload)+"\x02" {
+		t.Errorf("decrypted %q, want the payload and the last record delimiter", plaintext)
+	}
+
+	if _, err := Encrypt(subscription, make([]byte, recordSize)); err == nil {
+		t.Error("Encrypt() of a payload larger than a record succeeded")
+	}
+}
@@ -0,0 +1,62 @@
+package channel
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"simple-securities/internal/notification/application/delivery"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/pkg/discord"
+	"sync"
+)
+
+type DiscordConfig struct {
+	UserName  string
+	AvatarUrl string
+}
+
+// DiscordChannel posts notifications to the Discord webhook of the user, the clients are kept
+// per webhook to reuse their connections
+type DiscordChannel struct {
+	config    DiscordConfig
+	newClient func(discord.Config) discord.Client
+
+	mu      sync.Mutex
+	clients map[string]discord.Client
+}
+
+func NewDiscordChannel(config DiscordConfig) *DiscordChannel {
+	return &DiscordChannel{
+		config:    config,
+		newClient: discord.NewClient,
+		clients:   make(map[string]discord.Client),
+	}
+}
+
+func (c *DiscordChannel) Name() model.Channel {
+	return model.ChannelDiscord
+}
+
+func (c *DiscordChannel) Send(ctx context.Context, to *model.UserChannel, notification *model.NotificationCreated) error {
+	target, err := url.Parse(to.Address)
+	if err != nil || target.Scheme != "https" || target.Host == "" {
+		return delivery.Permanent(fmt.Errorf("invalid Discord webhook URL %q", to.Address))
+	}
+	return c.client(to.Address).SendWithPooling(fmt.Sprintf("**%s**\n%s", notification.Title, notification.Body))
+}
+
+func (c *DiscordChannel) client(webhookUrl string) discord.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	client, ok := c.clients[webhookUrl]
+	if !ok {
+		client = c.newClient(discord.Config{
+			UserName:   c.config.UserName,
+			AvatarUrl:  c.config.AvatarUrl,
+			WebhookUrl: webhookUrl,
+		})
+		c.clients[webhookUrl] = client
+	}
+	return client
+}
@@ -0,0 +1,96 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"simple-securities/internal/notification/application/delivery"
+	"simple-securities/internal/notification/domain/model"
+	"strconv"
+	"time"
+)
+
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender address, it may carry a name: "Simple Securities <noreply@example.com>"
+	From string
+}
+
+// EmailChannel sends notifications as plain text mails through an SMTP server
+type EmailChannel struct {
+	config   EmailConfig
+	from     *mail.Address
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewEmailChannel(config EmailConfig) (*EmailChannel, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("email channel needs an SMTP host")
+	}
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email sender %q: %w", config.From, err)
+	}
+	return &EmailChannel{config: config, from: from, sendMail: smtp.SendMail}, nil
+}
+
+func (c *EmailChannel) Name() model.Channel {
+	return model.ChannelEmail
+}
+
+func (c *EmailChannel) Send(ctx context.Context, to *model.UserChannel, notification *model.NotificationCreated) error {
+	recipient, err := mail.ParseAddress(to.Address)
+	if err != nil {
+		return delivery.Permanent(fmt.Errorf("invalid email address: %w", err))
+	}
+	msg, err := c.message(recipient, notification)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	return c.sendMail(addr, auth, c.from.Address, []string{recipient.Address}, msg)
+}
+
+// message builds the mail, the subject is encoded so that no title can add headers
+func (c *EmailChannel) message(to *mail.Address, notification *model.NotificationCreated) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := [][2]string{
+		{"From", c.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", notification.Title)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", notification.Uuid, c.config.Host)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", `text/plain; charset="utf-8"`},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&buf, "%s: %s\r\n", header[0], header[1])
+	}
+	buf.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write([]byte(notification.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
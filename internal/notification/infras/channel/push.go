@@ -0,0 +1,242 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"simple-securities/internal/notification/application/delivery"
+	"simple-securities/internal/notification/domain/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recordSize is the aes128gcm record size, a push payload fits in one record
+const recordSize = 4096
+
+type PushConfig struct {
+	// VAPIDPrivateKey is the raw P-256 private key identifying the service to the push
+	// services, base64url encoded
+	VAPIDPrivateKey string
+	// Subject is the contact of the service, a mailto: or https: URL
+	Subject string
+	// TTL is how long a push service keeps a message for an offline browser
+	TTL     time.Duration
+	Timeout time.Duration
+}
+
+// PushSubscription is the subscription a browser hands out from PushManager.subscribe, stored
+// as the address of the push channel
+type PushSubscription struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// PushChannel sends Web Push messages (RFC 8030) with VAPID authentication (RFC 8292) and
+// payloads encrypted for the browser (RFC 8291)
+type PushChannel struct {
+	config     PushConfig
+	vapidKey   *ecdsa.PrivateKey
+	publicKey  string
+	httpClient *http.Client
+}
+
+func NewPushChannel(config PushConfig) (*PushChannel, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(config.VAPIDPrivateKey, "="))
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	vapidKey, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+	publicKey, err := vapidKey.PublicKey.ECDH()
+	if err != nil {
+		return nil, err
+	}
+	if config.Subject == "" {
+		return nil, fmt.Errorf("push channel needs a VAPID subject")
+	}
+	if config.TTL <= 0 {
+		config.TTL = 24 * time.Hour
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &PushChannel{
+		config:     config,
+		vapidKey:   vapidKey,
+		publicKey:  base64.RawURLEncoding.EncodeToString(publicKey.Bytes()),
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}, nil
+}
+
+func (c *PushChannel) Name() model.Channel {
+	return model.ChannelPush
+}
+
+func (c *PushChannel) Send(ctx context.Context, to *model.UserChannel, notification *model.NotificationCreated) error {
+	var subscription PushSubscription
+	if err := json.Unmarshal([]byte(to.Address), &subscription); err != nil {
+		return delivery.Permanent(fmt.Errorf("invalid push subscription: %w", err))
+	}
+	endpoint, err := url.Parse(subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return delivery.Permanent(fmt.Errorf("invalid push endpoint %q", subscription.Endpoint))
+	}
+
+	payload, err := json.Marshal(map[string]any{
+		"id":    notification.ID,
+		"type":  notification.Type,
+		"title": notification.Title,
+		"body":  notification.Body,
+	})
+	if err != nil {
+		return err
+	}
+	body, err := Encrypt(&subscription, payload)
+	if err != nil {
+		return delivery.Permanent(err)
+	}
+	token, err := c.vapidToken(endpoint)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(c.config.TTL.Seconds())))
+	req.Header.Set("Authorization", "vapid t="+token+", k="+c.publicKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	// 404 and 410 are a subscription the browser dropped
+	return statusError("push", resp)
+}
+
+// vapidToken is the ES256 JWT granting the push service of the endpoint to the service
+func (c *PushChannel) vapidToken(endpoint *url.URL) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]any{
+		"aud": endpoint.Scheme + "://" + endpoint.Host,
+		"exp": time.Now().Add(12 * time.Hour).Unix(),
+		"sub": c.config.Subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	digest := sha256.Sum256([]byte(unsigned))
+	r, s, err := ecdsa.Sign(rand.Reader, c.vapidKey, digest[:])
+	if err != nil {
+		return "", err
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Encrypt encrypts the payload for the browser of the subscription into a single aes128gcm
+// record (RFC 8188) keyed as RFC 8291 sets
+func Encrypt(subscription *PushSubscription, payload []byte) ([]byte, error) {
+	userPublic, err := decodeKey(subscription.Keys.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	authSecret, err := decodeKey(subscription.Keys.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+	userKey, err := ecdh.P256().NewPublicKey(userPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+	// Push services take 4096 bytes: the 86 bytes of header, the payload, its delimiter and tag
+	if 86+len(payload)+1+aes.BlockSize > recordSize {
+		return nil, fmt.Errorf("push payload of %d bytes is too large", len(payload))
+	}
+
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	sharedSecret, err := serverKey.ECDH(userKey)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	keyInfo := "WebPush: info\x00" + string(userPublic) + string(serverPublic)
+	ikm, err := hkdf.Key(sha256.New, sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	contentKey, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(contentKey)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Header: salt, record size, key id length and the key id, the public key of the server
+	header := make([]byte, 0, 16+4+1+len(serverPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(serverPublic)))
+	header = append(header, serverPublic...)
+
+	// The 0x02 delimiter marks the last record
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// decodeKey decodes the base64url keys of a subscription, with or without padding
+func decodeKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
@@ -0,0 +1,112 @@
+package channel
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"simple-securities/internal/notification/application/delivery"
+	"simple-securities/internal/notification/domain/model"
+	"strconv"
+	"time"
+)
+
+// Headers of the webhook requests
+const (
+	HeaderWebhookID        = "X-Webhook-Id"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+type WebhookConfig struct {
+	// Secret signs the deliveries to the webhooks registered without their own secret
+	Secret  string
+	Timeout time.Duration
+}
+
+// WebhookChannel posts notifications as JSON to the URL of the user. Every request is signed
+// with HMAC-SHA256 over "<timestamp>.<body>", receivers check the signature and reject old
+// timestamps against replays.
+type WebhookChannel struct {
+	config     WebhookConfig
+	httpClient *http.Client
+}
+
+func NewWebhookChannel(config WebhookConfig) *WebhookChannel {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &WebhookChannel{
+		config:     config,
+		httpClient: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+func (c *WebhookChannel) Name() model.Channel {
+	return model.ChannelWebhook
+}
+
+// Sign is the signature of a webhook request
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *WebhookChannel) Send(ctx context.Context, to *model.UserChannel, notification *model.NotificationCreated) error {
+	target, err := url.Parse(to.Address)
+	if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+		return delivery.Permanent(fmt.Errorf("invalid webhook URL %q", to.Address))
+	}
+	secret := to.Secret
+	if secret == "" {
+		secret = c.config.Secret
+	}
+	if secret == "" {
+		return delivery.Permanent(fmt.Errorf("webhook has no signing secret"))
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, notification.Uuid)
+	req.Header.Set(HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderWebhookSignature, Sign(secret, timestamp, body))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	return statusError("webhook", resp)
+}
+
+// statusError tells a failed response apart: the receiver refusing the request is permanent,
+// throttling and server errors may pass later
+func statusError(name string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err := fmt.Errorf("%s failed with status %s", name, resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode != http.StatusRequestTimeout {
+		return delivery.Permanent(err)
+	}
+	return err
+}
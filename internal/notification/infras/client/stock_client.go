@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	stock "simple-securities/gen/stock/v1"
+	"simple-securities/internal/notification/domain/repo"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type StockClient struct {
+	conn   *grpc.ClientConn
+	client stock.StockServiceClient
+}
+
+// NewStockClient connects to the stock service at addr
+func NewStockClient(addr string) (*StockClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock client: %w", err)
+	}
+	return &StockClient{
+		conn:   conn,
+		client: stock.NewStockServiceClient(conn),
+	}, nil
+}
+
+var _ repo.IStockClient = (*StockClient)(nil)
+
+func (c *StockClient) ListHolders(ctx context.Context, symbol string, afterUserId uint64, limit uint32) ([]uint64, uint64, error) {
+	resp, err := c.client.ListHolders(ctx, &stock.ListHoldersRequest{
+		Symbol:      symbol,
+		AfterUserId: afterUserId,
+		Limit:       limit,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return resp.UserIds, resp.Total, nil
+}
+
+func (c *StockClient) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,275 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type BroadcastRepo struct {
+	db *sqlx.DB
+}
+
+func NewBroadcastRepo(db *sqlx.DB) repo.IBroadcastRepo {
+	return &BroadcastRepo{db: db}
+}
+
+const broadcastColumns = `
+	id, audience, user_ids, symbol, type, title, body, template_id, locale, variables,
+	status, total, sent, failed, cursor, last_error, lease_id, created_at, started_at, finished_at,
+	updated_at
+`
+
+// broadcastRow maps the JSON columns of notification_broadcast_jobs onto the domain model
+type broadcastRow struct {
+	ID         uint64     `db:"id"`
+	Audience   string     `db:"audience"`
+	UserIDs    string     `db:"user_ids"`
+	Symbol     string     `db:"symbol"`
+	Type       string     `db:"type"`
+	Title      string     `db:"title"`
+	Body       string     `db:"body"`
+	TemplateID string     `db:"template_id"`
+	Locale     string     `db:"locale"`
+	Variables  string     `db:"variables"`
+	Status     string     `db:"status"`
+	Total      uint64     `db:"total"`
+	Sent       uint64     `db:"sent"`
+	Failed     uint64     `db:"failed"`
+	Cursor     uint64     `db:"cursor"`
+	LastError  string     `db:"last_error"`
+	LeaseID    uint64     `db:"lease_id"`
+	CreatedAt  time.Time  `db:"created_at"`
+	StartedAt  *time.Time `db:"started_at"`
+	FinishedAt *time.Time `db:"finished_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+}
+
+func (row *broadcastRow) toModel() (*model.BroadcastJob, error) {
+	job := &model.BroadcastJob{
+		ID:         row.ID,
+		Audience:   model.BroadcastAudience(row.Audience),
+		Symbol:     row.Symbol,
+		Type:       row.Type,
+		Title:      row.Title,
+		Body:       row.Body,
+		TemplateID: row.TemplateID,
+		Locale:     row.Locale,
+		Status:     model.BroadcastStatus(row.Status),
+		Total:      row.Total,
+		Sent:       row.Sent,
+		Failed:     row.Failed,
+		Cursor:     row.Cursor,
+		LastError:  row.LastError,
+		LeaseID:    row.LeaseID,
+		CreatedAt:  row.CreatedAt,
+		StartedAt:  row.StartedAt,
+		FinishedAt: row.FinishedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+	if err := json.Unmarshal([]byte(row.UserIDs), &job.UserIDs); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(row.Variables), &job.Variables); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Create stores a new pending job
+func (r *BroadcastRepo) Create(ctx context.Context, job *model.BroadcastJob) (*model.BroadcastJob, error) {
+	userIDs, err := json.Marshal(job.UserIDs)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := json.Marshal(job.Variables)
+	if err != nil {
+		return nil, err
+	}
+	if job.UserIDs == nil {
+		userIDs = []byte("[]")
+	}
+	if job.Variables == nil {
+		variables = []byte("{}")
+	}
+
+	query := `
+		INSERT INTO notification_broadcast_jobs (
+			audience, user_ids, symbol, type, title, body, template_id, locale, variables,
+			status, total, created_at, updated_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $12)
+		RETURNING id
+	`
+	now := time.Now()
+	var id uint64
+	err = r.db.GetContext(ctx, &id, query,
+		job.Audience, string(userIDs), job.Symbol, job.Type, job.Title, job.Body,
+		job.TemplateID, job.Locale, string(variables), model.BroadcastPending, job.Total, now)
+	if err != nil {
+		return nil, err
+	}
+	return r.GetByID(ctx, id)
+}
+
+// GetByID fetches a job by ID
+func (r *BroadcastRepo) GetByID(ctx context.Context, id uint64) (*model.BroadcastJob, error) {
+	query := `SELECT ` + broadcastColumns + ` FROM notification_broadcast_jobs WHERE id = $1`
+	var row broadcastRow
+	if err := r.db.GetContext(ctx, &row, query, id); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return row.toModel()
+}
+
+// Claim starts or takes over the oldest job to send
+func (r *BroadcastRepo) Claim(ctx context.Context, lockedUntil time.Time) (*model.BroadcastJob, error) {
+	var job *model.BroadcastJob
+	err := withTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		now := time.Now()
+		query := `SELECT ` + broadcastColumns + `
+			FROM notification_broadcast_jobs
+			WHERE status = $1
+				OR (status = $2 AND (locked_until IS NULL OR julianday(locked_until) <= julianday($3)))
+			ORDER BY id
+			LIMIT 1
+		`
+		var row broadcastRow
+		err := tx.GetContext(ctx, &row, query, model.BroadcastPending, model.BroadcastRunning, now)
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			UPDATE notification_broadcast_jobs
+			SET status = $1, started_at = COALESCE(started_at, $2), lease_id = lease_id + 1,
+				locked_until = $3, updated_at = $2
+			WHERE id = $4
+		`, model.BroadcastRunning, now, lockedUntil, row.ID)
+		if err != nil {
+			return err
+		}
+		if row.StartedAt == nil {
+			row.StartedAt = &now
+		}
+		row.Status, row.LeaseID, row.UpdatedAt = string(model.BroadcastRunning), row.LeaseID+1, now
+		job, err = row.toModel()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// SaveProgress stores the progress of a running job, as long as its claim holds
+func (r *BroadcastRepo) SaveProgress(ctx context.Context, job *model.BroadcastJob, lockedUntil time.Time) error {
+	return r.update(ctx, job, model.BroadcastRunning, &lockedUntil)
+}
+
+// Finish closes a running job with its final counts
+func (r *BroadcastRepo) Finish(ctx context.Context, job *model.BroadcastJob, status model.BroadcastStatus) error {
+	return r.update(ctx, job, status, nil)
+}
+
+// update stores the progress of the job under its claim. A job cancelled meanwhile keeps its
+// status and gets the counts of what was sent before the runner noticed.
+func (r *BroadcastRepo) update(
+	ctx context.Context,
+	job *model.BroadcastJob,
+	status model.BroadcastStatus,
+	lockedUntil *time.Time,
+) error {
+	now := time.Now()
+	var finishedAt *time.Time
+	if status.Finished() {
+		finishedAt = &now
+	}
+	var saved struct {
+		Status     model.BroadcastStatus `db:"status"`
+		FinishedAt *time.Time            `db:"finished_at"`
+	}
+	err := r.db.GetContext(ctx, &saved, `
+		UPDATE notification_broadcast_jobs
+		SET status = CASE WHEN status = $1 THEN $2 ELSE status END,
+			total = $3, sent = $4, failed = $5, cursor = $6, last_error = $7,
+			locked_until = $8, finished_at = COALESCE(finished_at, $9), updated_at = $10
+		WHERE id = $11 AND lease_id = $12 AND status IN ($1, $13)
+		RETURNING status, finished_at
+	`, model.BroadcastRunning, status, job.Total, job.Sent, job.Failed, job.Cursor, job.LastError,
+		lockedUntil, finishedAt, now, job.ID, job.LeaseID, model.BroadcastCancelled)
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return model.ErrBroadcastNotRunning
+	}
+	if err != nil {
+		return err
+	}
+	job.Status, job.FinishedAt, job.UpdatedAt = saved.Status, saved.FinishedAt, now
+	if saved.Status == model.BroadcastCancelled {
+		return model.ErrBroadcastNotRunning
+	}
+	return nil
+}
+
+// Cancel stops a job that is not finished
+func (r *BroadcastRepo) Cancel(ctx context.Context, id uint64) (bool, error) {
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notification_broadcast_jobs
+		SET status = $1, locked_until = NULL, finished_at = $2, updated_at = $2
+		WHERE id = $3 AND status IN ($4, $5)
+	`, model.BroadcastCancelled, now, id, model.BroadcastPending, model.BroadcastRunning)
+	if err != nil {
+		return false, err
+	}
+	if err := requireRow(result); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
+
+// ListUsers fetches a page of the users with notifications, channels or preferences
+func (r *BroadcastRepo) ListUsers(ctx context.Context, afterUserId uint64, limit int) ([]uint64, error) {
+	query := `
+		SELECT user_id FROM notifications WHERE user_id > $1
+		UNION
+		SELECT user_id FROM notification_channels WHERE user_id > $1
+		UNION
+		SELECT user_id FROM notification_preferences WHERE user_id > $1
+		ORDER BY user_id
+		LIMIT $2
+	`
+	var userIds []uint64
+	if err := r.db.SelectContext(ctx, &userIds, query, afterUserId, limit); err != nil {
+		return nil, err
+	}
+	return userIds, nil
+}
+
+// CountUsers counts the users with notifications, channels or preferences
+func (r *BroadcastRepo) CountUsers(ctx context.Context) (uint64, error) {
+	query := `
+		SELECT COUNT(*) FROM (
+			SELECT user_id FROM notifications
+			UNION
+			SELECT user_id FROM notification_channels
+			UNION
+			SELECT user_id FROM notification_preferences
+		)
+	`
+	var count uint64
+	err := r.db.GetContext(ctx, &count, query)
+	return count, err
+}
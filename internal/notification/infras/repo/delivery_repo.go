@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type UserChannelRepo struct {
+	db *sqlx.DB
+}
+
+func NewUserChannelRepo(db *sqlx.DB) repo.IUserChannelRepo {
+	return &UserChannelRepo{db: db}
+}
+
+// Upsert creates the channel of a user or updates it
+func (r *UserChannelRepo) Upsert(ctx context.Context, channel *model.UserChannel) error {
+	now := time.Now()
+	query := `
+		INSERT INTO notification_channels (user_id, channel, address, secret, enabled, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $6)
+		ON CONFLICT (user_id, channel) DO UPDATE SET
+			address    = excluded.address,
+			secret     = excluded.secret,
+			enabled    = excluded.enabled,
+			updated_at = excluded.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		channel.UserID, channel.Channel, channel.Address, channel.Secret, channel.Enabled, now)
+	return err
+}
+
+// GetByUserId fetches the channels of a user
+func (r *UserChannelRepo) GetByUserId(ctx context.Context, userId uint64) ([]*model.UserChannel, error) {
+	query := `
+		SELECT user_id, channel, address, secret, enabled, created_at, updated_at
+		FROM notification_channels
+		WHERE user_id = $1
+		ORDER BY channel
+	`
+	var channels []*model.UserChannel
+	if err := r.db.SelectContext(ctx, &channels, query, userId); err != nil {
+		return nil, err
+	}
+	return channels, nil
+}
+
+const deliveryColumns = `
+	id, notification_id, user_id, channel, status, attempts, last_error,
+	delivered_at, deliver_after, created_at, updated_at
+`
+
+type DeliveryRepo struct {
+	db *sqlx.DB
+}
+
+func NewDeliveryRepo(db *sqlx.DB) repo.IDeliveryRepo {
+	return &DeliveryRepo{db: db}
+}
+
+// Start creates the pending delivery of a notification on a channel unless it exists and
+// returns it
+func (r *DeliveryRepo) Start(ctx context.Context, notificationId, userId uint64, channel model.Channel) (*model.Delivery, error) {
+	now := time.Now()
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO notification_deliveries (notification_id, user_id, channel, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (notification_id, channel) DO NOTHING
+	`, notificationId, userId, channel, model.DeliveryPending, now)
+	if err != nil {
+		return nil, err
+	}
+
+	var d model.Delivery
+	err = r.db.GetContext(ctx, &d, `SELECT `+deliveryColumns+`
+		FROM notification_deliveries
+		WHERE notification_id = $1 AND channel = $2
+	`, notificationId, channel)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Record stores the outcome of a delivery attempt
+func (r *DeliveryRepo) Record(ctx context.Context, id uint64, status model.DeliveryStatus, lastError string, at time.Time) error {
+	var attempt uint32 = 1
+	if status == model.DeliverySkipped {
+		attempt = 0
+	}
+	var deliveredAt *time.Time
+	if status == model.DeliverySent {
+		deliveredAt = &at
+	}
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notification_deliveries
+		SET
+			status       = $1,
+			attempts     = attempts + $2,
+			last_error   = $3,
+			delivered_at = COALESCE($4, delivered_at),
+			updated_at   = $5
+		WHERE id = $6
+	`, status, attempt, lastError, deliveredAt, at, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// Defer holds the delivery back until the instant, the attempt is not counted
+func (r *DeliveryRepo) Defer(ctx context.Context, id uint64, until time.Time, lastError string, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE notification_deliveries
+		SET
+			status        = $1,
+			last_error    = $2,
+			deliver_after = $3,
+			updated_at    = $4
+		WHERE id = $5
+	`, model.DeliveryDeferred, lastError, until, at, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// GetDue fetches the deferred deliveries due at the instant, the longest waiting first
+func (r *DeliveryRepo) GetDue(ctx context.Context, at time.Time, limit int) ([]*model.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+		FROM notification_deliveries
+		WHERE status = $1 AND julianday(deliver_after) <= julianday($2)
+		ORDER BY julianday(deliver_after), id
+		LIMIT $3
+	`
+	var deliveries []*model.Delivery
+	if err := r.db.SelectContext(ctx, &deliveries, query, model.DeliveryDeferred, at, limit); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetByNotificationId fetches the deliveries of a notification
+func (r *DeliveryRepo) GetByNotificationId(ctx context.Context, notificationId uint64) ([]*model.Delivery, error) {
+	query := `SELECT ` + deliveryColumns + `
+		FROM notification_deliveries
+		WHERE notification_id = $1
+		ORDER BY id
+	`
+	var deliveries []*model.Delivery
+	if err := r.db.SelectContext(ctx, &deliveries, query, notificationId); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,178 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type DigestRepo struct {
+	db           *sqlx.DB
+	outboxConfig OutboxConfig
+}
+
+// NewDigestRepo creates the digest repository, the summary notifications it stores have
+// their events written to the outbox like the others
+func NewDigestRepo(db *sqlx.DB, outboxConfig OutboxConfig) repo.IDigestRepo {
+	return &DigestRepo{db: db, outboxConfig: outboxConfig}
+}
+
+const digestColumns = `
+	id, user_id, frequency, status, window_end, item_count,
+	notification_id, emitted_at, created_at, updated_at
+`
+
+// AddItem adds the item to the open digest of the user or a new one
+func (r *DigestRepo) AddItem(
+	ctx context.Context,
+	frequency model.DigestFrequency,
+	windowEnd time.Time,
+	item *model.DigestItem,
+) (*model.Digest, error) {
+	var digest model.Digest
+	err := withTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		// A digest whose window ended is left to the scheduler, even when not emitted yet
+		query := `SELECT ` + digestColumns + `
+			FROM notification_digests
+			WHERE user_id = $1 AND status = $2 AND julianday(window_end) > julianday($3)
+			ORDER BY id DESC
+			LIMIT 1
+		`
+		err := tx.GetContext(ctx, &digest, query, item.UserID, model.DigestOpen, item.CreatedAt)
+		if stderrors.Is(err, sql.ErrNoRows) {
+			digest = model.Digest{
+				UserID:    item.UserID,
+				Frequency: frequency,
+				Status:    model.DigestOpen,
+				WindowEnd: windowEnd,
+				CreatedAt: item.CreatedAt,
+			}
+			err = tx.GetContext(ctx, &digest.ID, `
+				INSERT INTO notification_digests (user_id, frequency, status, window_end, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $5)
+				RETURNING id
+			`, digest.UserID, digest.Frequency, digest.Status, digest.WindowEnd, digest.CreatedAt)
+		}
+		if err != nil {
+			return err
+		}
+
+		item.DigestID = digest.ID
+		err = tx.GetContext(ctx, &item.ID, `
+			INSERT INTO notification_digest_items (digest_id, user_id, type, title, body, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id
+		`, item.DigestID, item.UserID, item.Type, item.Title, item.Body, item.CreatedAt)
+		if err != nil {
+			return err
+		}
+		digest.ItemCount++
+		digest.UpdatedAt = item.CreatedAt
+		_, err = tx.ExecContext(ctx, `
+			UPDATE notification_digests SET item_count = $1, updated_at = $2 WHERE id = $3
+		`, digest.ItemCount, digest.UpdatedAt, digest.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
+
+// GetDue fetches the open digests whose window ended
+func (r *DigestRepo) GetDue(ctx context.Context, at time.Time, limit int) ([]*model.Digest, error) {
+	query := `SELECT ` + digestColumns + `
+		FROM notification_digests
+		WHERE status = 'open' AND julianday(window_end) <= julianday($1)
+		ORDER BY julianday(window_end), id
+		LIMIT $2
+	`
+	var digests []*model.Digest
+	if err := r.db.SelectContext(ctx, &digests, query, at, limit); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// GetByID fetches a digest by ID
+func (r *DigestRepo) GetByID(ctx context.Context, id uint64) (*model.Digest, error) {
+	query := `SELECT ` + digestColumns + ` FROM notification_digests WHERE id = $1`
+	var digest model.Digest
+	if err := r.db.GetContext(ctx, &digest, query, id); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &digest, nil
+}
+
+// GetItems fetches the items of a digest in the order they came
+func (r *DigestRepo) GetItems(ctx context.Context, digestId uint64) ([]*model.DigestItem, error) {
+	query := `
+		SELECT id, digest_id, user_id, type, title, body, created_at
+		FROM notification_digest_items
+		WHERE digest_id = $1
+		ORDER BY id
+	`
+	var items []*model.DigestItem
+	if err := r.db.SelectContext(ctx, &items, query, digestId); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Emit closes the digest and stores its summary notification in one transaction, so that a
+// digest is emitted once whatever crashes or concurrent schedulers
+func (r *DigestRepo) Emit(
+	ctx context.Context,
+	digest *model.Digest,
+	items []*model.DigestItem,
+	summary *model.Notification,
+) (*model.Notification, error) {
+	n := model.NewNotification(summary.UserID, summary.Type, summary.Title, summary.Body)
+	now := time.Now()
+	err := withTransaction(ctx, r.db, func(tx *sqlx.Tx) error {
+		// Closing the digest first holds the write lock, no item can be added past the check
+		result, err := tx.ExecContext(ctx, `
+			UPDATE notification_digests
+			SET status = $1, emitted_at = $2, updated_at = $2
+			WHERE id = $3 AND status = $4
+		`, model.DigestEmitted, now, digest.ID, model.DigestOpen)
+		if err != nil {
+			return err
+		}
+		if err := requireRow(result); err != nil {
+			return model.ErrDigestChanged
+		}
+
+		var count, lastID uint64
+		err = tx.QueryRowxContext(ctx, `
+			SELECT COUNT(*), COALESCE(MAX(id), 0) FROM notification_digest_items WHERE digest_id = $1
+		`, digest.ID).Scan(&count, &lastID)
+		if err != nil {
+			return err
+		}
+		if count != uint64(len(items)) || (len(items) > 0 && items[len(items)-1].ID != lastID) {
+			return model.ErrDigestChanged
+		}
+
+		if err := insertNotification(ctx, tx, n, r.outboxConfig); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE notification_digests SET notification_id = $1 WHERE id = $2
+		`, n.ID, digest.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	digest.Status, digest.NotificationID, digest.EmittedAt = model.DigestEmitted, &n.ID, &now
+	return n, nil
+}
@@ -6,16 +6,49 @@ import (
 	"fmt"
 	"simple-securities/internal/notification/domain/model"
 	"simple-securities/internal/notification/domain/repo"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 )
 
+const (
+	defaultCacheTTL         = 10 * time.Minute
+	defaultCacheNegativeTTL = 30 * time.Second
+	defaultCachePageTTL     = time.Minute
+
+	// missingMarker is cached in place of a notification that does not exist
+	missingMarker = "-"
+	// scanCount is the number of keys asked per SCAN when invalidating the whole cache
+	scanCount = 500
+)
+
+// CacheConfig sets how long the cache keeps its entries, zero durations take the defaults
+type CacheConfig struct {
+	// TTL of a cached notification
+	TTL time.Duration
+	// NegativeTTL of the marker of a notification that does not exist, kept short as the id
+	// may be created later
+	NegativeTTL time.Duration
+	// PageTTL of a cached page of the inbox of a user
+	PageTTL time.Duration
+}
+
 type NotificationCacheRepo struct {
 	client *redis.Client
+	config CacheConfig
 }
 
-func NewNotificationCacheRepo(client *redis.Client) repo.INotificationCacheRepo {
-	return &NotificationCacheRepo{client: client}
+func NewNotificationCacheRepo(client *redis.Client, config CacheConfig) repo.INotificationCacheRepo {
+	if config.TTL <= 0 {
+		config.TTL = defaultCacheTTL
+	}
+	if config.NegativeTTL <= 0 {
+		config.NegativeTTL = defaultCacheNegativeTTL
+	}
+	if config.PageTTL <= 0 {
+		config.PageTTL = defaultCachePageTTL
+	}
+	return &NotificationCacheRepo{client: client, config: config}
 }
 
 func notificationKey(id uint64) string {
@@ -26,7 +59,17 @@ func userKey(userId uint64) string {
 	return fmt.Sprintf("user_notifications:%d", userId)
 }
 
-// Set stores a notification in Redis
+func pageKey(userId uint64, key string) string {
+	return fmt.Sprintf("user_notifications:%d:page:%s", userId, key)
+}
+
+// userPagesKey is the set of the page keys cached for a user
+func userPagesKey(userId uint64) string {
+	return fmt.Sprintf("user_notification_pages:%d", userId)
+}
+
+// Set stores a notification in Redis. The set of the ids cached for the user lives as long as
+// its newest notification.
 func (r *NotificationCacheRepo) Set(ctx context.Context, key uint64, notification *model.Notification) error {
 	data, err := json.Marshal(notification)
 	if err != nil {
@@ -34,79 +77,138 @@ func (r *NotificationCacheRepo) Set(ctx context.Context, key uint64, notificatio
 	}
 
 	pipe := r.client.TxPipeline()
-	pipe.Set(ctx, notificationKey(key), data, 0)
+	pipe.Set(ctx, notificationKey(key), data, r.config.TTL)
 	pipe.SAdd(ctx, userKey(notification.UserID), key)
+	pipe.Expire(ctx, userKey(notification.UserID), r.config.TTL)
 	_, err = pipe.Exec(ctx)
 	return err
 }
 
-// Get fetches a notification by ID
-func (r *NotificationCacheRepo) Get(ctx context.Context, id uint64) (*model.Notification, error) {
+// SetMissing caches that a notification does not exist
+func (r *NotificationCacheRepo) SetMissing(ctx context.Context, id uint64) error {
+	return r.client.Set(ctx, notificationKey(id), missingMarker, r.config.NegativeTTL).Err()
+}
+
+// Get fetches a notification by ID, hit is false when nothing is cached for it
+func (r *NotificationCacheRepo) Get(ctx context.Context, id uint64) (*model.Notification, bool, error) {
 	data, err := r.client.Get(ctx, notificationKey(id)).Bytes()
 	if err == redis.Nil {
-		return nil, nil // not found
+		return nil, false, nil // not found
 	} else if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if string(data) == missingMarker {
+		return nil, true, nil
 	}
 
 	var n model.Notification
 	if err := json.Unmarshal(data, &n); err != nil {
-		return nil, err
+		return nil, false, err
 	}
-	return &n, nil
+	return &n, true, nil
 }
 
 // Invalidate removes a notification by ID
 func (r *NotificationCacheRepo) Invalidate(ctx context.Context, id uint64) error {
 	// get notification first to know userID
-	n, err := r.Get(ctx, id)
+	n, _, err := r.Get(ctx, id)
 	if err != nil {
 		return err
 	}
-	if n == nil {
-		return nil
-	}
 
 	pipe := r.client.TxPipeline()
 	pipe.Del(ctx, notificationKey(id))
-	pipe.SRem(ctx, userKey(n.UserID), id)
+	if n != nil {
+		pipe.SRem(ctx, userKey(n.UserID), id)
+	}
 	_, err = pipe.Exec(ctx)
 	return err
 }
 
-// InvalidateByUserId removes all notifications for a given user
-func (r *NotificationCacheRepo) InvalidateByUserId(ctx context.Context, userId uint64) error {
-	ids, err := r.client.SMembers(ctx, userKey(userId)).Result()
+// SetPage stores a page of the notifications of a user
+func (r *NotificationCacheRepo) SetPage(ctx context.Context, userId uint64, key string, page *model.NotificationPage) error {
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, pageKey(userId, key), data, r.config.PageTTL)
+	pipe.SAdd(ctx, userPagesKey(userId), key)
+	pipe.Expire(ctx, userPagesKey(userId), r.config.PageTTL)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// GetPage fetches a page of the notifications of a user
+func (r *NotificationCacheRepo) GetPage(ctx context.Context, userId uint64, key string) (*model.NotificationPage, error) {
+	data, err := r.client.Get(ctx, pageKey(userId, key)).Bytes()
+	if err == redis.Nil {
+		return nil, nil // not found
+	} else if err != nil {
+		return nil, err
+	}
+
+	var page model.NotificationPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// InvalidatePages removes the cached pages of a user
+func (r *NotificationCacheRepo) InvalidatePages(ctx context.Context, userId uint64) error {
+	pages, err := r.client.SMembers(ctx, userPagesKey(userId)).Result()
 	if err != nil && err != redis.Nil {
 		return err
 	}
 
 	pipe := r.client.TxPipeline()
-	for _, idStr := range ids {
-		pipe.Del(ctx, fmt.Sprintf("notification:%s", idStr))
+	for _, key := range pages {
+		pipe.Del(ctx, pageKey(userId, key))
 	}
-	pipe.Del(ctx, userKey(userId))
+	pipe.Del(ctx, userPagesKey(userId))
 	_, err = pipe.Exec(ctx)
 	return err
 }
 
-// InvalidateAll clears the whole cache
-func (r *NotificationCacheRepo) InvalidateAll(ctx context.Context) error {
-	// Warning: KEYS is O(N), not recommended for very large datasets
-	keys, err := r.client.Keys(ctx, "notification:*").Result()
-	if err != nil {
+// InvalidateByUserId removes all notifications and pages for a given user
+func (r *NotificationCacheRepo) InvalidateByUserId(ctx context.Context, userId uint64) error {
+	ids, err := r.client.SMembers(ctx, userKey(userId)).Result()
+	if err != nil && err != redis.Nil {
 		return err
 	}
 
-	userKeys, err := r.client.Keys(ctx, "user_notifications:*").Result()
-	if err != nil {
+	pipe := r.client.TxPipeline()
+	for _, idStr := range ids {
+		pipe.Del(ctx, fmt.Sprintf("notification:%s", idStr))
+	}
+	pipe.Del(ctx, userKey(userId))
+	if _, err = pipe.Exec(ctx); err != nil {
 		return err
 	}
+	return r.InvalidatePages(ctx, userId)
+}
 
-	allKeys := append(keys, userKeys...)
-	if len(allKeys) > 0 {
-		if err := r.client.Del(ctx, allKeys...).Err(); err != nil {
-			return err
+// InvalidateAll clears the whole cache. SCAN walks the keyspace in steps so that Redis keeps
+// serving other clients, keys written during the walk may survive it.
+func (r *NotificationCacheRepo) InvalidateAll(ctx context.Context) error {
+	for _, pattern := range []string{"notification:*", "user_notifications:*", "user_notification_pages:*"} {
+		var cursor uint64
+		for {
+			keys, next, err := r.client.Scan(ctx, cursor, pattern, scanCount).Result()
+			if err != nil {
+				return err
+			}
+			if len(keys) > 0 {
+				if err := r.client.Del(ctx, keys...).Err(); err != nil {
+					return err
+				}
+			}
+			if next == 0 {
+				break
+			}
+			cursor = next
 		}
 	}
 	return nil
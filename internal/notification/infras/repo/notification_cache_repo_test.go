@@ -0,0 +1,151 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestCache(t *testing.T) (*miniredis.Miniredis, repo.INotificationCacheRepo) {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return server, NewNotificationCacheRepo(client, CacheConfig{
+		TTL:         time.Minute,
+		NegativeTTL: time.Second,
+		PageTTL:     10 * time.Second,
+	})
+}
+
+func testNotification(id, userId uint64) *model.Notification {
+	n := model.NewNotification(userId, "order", "Filled", "Your order is filled")
+	n.ID = id
+	return n
+}
+
+func TestCacheExpiresEntries(t *testing.T) {
+	server, cache := newTestCache(t)
+	ctx := context.Background()
+
+	if err := cache.Set(ctx, 1, testNotification(1, 7)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.SetMissing(ctx, 2); err != nil {
+		t.Fatal(err)
+	}
+	if n, hit, err := cache.Get(ctx, 1); err != nil || !hit || n == nil || n.UserID != 7 {
+		t.Fatalf("Get(1) = %+v, %t, %v", n, hit, err)
+	}
+	if n, hit, err := cache.Get(ctx, 2); err != nil || !hit || n != nil {
+		t.Fatalf("Get(2) of a missing notification = %+v, %t, %v, want a hit without notification", n, hit, err)
+	}
+	if _, hit, _ := cache.Get(ctx, 3); hit {
+		t.Fatal("Get(3) hit an id never cached")
+	}
+
+	// The missing marker goes first, then the notification and the set of ids of the user
+	server.FastForward(2 * time.Second)
+	if _, hit, _ := cache.Get(ctx, 2); hit {
+		t.Error("missing marker outlived the negative TTL")
+	}
+	if _, hit, _ := cache.Get(ctx, 1); !hit {
+		t.Error("notification expired before its TTL")
+	}
+	server.FastForward(time.Minute)
+	if _, hit, _ := cache.Get(ctx, 1); hit {
+		t.Error("notification outlived its TTL")
+	}
+	if server.Exists(userKey(7)) {
+		t.Error("set of the ids of the user outlived its notifications")
+	}
+}
+
+func TestCacheInvalidatesPages(t *testing.T) {
+	server, cache := newTestCache(t)
+	ctx := context.Background()
+
+	page := &model.NotificationPage{
+		Notifications: []*model.Notification{testNotification(1, 7)},
+		Next:          testNotification(1, 7).Cursor(),
+		Total:         2,
+		Unread:        1,
+	}
+	for _, key := range []string{"first", "second"} {
+		if err := cache.SetPage(ctx, 7, key, page); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cache.SetPage(ctx, 8, "first", page); err != nil {
+		t.Fatal(err)
+	}
+	got, err := cache.GetPage(ctx, 7, "first")
+	if err != nil || got == nil || got.Total != 2 || got.Next == nil || got.Next.ID != 1 || len(got.Notifications) != 1 {
+		t.Fatalf("GetPage() = %+v, %v", got, err)
+	}
+
+	if err := cache.InvalidatePages(ctx, 7); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"first", "second"} {
+		if got, _ := cache.GetPage(ctx, 7, key); got != nil {
+			t.Errorf("page %s of the user survived the invalidation", key)
+		}
+	}
+	if got, _ := cache.GetPage(ctx, 8, "first"); got == nil {
+		t.Error("page of another user was invalidated")
+	}
+
+	// Dropping a user drops its notifications and pages
+	if err := cache.Set(ctx, 1, testNotification(1, 8)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.InvalidateByUserId(ctx, 8); err != nil {
+		t.Fatal(err)
+	}
+	if _, hit, _ := cache.Get(ctx, 1); hit {
+		t.Error("notification of the user survived the invalidation")
+	}
+	if got, _ := cache.GetPage(ctx, 8, "first"); got != nil {
+		t.Error("page of the user survived the invalidation")
+	}
+	if keys := server.Keys(); len(keys) != 0 {
+		t.Errorf("keys left: %v", keys)
+	}
+}
+
+func TestCacheInvalidateAllScans(t *testing.T) {
+	server, cache := newTestCache(t)
+	ctx := context.Background()
+
+	// More keys than a SCAN step returns
+	for id := uint64(1); id <= 2*scanCount; id++ {
+		if err := cache.Set(ctx, id, testNotification(id, id%10)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cache.SetPage(ctx, 1, "first", &model.NotificationPage{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.Set("session:1", "kept"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cache.InvalidateAll(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if keys := server.Keys(); fmt.Sprint(keys) != "[session:1]" {
+		t.Errorf("keys left: %v, want only the keys of others", keys)
+	}
+}
@@ -2,9 +2,11 @@ package repo
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"simple-securities/internal/notification/domain/model"
 	"simple-securities/internal/notification/domain/repo"
+	"sort"
 	"sync"
 	"time"
 )
@@ -43,7 +45,7 @@ func (r *NotificationInmemRepo) Delete(ctx context.Context, id uint64) error {
 	defer r.mu.Unlock()
 
 	if _, ok := r.data[id]; !ok {
-		return errors.New("not found")
+		return sql.ErrNoRows
 	}
 	delete(r.data, id)
 	return nil
@@ -73,14 +75,19 @@ func (r *NotificationInmemRepo) GetByID(ctx context.Context, id uint64) (*model.
 	return nil, nil
 }
 
-// GetByUserId fetches notifications by User ID with pagination
-func (r *NotificationInmemRepo) GetByUserId(ctx context.Context, userId uint64, limit, offset uint32) ([]*model.Notification, error) {
+// GetByUserId fetches the notifications of a user matching the filter with pagination
+func (r *NotificationInmemRepo) GetByUserId(
+	ctx context.Context,
+	userId uint64,
+	filter model.NotificationFilter,
+	limit, offset uint32,
+) ([]*model.Notification, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	var res []*model.Notification
 	for _, n := range r.data {
-		if n.UserID == userId {
+		if matches(n, userId, filter) {
 			res = append(res, n)
 		}
 	}
@@ -106,3 +113,127 @@ func (r *NotificationInmemRepo) GetByUserId(ctx context.Context, userId uint64,
 
 	return res[start:end], nil
 }
+
+func matches(n *model.Notification, userId uint64, filter model.NotificationFilter) bool {
+	return n.UserID == userId &&
+		(filter.Read == nil || n.Read == *filter.Read) &&
+		(filter.Viewed == nil || n.Viewed == *filter.Viewed) &&
+		(filter.Type == "" || n.Type == filter.Type)
+}
+
+// GetPageByUserId fetches a page of notifications after the cursor, newest first
+func (r *NotificationInmemRepo) GetPageByUserId(
+	ctx context.Context,
+	userId uint64,
+	filter model.NotificationFilter,
+	after *model.PageCursor,
+	limit uint32,
+) (*model.NotificationPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var total, unread uint64
+	var res []*model.Notification
+	for _, n := range r.data {
+		if !matches(n, userId, filter) {
+			continue
+		}
+		total++
+		if !n.Read {
+			unread++
+		}
+		if after == nil || before(n.Cursor(), after) {
+			res = append(res, n)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return before(res[j].Cursor(), res[i].Cursor())
+	})
+	if len(res) > int(limit)+1 {
+		res = res[:limit+1]
+	}
+	return newPage(res, limit, total, unread), nil
+}
+
+// before tells whether the cursor a comes before b in the (CreatedAt, ID) order
+func before(a, b *model.PageCursor) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	return a.ID < b.ID
+}
+
+// MarkRead marks a notification read and viewed, keeping the times of earlier reads and views
+func (r *NotificationInmemRepo) MarkRead(ctx context.Context, id uint64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, ok := r.data[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	markRead(n, at)
+	return nil
+}
+
+// MarkViewed marks a notification viewed, keeping the time of an earlier view
+func (r *NotificationInmemRepo) MarkViewed(ctx context.Context, id uint64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n, ok := r.data[id]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	markViewed(n, at)
+	return nil
+}
+
+// MarkAllRead marks the unread notifications of a user read and viewed
+func (r *NotificationInmemRepo) MarkAllRead(ctx context.Context, userId uint64, at time.Time) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var updated uint64
+	for _, n := range r.data {
+		if n.UserID == userId && !n.Read {
+			markRead(n, at)
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+// CountUnread counts the unread and the unviewed notifications of a user
+func (r *NotificationInmemRepo) CountUnread(ctx context.Context, userId uint64) (*model.UnreadCount, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count model.UnreadCount
+	for _, n := range r.data {
+		if n.UserID != userId {
+			continue
+		}
+		if !n.Read {
+			count.Unread++
+		}
+		if !n.Viewed {
+			count.Unviewed++
+		}
+	}
+	return &count, nil
+}
+
+func markRead(n *model.Notification, at time.Time) {
+	if !n.Read {
+		n.Read, n.ReadAt = true, &at
+	}
+	markViewed(n, at)
+}
+
+func markViewed(n *model.Notification, at time.Time) {
+	if !n.Viewed {
+		n.Viewed, n.ViewedAt = true, &at
+	}
+	n.UpdatedAt = at
+}
@@ -6,23 +6,39 @@ import (
 	"fmt"
 	"simple-securities/internal/notification/domain/model"
 	"simple-securities/internal/notification/domain/repo"
+	"simple-securities/pkg/conv"
+	"simple-securities/pkg/outbox"
+	"strings"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	"golang.org/x/sync/singleflight"
 )
 
+// OutboxConfig names the service and the topic of the events the repository writes to the outbox
+type OutboxConfig struct {
+	ServiceName string
+	Topic       string
+}
+
 type NotificationRepo struct {
-	db *sqlx.DB
-	sf singleflight.Group
+	db           *sqlx.DB
+	sf           singleflight.Group
+	outboxConfig OutboxConfig
 }
 
-func NewNotificationRepo(db *sqlx.DB) repo.INotificationRepo {
-	return &NotificationRepo{db: db}
+func NewNotificationRepo(db *sqlx.DB, outboxConfig OutboxConfig) repo.INotificationRepo {
+	return &NotificationRepo{db: db, outboxConfig: outboxConfig}
 }
 
 // withTransaction runs fn inside a transaction with proper commit/rollback handling
-func (r *NotificationRepo) withTransaction(ctx context.Context, fn func(*sqlx.Tx) error) (err error) {
-	tx, err := r.db.BeginTxx(ctx, nil)
+func (r *NotificationRepo) withTransaction(ctx context.Context, fn func(*sqlx.Tx) error) error {
+	return withTransaction(ctx, r.db, fn)
+}
+
+// withTransaction runs fn inside a transaction of the database
+func withTransaction(ctx context.Context, db *sqlx.DB, fn func(*sqlx.Tx) error) (err error) {
+	tx, err := db.BeginTxx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -43,10 +59,24 @@ func (r *NotificationRepo) withTransaction(ctx context.Context, fn func(*sqlx.Tx
 	return err
 }
 
-// Create builds a new notification entity and persists it
+// Create builds a new notification entity and persists it together with its
+// NotificationCreated event in the outbox
 func (r *NotificationRepo) Create(ctx context.Context, noti *model.Notification) (*model.Notification, error) {
 	n := model.NewNotification(noti.UserID, noti.Type, noti.Title, noti.Body)
 
+	err := r.withTransaction(ctx, func(tx *sqlx.Tx) error {
+		return insertNotification(ctx, tx, n, r.outboxConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// insertNotification inserts the notification and enqueues its NotificationCreated event in
+// the transaction, setting its ID
+func insertNotification(ctx context.Context, tx *sqlx.Tx, n *model.Notification, outboxConfig OutboxConfig) error {
 	query := `
 		INSERT INTO notifications (
 			uuid, user_id, type, title, body,
@@ -60,30 +90,47 @@ func (r *NotificationRepo) Create(ctx context.Context, noti *model.Notification)
 		RETURNING id
 	`
 
-	err := r.withTransaction(ctx, func(tx *sqlx.Tx) error {
-		stmt, err := tx.PrepareNamedContext(ctx, query)
-		if err != nil {
-			return err
-		}
-		defer stmt.Close()
-
-		if err := stmt.GetContext(ctx, &n.ID, n); err != nil {
-			return err
-		}
-		return nil
-	})
+	stmt, err := tx.PrepareNamedContext(ctx, query)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	defer stmt.Close()
 
-	return n, nil
+	if err := stmt.GetContext(ctx, &n.ID, n); err != nil {
+		return err
+	}
+
+	msg, err := outbox.NewMessage(
+		outboxConfig.Topic,
+		conv.ConvertUInt64ToString(n.UserID),
+		outbox.NewEvent(outboxConfig.ServiceName, model.EventNotificationCreated, model.NewNotificationCreated(n)),
+	)
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(ctx, tx, msg)
 }
 
 // Delete removes a notification by ID
 func (r *NotificationRepo) Delete(ctx context.Context, id uint64) error {
 	query := `DELETE FROM notifications WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// requireRow returns sql.ErrNoRows when the statement changed no row
+func requireRow(result sql.Result) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
 }
 
 // Update modifies an existing notification
@@ -142,9 +189,41 @@ func (r *NotificationRepo) GetByID(ctx context.Context, id uint64) (*model.Notif
 	return v.(*model.Notification), nil
 }
 
-// GetByUserId fetches notifications by User ID with pagination (with singleflight)
-func (r *NotificationRepo) GetByUserId(ctx context.Context, userId uint64, limit, offset uint32) ([]*model.Notification, error) {
-	key := fmt.Sprintf("notification:user:%d:limit:%d:offset:%d", userId, limit, offset)
+// filterConditions builds the WHERE conditions and arguments selecting the notifications of a
+// user matching the filter, with the part of the singleflight key naming them
+func filterConditions(userId uint64, filter model.NotificationFilter) ([]string, []any, string) {
+	args := []any{userId}
+	conditions := []string{"user_id = $1"}
+	key := fmt.Sprintf("user:%d", userId)
+	if filter.Read != nil {
+		args = append(args, *filter.Read)
+		conditions = append(conditions, fmt.Sprintf("read = $%d", len(args)))
+		key += fmt.Sprintf(":read:%t", *filter.Read)
+	}
+	if filter.Viewed != nil {
+		args = append(args, *filter.Viewed)
+		conditions = append(conditions, fmt.Sprintf("viewed = $%d", len(args)))
+		key += fmt.Sprintf(":viewed:%t", *filter.Viewed)
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+		key += ":type:" + filter.Type
+	}
+	return conditions, args, key
+}
+
+// GetByUserId fetches the notifications of a user matching the filter with pagination (with singleflight)
+func (r *NotificationRepo) GetByUserId(
+	ctx context.Context,
+	userId uint64,
+	filter model.NotificationFilter,
+	limit, offset uint32,
+) ([]*model.Notification, error) {
+	conditions, args, filterKey := filterConditions(userId, filter)
+	key := fmt.Sprintf("notification:%s:limit:%d:offset:%d", filterKey, limit, offset)
+	args = append(args, limit, offset)
+	page := fmt.Sprintf("LIMIT $%d OFFSET $%d", len(args)-1, len(args))
 
 	v, err, _ := r.sf.Do(key, func() (interface{}, error) {
 		query := `
@@ -153,13 +232,12 @@ func (r *NotificationRepo) GetByUserId(ctx context.Context, userId uint64, limit
 				read, read_at, viewed, viewed_at,
 				created_at, updated_at, created_by, updated_by
 			FROM notifications
-			WHERE user_id = $1
+			WHERE ` + strings.Join(conditions, " AND ") + `
 			ORDER BY created_at DESC
-			LIMIT $2 OFFSET $3
-		`
+			` + page
 
 		var notifications []*model.Notification
-		err := r.db.SelectContext(ctx, &notifications, query, userId, limit, offset)
+		err := r.db.SelectContext(ctx, &notifications, query, args...)
 		if err != nil {
 			if err == sql.ErrNoRows {
 				return nil, nil
@@ -178,3 +256,151 @@ func (r *NotificationRepo) GetByUserId(ctx context.Context, userId uint64, limit
 
 	return v.([]*model.Notification), nil
 }
+
+// MarkRead marks a notification read and viewed, keeping the times of earlier reads and views
+func (r *NotificationRepo) MarkRead(ctx context.Context, id uint64, at time.Time) error {
+	query := `
+		UPDATE notifications
+		SET
+			read       = 1,
+			read_at    = COALESCE(read_at, $1),
+			viewed     = 1,
+			viewed_at  = COALESCE(viewed_at, $1),
+			updated_at = $1
+		WHERE id = $2
+	`
+	result, err := r.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// MarkViewed marks a notification viewed, keeping the time of an earlier view
+func (r *NotificationRepo) MarkViewed(ctx context.Context, id uint64, at time.Time) error {
+	query := `
+		UPDATE notifications
+		SET
+			viewed     = 1,
+			viewed_at  = COALESCE(viewed_at, $1),
+			updated_at = $1
+		WHERE id = $2
+	`
+	result, err := r.db.ExecContext(ctx, query, at, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(result)
+}
+
+// MarkAllRead marks the unread notifications of a user read and viewed
+func (r *NotificationRepo) MarkAllRead(ctx context.Context, userId uint64, at time.Time) (uint64, error) {
+	query := `
+		UPDATE notifications
+		SET
+			read       = 1,
+			read_at    = $1,
+			viewed     = 1,
+			viewed_at  = COALESCE(viewed_at, $1),
+			updated_at = $1
+		WHERE user_id = $2 AND read = 0
+	`
+	result, err := r.db.ExecContext(ctx, query, at, userId)
+	if err != nil {
+		return 0, err
+	}
+	n, err := result.RowsAffected()
+	return uint64(n), err
+}
+
+// CountUnread counts the unread and the unviewed notifications of a user on their partial indexes
+func (r *NotificationRepo) CountUnread(ctx context.Context, userId uint64) (*model.UnreadCount, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read = 0) AS unread,
+			(SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND viewed = 0) AS unviewed
+	`
+	var count model.UnreadCount
+	if err := r.db.GetContext(ctx, &count, query, userId); err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// GetPageByUserId fetches a page of notifications after the cursor (with singleflight). The
+// rows are ordered on julianday(created_at) as created_at holds times written with different
+// offsets and precisions, idx_notifications_user_created serves the order.
+func (r *NotificationRepo) GetPageByUserId(
+	ctx context.Context,
+	userId uint64,
+	filter model.NotificationFilter,
+	after *model.PageCursor,
+	limit uint32,
+) (*model.NotificationPage, error) {
+	conditions, args, filterKey := filterConditions(userId, filter)
+	key := fmt.Sprintf("notification:page:%s:limit:%d", filterKey, limit)
+
+	v, err, _ := r.sf.Do(key+afterKey(after), func() (interface{}, error) {
+		var counts struct {
+			Total  uint64 `db:"total"`
+			Unread uint64 `db:"unread"`
+		}
+		where := strings.Join(conditions, " AND ")
+		countQuery := `
+			SELECT COUNT(*) AS total, COALESCE(SUM(read = 0), 0) AS unread
+			FROM notifications
+			WHERE ` + where
+		if err := r.db.GetContext(ctx, &counts, countQuery, args...); err != nil {
+			return nil, err
+		}
+
+		pageArgs := args
+		if after != nil {
+			pageArgs = append(pageArgs, after.CreatedAt, after.ID)
+			where += fmt.Sprintf(" AND (julianday(created_at), id) < (julianday($%d), $%d)", len(pageArgs)-1, len(pageArgs))
+		}
+		// One more row than the page tells whether a next page exists
+		pageArgs = append(pageArgs, limit+1)
+		query := `
+			SELECT 
+				id, uuid, user_id, type, title, body,
+				read, read_at, viewed, viewed_at,
+				created_at, updated_at, created_by, updated_by
+			FROM notifications
+			WHERE ` + where + `
+			ORDER BY julianday(created_at) DESC, id DESC
+			` + fmt.Sprintf("LIMIT $%d", len(pageArgs))
+
+		var notifications []*model.Notification
+		if err := r.db.SelectContext(ctx, &notifications, query, pageArgs...); err != nil {
+			return nil, err
+		}
+		return newPage(notifications, limit, counts.Total, counts.Unread), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*model.NotificationPage), nil
+}
+
+func afterKey(after *model.PageCursor) string {
+	if after == nil {
+		return ""
+	}
+	return fmt.Sprintf(":after:%d:%d", after.CreatedAt.UnixNano(), after.ID)
+}
+
+// newPage cuts the rows fetched past the limit into a page, its cursor is set when more rows exist
+func newPage(notifications []*model.Notification, limit uint32, total, unread uint64) *model.NotificationPage {
+	page := &model.NotificationPage{Notifications: notifications, Total: total, Unread: unread}
+	if len(notifications) > int(limit) {
+		page.Notifications = notifications[:limit]
+		if limit > 0 {
+			page.Next = page.Notifications[limit-1].Cursor()
+		}
+	}
+	if page.Notifications == nil {
+		page.Notifications = []*model.Notification{}
+	}
+	return page
+}
@@ -0,0 +1,296 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// testNotificationRepo is a notification repo with a way to backdate its rows
+type testNotificationRepo struct {
+	name         string
+	repo         repo.INotificationRepo
+	setCreatedAt func(t *testing.T, id uint64, at time.Time)
+}
+
+func newTestNotificationRepos(t *testing.T) []testNotificationRepo {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "notification.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000001_init_notificationdb",
+		"000006_init_outbox",
+		"000009_init_notifications_page_index",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+
+	inmem := NewNotificationInmemRepo()
+	return []testNotificationRepo{
+		{
+			name: "sqlite",
+			repo: NewNotificationRepo(db, OutboxConfig{Topic: "notification-events"}),
+			setCreatedAt: func(t *testing.T, id uint64, at time.Time) {
+				db.MustExec(`UPDATE notifications SET created_at = $1 WHERE id = $2`, at, id)
+			},
+		},
+		{
+			name: "inmem",
+			repo: inmem,
+			setCreatedAt: func(t *testing.T, id uint64, at time.Time) {
+				n, err := inmem.GetByID(context.Background(), id)
+				if err != nil || n == nil {
+					t.Fatalf("GetByID(%d) = %+v, %v", id, n, err)
+				}
+				n.CreatedAt = at
+			},
+		},
+	}
+}
+
+func createNotification(t *testing.T, notiRepo repo.INotificationRepo, userId uint64, nType string) *model.Notification {
+	t.Helper()
+	n, err := notiRepo.Create(context.Background(), &model.Notification{UserID: userId, Type: nType, Title: "Filled"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+// pageIDs walks the pages of the notifications of a user and lists their IDs page by page
+func pageIDs(t *testing.T, notiRepo repo.INotificationRepo, userId uint64, filter model.NotificationFilter, limit uint32) string {
+	t.Helper()
+	var pages []string
+	var after *model.PageCursor
+	for {
+		page, err := notiRepo.GetPageByUserId(context.Background(), userId, filter, after, limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []uint64
+		for _, n := range page.Notifications {
+			ids = append(ids, n.ID)
+		}
+		pages = append(pages, fmt.Sprint(ids))
+		if page.Next == nil {
+			return fmt.Sprint(pages)
+		}
+		if len(pages) > 10 {
+			t.Fatalf("pages do not end: %v", pages)
+		}
+		after = page.Next
+	}
+}
+
+func TestPagesKeepRowsSharingCreatedAt(t *testing.T) {
+	for _, tc := range newTestNotificationRepos(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 5; i++ {
+				createNotification(t, tc.repo, 7, "order")
+			}
+			createNotification(t, tc.repo, 8, "order")
+
+			// 1, 2 and 3 share an instant, 2 written with another offset and a coarser precision
+			at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			tc.setCreatedAt(t, 1, at)
+			tc.setCreatedAt(t, 2, at.In(time.FixedZone("CEST", 2*60*60)))
+			tc.setCreatedAt(t, 3, at)
+			tc.setCreatedAt(t, 4, at.Add(-time.Second))
+			tc.setCreatedAt(t, 5, at.Add(time.Millisecond))
+
+			for limit, want := range map[uint32]string{
+				1: "[[5] [3] [2] [1] [4]]",
+				2: "[[5 3] [2 1] [4]]",
+				// A full last page has no next page
+				5: "[[5 3 2 1 4]]",
+				6: "[[5 3 2 1 4]]",
+			} {
+				if got := pageIDs(t, tc.repo, 7, model.NotificationFilter{}, limit); got != want {
+					t.Errorf("pages of %d = %s, want %s", limit, got, want)
+				}
+			}
+
+			page, err := tc.repo.GetPageByUserId(context.Background(), 7, model.NotificationFilter{}, nil, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if page.Total != 5 || page.Unread != 5 {
+				t.Errorf("page counts %d total and %d unread, want 5 and 5", page.Total, page.Unread)
+			}
+		})
+	}
+}
+
+func getNotification(t *testing.T, notiRepo repo.INotificationRepo, id uint64) *model.Notification {
+	t.Helper()
+	n, err := notiRepo.GetByID(context.Background(), id)
+	if err != nil || n == nil {
+		t.Fatalf("GetByID(%d) = %+v, %v", id, n, err)
+	}
+	return n
+}
+
+func sameTime(got *time.Time, want time.Time) bool {
+	return got != nil && got.Equal(want)
+}
+
+func TestMarkReadKeepsEarlierTimes(t *testing.T) {
+	for _, tc := range newTestNotificationRepos(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			n := createNotification(t, tc.repo, 7, "order")
+			viewedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			readAt := viewedAt.Add(time.Hour)
+
+			if err := tc.repo.MarkViewed(ctx, n.ID, viewedAt); err != nil {
+				t.Fatal(err)
+			}
+			// Reading marks the notification viewed, a second read keeps the first time
+			for _, at := range []time.Time{readAt, readAt.Add(time.Hour)} {
+				if err := tc.repo.MarkRead(ctx, n.ID, at); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := tc.repo.MarkViewed(ctx, n.ID, readAt.Add(2*time.Hour)); err != nil {
+				t.Fatal(err)
+			}
+			got := getNotification(t, tc.repo, n.ID)
+			if !got.Read || !got.Viewed || !sameTime(got.ReadAt, readAt) || !sameTime(got.ViewedAt, viewedAt) {
+				t.Errorf("notification read %t at %v, viewed %t at %v, want read at %s and viewed at %s",
+					got.Read, got.ReadAt, got.Viewed, got.ViewedAt, readAt, viewedAt)
+			}
+
+			// A read of a notification never viewed views it at the same time
+			other := createNotification(t, tc.repo, 7, "order")
+			if err := tc.repo.MarkRead(ctx, other.ID, readAt); err != nil {
+				t.Fatal(err)
+			}
+			if got := getNotification(t, tc.repo, other.ID); !got.Viewed || !sameTime(got.ViewedAt, readAt) {
+				t.Errorf("read notification viewed %t at %v, want viewed at %s", got.Viewed, got.ViewedAt, readAt)
+			}
+
+			for name, err := range map[string]error{
+				"MarkRead":   tc.repo.MarkRead(ctx, 99, readAt),
+				"MarkViewed": tc.repo.MarkViewed(ctx, 99, readAt),
+			} {
+				if !stderrors.Is(err, sql.ErrNoRows) {
+					t.Errorf("%s() of a missing notification = %v, want sql.ErrNoRows", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkAllReadTouchesUnreadOnly(t *testing.T) {
+	for _, tc := range newTestNotificationRepos(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			read := createNotification(t, tc.repo, 7, "order")
+			viewed := createNotification(t, tc.repo, 7, "order")
+			createNotification(t, tc.repo, 7, "alert")
+			createNotification(t, tc.repo, 8, "order")
+			earlier := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+			if err := tc.repo.MarkRead(ctx, read.ID, earlier); err != nil {
+				t.Fatal(err)
+			}
+			if err := tc.repo.MarkViewed(ctx, viewed.ID, earlier); err != nil {
+				t.Fatal(err)
+			}
+
+			if count, err := tc.repo.CountUnread(ctx, 7); err != nil || count.Unread != 2 || count.Unviewed != 1 {
+				t.Fatalf("CountUnread() = %+v, %v, want 2 unread and 1 unviewed", count, err)
+			}
+			updated, err := tc.repo.MarkAllRead(ctx, 7, earlier.Add(time.Hour))
+			if err != nil || updated != 2 {
+				t.Fatalf("MarkAllRead() = %d, %v, want the 2 unread", updated, err)
+			}
+			if got := getNotification(t, tc.repo, read.ID); !sameTime(got.ReadAt, earlier) {
+				t.Errorf("notification read earlier has its read time moved to %v", got.ReadAt)
+			}
+			if got := getNotification(t, tc.repo, viewed.ID); !sameTime(got.ViewedAt, earlier) {
+				t.Errorf("notification viewed earlier has its view time moved to %v", got.ViewedAt)
+			}
+
+			for userId, want := range map[uint64]model.UnreadCount{7: {}, 8: {Unread: 1, Unviewed: 1}} {
+				if count, err := tc.repo.CountUnread(ctx, userId); err != nil || *count != want {
+					t.Errorf("CountUnread(%d) = %+v, %v, want %+v", userId, count, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFiltersAndDelete(t *testing.T) {
+	for _, tc := range newTestNotificationRepos(t) {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			for i := 0; i < 4; i++ {
+				createNotification(t, tc.repo, 7, []string{"order", "alert"}[i%2])
+				tc.setCreatedAt(t, uint64(i+1), time.Date(2024, 3, 1, 12, i, 0, 0, time.UTC))
+			}
+			if err := tc.repo.MarkRead(ctx, 1, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+			if err := tc.repo.MarkViewed(ctx, 2, time.Now()); err != nil {
+				t.Fatal(err)
+			}
+
+			yes, no := true, false
+			for name, tt := range map[string]struct {
+				filter model.NotificationFilter
+				want   string
+			}{
+				"all":         {model.NotificationFilter{}, "[[4 3 2 1]]"},
+				"read":        {model.NotificationFilter{Read: &yes}, "[[1]]"},
+				"unread":      {model.NotificationFilter{Read: &no}, "[[4 3 2]]"},
+				"viewed":      {model.NotificationFilter{Viewed: &yes}, "[[2 1]]"},
+				"type":        {model.NotificationFilter{Type: "alert"}, "[[4 2]]"},
+				"unread+type": {model.NotificationFilter{Read: &no, Type: "order"}, "[[3]]"},
+			} {
+				if got := pageIDs(t, tc.repo, 7, tt.filter, 10); got != tt.want {
+					t.Errorf("%s pages = %s, want %s", name, got, tt.want)
+				}
+				notifications, err := tc.repo.GetByUserId(ctx, 7, tt.filter, 10, 0)
+				if err != nil {
+					t.Fatal(err)
+				}
+				var ids []uint64
+				for _, n := range notifications {
+					ids = append(ids, n.ID)
+				}
+				if got := fmt.Sprint([]string{fmt.Sprint(ids)}); got != tt.want {
+					t.Errorf("%s by offset = %s, want %s", name, got, tt.want)
+				}
+			}
+
+			if err := tc.repo.Delete(ctx, 3); err != nil {
+				t.Fatal(err)
+			}
+			if n, err := tc.repo.GetByID(ctx, 3); err != nil || n != nil {
+				t.Errorf("GetByID() of a deleted notification = %+v, %v", n, err)
+			}
+			if err := tc.repo.Delete(ctx, 3); !stderrors.Is(err, sql.ErrNoRows) {
+				t.Errorf("Delete() of a deleted notification = %v, want sql.ErrNoRows", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"simple-securities/internal/notification/domain/model"
+	"simple-securities/internal/notification/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type PreferencesRepo struct {
+	db *sqlx.DB
+}
+
+func NewPreferencesRepo(db *sqlx.DB) repo.IPreferencesRepo {
+	return &PreferencesRepo{db: db}
+}
+
+// preferencesRow maps the JSON and quiet hours columns of notification_preferences onto the
+// domain model
+type preferencesRow struct {
+	UserID          uint64    `db:"user_id"`
+	MutedTypes      string    `db:"muted_types"`
+	MutedChannels   string    `db:"muted_channels"`
+	QuietStart      string    `db:"quiet_start"`
+	QuietEnd        string    `db:"quiet_end"`
+	TimeZone        string    `db:"time_zone"`
+	DigestFrequency string    `db:"digest_frequency"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+func toPreferencesRow(p *model.Preferences) (*preferencesRow, error) {
+	mutedTypes, err := json.Marshal(p.MutedTypes)
+	if err != nil {
+		return nil, err
+	}
+	mutedChannels, err := json.Marshal(p.MutedChannels)
+	if err != nil {
+		return nil, err
+	}
+	row := &preferencesRow{
+		UserID:          p.UserID,
+		MutedTypes:      string(mutedTypes),
+		MutedChannels:   string(mutedChannels),
+		DigestFrequency: string(p.DigestFrequency),
+	}
+	if q := p.QuietHours; q != nil {
+		row.QuietStart, row.QuietEnd, row.TimeZone = q.Start, q.End, q.TimeZone
+	}
+	return row, nil
+}
+
+func (row *preferencesRow) toModel() (*model.Preferences, error) {
+	p := model.NewPreferences(row.UserID)
+	if err := json.Unmarshal([]byte(row.MutedTypes), &p.MutedTypes); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(row.MutedChannels), &p.MutedChannels); err != nil {
+		return nil, err
+	}
+	if row.QuietStart != "" {
+		p.QuietHours = &model.QuietHours{Start: row.QuietStart, End: row.QuietEnd, TimeZone: row.TimeZone}
+	}
+	p.DigestFrequency = model.DigestFrequency(row.DigestFrequency)
+	p.CreatedAt, p.UpdatedAt = row.CreatedAt, row.UpdatedAt
+	return p, nil
+}
+
+// Get fetches the preferences of a user
+func (r *PreferencesRepo) Get(ctx context.Context, userId uint64) (*model.Preferences, error) {
+	query := `
+		SELECT user_id, muted_types, muted_channels, quiet_start, quiet_end, time_zone,
+			digest_frequency, created_at, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1
+	`
+	var row preferencesRow
+	if err := r.db.GetContext(ctx, &row, query, userId); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return row.toModel()
+}
+
+// Upsert creates the preferences of a user or replaces them
+func (r *PreferencesRepo) Upsert(ctx context.Context, preferences *model.Preferences) error {
+	row, err := toPreferencesRow(preferences)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	query := `
+		INSERT INTO notification_preferences (
+			user_id, muted_types, muted_channels, quiet_start, quiet_end, time_zone,
+			digest_frequency, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+		ON CONFLICT (user_id) DO UPDATE SET
+			muted_types      = excluded.muted_types,
+			muted_channels   = excluded.muted_channels,
+			quiet_start      = excluded.quiet_start,
+			quiet_end        = excluded.quiet_end,
+			time_zone        = excluded.time_zone,
+			digest_frequency = excluded.digest_frequency,
+			updated_at       = excluded.updated_at
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		row.UserID, row.MutedTypes, row.MutedChannels, row.QuietStart, row.QuietEnd, row.TimeZone,
+		row.DigestFrequency, now)
+	if err != nil {
+		return err
+	}
+	preferences.UpdatedAt = now
+	return nil
+}
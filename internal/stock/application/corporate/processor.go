@@ -0,0 +1,240 @@
+package corporate
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NotificationType is the notification type sent to holders of an adjusted position
+const NotificationType = "corporate_action"
+
+// errReadModelsBehind defers the actions of a run until the projector caught up
+var errReadModelsBehind = stderrors.New("read models are behind the event store")
+
+// Processor books the corporate actions of the calendar once their ex-date is reached. An action
+// is recorded by an event on its own stream that adjusts the holdings, and by an adjustment event
+// on the stream of every open order of the symbol, so that the aggregates and the read models
+// rebuilt from the event store both see it. The holdings are read from the read models while the
+// order store runs no other command, an action waits until the read models caught up.
+type Processor struct {
+	interval            time.Duration
+	location            *time.Location
+	corporateActionRepo repo.ICorporateActionRepo
+	accountRepo         repo.IAccountRepo
+	stockOrderRepo      repo.IStockOrderRepo
+	eventRepo           repo.IEventRepo
+	readModelRepo       repo.IReadModelRepo
+	orderStore          *order.Store
+	notificationClient  repo.INotificationClient
+	logger              *zap.Logger
+}
+
+// NewProcessor builds a processor that checks the calendar every interval, ex-dates are
+// compared with the current date in location
+func NewProcessor(
+	interval time.Duration,
+	location *time.Location,
+	corporateActionRepo repo.ICorporateActionRepo,
+	accountRepo repo.IAccountRepo,
+	stockOrderRepo repo.IStockOrderRepo,
+	eventRepo repo.IEventRepo,
+	readModelRepo repo.IReadModelRepo,
+	orderStore *order.Store,
+	notificationClient repo.INotificationClient,
+	logger *zap.Logger,
+) *Processor {
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	if location == nil {
+		location = time.UTC
+	}
+	return &Processor{
+		interval:            interval,
+		location:            location,
+		corporateActionRepo: corporateActionRepo,
+		accountRepo:         accountRepo,
+		stockOrderRepo:      stockOrderRepo,
+		eventRepo:           eventRepo,
+		readModelRepo:       readModelRepo,
+		orderStore:          orderStore,
+		notificationClient:  notificationClient,
+		logger:              logger,
+	}
+}
+
+// Start processes the due actions right away and then on every interval until ctx is cancelled
+func (p *Processor) Start(ctx context.Context) error {
+	p.logger.Info("🗓 corporate action processor started", zap.Duration("interval", p.interval))
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.Process(ctx, time.Now()); err != nil {
+			p.logger.Error("failed to process corporate actions", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("corporate action processor stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Process applies every pending action whose ex-date is on or before now and returns how many were booked.
+// A failing action is marked FAILED and does not block the others, while read models that are behind
+// leave the remaining actions pending for the next run.
+func (p *Processor) Process(ctx context.Context, now time.Time) (int, error) {
+	asOf := now.In(p.location).Format(model.DateLayout)
+
+	actions, err := p.corporateActionRepo.GetDue(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	var processed int
+	for _, action := range actions {
+		err := p.apply(ctx, action)
+		if stderrors.Is(err, errReadModelsBehind) {
+			p.logger.Debug("corporate actions deferred", zap.Int("pending", len(actions)-processed), zap.Error(err))
+			return processed, nil
+		}
+		if err != nil {
+			p.logger.Error("❌ corporate action failed",
+				zap.String("uuid", action.Uuid),
+				zap.String("symbol", action.Symbol),
+				zap.String("type", string(action.Type)),
+				zap.Error(err),
+			)
+			action.Status = model.CorporateActionStatusFailed
+			action.Reason = err.Error()
+			if err := p.corporateActionRepo.Update(ctx, action); err != nil {
+				return processed, err
+			}
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func (p *Processor) apply(ctx context.Context, action *model.CorporateAction) error {
+	if err := action.Validate(); err != nil {
+		return err
+	}
+
+	var applied model.CorporateActionApplied
+	err := p.orderStore.Exclusive(func(store *order.Store) error {
+		if err := p.book(ctx, store, action, &applied); err != nil {
+			return err
+		}
+		for _, uuid := range applied.Orders {
+			if _, err := store.Update(ctx, uuid, func(aggregate *model.OrderAggregate) error {
+				return aggregate.Adjust(action)
+			}); err != nil {
+				return fmt.Errorf("failed to adjust order %s: %w", uuid, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := p.corporateActionRepo.Complete(ctx, action); err != nil {
+		return err
+	}
+
+	p.logger.Info("✅ corporate action processed",
+		zap.String("uuid", action.Uuid),
+		zap.String("symbol", action.Symbol),
+		zap.String("type", string(action.Type)),
+		zap.String("ex_date", action.ExDate),
+		zap.Int("positions", len(applied.Adjustments)),
+	)
+
+	// Positions are already booked, a failed notification must not roll them back
+	for _, adj := range applied.Adjustments {
+		title, body := notificationText(action, adj)
+		if err := p.notificationClient.Send(ctx, adj.UserID, NotificationType, title, body); err != nil {
+			p.logger.Warn("failed to notify corporate action",
+				zap.String("uuid", action.Uuid),
+				zap.Uint64("user_id", adj.UserID),
+				zap.Error(err),
+			)
+		}
+	}
+	return nil
+}
+
+// book records the action on its stream from the holdings and open orders of the symbol. An
+// action recorded by an earlier run that stopped before completing it is read back instead,
+// so that its orders are adjusted and its holders notified once.
+func (p *Processor) book(ctx context.Context, store *order.Store, action *model.CorporateAction, applied *model.CorporateActionApplied) error {
+	streamID := model.CorporateActionStreamID(action.Uuid)
+	events, err := p.eventRepo.Load(ctx, streamID, 0)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		return events[0].Decode(applied)
+	}
+
+	head, err := p.eventRepo.Head(ctx)
+	if err != nil {
+		return err
+	}
+	checkpoint, err := p.readModelRepo.Checkpoint(ctx, order.ReadModelProjection)
+	if err != nil {
+		return err
+	}
+	if checkpoint < head {
+		return fmt.Errorf("%w: projected up to %d of %d", errReadModelsBehind, checkpoint, head)
+	}
+
+	positions, err := p.accountRepo.ListHoldings(ctx, action.Symbol)
+	if err != nil {
+		return err
+	}
+	orders, err := p.stockOrderRepo.ListOpen(ctx, action.Symbol)
+	if err != nil {
+		return err
+	}
+	e, err := action.Book(positions, orders)
+	if err != nil {
+		return err
+	}
+	if err := store.Append(ctx, streamID, 0, []*model.Event{e}); err != nil {
+		return err
+	}
+	return e.Decode(applied)
+}
+
+func notificationText(action *model.CorporateAction, adj *model.PositionAdjustment) (string, string) {
+	title := fmt.Sprintf("Corporate action on %s", action.Symbol)
+
+	var change string
+	switch action.Type {
+	case model.CorporateActionCashDividend:
+		if adj.CashAmount < 0 {
+			change = fmt.Sprintf("%g was debited for your short position of %g shares", -adj.CashAmount, -adj.OldQuantity)
+		} else {
+			change = fmt.Sprintf("%g was credited for your %g shares", adj.CashAmount, adj.OldQuantity)
+		}
+	case model.CorporateActionSymbolChange:
+		change = fmt.Sprintf("your %g shares now trade as %s", adj.NewQuantity, adj.NewSymbol)
+	default:
+		change = fmt.Sprintf("your position changed from %g to %g shares, open orders were adjusted",
+			adj.OldQuantity, adj.NewQuantity)
+	}
+	return title, fmt.Sprintf("The %s took effect on %s: %s.", action.Describe(), action.ExDate, change)
+}
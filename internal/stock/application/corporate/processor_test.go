@@ -0,0 +1,258 @@
+package corporate
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	infrasRepo "simple-securities/internal/stock/infras/repo"
+	"simple-securities/pkg/eventstore"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// errCaughtUp stops the projection once the last stored event is applied
+var errCaughtUp = stderrors.New("caught up")
+
+// fakeNotifier counts the notifications sent
+type fakeNotifier struct {
+	sent int
+}
+
+func (n *fakeNotifier) Send(ctx context.Context, userID uint64, nType, title, body string) error {
+	n.sent++
+	return nil
+}
+
+type testEnv struct {
+	db                  *sqlx.DB
+	eventRepo           repo.IEventRepo
+	readModelRepo       repo.IReadModelRepo
+	corporateActionRepo repo.ICorporateActionRepo
+	store               *order.Store
+	processor           *Processor
+	notifier            *fakeNotifier
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "stock.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000003_init_stockdb",
+		"000004_init_statements",
+		"000005_init_stock_events",
+		"000014_add_stock_order_sessions",
+		"000016_add_stock_fill_fees",
+		"000017_init_stock_snapshots",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+
+	eventStore, err := eventstore.NewSQLStore(db, eventstore.Config{
+		EventsTable:    "stock_events",
+		SnapshotsTable: "stock_snapshots",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &testEnv{
+		db:                  db,
+		eventRepo:           infrasRepo.NewEventRepo(eventStore),
+		readModelRepo:       infrasRepo.NewReadModelRepo(db),
+		corporateActionRepo: infrasRepo.NewCorporateActionRepo(db),
+		notifier:            &fakeNotifier{},
+	}
+	env.store = order.NewStore(order.StoreConfig{Snapshots: eventstore.SnapshotPolicy{Every: 2}},
+		env.eventRepo, nil, zap.NewNop())
+	env.processor = NewProcessor(time.Minute, time.UTC, env.corporateActionRepo, infrasRepo.NewAccountRepo(db),
+		infrasRepo.NewStockOrderRepo(db), env.eventRepo, env.readModelRepo, env.store, env.notifier, zap.NewNop())
+	return env
+}
+
+// project applies the stored events to the read models as the projector does
+func (env *testEnv) project(t *testing.T) {
+	t.Helper()
+	ctx := context.Background()
+	head, err := env.eventRepo.Head(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpoint, err := env.readModelRepo.Checkpoint(ctx, order.ReadModelProjection)
+	if err != nil || checkpoint >= head {
+		return
+	}
+
+	err = env.eventRepo.Subscribe(ctx, checkpoint, func(ctx context.Context, e *model.Event) error {
+		if err := env.readModelRepo.Apply(ctx, order.ReadModelProjection, e); err != nil {
+			return err
+		}
+		if e.Position == head {
+			return errCaughtUp
+		}
+		return nil
+	})
+	if !stderrors.Is(err, errCaughtUp) {
+		t.Fatal(err)
+	}
+}
+
+// readModels renders the order, position and cash ledger rows
+func (env *testEnv) readModels(t *testing.T) string {
+	t.Helper()
+	var rows []string
+	for _, query := range []string{
+		`SELECT uuid || ' ' || symbol || ' ' || quantity || ' ' || filled_quantity || ' ' || price || ' ' || stop_price || ' ' || status
+		FROM stock_orders ORDER BY uuid`,
+		`SELECT user_id || ' ' || symbol || ' ' || quantity || ' ' || cost_basis FROM positions ORDER BY user_id, symbol`,
+		`SELECT uuid || ' ' || user_id || ' ' || type || ' ' || amount FROM cash_movements ORDER BY uuid`,
+	} {
+		var lines []string
+		if err := env.db.Select(&lines, query); err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, lines...)
+	}
+	return strings.Join(rows, "\n")
+}
+
+func (env *testEnv) placeOrder(t *testing.T, stockOrder *model.StockOrder) {
+	t.Helper()
+	_, err := env.store.Update(context.Background(), stockOrder.Uuid, func(aggregate *model.OrderAggregate) error {
+		return aggregate.Place(stockOrder)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (env *testEnv) fill(t *testing.T, orderUuid, fillUuid string, quantity, price float64) error {
+	t.Helper()
+	_, err := env.store.Update(context.Background(), orderUuid, func(aggregate *model.OrderAggregate) error {
+		return aggregate.Fill(&model.Fill{
+			Uuid:       fillUuid,
+			OrderUuid:  orderUuid,
+			UserID:     aggregate.Order.UserID,
+			Symbol:     aggregate.Order.Symbol,
+			Side:       aggregate.Order.Side,
+			Quantity:   quantity,
+			Price:      price,
+			ExecutedAt: time.Now(),
+		})
+	})
+	return err
+}
+
+func (env *testEnv) process(t *testing.T, now time.Time) int {
+	t.Helper()
+	processed, err := env.processor.Process(context.Background(), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return processed
+}
+
+func TestSplitIsReplayedByAggregatesAndReadModels(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	today := now.Format(model.DateLayout)
+
+	env.placeOrder(t, &model.StockOrder{Uuid: "order-a", UserID: 1, Symbol: "AAPL", Side: model.OrderSideBuy,
+		Type: model.OrderTypeLimit, TimeInForce: model.TimeInForceGTC, Price: 200, Quantity: 10})
+	env.placeOrder(t, &model.StockOrder{Uuid: "order-b", UserID: 2, Symbol: "AAPL", Side: model.OrderSideBuy,
+		Type: model.OrderTypeStopLimit, TimeInForce: model.TimeInForceGTC, Price: 210, StopPrice: 205, Quantity: 5})
+	if err := env.fill(t, "order-a", "fill-1", 4, 200); err != nil {
+		t.Fatal(err)
+	}
+
+	split := model.NewCorporateAction("AAPL", model.CorporateActionSplit, today, "", 1, 2, 0, "")
+	if _, err := env.corporateActionRepo.Create(ctx, split); err != nil {
+		t.Fatal(err)
+	}
+	// The holdings are read from the read models, the split waits until they caught up
+	if processed := env.process(t, now); processed != 0 {
+		t.Fatalf("%d actions processed before the fills were projected", processed)
+	}
+	env.project(t)
+	if processed := env.process(t, now); processed != 1 {
+		t.Fatalf("%d actions processed, want the split", processed)
+	}
+
+	// The venue fills the rest of the order on split-adjusted terms
+	if err := env.fill(t, "order-a", "fill-2", 12, 100); err != nil {
+		t.Fatalf("split-adjusted fill rejected: %v", err)
+	}
+	env.project(t)
+
+	dividend := model.NewCorporateAction("AAPL", model.CorporateActionCashDividend, today, "", 0, 0, 0.5, "")
+	if _, err := env.corporateActionRepo.Create(ctx, dividend); err != nil {
+		t.Fatal(err)
+	}
+	if processed := env.process(t, now); processed != 1 {
+		t.Fatalf("%d actions processed, want the dividend", processed)
+	}
+	env.project(t)
+	if env.notifier.sent != 2 {
+		t.Errorf("%d notifications sent, want 2", env.notifier.sent)
+	}
+
+	// Loading from the snapshot and replaying the whole stream agree on the adjusted order
+	aggregate, err := env.store.Load(ctx, "order-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, err := env.eventRepo.Load(ctx, model.OrderStreamID("order-a"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := model.NewOrderAggregate("order-a")
+	if err := replayed.Load(events); err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range []*model.OrderAggregate{aggregate, replayed} {
+		got := fmt.Sprint(a.Version, a.Order.Quantity, a.Order.FilledQuantity, a.Order.Price, a.Order.Status, a.Order.CorporateActions)
+		if want := fmt.Sprint(4, 20, 20, 100, model.OrderStatusFilled, []string{split.Uuid}); got != want {
+			t.Errorf("order-a = %s, want %s", got, want)
+		}
+	}
+
+	projected := env.readModels(t)
+	for _, want := range []string{
+		"order-a AAPL 20.0 20.0 100.0 0.0 FILLED",
+		// Split to 105 and 102.5, then the dividend lowers the buy limit
+		"order-b AAPL 10.0 0.0 104.5 102.5 NEW",
+		"1 AAPL 20.0 2000.0",
+		" 1 DIVIDEND 10.0",
+	} {
+		if !strings.Contains(projected, want) {
+			t.Errorf("read models miss %q:\n%s", want, projected)
+		}
+	}
+
+	// Rebuilding the read models from the event store gives the same rows
+	env.db.MustExec(`DELETE FROM stock_orders; DELETE FROM stock_fills; DELETE FROM positions;
+		DELETE FROM cash_movements; DELETE FROM projection_checkpoints;`)
+	env.project(t)
+	if rebuilt := env.readModels(t); rebuilt != projected {
+		t.Errorf("rebuilt read models:\n%s\nwant:\n%s", rebuilt, projected)
+	}
+}
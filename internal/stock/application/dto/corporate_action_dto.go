@@ -0,0 +1,47 @@
+package dto
+
+type CorporateActionDto struct {
+	ID          uint64  `json:"id"`
+	Uuid        string  `json:"uuid"`
+	Symbol      string  `json:"symbol"`
+	Type        string  `json:"type"`
+	ExDate      string  `json:"ex_date"`
+	PayDate     string  `json:"pay_date,omitempty"`
+	RatioFrom   float64 `json:"ratio_from,omitempty"`
+	RatioTo     float64 `json:"ratio_to,omitempty"`
+	CashAmount  float64 `json:"cash_amount,omitempty"`
+	NewSymbol   string  `json:"new_symbol,omitempty"`
+	Status      string  `json:"status"`
+	Reason      string  `json:"reason,omitempty"`
+	ProcessedAt uint64  `json:"processed_at,omitempty"`
+	CreatedAt   uint64  `json:"created_at"`
+	UpdatedAt   uint64  `json:"updated_at"`
+}
+
+// CorporateActionReq is one row of an imported calendar file
+type CorporateActionReq struct {
+	Symbol     string  `json:"symbol" validate:"required"`
+	Type       string  `json:"type" validate:"required"`
+	ExDate     string  `json:"ex_date" validate:"required"`
+	PayDate    string  `json:"pay_date"`
+	RatioFrom  float64 `json:"ratio_from"`
+	RatioTo    float64 `json:"ratio_to"`
+	CashAmount float64 `json:"cash_amount"`
+	NewSymbol  string  `json:"new_symbol"`
+}
+
+type ImportCorporateActionsReq struct {
+	Format  string `json:"format" validate:"required"`
+	Content []byte `json:"content" validate:"required"`
+}
+
+type ImportCorporateActionsResult struct {
+	Imported uint32 `json:"imported"`
+	Skipped  uint32 `json:"skipped"`
+}
+
+type ListCorporateActionsReq struct {
+	Symbol string `json:"symbol"`
+	Limit  uint32 `json:"limit"`
+	Offset uint32 `json:"offset"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+type FeeTierDto struct {
+	Name      string  `json:"name"`
+	MinVolume float64 `json:"min_volume"`
+	MakerRate float64 `json:"maker_rate"`
+	TakerRate float64 `json:"taker_rate"`
+}
+
+type FeeMinimumDto struct {
+	Asset  string  `json:"asset"`
+	Amount float64 `json:"amount"`
+}
+
+type PassThroughFeeDto struct {
+	Name    string  `json:"name"`
+	Side    string  `json:"side"`
+	Rate    float64 `json:"rate"`
+	PerUnit float64 `json:"per_unit"`
+	Max     float64 `json:"max"`
+}
+
+type FeeScheduleDto struct {
+	Name        string               `json:"name"`
+	Discount    float64              `json:"discount"`
+	Tiers       []*FeeTierDto        `json:"tiers"`
+	Minimums    []*FeeMinimumDto     `json:"minimums"`
+	PassThrough []*PassThroughFeeDto `json:"pass_through"`
+}
+
+type FeePreviewDto struct {
+	Notional    float64 `json:"notional"`
+	Rate        float64 `json:"rate"`
+	Commission  float64 `json:"commission"`
+	Discount    float64 `json:"discount"`
+	PassThrough float64 `json:"pass_through"`
+	Total       float64 `json:"total"`
+}
+
+type GetFeeScheduleReq struct {
+	UserID    uint64  `json:"user_id"`
+	Symbol    string  `json:"symbol"`
+	Side      string  `json:"side"`
+	Quantity  float64 `json:"quantity"`
+	Price     float64 `json:"price"`
+	Liquidity string  `json:"liquidity"`
+}
+
+type GetFeeScheduleResult struct {
+	Schedule  *FeeScheduleDto `json:"schedule"`
+	Volume30d float64         `json:"volume_30d"`
+	Tier      string          `json:"tier"`
+	Preview   *FeePreviewDto  `json:"preview"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+type ListHoldersReq struct {
+	Symbol      string `json:"symbol"`
+	AfterUserID uint64 `json:"after_user_id"`
+	Limit       uint32 `json:"limit"`
+}
+
+type HoldersDto struct {
+	UserIDs []uint64 `json:"user_ids"`
+	Total   uint64   `json:"total"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+type StatementDto struct {
+	ID          uint64 `json:"id"`
+	Uuid        string `json:"uuid"`
+	UserID      uint64 `json:"user_id"`
+	Type        string `json:"type"`
+	PeriodStart string `json:"period_start"`
+	PeriodEnd   string `json:"period_end"`
+	CreatedAt   uint64 `json:"created_at"`
+}
+
+type ListStatementsReq struct {
+	UserID uint64 `json:"user_id" validate:"required"`
+	Type   string `json:"type"`
+	Limit  uint32 `json:"limit"`
+	Offset uint32 `json:"offset"`
+}
+
+type GetStatementReq struct {
+	Uuid   string `json:"uuid" validate:"required"`
+	Format string `json:"format"`
+}
+
+type GetStatementResult struct {
+	Statement   *StatementDto `json:"statement"`
+	ContentType string        `json:"content_type"`
+	Content     []byte        `json:"content"`
+}
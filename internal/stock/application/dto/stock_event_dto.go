@@ -0,0 +1,15 @@
+package dto
+
+import "encoding/json"
+
+// StockEventDto is the envelope of a stock domain event published on Kafka
+type StockEventDto struct {
+	Position      uint64          `json:"position"`
+	Uuid          string          `json:"uuid"`
+	StreamID      string          `json:"stream_id"`
+	Version       uint64          `json:"version"`
+	Type          string          `json:"type"`
+	SchemaVersion uint32          `json:"schema_version"`
+	Payload       json.RawMessage `json:"payload"`
+	OccurredAt    uint64          `json:"occurred_at"`
+}
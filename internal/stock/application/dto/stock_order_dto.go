@@ -0,0 +1,79 @@
+package dto
+
+type StockOrderDto struct {
+	ID             uint64  `json:"id"`
+	Uuid           string  `json:"uuid"`
+	UserID         uint64  `json:"user_id"`
+	Symbol         string  `json:"symbol"`
+	Side           string  `json:"side"`
+	Type           string  `json:"type"`
+	TimeInForce    string  `json:"time_in_force"`
+	ExtendedHours  bool    `json:"extended_hours"`
+	Price          float64 `json:"price"`
+	StopPrice      float64 `json:"stop_price"`
+	Quantity       float64 `json:"quantity"`
+	FilledQuantity float64 `json:"filled_quantity"`
+	Status         string  `json:"status"`
+	ExpiresAt      uint64  `json:"expires_at"`
+	CreatedAt      uint64  `json:"created_at"`
+	UpdatedAt      uint64  `json:"updated_at"`
+}
+
+type FillDto struct {
+	ID             uint64  `json:"id"`
+	Uuid           string  `json:"uuid"`
+	OrderUuid      string  `json:"order_uuid"`
+	UserID         uint64  `json:"user_id"`
+	Symbol         string  `json:"symbol"`
+	Side           string  `json:"side"`
+	Quantity       float64 `json:"quantity"`
+	Price          float64 `json:"price"`
+	Liquidity      string  `json:"liquidity"`
+	Commission     float64 `json:"commission"`
+	PassThroughFee float64 `json:"pass_through_fee"`
+	Fee            float64 `json:"fee"`
+	RealizedPnL    float64 `json:"realized_pnl"`
+	ExecutedAt     uint64  `json:"executed_at"`
+}
+
+type PlaceOrderReq struct {
+	UserID        uint64  `json:"user_id" validate:"required"`
+	Symbol        string  `json:"symbol" validate:"required"`
+	Side          string  `json:"side" validate:"required"`
+	Type          string  `json:"type" validate:"required"`
+	TimeInForce   string  `json:"time_in_force"`
+	ExtendedHours bool    `json:"extended_hours"`
+	Quantity      float64 `json:"quantity" validate:"required"`
+	Price         float64 `json:"price"`
+	StopPrice     float64 `json:"stop_price"`
+}
+
+type CancelOrderReq struct {
+	Uuid   string `json:"uuid" validate:"required"`
+	Reason string `json:"reason"`
+}
+
+type ReportExecutionReq struct {
+	Uuid       string  `json:"uuid" validate:"required"`
+	Quantity   float64 `json:"quantity" validate:"required"`
+	Price      float64 `json:"price" validate:"required"`
+	Liquidity  string  `json:"liquidity"`
+	ExecutedAt uint64  `json:"executed_at"`
+}
+
+type ReportExecutionResult struct {
+	Order *StockOrderDto `json:"order"`
+	Fill  *FillDto       `json:"fill"`
+}
+
+type ListOrdersReq struct {
+	UserID uint64 `json:"user_id" validate:"required"`
+	Status string `json:"status"`
+	Limit  uint32 `json:"limit"`
+	Offset uint32 `json:"offset"`
+}
+
+type GetOrderResult struct {
+	Order *StockOrderDto `json:"order"`
+	Fills []*FillDto     `json:"fills"`
+}
@@ -0,0 +1,66 @@
+package mapper
+
+import (
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/domain/model"
+	"strings"
+	"time"
+)
+
+func unixOrZero(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.Unix())
+}
+
+func ToCorporateActionDto(input *model.CorporateAction) *dto.CorporateActionDto {
+	if input == nil {
+		return nil
+	}
+	var processedAt uint64
+	if input.ProcessedAt != nil {
+		processedAt = unixOrZero(*input.ProcessedAt)
+	}
+	return &dto.CorporateActionDto{
+		ID:          input.ID,
+		Uuid:        input.Uuid,
+		Symbol:      input.Symbol,
+		Type:        string(input.Type),
+		ExDate:      input.ExDate,
+		PayDate:     input.PayDate,
+		RatioFrom:   input.RatioFrom,
+		RatioTo:     input.RatioTo,
+		CashAmount:  input.CashAmount,
+		NewSymbol:   input.NewSymbol,
+		Status:      string(input.Status),
+		Reason:      input.Reason,
+		ProcessedAt: processedAt,
+		CreatedAt:   unixOrZero(input.CreatedAt),
+		UpdatedAt:   unixOrZero(input.UpdatedAt),
+	}
+}
+
+func ToCorporateActionDtos(inputs []*model.CorporateAction) []*dto.CorporateActionDto {
+	if inputs == nil {
+		return nil
+	}
+	dtos := make([]*dto.CorporateActionDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToCorporateActionDto(input))
+	}
+	return dtos
+}
+
+func ToCorporateActionModel(req *dto.CorporateActionReq) *model.CorporateAction {
+	return model.NewCorporateAction(
+		strings.ToUpper(strings.TrimSpace(req.Symbol)),
+		model.CorporateActionType(strings.ToUpper(strings.TrimSpace(req.Type))),
+		strings.TrimSpace(req.ExDate),
+		strings.TrimSpace(req.PayDate),
+		req.RatioFrom,
+		req.RatioTo,
+		req.CashAmount,
+		strings.ToUpper(strings.TrimSpace(req.NewSymbol)),
+	)
+}
@@ -0,0 +1,116 @@
+package mapper
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"simple-securities/internal/stock/application/dto"
+	"strconv"
+	"strings"
+)
+
+// Calendar file formats accepted by ParseCorporateActions
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// csvColumns is the header every CSV calendar has to carry, in any order
+var csvColumns = []string{
+	"symbol", "type", "ex_date", "pay_date",
+	"ratio_from", "ratio_to", "cash_amount", "new_symbol",
+}
+
+// FormatFromPath guesses the calendar format from a file extension
+func FormatFromPath(path string) string {
+	return strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+}
+
+// ParseCorporateActions reads a calendar file. JSON files hold an array of objects
+// with the csv column names as keys.
+func ParseCorporateActions(format string, content []byte) ([]*dto.CorporateActionReq, error) {
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		return parseCSV(content)
+	case FormatJSON:
+		var reqs []*dto.CorporateActionReq
+		if err := json.Unmarshal(content, &reqs); err != nil {
+			return nil, fmt.Errorf("invalid corporate action json: %w", err)
+		}
+		return reqs, nil
+	}
+	return nil, fmt.Errorf("unsupported corporate action format %q", format)
+}
+
+func parseCSV(content []byte) ([]*dto.CorporateActionReq, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid corporate action csv header: %w", err)
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"symbol", "type", "ex_date"} {
+		if _, ok := index[name]; !ok {
+			return nil, fmt.Errorf("corporate action csv is missing column %q, expected %s",
+				name, strings.Join(csvColumns, ","))
+		}
+	}
+
+	var reqs []*dto.CorporateActionReq
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid corporate action csv: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := index[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		number := func(name string) (float64, error) {
+			v := field(name)
+			if v == "" {
+				return 0, nil
+			}
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return 0, fmt.Errorf("line %d: invalid %s %q", line, name, v)
+			}
+			return f, nil
+		}
+
+		req := &dto.CorporateActionReq{
+			Symbol:    field("symbol"),
+			Type:      field("type"),
+			ExDate:    field("ex_date"),
+			PayDate:   field("pay_date"),
+			NewSymbol: field("new_symbol"),
+		}
+		if req.RatioFrom, err = number("ratio_from"); err != nil {
+			return nil, err
+		}
+		if req.RatioTo, err = number("ratio_to"); err != nil {
+			return nil, err
+		}
+		if req.CashAmount, err = number("cash_amount"); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
@@ -0,0 +1,48 @@
+package mapper
+
+import (
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/pkg/fee"
+)
+
+func ToFeeScheduleDto(input *fee.Schedule) *dto.FeeScheduleDto {
+	output := &dto.FeeScheduleDto{
+		Name:     input.Name,
+		Discount: input.Discount,
+	}
+	for _, t := range input.Tiers {
+		output.Tiers = append(output.Tiers, &dto.FeeTierDto{
+			Name:      t.Name,
+			MinVolume: t.MinVolume,
+			MakerRate: t.MakerRate,
+			TakerRate: t.TakerRate,
+		})
+	}
+	for _, m := range input.Minimums {
+		output.Minimums = append(output.Minimums, &dto.FeeMinimumDto{
+			Asset:  m.Asset,
+			Amount: m.Amount,
+		})
+	}
+	for _, p := range input.PassThrough {
+		output.PassThrough = append(output.PassThrough, &dto.PassThroughFeeDto{
+			Name:    p.Name,
+			Side:    p.Side,
+			Rate:    p.Rate,
+			PerUnit: p.PerUnit,
+			Max:     p.Max,
+		})
+	}
+	return output
+}
+
+func ToFeePreviewDto(notional float64, input fee.Breakdown) *dto.FeePreviewDto {
+	return &dto.FeePreviewDto{
+		Notional:    notional,
+		Rate:        input.Rate,
+		Commission:  input.Commission,
+		Discount:    input.Discount,
+		PassThrough: input.PassThrough,
+		Total:       input.Total,
+	}
+}
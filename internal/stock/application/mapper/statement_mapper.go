@@ -0,0 +1,32 @@
+package mapper
+
+import (
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/domain/model"
+)
+
+func ToStatementDto(input *model.Statement) *dto.StatementDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.StatementDto{
+		ID:          input.ID,
+		Uuid:        input.Uuid,
+		UserID:      input.UserID,
+		Type:        string(input.Type),
+		PeriodStart: input.PeriodStart,
+		PeriodEnd:   input.PeriodEnd,
+		CreatedAt:   unixOrZero(input.CreatedAt),
+	}
+}
+
+func ToStatementDtos(inputs []*model.Statement) []*dto.StatementDto {
+	if inputs == nil {
+		return nil
+	}
+	dtos := make([]*dto.StatementDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToStatementDto(input))
+	}
+	return dtos
+}
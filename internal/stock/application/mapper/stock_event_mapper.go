@@ -0,0 +1,22 @@
+package mapper
+
+import (
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/domain/model"
+)
+
+func ToStockEventDto(input *model.Event) *dto.StockEventDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.StockEventDto{
+		Position:      input.Position,
+		Uuid:          input.Uuid,
+		StreamID:      input.StreamID,
+		Version:       input.Version,
+		Type:          string(input.Type),
+		SchemaVersion: input.SchemaVersion,
+		Payload:       input.Payload,
+		OccurredAt:    unixOrZero(input.OccurredAt),
+	}
+}
@@ -0,0 +1,272 @@
+package mapper
+
+import (
+	stock "simple-securities/gen/stock/v1"
+	"simple-securities/internal/stock/application/dto"
+)
+
+func ToImportCorporateActionsReq(req *stock.ImportCorporateActionsRequest) *dto.ImportCorporateActionsReq {
+	return &dto.ImportCorporateActionsReq{
+		Format:  req.Format,
+		Content: req.Content,
+	}
+}
+
+func ToListCorporateActionsReq(req *stock.ListCorporateActionsRequest) *dto.ListCorporateActionsReq {
+	return &dto.ListCorporateActionsReq{
+		Symbol: req.Symbol,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+}
+
+func ToCorporateAction(actionDto *dto.CorporateActionDto) *stock.CorporateAction {
+	if actionDto == nil {
+		return nil
+	}
+	return &stock.CorporateAction{
+		Id:          actionDto.ID,
+		Uuid:        actionDto.Uuid,
+		Symbol:      actionDto.Symbol,
+		Type:        actionDto.Type,
+		ExDate:      actionDto.ExDate,
+		PayDate:     actionDto.PayDate,
+		RatioFrom:   actionDto.RatioFrom,
+		RatioTo:     actionDto.RatioTo,
+		CashAmount:  actionDto.CashAmount,
+		NewSymbol:   actionDto.NewSymbol,
+		Status:      actionDto.Status,
+		Reason:      actionDto.Reason,
+		ProcessedAt: actionDto.ProcessedAt,
+		CreatedAt:   actionDto.CreatedAt,
+		UpdatedAt:   actionDto.UpdatedAt,
+	}
+}
+
+func ToCorporateActions(actionDtos []*dto.CorporateActionDto) []*stock.CorporateAction {
+	if actionDtos == nil {
+		return nil
+	}
+	actions := make([]*stock.CorporateAction, 0, len(actionDtos))
+	for _, actionDto := range actionDtos {
+		actions = append(actions, ToCorporateAction(actionDto))
+	}
+	return actions
+}
+
+func ToListStatementsReq(req *stock.ListStatementsRequest) *dto.ListStatementsReq {
+	return &dto.ListStatementsReq{
+		UserID: req.UserId,
+		Type:   req.Type,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+}
+
+func ToGetStatementReq(req *stock.GetStatementRequest) *dto.GetStatementReq {
+	return &dto.GetStatementReq{
+		Uuid:   req.Uuid,
+		Format: req.Format,
+	}
+}
+
+func ToStatement(statementDto *dto.StatementDto) *stock.Statement {
+	if statementDto == nil {
+		return nil
+	}
+	return &stock.Statement{
+		Id:          statementDto.ID,
+		Uuid:        statementDto.Uuid,
+		UserId:      statementDto.UserID,
+		Type:        statementDto.Type,
+		PeriodStart: statementDto.PeriodStart,
+		PeriodEnd:   statementDto.PeriodEnd,
+		CreatedAt:   statementDto.CreatedAt,
+	}
+}
+
+func ToStatements(statementDtos []*dto.StatementDto) []*stock.Statement {
+	if statementDtos == nil {
+		return nil
+	}
+	statements := make([]*stock.Statement, 0, len(statementDtos))
+	for _, statementDto := range statementDtos {
+		statements = append(statements, ToStatement(statementDto))
+	}
+	return statements
+}
+
+func ToGetFeeScheduleReq(req *stock.GetFeeScheduleRequest) *dto.GetFeeScheduleReq {
+	return &dto.GetFeeScheduleReq{
+		UserID:    req.UserId,
+		Symbol:    req.Symbol,
+		Side:      req.Side,
+		Quantity:  req.Quantity,
+		Price:     req.Price,
+		Liquidity: req.Liquidity,
+	}
+}
+
+func ToFeeSchedule(scheduleDto *dto.FeeScheduleDto) *stock.FeeSchedule {
+	if scheduleDto == nil {
+		return nil
+	}
+	schedule := &stock.FeeSchedule{
+		Name:     scheduleDto.Name,
+		Discount: scheduleDto.Discount,
+	}
+	for _, t := range scheduleDto.Tiers {
+		schedule.Tiers = append(schedule.Tiers, &stock.FeeTier{
+			Name:      t.Name,
+			MinVolume: t.MinVolume,
+			MakerRate: t.MakerRate,
+			TakerRate: t.TakerRate,
+		})
+	}
+	for _, m := range scheduleDto.Minimums {
+		schedule.Minimums = append(schedule.Minimums, &stock.FeeMinimum{
+			Asset:  m.Asset,
+			Amount: m.Amount,
+		})
+	}
+	for _, p := range scheduleDto.PassThrough {
+		schedule.PassThrough = append(schedule.PassThrough, &stock.PassThroughFee{
+			Name:    p.Name,
+			Side:    p.Side,
+			Rate:    p.Rate,
+			PerUnit: p.PerUnit,
+			Max:     p.Max,
+		})
+	}
+	return schedule
+}
+
+func ToFeePreview(previewDto *dto.FeePreviewDto) *stock.FeePreview {
+	if previewDto == nil {
+		return nil
+	}
+	return &stock.FeePreview{
+		Notional:    previewDto.Notional,
+		Rate:        previewDto.Rate,
+		Commission:  previewDto.Commission,
+		Discount:    previewDto.Discount,
+		PassThrough: previewDto.PassThrough,
+		Total:       previewDto.Total,
+	}
+}
+
+func ToPlaceOrderReq(req *stock.PlaceOrderRequest) *dto.PlaceOrderReq {
+	return &dto.PlaceOrderReq{
+		UserID:        req.UserId,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		TimeInForce:   req.TimeInForce,
+		ExtendedHours: req.ExtendedHours,
+		Quantity:      req.Quantity,
+		Price:         req.Price,
+		StopPrice:     req.StopPrice,
+	}
+}
+
+func ToCancelOrderReq(req *stock.CancelOrderRequest) *dto.CancelOrderReq {
+	return &dto.CancelOrderReq{
+		Uuid:   req.Uuid,
+		Reason: req.Reason,
+	}
+}
+
+func ToReportExecutionReq(req *stock.ReportExecutionRequest) *dto.ReportExecutionReq {
+	return &dto.ReportExecutionReq{
+		Uuid:       req.Uuid,
+		Quantity:   req.Quantity,
+		Price:      req.Price,
+		Liquidity:  req.Liquidity,
+		ExecutedAt: req.ExecutedAt,
+	}
+}
+
+func ToListOrdersReq(req *stock.ListOrdersRequest) *dto.ListOrdersReq {
+	return &dto.ListOrdersReq{
+		UserID: req.UserId,
+		Status: req.Status,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}
+}
+
+func ToStockOrder(orderDto *dto.StockOrderDto) *stock.StockOrder {
+	if orderDto == nil {
+		return nil
+	}
+	return &stock.StockOrder{
+		Id:             orderDto.ID,
+		Uuid:           orderDto.Uuid,
+		UserId:         orderDto.UserID,
+		Symbol:         orderDto.Symbol,
+		Side:           orderDto.Side,
+		Type:           orderDto.Type,
+		TimeInForce:    orderDto.TimeInForce,
+		ExtendedHours:  orderDto.ExtendedHours,
+		Price:          orderDto.Price,
+		StopPrice:      orderDto.StopPrice,
+		Quantity:       orderDto.Quantity,
+		FilledQuantity: orderDto.FilledQuantity,
+		Status:         orderDto.Status,
+		ExpiresAt:      orderDto.ExpiresAt,
+		CreatedAt:      orderDto.CreatedAt,
+		UpdatedAt:      orderDto.UpdatedAt,
+	}
+}
+
+func ToStockOrders(orderDtos []*dto.StockOrderDto) []*stock.StockOrder {
+	if orderDtos == nil {
+		return nil
+	}
+	orders := make([]*stock.StockOrder, 0, len(orderDtos))
+	for _, orderDto := range orderDtos {
+		orders = append(orders, ToStockOrder(orderDto))
+	}
+	return orders
+}
+
+func ToFill(fillDto *dto.FillDto) *stock.Fill {
+	if fillDto == nil {
+		return nil
+	}
+	return &stock.Fill{
+		Id:             fillDto.ID,
+		Uuid:           fillDto.Uuid,
+		OrderUuid:      fillDto.OrderUuid,
+		UserId:         fillDto.UserID,
+		Symbol:         fillDto.Symbol,
+		Side:           fillDto.Side,
+		Quantity:       fillDto.Quantity,
+		Price:          fillDto.Price,
+		Liquidity:      fillDto.Liquidity,
+		Commission:     fillDto.Commission,
+		PassThroughFee: fillDto.PassThroughFee,
+		Fee:            fillDto.Fee,
+		RealizedPnl:    fillDto.RealizedPnL,
+		ExecutedAt:     fillDto.ExecutedAt,
+	}
+}
+
+func ToFills(fillDtos []*dto.FillDto) []*stock.Fill {
+	if fillDtos == nil {
+		return nil
+	}
+	fills := make([]*stock.Fill, 0, len(fillDtos))
+	for _, fillDto := range fillDtos {
+		fills = append(fills, ToFill(fillDto))
+	}
+	return fills
+}
+
+func ToListHoldersReq(req *stock.ListHoldersRequest) *dto.ListHoldersReq {
+	return &dto.ListHoldersReq{
+		Symbol:      req.Symbol,
+		AfterUserID: req.AfterUserId,
+		Limit:       req.Limit,
+	}
+}
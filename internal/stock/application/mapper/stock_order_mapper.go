@@ -0,0 +1,77 @@
+package mapper
+
+import (
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/domain/model"
+)
+
+func ToStockOrderDto(input *model.StockOrder) *dto.StockOrderDto {
+	if input == nil {
+		return nil
+	}
+	output := &dto.StockOrderDto{
+		ID:             input.ID,
+		Uuid:           input.Uuid,
+		UserID:         input.UserID,
+		Symbol:         input.Symbol,
+		Side:           string(input.Side),
+		Type:           string(input.Type),
+		TimeInForce:    string(input.TimeInForce),
+		ExtendedHours:  input.ExtendedHours,
+		Price:          input.Price,
+		StopPrice:      input.StopPrice,
+		Quantity:       input.Quantity,
+		FilledQuantity: input.FilledQuantity,
+		Status:         string(input.Status),
+		CreatedAt:      unixOrZero(input.CreatedAt),
+		UpdatedAt:      unixOrZero(input.UpdatedAt),
+	}
+	if input.ExpiresAt != nil {
+		output.ExpiresAt = unixOrZero(*input.ExpiresAt)
+	}
+	return output
+}
+
+func ToStockOrderDtos(inputs []*model.StockOrder) []*dto.StockOrderDto {
+	if inputs == nil {
+		return nil
+	}
+	dtos := make([]*dto.StockOrderDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToStockOrderDto(input))
+	}
+	return dtos
+}
+
+func ToFillDto(input *model.Fill) *dto.FillDto {
+	if input == nil {
+		return nil
+	}
+	return &dto.FillDto{
+		ID:             input.ID,
+		Uuid:           input.Uuid,
+		OrderUuid:      input.OrderUuid,
+		UserID:         input.UserID,
+		Symbol:         input.Symbol,
+		Side:           string(input.Side),
+		Quantity:       input.Quantity,
+		Price:          input.Price,
+		Liquidity:      string(input.Liquidity),
+		Commission:     input.Commission,
+		PassThroughFee: input.PassThroughFee,
+		Fee:            input.Fee,
+		RealizedPnL:    input.RealizedPnL,
+		ExecutedAt:     unixOrZero(input.ExecutedAt),
+	}
+}
+
+func ToFillDtos(inputs []*model.Fill) []*dto.FillDto {
+	if inputs == nil {
+		return nil
+	}
+	dtos := make([]*dto.FillDto, 0, len(inputs))
+	for _, input := range inputs {
+		dtos = append(dtos, ToFillDto(input))
+	}
+	return dtos
+}
@@ -0,0 +1,112 @@
+package market
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/calendar"
+	"strings"
+	"time"
+)
+
+// SessionInterval is the bar interval covering a whole regular session
+const SessionInterval = "1d"
+
+type barInterval struct {
+	name     string
+	duration time.Duration // zero for SessionInterval
+}
+
+// BarAggregator rolls the source bars of a regular session up into larger intervals.
+// Buckets are aligned to the session open instead of the clock, pre and post-market
+// bars are left out and the last bucket of a half day is cut short by the early close.
+type BarAggregator struct {
+	exchange  *calendar.Exchange
+	barRepo   repo.IBarRepo
+	source    string
+	intervals []barInterval
+}
+
+// NewBarAggregator builds the aggregator of source bars into intervals such as "5m", "1h" or "1d"
+func NewBarAggregator(
+	exchange *calendar.Exchange,
+	barRepo repo.IBarRepo,
+	source string,
+	intervals []string,
+) (*BarAggregator, error) {
+	a := &BarAggregator{
+		exchange: exchange,
+		barRepo:  barRepo,
+		source:   source,
+	}
+	for _, name := range intervals {
+		name = strings.TrimSpace(name)
+		if name == SessionInterval {
+			a.intervals = append(a.intervals, barInterval{name: name})
+			continue
+		}
+		d, err := time.ParseDuration(name)
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("invalid bar interval %q", name)
+		}
+		a.intervals = append(a.intervals, barInterval{name: name, duration: d})
+	}
+	return a, nil
+}
+
+// AggregateSession builds the bars of the regular session of day and returns how many were stored
+func (a *BarAggregator) AggregateSession(ctx context.Context, day calendar.Day) (int, error) {
+	if day.Regular.IsZero() || len(a.intervals) == 0 {
+		return 0, nil
+	}
+
+	sources, err := a.barRepo.GetRange(ctx, a.source, day.Regular.Open.UnixMilli(), day.Regular.Close.UnixMilli())
+	if err != nil {
+		return 0, err
+	}
+
+	var bars []*model.Bar
+	for _, interval := range a.intervals {
+		bars = append(bars, a.rollUp(day, interval, sources)...)
+	}
+	if err := a.barRepo.Upsert(ctx, bars); err != nil {
+		return 0, err
+	}
+	return len(bars), nil
+}
+
+// rollUp merges the sources, which are ordered by symbol and open time, into buckets of interval
+func (a *BarAggregator) rollUp(day calendar.Day, interval barInterval, sources []*model.Bar) []*model.Bar {
+	var (
+		bars    []*model.Bar
+		current *model.Bar
+	)
+	for _, src := range sources {
+		openTime := day.Regular.Open
+		if interval.duration > 0 {
+			var ok bool
+			openTime, ok = a.exchange.BarOpenTime(time.UnixMilli(src.OpenTime), interval.duration)
+			if !ok {
+				continue
+			}
+		}
+
+		if current == nil || current.Symbol != src.Symbol || current.OpenTime != openTime.UnixMilli() {
+			current = &model.Bar{
+				Symbol:   src.Symbol,
+				Interval: interval.name,
+				OpenTime: openTime.UnixMilli(),
+				Open:     src.Open,
+				High:     src.High,
+				Low:      src.Low,
+			}
+			bars = append(bars, current)
+		}
+		current.High = max(current.High, src.High)
+		current.Low = min(current.Low, src.Low)
+		current.Close = src.Close
+		current.Volume += src.Volume
+	}
+	return bars
+}
@@ -0,0 +1,42 @@
+package market
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/fee"
+)
+
+// FeePricer attaches the fee of the fee schedule to the executions
+type FeePricer struct {
+	schedule *fee.Schedule
+	fillRepo repo.IFillRepo
+}
+
+func NewFeePricer(schedule *fee.Schedule, fillRepo repo.IFillRepo) *FeePricer {
+	return &FeePricer{schedule: schedule, fillRepo: fillRepo}
+}
+
+// Price sets the fee of the fill, fills without a liquidity flag are charged as takers.
+// The tier comes from the volume traded in the window before the execution.
+func (p *FeePricer) Price(ctx context.Context, fill *model.Fill) error {
+	if fill.Liquidity == "" {
+		fill.Liquidity = fee.LiquidityTaker
+	}
+
+	volume, err := p.fillRepo.GetVolume(ctx, fill.UserID, fill.ExecutedAt.Add(-fee.VolumeWindow))
+	if err != nil {
+		return err
+	}
+
+	fill.ApplyFee(p.schedule.Compute(fee.Trade{
+		Asset:     fill.Symbol,
+		Side:      string(fill.Side),
+		Liquidity: fill.Liquidity,
+		Quantity:  fill.Quantity,
+		Price:     fill.Price,
+		Volume30d: volume,
+		Discount:  p.schedule.DiscountFor(fill.UserID),
+	}))
+	return nil
+}
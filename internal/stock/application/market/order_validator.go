@@ -0,0 +1,62 @@
+package market
+
+import (
+	"fmt"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/pkg/calendar"
+	"time"
+)
+
+// OrderValidator checks orders against the trading hours of the exchange they are routed to
+type OrderValidator struct {
+	exchange *calendar.Exchange
+}
+
+func NewOrderValidator(exchange *calendar.Exchange) *OrderValidator {
+	return &OrderValidator{exchange: exchange}
+}
+
+// Validate checks the order as if it was received at now and sets the expiry of DAY orders.
+//
+// Market orders are only taken while the regular session runs. A DAY order expires at the
+// next regular close, so one received after the close works the next session, and a DAY
+// extended hours order expires at the end of the post-market of the day it works.
+func (v *OrderValidator) Validate(order *model.StockOrder, now time.Time) error {
+	if err := order.Validate(); err != nil {
+		return err
+	}
+
+	phase := v.exchange.Phase(now)
+	if order.Type == model.OrderTypeMarket && phase != calendar.PhaseRegular {
+		return fmt.Errorf("%s is %s, market orders are accepted from the next open at %s",
+			v.exchange.Code, phase, v.exchange.NextOpen(now).Format(time.RFC3339))
+	}
+
+	order.ExpiresAt = nil
+	if order.TimeInForce != model.TimeInForceDay {
+		return nil
+	}
+
+	expiresAt := v.exchange.NextClose(now)
+	if order.ExtendedHours {
+		expiresAt = v.extendedClose(now)
+	}
+	if expiresAt.IsZero() {
+		return fmt.Errorf("%s has no upcoming trading session", v.exchange.Code)
+	}
+	expiresAt = expiresAt.UTC()
+	order.ExpiresAt = &expiresAt
+	return nil
+}
+
+// extendedClose is the end of the last session of the first trading day still running after now
+func (v *OrderValidator) extendedClose(now time.Time) time.Time {
+	if end := v.exchange.Day(now).End(); end.After(now) {
+		return end
+	}
+	next := v.exchange.NextOpen(now)
+	if next.IsZero() {
+		return next
+	}
+	return v.exchange.Day(next).End()
+}
@@ -0,0 +1,118 @@
+package market
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/calendar"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// SessionCloser runs the end of session work of an exchange: DAY orders are expired at every
+// regular and post-market close and the bars of the session are aggregated at the regular close.
+// The due orders are read from the read model and expired through their event streams.
+type SessionCloser struct {
+	exchange       *calendar.Exchange
+	stockOrderRepo repo.IStockOrderRepo
+	orderStore     *order.Store
+	barAggregator  *BarAggregator
+	logger         *zap.Logger
+}
+
+func NewSessionCloser(
+	exchange *calendar.Exchange,
+	stockOrderRepo repo.IStockOrderRepo,
+	orderStore *order.Store,
+	barAggregator *BarAggregator,
+	logger *zap.Logger,
+) *SessionCloser {
+	return &SessionCloser{
+		exchange:       exchange,
+		stockOrderRepo: stockOrderRepo,
+		orderStore:     orderStore,
+		barAggregator:  barAggregator,
+		logger:         logger,
+	}
+}
+
+// Start expires the orders left over from a previous run and then sleeps until every
+// session end until ctx is cancelled
+func (c *SessionCloser) Start(ctx context.Context) error {
+	c.logger.Info("🔔 session closer started", zap.String("exchange", c.exchange.Code))
+	c.expire(ctx, time.Now())
+
+	for {
+		now := time.Now()
+		at, regular := c.nextEnd(now)
+		if at.IsZero() {
+			c.logger.Warn("no upcoming trading session, session closer stopped", zap.String("exchange", c.exchange.Code))
+			return nil
+		}
+
+		timer := time.NewTimer(at.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			c.logger.Info("session closer stopped")
+			return nil
+		case <-timer.C:
+		}
+
+		c.expire(ctx, at)
+		if regular {
+			c.aggregate(ctx, at)
+		}
+	}
+}
+
+// nextEnd returns the first regular or post-market close after now and whether it is the regular one
+func (c *SessionCloser) nextEnd(now time.Time) (time.Time, bool) {
+	regularClose := c.exchange.NextClose(now)
+	if regularClose.IsZero() {
+		return regularClose, false
+	}
+
+	// The post-market of today, if still ahead, ends before the next regular close
+	if post := c.exchange.Day(now).PostMarket; !post.IsZero() && post.Close.After(now) && post.Close.Before(regularClose) {
+		return post.Close, false
+	}
+	return regularClose, true
+}
+
+func (c *SessionCloser) expire(ctx context.Context, now time.Time) {
+	uuids, err := c.stockOrderRepo.ListDue(ctx, now)
+	if err != nil {
+		c.logger.Error("failed to list due DAY orders", zap.Error(err))
+		return
+	}
+
+	var expired int
+	for _, uuid := range uuids {
+		_, err := c.orderStore.Update(ctx, uuid, func(aggregate *model.OrderAggregate) error {
+			return aggregate.Expire(now)
+		})
+		if err != nil {
+			// The read model may lag behind an order filled or cancelled just before the close
+			c.logger.Warn("DAY order not expired", zap.String("order_uuid", uuid), zap.Error(err))
+			continue
+		}
+		expired++
+	}
+	if expired > 0 {
+		c.logger.Info("⌛ DAY orders expired", zap.String("exchange", c.exchange.Code), zap.Int("orders", expired))
+	}
+}
+
+func (c *SessionCloser) aggregate(ctx context.Context, at time.Time) {
+	// A session that closes at midnight belongs to the day before
+	day := c.exchange.Day(at.Add(-time.Nanosecond))
+	bars, err := c.barAggregator.AggregateSession(ctx, day)
+	if err != nil {
+		c.logger.Error("failed to aggregate session bars", zap.String("date", day.Date), zap.Error(err))
+		return
+	}
+	c.logger.Info("📊 session bars aggregated", zap.String("date", day.Date), zap.Int("bars", bars))
+}
@@ -0,0 +1,73 @@
+package order
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ReadModelProjection is the checkpoint name of the stock_orders, stock_fills, positions
+// and cash_movements projection
+const ReadModelProjection = "stock_read_models"
+
+// Projector keeps the read models in line with the event store through a catch-up
+// subscription from its checkpoint. An event that fails to apply ends the subscription,
+// it is resubscribed from the checkpoint after retryInterval so that the read models
+// never skip an event.
+type Projector struct {
+	retryInterval time.Duration
+	eventRepo     repo.IEventRepo
+	readModelRepo repo.IReadModelRepo
+	logger        *zap.Logger
+}
+
+func NewProjector(
+	retryInterval time.Duration,
+	eventRepo repo.IEventRepo,
+	readModelRepo repo.IReadModelRepo,
+	logger *zap.Logger,
+) *Projector {
+	if retryInterval <= 0 {
+		retryInterval = time.Second
+	}
+	return &Projector{
+		retryInterval: retryInterval,
+		eventRepo:     eventRepo,
+		readModelRepo: readModelRepo,
+		logger:        logger,
+	}
+}
+
+// Start runs the projection until ctx is cancelled
+func (p *Projector) Start(ctx context.Context) error {
+	p.logger.Info("🪞 order projector started")
+
+	for {
+		err := p.subscribe(ctx)
+		if ctx.Err() != nil {
+			p.logger.Info("order projector stopped")
+			return nil
+		}
+		p.logger.Error("failed to project stock events", zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			p.logger.Info("order projector stopped")
+			return nil
+		case <-time.After(p.retryInterval):
+		}
+	}
+}
+
+func (p *Projector) subscribe(ctx context.Context) error {
+	checkpoint, err := p.readModelRepo.Checkpoint(ctx, ReadModelProjection)
+	if err != nil {
+		return err
+	}
+	return p.eventRepo.Subscribe(ctx, checkpoint, func(ctx context.Context, e *model.Event) error {
+		return p.readModelRepo.Apply(ctx, ReadModelProjection, e)
+	})
+}
@@ -0,0 +1,197 @@
+package order
+
+import (
+	"context"
+	stderrors "errors"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/eventstore"
+	"simple-securities/pkg/kafka"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// maxConflictRetries bounds how many times a command is replayed on a fresh aggregate
+// after losing a race on the stream version
+const maxConflictRetries = 3
+
+// EventPublisher is the part of kafka.MessageBus the store needs
+type EventPublisher interface {
+	SendMessage(ctx context.Context, topic string, key string, partition int, event kafka.Event) error
+}
+
+type StoreConfig struct {
+	ServiceName string
+	Topic       string
+	Snapshots   eventstore.SnapshotPolicy
+}
+
+// Store loads the order aggregates from their event streams and appends the events raised
+// by the commands. Stored events are published on the stock event topic keyed by stream so
+// that the events of an order stay in order; a failed publish is logged and not retried.
+// Orders are snapshotted as the snapshot policy asks, a snapshot is only a shortcut for
+// loading so failing to take or read one is logged and the stream replayed instead.
+//
+// Commands run side by side, a batch that has to see every order as it stands, such as a
+// corporate action, runs alone through Exclusive.
+type Store struct {
+	config    StoreConfig
+	eventRepo repo.IEventRepo
+	publisher EventPublisher
+	logger    *zap.Logger
+	mu        *sync.RWMutex
+	exclusive bool
+}
+
+func NewStore(config StoreConfig, eventRepo repo.IEventRepo, publisher EventPublisher, logger *zap.Logger) *Store {
+	return &Store{
+		config:    config,
+		eventRepo: eventRepo,
+		publisher: publisher,
+		logger:    logger,
+		mu:        &sync.RWMutex{},
+	}
+}
+
+// Exclusive runs fn once the commands in flight are done and holds back the other commands
+// until it returns. fn runs its own commands through the store handed to it.
+func (s *Store) Exclusive(fn func(store *Store) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fn(&Store{
+		config:    s.config,
+		eventRepo: s.eventRepo,
+		publisher: s.publisher,
+		logger:    s.logger,
+		mu:        s.mu,
+		exclusive: true,
+	})
+}
+
+// Load rebuilds the order from its latest snapshot and the events after it, the aggregate
+// does not exist when the stream is empty
+func (s *Store) Load(ctx context.Context, uuid string) (*model.OrderAggregate, error) {
+	streamID := model.OrderStreamID(uuid)
+	aggregate := model.NewOrderAggregate(uuid)
+
+	snapshot, err := s.eventRepo.LoadSnapshot(ctx, streamID)
+	if err != nil {
+		s.logger.Warn("failed to load order snapshot", zap.String("order_uuid", uuid), zap.Error(err))
+	} else if snapshot != nil {
+		if err := aggregate.Restore(snapshot); err != nil {
+			s.logger.Warn("skipping order snapshot", zap.String("order_uuid", uuid), zap.Error(err))
+			aggregate = model.NewOrderAggregate(uuid)
+		}
+	}
+
+	events, err := s.eventRepo.Load(ctx, streamID, aggregate.Version)
+	if err != nil {
+		return nil, err
+	}
+	if err := aggregate.Load(events); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// Save appends the changes of the aggregate at the version it was loaded at
+func (s *Store) Save(ctx context.Context, aggregate *model.OrderAggregate) error {
+	changes := aggregate.Changes()
+	if len(changes) == 0 {
+		return nil
+	}
+
+	expectedVersion := aggregate.Version - uint64(len(changes))
+	if err := s.Append(ctx, model.OrderStreamID(aggregate.Order.Uuid), expectedVersion, changes); err != nil {
+		return err
+	}
+	aggregate.ClearChanges()
+
+	if s.config.Snapshots.Due(expectedVersion, aggregate.Version) {
+		s.snapshot(ctx, aggregate)
+	}
+	return nil
+}
+
+// Append stores the events of a stream and publishes them, the streams without an aggregate
+// such as the corporate actions are written through it
+func (s *Store) Append(ctx context.Context, streamID string, expectedVersion uint64, events []*model.Event) error {
+	if err := s.eventRepo.Append(ctx, streamID, expectedVersion, events); err != nil {
+		return err
+	}
+	for _, e := range events {
+		s.publish(ctx, e)
+	}
+	return nil
+}
+
+// Update runs the command on the current state of the order and saves its events. On a
+// version conflict the order is reloaded and the command runs again.
+func (s *Store) Update(ctx context.Context, uuid string, command func(*model.OrderAggregate) error) (*model.OrderAggregate, error) {
+	if !s.exclusive {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+	}
+
+	for attempt := 1; ; attempt++ {
+		aggregate, err := s.Load(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		if err := command(aggregate); err != nil {
+			return nil, err
+		}
+
+		err = s.Save(ctx, aggregate)
+		if err == nil {
+			return aggregate, nil
+		}
+		if !stderrors.Is(err, model.ErrVersionConflict) || attempt == maxConflictRetries {
+			return nil, err
+		}
+		s.logger.Debug("order stream changed, retrying command", zap.String("order_uuid", uuid), zap.Int("attempt", attempt))
+	}
+}
+
+func (s *Store) snapshot(ctx context.Context, aggregate *model.OrderAggregate) {
+	snapshot, err := aggregate.Snapshot()
+	if err == nil {
+		err = s.eventRepo.SaveSnapshot(ctx, snapshot)
+	}
+	if err != nil {
+		s.logger.Warn("failed to snapshot order",
+			zap.String("order_uuid", aggregate.Order.Uuid),
+			zap.Uint64("version", aggregate.Version),
+			zap.Error(err))
+	}
+}
+
+func (s *Store) publish(ctx context.Context, e *model.Event) {
+	if s.publisher == nil {
+		return
+	}
+
+	now := time.Now()
+	event := kafka.Event{
+		Meta: kafka.Meta{
+			ServiceName: s.config.ServiceName,
+			RequestID:   e.Uuid,
+			Code:        200,
+			Message:     string(e.Type),
+			Timestamp:   now.Unix(),
+			Datetime:    now.Format("2006-01-02 15:04:05"),
+		},
+		Data: mapper.ToStockEventDto(e),
+	}
+
+	if err := s.publisher.SendMessage(ctx, s.config.Topic, e.StreamID, -1, event); err != nil {
+		s.logger.Error("failed to publish stock event",
+			zap.String("event_type", string(e.Type)),
+			zap.Uint64("position", e.Position),
+			zap.Error(err))
+	}
+}
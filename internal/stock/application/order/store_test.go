@@ -0,0 +1,120 @@
+package order
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"simple-securities/internal/stock/domain/model"
+	infrasRepo "simple-securities/internal/stock/infras/repo"
+	"simple-securities/pkg/eventstore"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "stock.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000003_init_stockdb",
+		"000004_init_statements",
+		"000005_init_stock_events",
+		"000017_init_stock_snapshots",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+	eventStore, err := eventstore.NewSQLStore(db, eventstore.Config{
+		EventsTable:    "stock_events",
+		SnapshotsTable: "stock_snapshots",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewStore(StoreConfig{}, infrasRepo.NewEventRepo(eventStore), nil, zap.NewNop())
+}
+
+func fillCommand(fillUuid string, quantity float64) func(*model.OrderAggregate) error {
+	return func(aggregate *model.OrderAggregate) error {
+		return aggregate.Fill(&model.Fill{Uuid: fillUuid, OrderUuid: aggregate.Order.Uuid, UserID: 1,
+			Symbol: "AAPL", Side: model.OrderSideBuy, Quantity: quantity, Price: 200, ExecutedAt: time.Now()})
+	}
+}
+
+// fillBehind fills the order through another aggregate, as a concurrent command would
+func fillBehind(t *testing.T, store *Store, orderUuid, fillUuid string, quantity float64) {
+	t.Helper()
+	ctx := context.Background()
+	aggregate, err := store.Load(ctx, orderUuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fillCommand(fillUuid, quantity)(aggregate); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(ctx, aggregate); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateRetriesOnVersionConflict(t *testing.T) {
+	store := newTestStore(t)
+	ctx := context.Background()
+	_, err := store.Update(ctx, "order-1", func(aggregate *model.OrderAggregate) error {
+		return aggregate.Place(&model.StockOrder{Uuid: "order-1", UserID: 1, Symbol: "AAPL", Side: model.OrderSideBuy,
+			Type: model.OrderTypeLimit, TimeInForce: model.TimeInForceGTC, Price: 200, Quantity: 10})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another fill lands between the load and the save, the command runs again on top of it
+	runs := 0
+	aggregate, err := store.Update(ctx, "order-1", func(aggregate *model.OrderAggregate) error {
+		runs++
+		if runs == 1 {
+			fillBehind(t, store, "order-1", "fill-1", 4)
+		}
+		return fillCommand("fill-2", 6)(aggregate)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs != 2 || aggregate.Version != 3 || aggregate.Order.FilledQuantity != 10 {
+		t.Errorf("command ran %d times to version %d with %g filled, want 2 runs to version 3 with 10",
+			runs, aggregate.Version, aggregate.Order.FilledQuantity)
+	}
+
+	// The command gives up once it lost every race
+	_, err = store.Update(ctx, "order-2", func(aggregate *model.OrderAggregate) error {
+		return aggregate.Place(&model.StockOrder{Uuid: "order-2", UserID: 1, Symbol: "AAPL", Side: model.OrderSideBuy,
+			Type: model.OrderTypeLimit, TimeInForce: model.TimeInForceGTC, Price: 200, Quantity: 10})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs = 0
+	_, err = store.Update(ctx, "order-2", func(aggregate *model.OrderAggregate) error {
+		runs++
+		fillBehind(t, store, "order-2", fmt.Sprintf("fill-behind-%d", runs), 1)
+		return fillCommand("fill-3", 1)(aggregate)
+	})
+	if !stderrors.Is(err, model.ErrVersionConflict) || runs != maxConflictRetries {
+		t.Errorf("Update() losing every race = %v after %d runs, want a version conflict after %d",
+			err, runs, maxConflictRetries)
+	}
+}
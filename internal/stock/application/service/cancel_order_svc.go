@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/pkg/errors"
+)
+
+type CancelOrderSvc interface {
+	Handle(ctx context.Context, req *dto.CancelOrderReq) (*dto.StockOrderDto, error)
+}
+
+type cancelOrderSvc struct {
+	orderStore *order.Store
+}
+
+func NewCancelOrderSvc(orderStore *order.Store) CancelOrderSvc {
+	return &cancelOrderSvc{
+		orderStore: orderStore,
+	}
+}
+
+func (s *cancelOrderSvc) Handle(ctx context.Context, req *dto.CancelOrderReq) (*dto.StockOrderDto, error) {
+	if req.Uuid == "" {
+		return nil, errors.NewValidationError("uuid is required", nil)
+	}
+
+	aggregate, err := s.orderStore.Update(ctx, req.Uuid, func(aggregate *model.OrderAggregate) error {
+		if !aggregate.Exists() {
+			return errors.NewNotFoundError("order not found", nil)
+		}
+		if err := aggregate.Cancel(req.Reason); err != nil {
+			return errors.NewBusinessError(err.Error(), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, toOrderError("failed to cancel order", err)
+	}
+	return mapper.ToStockOrderDto(&aggregate.Order), nil
+}
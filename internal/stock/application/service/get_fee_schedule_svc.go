@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+	"simple-securities/pkg/fee"
+	"strings"
+	"time"
+)
+
+type GetFeeScheduleSvc interface {
+	Handle(ctx context.Context, req *dto.GetFeeScheduleReq) (*dto.GetFeeScheduleResult, error)
+}
+
+type getFeeScheduleSvc struct {
+	schedule *fee.Schedule
+	fillRepo repo.IFillRepo
+}
+
+func NewGetFeeScheduleSvc(schedule *fee.Schedule, fillRepo repo.IFillRepo) GetFeeScheduleSvc {
+	return &getFeeScheduleSvc{
+		schedule: schedule,
+		fillRepo: fillRepo,
+	}
+}
+
+// Handle returns the fee schedule with the tier of the user, and previews the fee of a fill
+// when a quantity and price are given
+func (s *getFeeScheduleSvc) Handle(ctx context.Context, req *dto.GetFeeScheduleReq) (*dto.GetFeeScheduleResult, error) {
+	liquidity := fee.Liquidity(strings.ToUpper(strings.TrimSpace(req.Liquidity)))
+	switch liquidity {
+	case "":
+		liquidity = fee.LiquidityTaker
+	case fee.LiquidityMaker, fee.LiquidityTaker:
+	default:
+		return nil, errors.NewValidationError("liquidity must be MAKER or TAKER", nil)
+	}
+
+	side := model.OrderSide(strings.ToUpper(strings.TrimSpace(req.Side)))
+	switch side {
+	case "", model.OrderSideBuy, model.OrderSideSell:
+	default:
+		return nil, errors.NewValidationError("side must be BUY or SELL", nil)
+	}
+
+	if req.Quantity < 0 || req.Price < 0 {
+		return nil, errors.NewValidationError("quantity and price must not be negative", nil)
+	}
+
+	var volume float64
+	if req.UserID != 0 {
+		var err error
+		volume, err = s.fillRepo.GetVolume(ctx, req.UserID, time.Now().Add(-fee.VolumeWindow))
+		if err != nil {
+			return nil, errors.NewPersistenceError("failed to get traded volume", err)
+		}
+	}
+
+	result := &dto.GetFeeScheduleResult{
+		Schedule:  mapper.ToFeeScheduleDto(s.schedule),
+		Volume30d: volume,
+		Tier:      s.schedule.TierFor(volume).Name,
+	}
+	if req.Quantity > 0 && req.Price > 0 {
+		breakdown := s.schedule.Compute(fee.Trade{
+			Asset:     strings.ToUpper(strings.TrimSpace(req.Symbol)),
+			Side:      string(side),
+			Liquidity: liquidity,
+			Quantity:  req.Quantity,
+			Price:     req.Price,
+			Volume30d: volume,
+			Discount:  s.schedule.DiscountFor(req.UserID),
+		})
+		result.Preview = mapper.ToFeePreviewDto(req.Quantity*req.Price, breakdown)
+	}
+	return result, nil
+}
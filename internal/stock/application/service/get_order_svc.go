@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type GetOrderSvc interface {
+	Handle(ctx context.Context, uuid string) (*dto.GetOrderResult, error)
+}
+
+type getOrderSvc struct {
+	stockOrderRepo repo.IStockOrderRepo
+	fillRepo       repo.IFillRepo
+}
+
+func NewGetOrderSvc(stockOrderRepo repo.IStockOrderRepo, fillRepo repo.IFillRepo) GetOrderSvc {
+	return &getOrderSvc{
+		stockOrderRepo: stockOrderRepo,
+		fillRepo:       fillRepo,
+	}
+}
+
+// Handle reads the order and its fills from the read models
+func (s *getOrderSvc) Handle(ctx context.Context, uuid string) (*dto.GetOrderResult, error) {
+	if uuid == "" {
+		return nil, errors.NewValidationError("uuid is required", nil)
+	}
+
+	stockOrder, err := s.stockOrderRepo.GetByUuid(ctx, uuid)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("order not found", err)
+		}
+		return nil, errors.NewPersistenceError("failed to get order", err)
+	}
+
+	fills, err := s.fillRepo.ListByOrder(ctx, uuid)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to list fills", err)
+	}
+
+	return &dto.GetOrderResult{
+		Order: mapper.ToStockOrderDto(stockOrder),
+		Fills: mapper.ToFillDtos(fills),
+	}, nil
+}
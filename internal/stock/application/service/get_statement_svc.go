@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+	"strings"
+)
+
+type GetStatementSvc interface {
+	Handle(ctx context.Context, req *dto.GetStatementReq) (*dto.GetStatementResult, error)
+}
+
+type getStatementSvc struct {
+	statementRepo repo.IStatementRepo
+}
+
+func NewGetStatementSvc(statementRepo repo.IStatementRepo) GetStatementSvc {
+	return &getStatementSvc{
+		statementRepo: statementRepo,
+	}
+}
+
+// Handle returns the statement rendered in the requested format, HTML by default
+func (s *getStatementSvc) Handle(ctx context.Context, req *dto.GetStatementReq) (*dto.GetStatementResult, error) {
+	if req.Uuid == "" {
+		return nil, errors.NewValidationError("uuid is required", nil)
+	}
+
+	format := model.StatementFormat(strings.ToUpper(strings.TrimSpace(req.Format)))
+	switch format {
+	case "":
+		format = model.StatementFormatHTML
+	case model.StatementFormatHTML, model.StatementFormatCSV:
+	default:
+		return nil, errors.NewValidationError("format must be html or csv", nil)
+	}
+
+	statement, err := s.statementRepo.GetByUuid(ctx, req.Uuid)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewNotFoundError("statement not found", err)
+		}
+		return nil, errors.NewPersistenceError("failed to get statement", err)
+	}
+
+	return &dto.GetStatementResult{
+		Statement:   mapper.ToStatementDto(statement),
+		ContentType: format.ContentType(),
+		Content:     statement.Content(format),
+	}, nil
+}
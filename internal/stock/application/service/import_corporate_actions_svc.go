@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+)
+
+type ImportCorporateActionsSvc interface {
+	Handle(ctx context.Context, req *dto.ImportCorporateActionsReq) (*dto.ImportCorporateActionsResult, error)
+}
+
+type importCorporateActionsSvc struct {
+	corporateActionRepo repo.ICorporateActionRepo
+}
+
+func NewImportCorporateActionsSvc(corporateActionRepo repo.ICorporateActionRepo) ImportCorporateActionsSvc {
+	return &importCorporateActionsSvc{
+		corporateActionRepo: corporateActionRepo,
+	}
+}
+
+// Handle validates the whole file before storing anything, entries already on the calendar are skipped
+func (s *importCorporateActionsSvc) Handle(
+	ctx context.Context,
+	req *dto.ImportCorporateActionsReq,
+) (*dto.ImportCorporateActionsResult, error) {
+	reqs, err := mapper.ParseCorporateActions(req.Format, req.Content)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+
+	actions := make([]*model.CorporateAction, 0, len(reqs))
+	for i, r := range reqs {
+		action := mapper.ToCorporateActionModel(r)
+		if err := action.Validate(); err != nil {
+			return nil, errors.NewValidationError(fmt.Sprintf("entry %d: %s", i+1, err.Error()), err)
+		}
+		actions = append(actions, action)
+	}
+
+	result := &dto.ImportCorporateActionsResult{}
+	for _, action := range actions {
+		created, err := s.corporateActionRepo.Create(ctx, action)
+		if err != nil {
+			return nil, errors.NewPersistenceError("failed to create corporate action", err)
+		}
+		if created {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
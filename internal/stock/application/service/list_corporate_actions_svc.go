@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/repo"
+	"strings"
+)
+
+const defaultCorporateActionsLimit = 100
+
+type ListCorporateActionsSvc interface {
+	Handle(ctx context.Context, req *dto.ListCorporateActionsReq) ([]*dto.CorporateActionDto, error)
+}
+
+type listCorporateActionsSvc struct {
+	corporateActionRepo repo.ICorporateActionRepo
+}
+
+func NewListCorporateActionsSvc(corporateActionRepo repo.ICorporateActionRepo) ListCorporateActionsSvc {
+	return &listCorporateActionsSvc{
+		corporateActionRepo: corporateActionRepo,
+	}
+}
+
+func (s *listCorporateActionsSvc) Handle(
+	ctx context.Context,
+	req *dto.ListCorporateActionsReq,
+) ([]*dto.CorporateActionDto, error) {
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultCorporateActionsLimit
+	}
+
+	actions, err := s.corporateActionRepo.List(ctx, strings.ToUpper(req.Symbol), limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.ToCorporateActionDtos(actions), nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+	"strings"
+)
+
+const (
+	defaultHoldersLimit = 1000
+	maxHoldersLimit     = 10000
+)
+
+type ListHoldersSvc interface {
+	Handle(ctx context.Context, req *dto.ListHoldersReq) (*dto.HoldersDto, error)
+}
+
+type listHoldersSvc struct {
+	accountRepo repo.IAccountRepo
+}
+
+func NewListHoldersSvc(accountRepo repo.IAccountRepo) ListHoldersSvc {
+	return &listHoldersSvc{
+		accountRepo: accountRepo,
+	}
+}
+
+func (s *listHoldersSvc) Handle(ctx context.Context, req *dto.ListHoldersReq) (*dto.HoldersDto, error) {
+	symbol := strings.ToUpper(strings.TrimSpace(req.Symbol))
+	if symbol == "" {
+		return nil, errors.NewValidationError("symbol is required", nil)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultHoldersLimit
+	}
+	limit = min(limit, maxHoldersLimit)
+
+	userIDs, err := s.accountRepo.ListHolders(ctx, symbol, req.AfterUserID, limit)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to list holders", err)
+	}
+	total, err := s.accountRepo.CountHolders(ctx, symbol)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to count holders", err)
+	}
+	if userIDs == nil {
+		userIDs = []uint64{}
+	}
+	return &dto.HoldersDto{UserIDs: userIDs, Total: total}, nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+	"strings"
+)
+
+const defaultOrdersLimit = 50
+
+type ListOrdersSvc interface {
+	Handle(ctx context.Context, req *dto.ListOrdersReq) ([]*dto.StockOrderDto, error)
+}
+
+type listOrdersSvc struct {
+	stockOrderRepo repo.IStockOrderRepo
+}
+
+func NewListOrdersSvc(stockOrderRepo repo.IStockOrderRepo) ListOrdersSvc {
+	return &listOrdersSvc{
+		stockOrderRepo: stockOrderRepo,
+	}
+}
+
+func (s *listOrdersSvc) Handle(ctx context.Context, req *dto.ListOrdersReq) ([]*dto.StockOrderDto, error) {
+	if req.UserID == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultOrdersLimit
+	}
+
+	status := model.OrderStatus(strings.ToUpper(strings.TrimSpace(req.Status)))
+	orders, err := s.stockOrderRepo.List(ctx, req.UserID, status, limit, req.Offset)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to list orders", err)
+	}
+	return mapper.ToStockOrderDtos(orders), nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/errors"
+	"strings"
+)
+
+const defaultStatementsLimit = 24
+
+type ListStatementsSvc interface {
+	Handle(ctx context.Context, req *dto.ListStatementsReq) ([]*dto.StatementDto, error)
+}
+
+type listStatementsSvc struct {
+	statementRepo repo.IStatementRepo
+}
+
+func NewListStatementsSvc(statementRepo repo.IStatementRepo) ListStatementsSvc {
+	return &listStatementsSvc{
+		statementRepo: statementRepo,
+	}
+}
+
+func (s *listStatementsSvc) Handle(ctx context.Context, req *dto.ListStatementsReq) ([]*dto.StatementDto, error) {
+	if req.UserID == 0 {
+		return nil, errors.NewValidationError("user_id is required", nil)
+	}
+
+	statementType := model.StatementType(strings.ToUpper(strings.TrimSpace(req.Type)))
+	switch statementType {
+	case "", model.StatementTypeConfirmation, model.StatementTypeMonthly:
+	default:
+		return nil, errors.NewValidationError("type must be CONFIRMATION or MONTHLY", nil)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultStatementsLimit
+	}
+
+	statements, err := s.statementRepo.List(ctx, req.UserID, statementType, limit, req.Offset)
+	if err != nil {
+		return nil, errors.NewPersistenceError("failed to list statements", err)
+	}
+	return mapper.ToStatementDtos(statements), nil
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/application/market"
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/pkg/errors"
+	"simple-securities/pkg/uuid"
+	"strings"
+	"time"
+)
+
+type PlaceOrderSvc interface {
+	Handle(ctx context.Context, req *dto.PlaceOrderReq) (*dto.StockOrderDto, error)
+}
+
+type placeOrderSvc struct {
+	validator  *market.OrderValidator
+	orderStore *order.Store
+}
+
+func NewPlaceOrderSvc(validator *market.OrderValidator, orderStore *order.Store) PlaceOrderSvc {
+	return &placeOrderSvc{
+		validator:  validator,
+		orderStore: orderStore,
+	}
+}
+
+// Handle checks the order against the market hours and starts its event stream
+func (s *placeOrderSvc) Handle(ctx context.Context, req *dto.PlaceOrderReq) (*dto.StockOrderDto, error) {
+	timeInForce := model.TimeInForce(strings.ToUpper(strings.TrimSpace(req.TimeInForce)))
+	if timeInForce == "" {
+		timeInForce = model.TimeInForceDay
+	}
+
+	stockOrder := &model.StockOrder{
+		Uuid:          uuid.NewGoogleUUID(),
+		UserID:        req.UserID,
+		Symbol:        strings.ToUpper(strings.TrimSpace(req.Symbol)),
+		Side:          model.OrderSide(strings.ToUpper(strings.TrimSpace(req.Side))),
+		Type:          model.OrderType(strings.ToUpper(strings.TrimSpace(req.Type))),
+		TimeInForce:   timeInForce,
+		ExtendedHours: req.ExtendedHours,
+		Price:         req.Price,
+		StopPrice:     req.StopPrice,
+		Quantity:      req.Quantity,
+	}
+	if err := s.validator.Validate(stockOrder, time.Now()); err != nil {
+		return nil, errors.NewValidationError(err.Error(), err)
+	}
+
+	aggregate, err := s.orderStore.Update(ctx, stockOrder.Uuid, func(aggregate *model.OrderAggregate) error {
+		return aggregate.Place(stockOrder)
+	})
+	if err != nil {
+		return nil, toOrderError("failed to place order", err)
+	}
+	return mapper.ToStockOrderDto(&aggregate.Order), nil
+}
+
+// toOrderError keeps the application errors raised by a command and maps the store failures
+func toOrderError(message string, err error) error {
+	var appErr *errors.AppError
+	if stderrors.As(err, &appErr) {
+		return err
+	}
+	if stderrors.Is(err, model.ErrVersionConflict) {
+		return errors.New(errors.ErrorTypeConflict, "order was changed concurrently, please retry")
+	}
+	return errors.NewPersistenceError(message, err)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"simple-securities/internal/stock/application/dto"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/application/market"
+	"simple-securities/internal/stock/application/order"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/pkg/errors"
+	"simple-securities/pkg/fee"
+	"simple-securities/pkg/uuid"
+	"strings"
+	"time"
+)
+
+type ReportExecutionSvc interface {
+	Handle(ctx context.Context, req *dto.ReportExecutionReq) (*dto.ReportExecutionResult, error)
+}
+
+type reportExecutionSvc struct {
+	orderStore *order.Store
+	feePricer  *market.FeePricer
+}
+
+func NewReportExecutionSvc(orderStore *order.Store, feePricer *market.FeePricer) ReportExecutionSvc {
+	return &reportExecutionSvc{
+		orderStore: orderStore,
+		feePricer:  feePricer,
+	}
+}
+
+// Handle records an execution of the venue on the order stream with its fee and fee ledger entries.
+// The realized P&L of the fill is worked out by the projection and served by GetOrder.
+func (s *reportExecutionSvc) Handle(ctx context.Context, req *dto.ReportExecutionReq) (*dto.ReportExecutionResult, error) {
+	if req.Uuid == "" {
+		return nil, errors.NewValidationError("uuid is required", nil)
+	}
+
+	liquidity := fee.Liquidity(strings.ToUpper(strings.TrimSpace(req.Liquidity)))
+	switch liquidity {
+	case "":
+		liquidity = fee.LiquidityTaker
+	case fee.LiquidityMaker, fee.LiquidityTaker:
+	default:
+		return nil, errors.NewValidationError("liquidity must be MAKER or TAKER", nil)
+	}
+
+	executedAt := time.Now()
+	if req.ExecutedAt != 0 {
+		executedAt = time.Unix(int64(req.ExecutedAt), 0)
+	}
+
+	var fill *model.Fill
+	aggregate, err := s.orderStore.Update(ctx, req.Uuid, func(aggregate *model.OrderAggregate) error {
+		if !aggregate.Exists() {
+			return errors.NewNotFoundError("order not found", nil)
+		}
+
+		fill = &model.Fill{
+			Uuid:       uuid.NewGoogleUUID(),
+			OrderUuid:  aggregate.Order.Uuid,
+			UserID:     aggregate.Order.UserID,
+			Symbol:     aggregate.Order.Symbol,
+			Side:       aggregate.Order.Side,
+			Quantity:   req.Quantity,
+			Price:      req.Price,
+			Liquidity:  liquidity,
+			ExecutedAt: executedAt,
+		}
+		if err := s.feePricer.Price(ctx, fill); err != nil {
+			return errors.NewPersistenceError("failed to price fill", err)
+		}
+		if err := aggregate.Fill(fill); err != nil {
+			return errors.NewBusinessError(err.Error(), err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, toOrderError("failed to report execution", err)
+	}
+
+	return &dto.ReportExecutionResult{
+		Order: mapper.ToStockOrderDto(&aggregate.Order),
+		Fill:  mapper.ToFillDto(fill),
+	}, nil
+}
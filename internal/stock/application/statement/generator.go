@@ -0,0 +1,266 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/calendar"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NotificationType is the notification type sent when a new statement is ready
+const NotificationType = "statement"
+
+// Generator builds the statements of the accounts from the fills store and the cash ledger.
+// Periods follow the calendar days of the exchange time zone.
+type Generator struct {
+	exchange           *calendar.Exchange
+	accountRepo        repo.IAccountRepo
+	statementRepo      repo.IStatementRepo
+	notificationClient repo.INotificationClient
+	logger             *zap.Logger
+}
+
+func NewGenerator(
+	exchange *calendar.Exchange,
+	accountRepo repo.IAccountRepo,
+	statementRepo repo.IStatementRepo,
+	notificationClient repo.INotificationClient,
+	logger *zap.Logger,
+) *Generator {
+	return &Generator{
+		exchange:           exchange,
+		accountRepo:        accountRepo,
+		statementRepo:      statementRepo,
+		notificationClient: notificationClient,
+		logger:             logger,
+	}
+}
+
+// GenerateConfirmations builds the trade confirmations of every user who traded on the day of t
+// and returns how many were created
+func (g *Generator) GenerateConfirmations(ctx context.Context, t time.Time) (int, error) {
+	from := startOfDay(t.In(g.exchange.Location))
+	to := from.AddDate(0, 0, 1)
+	date := from.Format(calendar.DateLayout)
+
+	userIDs, err := g.accountRepo.ListTradingUsers(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	var created int
+	for _, userID := range userIDs {
+		ok, err := g.generate(ctx, userID, model.StatementTypeConfirmation, from, to, date, date)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// GenerateConfirmationsThrough builds the trade confirmations of every day from the one of the
+// last confirmation produced through the day of last, so that the days missed while the service
+// was down are caught up. Without any confirmation yet it starts at the day of the first fill.
+// It returns how many were created.
+func (g *Generator) GenerateConfirmationsThrough(ctx context.Context, last time.Time) (int, error) {
+	last = startOfDay(last.In(g.exchange.Location))
+
+	// The day of the last confirmation is generated again, it may have been cut short
+	from := last
+	periodStart, err := g.statementRepo.LastPeriodStart(ctx, model.StatementTypeConfirmation)
+	if err != nil {
+		return 0, err
+	}
+	if periodStart != "" {
+		if from, err = time.ParseInLocation(calendar.DateLayout, periodStart, g.exchange.Location); err != nil {
+			return 0, fmt.Errorf("invalid confirmation period %q: %w", periodStart, err)
+		}
+	} else {
+		firstFill, err := g.accountRepo.GetFirstFillTime(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if firstFill != nil {
+			from = startOfDay(firstFill.In(g.exchange.Location))
+		}
+	}
+
+	var created int
+	for day := from; !day.After(last); day = day.AddDate(0, 0, 1) {
+		n, err := g.GenerateConfirmations(ctx, day)
+		created += n
+		if err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
+
+// GenerateMonthly builds the statements of the month of t for every account and returns how many were
+// created. Holdings are the positions at generation time valued at the last close of the month, so
+// the statement is meant to be generated right after the month ends.
+func (g *Generator) GenerateMonthly(ctx context.Context, t time.Time) (int, error) {
+	local := t.In(g.exchange.Location)
+	from := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, g.exchange.Location)
+	to := from.AddDate(0, 1, 0)
+
+	userIDs, err := g.accountRepo.ListAccountUsers(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	var created int
+	for _, userID := range userIDs {
+		ok, err := g.generate(ctx, userID, model.StatementTypeMonthly, from, to,
+			from.Format(calendar.DateLayout), to.AddDate(0, 0, -1).Format(calendar.DateLayout))
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+func (g *Generator) generate(
+	ctx context.Context,
+	userID uint64,
+	statementType model.StatementType,
+	from, to time.Time,
+	periodStart, periodEnd string,
+) (bool, error) {
+	exists, err := g.statementRepo.Exists(ctx, userID, statementType, periodStart)
+	if err != nil || exists {
+		return false, err
+	}
+
+	data, err := g.collect(ctx, userID, statementType, from, to)
+	if err != nil {
+		return false, err
+	}
+	data.PeriodStart, data.PeriodEnd = periodStart, periodEnd
+
+	html, err := RenderHTML(data)
+	if err != nil {
+		return false, err
+	}
+	csv, err := RenderCSV(data)
+	if err != nil {
+		return false, err
+	}
+
+	statement := model.NewStatement(userID, statementType, periodStart, periodEnd, html, csv)
+	created, err := g.statementRepo.Create(ctx, statement)
+	if err != nil || !created {
+		return false, err
+	}
+
+	g.logger.Info("🧾 statement generated",
+		zap.String("uuid", statement.Uuid),
+		zap.Uint64("user_id", userID),
+		zap.String("type", string(statementType)),
+		zap.String("period_start", periodStart),
+	)
+
+	// The statement is stored, a failed notification does not undo it
+	title, body := notificationText(statement)
+	if err := g.notificationClient.Send(ctx, userID, NotificationType, title, body); err != nil {
+		g.logger.Warn("failed to notify statement",
+			zap.String("uuid", statement.Uuid),
+			zap.Uint64("user_id", userID),
+			zap.Error(err),
+		)
+	}
+	return true, nil
+}
+
+// collect reads the activity of the period, holdings are only part of monthly statements
+func (g *Generator) collect(
+	ctx context.Context,
+	userID uint64,
+	statementType model.StatementType,
+	from, to time.Time,
+) (*model.StatementData, error) {
+	data := &model.StatementData{
+		UserID:      userID,
+		Type:        statementType,
+		GeneratedAt: time.Now(),
+	}
+
+	fills, err := g.accountRepo.ListFills(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	data.Fills = fills
+	for _, f := range fills {
+		data.Summary.Fees += f.Fee
+		data.Summary.RealizedPnL += f.RealizedPnL
+	}
+
+	if statementType != model.StatementTypeMonthly {
+		return data, nil
+	}
+
+	movements, err := g.accountRepo.ListCashMovements(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	data.CashMovements = movements
+	for _, m := range movements {
+		data.Summary.NetCash += m.Amount
+	}
+
+	positions, err := g.accountRepo.ListPositions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range positions {
+		price, err := g.accountRepo.GetClosePrice(ctx, p.Symbol, to)
+		if err != nil {
+			return nil, err
+		}
+		holding := &model.StatementHolding{
+			Symbol:      p.Symbol,
+			Quantity:    p.Quantity,
+			CostBasis:   p.CostBasis,
+			ClosePrice:  price,
+			MarketValue: p.Quantity * price,
+		}
+		// Without a close the position is carried at cost
+		if price == 0 {
+			holding.MarketValue = p.CostBasis
+		}
+		holding.UnrealizedPnL = holding.MarketValue - holding.CostBasis
+		data.Holdings = append(data.Holdings, holding)
+
+		data.Summary.MarketValue += holding.MarketValue
+		data.Summary.CostBasis += holding.CostBasis
+		data.Summary.UnrealizedPnL += holding.UnrealizedPnL
+	}
+	return data, nil
+}
+
+func notificationText(statement *model.Statement) (string, string) {
+	if statement.Type == model.StatementTypeConfirmation {
+		return "Trade confirmation ready",
+			fmt.Sprintf("Your trade confirmation for %s is ready to download.", statement.PeriodStart)
+	}
+	month, err := time.Parse(calendar.DateLayout, statement.PeriodStart)
+	if err != nil {
+		return "Monthly statement ready", "Your monthly statement is ready to download."
+	}
+	return "Monthly statement ready",
+		fmt.Sprintf("Your statement for %s is ready to download.", month.Format("January 2006"))
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
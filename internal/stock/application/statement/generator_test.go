@@ -0,0 +1,93 @@
+package statement
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"simple-securities/internal/stock/domain/model"
+	infrasRepo "simple-securities/internal/stock/infras/repo"
+	"simple-securities/pkg/calendar"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+// fakeNotifier counts the statements notified
+type fakeNotifier struct {
+	sent int
+}
+
+func (n *fakeNotifier) Send(ctx context.Context, userID uint64, nType, title, body string) error {
+	n.sent++
+	return nil
+}
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "stock.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, migration := range []string{
+		"000003_init_stockdb",
+		"000004_init_statements",
+		"000016_add_stock_fill_fees",
+	} {
+		schema, err := os.ReadFile("../../../../migrations/sqlite/" + migration + ".up.sql")
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+	return db
+}
+
+func insertFill(t *testing.T, db *sqlx.DB, uuid string, userID uint64, executedAt time.Time) {
+	t.Helper()
+	db.MustExec(`
+		INSERT INTO stock_fills (uuid, order_uuid, user_id, symbol, side, quantity, price, executed_at)
+		VALUES ($1, $1, $2, 'AAPL', 'BUY', 10, 200, $3)
+	`, uuid, userID, executedAt.UTC())
+}
+
+func TestConfirmationsCatchUpMissedDays(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	exchange := calendar.NewExchange("XNYS", time.UTC, calendar.Session{Open: 9*time.Hour + 30*time.Minute, Close: 16 * time.Hour})
+	statementRepo := infrasRepo.NewStatementRepo(db)
+	notifier := &fakeNotifier{}
+	generator := NewGenerator(exchange, infrasRepo.NewAccountRepo(db), statementRepo, notifier, zap.NewNop())
+	scheduler := NewScheduler(time.Minute, exchange, generator, zap.NewNop())
+
+	// Monday to Thursday, the service runs on Tuesday evening then stops until Friday evening
+	insertFill(t, db, "mon", 1, time.Date(2026, 3, 2, 15, 0, 0, 0, time.UTC))
+	insertFill(t, db, "tue", 2, time.Date(2026, 3, 3, 15, 0, 0, 0, time.UTC))
+	scheduler.Run(ctx, time.Date(2026, 3, 3, 20, 0, 0, 0, time.UTC))
+	insertFill(t, db, "wed", 1, time.Date(2026, 3, 4, 15, 0, 0, 0, time.UTC))
+	insertFill(t, db, "thu", 2, time.Date(2026, 3, 5, 15, 0, 0, 0, time.UTC))
+	scheduler.Run(ctx, time.Date(2026, 3, 6, 20, 0, 0, 0, time.UTC))
+
+	want := map[uint64][]string{1: {"2026-03-04", "2026-03-02"}, 2: {"2026-03-05", "2026-03-03"}}
+	for userID, periods := range want {
+		statements, err := statementRepo.List(ctx, userID, model.StatementTypeConfirmation, 10, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, statement := range statements {
+			got = append(got, statement.PeriodStart)
+		}
+		if len(got) != len(periods) || got[0] != periods[0] || got[1] != periods[1] {
+			t.Errorf("confirmations of user %d = %v, want %v", userID, got, periods)
+		}
+	}
+	if notifier.sent != 4 {
+		t.Errorf("%d confirmations notified, want 4", notifier.sent)
+	}
+}
@@ -0,0 +1,128 @@
+package statement
+
+import (
+	"bytes"
+	"encoding/csv"
+	"html/template"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/pkg/datetime"
+	"strconv"
+	"time"
+)
+
+var statementTemplate = template.Must(template.New("statement").Funcs(template.FuncMap{
+	"money": formatMoney,
+	"qty":   formatQuantity,
+	"time":  formatTime,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{if eq .Type "CONFIRMATION"}}Trade confirmation {{.PeriodStart}}{{else}}Statement {{.PeriodStart}} - {{.PeriodEnd}}{{end}}</title>
+<style>
+body { font-family: sans-serif; font-size: 13px; }
+table { border-collapse: collapse; margin-bottom: 24px; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: right; }
+th:first-child, td:first-child { text-align: left; }
+</style>
+</head>
+<body>
+{{if eq .Type "CONFIRMATION"}}<h1>Trade confirmation</h1>
+<p>Account {{.UserID}} &middot; Trade date {{.PeriodStart}}</p>
+{{else}}<h1>Account statement</h1>
+<p>Account {{.UserID}} &middot; Period {{.PeriodStart}} to {{.PeriodEnd}}</p>
+<h2>Summary</h2>
+<table>
+<tr><th>Market value</th><td>{{money .Summary.MarketValue}}</td></tr>
+<tr><th>Cost basis</th><td>{{money .Summary.CostBasis}}</td></tr>
+<tr><th>Unrealized P&amp;L</th><td>{{money .Summary.UnrealizedPnL}}</td></tr>
+<tr><th>Realized P&amp;L</th><td>{{money .Summary.RealizedPnL}}</td></tr>
+<tr><th>Fees</th><td>{{money .Summary.Fees}}</td></tr>
+<tr><th>Net cash movements</th><td>{{money .Summary.NetCash}}</td></tr>
+</table>
+<h2>Holdings</h2>
+<table>
+<tr><th>Symbol</th><th>Quantity</th><th>Cost basis</th><th>Close</th><th>Market value</th><th>Unrealized P&amp;L</th></tr>
+{{range .Holdings}}<tr><td>{{.Symbol}}</td><td>{{qty .Quantity}}</td><td>{{money .CostBasis}}</td><td>{{money .ClosePrice}}</td><td>{{money .MarketValue}}</td><td>{{money .UnrealizedPnL}}</td></tr>
+{{else}}<tr><td colspan="6">No holdings</td></tr>
+{{end}}</table>
+<h2>Cash movements</h2>
+<table>
+<tr><th>Date</th><th>Type</th><th>Symbol</th><th>Amount</th><th>Reference</th></tr>
+{{range .CashMovements}}<tr><td>{{time .CreatedAt}}</td><td>{{.Type}}</td><td>{{.Symbol}}</td><td>{{money .Amount}}</td><td>{{.Reference}}</td></tr>
+{{else}}<tr><td colspan="5">No cash movements</td></tr>
+{{end}}</table>
+{{end}}<h2>Trades</h2>
+<table>
+<tr><th>Executed at</th><th>Symbol</th><th>Side</th><th>Quantity</th><th>Price</th><th>Notional</th><th>Fee</th><th>Realized P&amp;L</th></tr>
+{{range .Fills}}<tr><td>{{time .ExecutedAt}}</td><td>{{.Symbol}}</td><td>{{.Side}}</td><td>{{qty .Quantity}}</td><td>{{money .Price}}</td><td>{{money .Notional}}</td><td>{{money .Fee}}</td><td>{{money .RealizedPnL}}</td></tr>
+{{else}}<tr><td colspan="8">No trades</td></tr>
+{{end}}</table>
+<p>Total fees {{money .Summary.Fees}} &middot; Generated {{time .GeneratedAt}}</p>
+</body>
+</html>
+`))
+
+// RenderHTML renders the statement as a standalone HTML page
+func RenderHTML(data *model.StatementData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := statementTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RenderCSV renders the statement as CSV, the first column names the section of every row
+func RenderCSV(data *model.StatementData) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{
+		{"section", "user_id", "type", "period_start", "period_end"},
+		{"statement", strconv.FormatUint(data.UserID, 10), string(data.Type), data.PeriodStart, data.PeriodEnd},
+	}
+
+	if data.Type == model.StatementTypeMonthly {
+		rows = append(rows,
+			[]string{"section", "market_value", "cost_basis", "unrealized_pnl", "realized_pnl", "fees", "net_cash"},
+			[]string{"summary", formatMoney(data.Summary.MarketValue), formatMoney(data.Summary.CostBasis),
+				formatMoney(data.Summary.UnrealizedPnL), formatMoney(data.Summary.RealizedPnL),
+				formatMoney(data.Summary.Fees), formatMoney(data.Summary.NetCash)},
+			[]string{"section", "symbol", "quantity", "cost_basis", "close_price", "market_value", "unrealized_pnl"},
+		)
+		for _, h := range data.Holdings {
+			rows = append(rows, []string{"holding", h.Symbol, formatQuantity(h.Quantity), formatMoney(h.CostBasis),
+				formatMoney(h.ClosePrice), formatMoney(h.MarketValue), formatMoney(h.UnrealizedPnL)})
+		}
+
+		rows = append(rows, []string{"section", "created_at", "type", "symbol", "amount", "reference"})
+		for _, m := range data.CashMovements {
+			rows = append(rows, []string{"cash", formatTime(m.CreatedAt), m.Type, m.Symbol,
+				formatMoney(m.Amount), m.Reference})
+		}
+	}
+
+	rows = append(rows, []string{"section", "executed_at", "symbol", "side", "quantity", "price", "notional", "fee", "realized_pnl"})
+	for _, f := range data.Fills {
+		rows = append(rows, []string{"fill", formatTime(f.ExecutedAt), f.Symbol, string(f.Side),
+			formatQuantity(f.Quantity), formatMoney(f.Price), formatMoney(f.Notional()), formatMoney(f.Fee),
+			formatMoney(f.RealizedPnL)})
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func formatMoney(v float64) string {
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+func formatTime(t time.Time) string {
+	return t.Format(datetime.YYYY_MM_DD_HH_MM_SS)
+}
+
+func formatQuantity(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,87 @@
+package statement
+
+import (
+	"context"
+	"simple-securities/pkg/calendar"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// lookbackDays bounds the search for the last completed trading day
+const lookbackDays = 14
+
+// Scheduler generates the confirmations of the trading days completed since the last confirmation
+// and the statements of the previous month, statements already generated are skipped so every
+// check is idempotent
+type Scheduler struct {
+	interval  time.Duration
+	exchange  *calendar.Exchange
+	generator *Generator
+	logger    *zap.Logger
+}
+
+func NewScheduler(interval time.Duration, exchange *calendar.Exchange, generator *Generator, logger *zap.Logger) *Scheduler {
+	if interval <= 0 {
+		interval = 10 * time.Minute
+	}
+	return &Scheduler{
+		interval:  interval,
+		exchange:  exchange,
+		generator: generator,
+		logger:    logger,
+	}
+}
+
+// Start generates the due statements right away and then on every interval until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.logger.Info("🧾 statement scheduler started", zap.Duration("interval", s.interval))
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		s.Run(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			s.logger.Info("statement scheduler stopped")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run generates the statements due at now
+func (s *Scheduler) Run(ctx context.Context, now time.Time) {
+	if day, ok := s.lastCompletedDay(now); ok {
+		if created, err := s.generator.GenerateConfirmationsThrough(ctx, day); err != nil {
+			s.logger.Error("failed to generate trade confirmations", zap.Error(err))
+		} else if created > 0 {
+			s.logger.Info("trade confirmations generated", zap.Time("through", day), zap.Int("statements", created))
+		}
+	}
+
+	local := now.In(s.exchange.Location)
+	previousMonth := time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, s.exchange.Location).AddDate(0, -1, 0)
+	if created, err := s.generator.GenerateMonthly(ctx, previousMonth); err != nil {
+		s.logger.Error("failed to generate monthly statements", zap.Error(err))
+	} else if created > 0 {
+		s.logger.Info("monthly statements generated",
+			zap.String("month", previousMonth.Format("2006-01")), zap.Int("statements", created))
+	}
+}
+
+// lastCompletedDay finds the latest trading day whose last session has ended
+func (s *Scheduler) lastCompletedDay(now time.Time) (time.Time, bool) {
+	local := now.In(s.exchange.Location)
+	for i := 0; i < lookbackDays; i++ {
+		t := local.AddDate(0, 0, -i)
+		day := s.exchange.Day(t)
+		if day.Regular.IsZero() || day.End().After(now) {
+			continue
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,18 @@
+package model
+
+// Bar is one OHLCV candle, OpenTime is in unix milliseconds
+type Bar struct {
+	ID       uint64  `db:"id"`
+	Symbol   string  `db:"symbol"`
+	Interval string  `db:"interval"`
+	OpenTime int64   `db:"open_time"`
+	Open     float64 `db:"open"`
+	High     float64 `db:"high"`
+	Low      float64 `db:"low"`
+	Close    float64 `db:"close"`
+	Volume   float64 `db:"volume"`
+}
+
+func (b Bar) TableName() string {
+	return "bars"
+}
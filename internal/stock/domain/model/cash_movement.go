@@ -0,0 +1,43 @@
+package model
+
+import (
+	"simple-securities/pkg/uuid"
+	"time"
+)
+
+const (
+	// CashMovementFee is the commission charged on a fill
+	CashMovementFee = "FEE"
+	// CashMovementPassThroughFee is the exchange and regulatory fee charged at cost on a fill
+	CashMovementPassThroughFee = "PASS_THROUGH_FEE"
+	// CashMovementDividend is a cash dividend credited to the holders, or debited to the short ones
+	CashMovementDividend = "DIVIDEND"
+)
+
+// CashMovement is an entry of the cash ledger of a user, credits are positive
+type CashMovement struct {
+	ID        uint64    `db:"id"`
+	Uuid      string    `db:"uuid"`
+	UserID    uint64    `db:"user_id"`
+	Symbol    string    `db:"symbol"`
+	Type      string    `db:"type"`
+	Amount    float64   `db:"amount"`
+	Reference string    `db:"reference"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (c CashMovement) TableName() string {
+	return "cash_movements"
+}
+
+func NewCashMovement(userID uint64, symbol, movementType string, amount float64, reference string) *CashMovement {
+	return &CashMovement{
+		Uuid:      uuid.NewGoogleUUID(),
+		UserID:    userID,
+		Symbol:    symbol,
+		Type:      movementType,
+		Amount:    amount,
+		Reference: reference,
+		CreatedAt: time.Now(),
+	}
+}
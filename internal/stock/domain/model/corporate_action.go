@@ -0,0 +1,241 @@
+package model
+
+import (
+	"fmt"
+	"simple-securities/pkg/uuid"
+	"time"
+)
+
+// DateLayout is the layout of ex_date and pay_date
+const DateLayout = "2006-01-02"
+
+type CorporateActionType string
+
+const (
+	CorporateActionSplit         CorporateActionType = "SPLIT"
+	CorporateActionReverseSplit  CorporateActionType = "REVERSE_SPLIT"
+	CorporateActionCashDividend  CorporateActionType = "CASH_DIVIDEND"
+	CorporateActionStockDividend CorporateActionType = "STOCK_DIVIDEND"
+	CorporateActionSymbolChange  CorporateActionType = "SYMBOL_CHANGE"
+)
+
+type CorporateActionStatus string
+
+const (
+	CorporateActionStatusPending   CorporateActionStatus = "PENDING"
+	CorporateActionStatusProcessed CorporateActionStatus = "PROCESSED"
+	CorporateActionStatusFailed    CorporateActionStatus = "FAILED"
+)
+
+// CorporateAction is an entry of the corporate action calendar.
+//
+// The ratio is read as "ratio_from old shares become ratio_to new shares" for
+// splits and reverse splits (2:1 split => from 1, to 2) and as "ratio_to new
+// shares for every ratio_from held" for stock dividends (5% => from 100, to 5).
+// CashAmount is the dividend per share.
+type CorporateAction struct {
+	ID          uint64                `db:"id"`
+	Uuid        string                `db:"uuid"`
+	Symbol      string                `db:"symbol"`
+	Type        CorporateActionType   `db:"type"`
+	ExDate      string                `db:"ex_date"`
+	PayDate     string                `db:"pay_date"`
+	RatioFrom   float64               `db:"ratio_from"`
+	RatioTo     float64               `db:"ratio_to"`
+	CashAmount  float64               `db:"cash_amount"`
+	NewSymbol   string                `db:"new_symbol"`
+	Status      CorporateActionStatus `db:"status"`
+	Reason      string                `db:"reason"`
+	ProcessedAt *time.Time            `db:"processed_at"`
+	CreatedAt   time.Time             `db:"created_at"`
+	UpdatedAt   time.Time             `db:"updated_at"`
+}
+
+func (a CorporateAction) TableName() string {
+	return "corporate_actions"
+}
+
+func NewCorporateAction(
+	symbol string,
+	actionType CorporateActionType,
+	exDate, payDate string,
+	ratioFrom, ratioTo, cashAmount float64,
+	newSymbol string,
+) *CorporateAction {
+	now := time.Now()
+	return &CorporateAction{
+		Uuid:       uuid.NewGoogleUUID(),
+		Symbol:     symbol,
+		Type:       actionType,
+		ExDate:     exDate,
+		PayDate:    payDate,
+		RatioFrom:  ratioFrom,
+		RatioTo:    ratioTo,
+		CashAmount: cashAmount,
+		NewSymbol:  newSymbol,
+		Status:     CorporateActionStatusPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// Validate checks that the action carries the fields its type needs
+func (a *CorporateAction) Validate() error {
+	if a.Symbol == "" {
+		return fmt.Errorf("symbol is required")
+	}
+	if _, err := a.ExDateTime(); err != nil {
+		return fmt.Errorf("invalid ex_date %q, expected %s", a.ExDate, DateLayout)
+	}
+	if a.PayDate != "" {
+		if _, err := time.Parse(DateLayout, a.PayDate); err != nil {
+			return fmt.Errorf("invalid pay_date %q, expected %s", a.PayDate, DateLayout)
+		}
+	}
+
+	switch a.Type {
+	case CorporateActionSplit:
+		if a.RatioFrom <= 0 || a.RatioTo <= a.RatioFrom {
+			return fmt.Errorf("split needs ratio_to > ratio_from > 0")
+		}
+	case CorporateActionReverseSplit:
+		if a.RatioTo <= 0 || a.RatioFrom <= a.RatioTo {
+			return fmt.Errorf("reverse split needs ratio_from > ratio_to > 0")
+		}
+	case CorporateActionStockDividend:
+		if a.RatioFrom <= 0 || a.RatioTo <= 0 {
+			return fmt.Errorf("stock dividend needs ratio_from and ratio_to > 0")
+		}
+	case CorporateActionCashDividend:
+		if a.CashAmount <= 0 {
+			return fmt.Errorf("cash dividend needs cash_amount > 0")
+		}
+	case CorporateActionSymbolChange:
+		if a.NewSymbol == "" || a.NewSymbol == a.Symbol {
+			return fmt.Errorf("symbol change needs a new_symbol different from symbol")
+		}
+	default:
+		return fmt.Errorf("unsupported corporate action type %q", a.Type)
+	}
+	return nil
+}
+
+// ExDateTime parses the ex-date, the first day the stock trades without the entitlement
+func (a *CorporateAction) ExDateTime() (time.Time, error) {
+	return time.Parse(DateLayout, a.ExDate)
+}
+
+// ShareFactor is the number of shares held after the ex-date for every share held before
+func (a *CorporateAction) ShareFactor() float64 {
+	switch a.Type {
+	case CorporateActionSplit, CorporateActionReverseSplit:
+		return a.RatioTo / a.RatioFrom
+	case CorporateActionStockDividend:
+		return 1 + a.RatioTo/a.RatioFrom
+	}
+	return 1
+}
+
+// Describe renders the action for notifications
+func (a *CorporateAction) Describe() string {
+	switch a.Type {
+	case CorporateActionSplit:
+		return fmt.Sprintf("%g-for-%g stock split of %s", a.RatioTo, a.RatioFrom, a.Symbol)
+	case CorporateActionReverseSplit:
+		return fmt.Sprintf("1-for-%g reverse split of %s", a.RatioFrom/a.RatioTo, a.Symbol)
+	case CorporateActionStockDividend:
+		return fmt.Sprintf("stock dividend of %g shares per %g shares of %s", a.RatioTo, a.RatioFrom, a.Symbol)
+	case CorporateActionCashDividend:
+		return fmt.Sprintf("cash dividend of %g per share of %s", a.CashAmount, a.Symbol)
+	case CorporateActionSymbolChange:
+		return fmt.Sprintf("symbol change from %s to %s", a.Symbol, a.NewSymbol)
+	}
+	return fmt.Sprintf("%s of %s", a.Type, a.Symbol)
+}
+
+// CorporateActionStreamID is the event stream of the corporate action with the given uuid
+func CorporateActionStreamID(uuid string) string {
+	return "corporate-action-" + uuid
+}
+
+// Book computes how the action changes the holdings of the symbol and returns the event that
+// records it along with the open orders to adjust. Dividends get their ledger uuid here so that
+// a replay of the event posts the same movements.
+func (a *CorporateAction) Book(positions []*Position, orders []string) (*Event, error) {
+	factor := a.ShareFactor()
+	adjustments := make([]*PositionAdjustment, 0, len(positions))
+	for _, p := range positions {
+		adj := &PositionAdjustment{
+			UserID:      p.UserID,
+			Symbol:      p.Symbol,
+			OldQuantity: p.Quantity,
+			NewQuantity: p.Quantity * factor,
+		}
+		switch a.Type {
+		case CorporateActionCashDividend:
+			// Short holders owe the dividend, hence the signed amount
+			adj.CashAmount = p.Quantity * a.CashAmount
+			adj.MovementUuid = uuid.NewGoogleUUID()
+		case CorporateActionSymbolChange:
+			adj.NewSymbol = a.NewSymbol
+		}
+		adjustments = append(adjustments, adj)
+	}
+
+	return NewEvent(CorporateActionStreamID(a.Uuid), 1, EventCorporateActionApplied, &CorporateActionApplied{
+		ActionUuid:  a.Uuid,
+		Type:        a.Type,
+		Symbol:      a.Symbol,
+		NewSymbol:   a.NewSymbol,
+		Adjustments: adjustments,
+		Orders:      orders,
+	})
+}
+
+// AdjustOrder returns the terms of an open order of the symbol after the action: share counts
+// follow the share factor and prices its inverse, a cash dividend lowers the resting buy limits
+// and sell stops by the dividend and a symbol change moves the order to the new symbol
+func (a *CorporateAction) AdjustOrder(order *StockOrder) *OrderAdjusted {
+	adjusted := &OrderAdjusted{
+		ActionUuid:     a.Uuid,
+		Symbol:         order.Symbol,
+		Quantity:       order.Quantity,
+		FilledQuantity: order.FilledQuantity,
+		Price:          order.Price,
+		StopPrice:      order.StopPrice,
+	}
+
+	switch a.Type {
+	case CorporateActionSplit, CorporateActionReverseSplit, CorporateActionStockDividend:
+		factor := a.ShareFactor()
+		adjusted.Quantity *= factor
+		adjusted.FilledQuantity *= factor
+		adjusted.Price /= factor
+		adjusted.StopPrice /= factor
+	case CorporateActionCashDividend:
+		if order.Side == OrderSideBuy && (order.Type == OrderTypeLimit || order.Type == OrderTypeStopLimit) {
+			adjusted.Price = max(order.Price-a.CashAmount, 0)
+		}
+		if order.Side == OrderSideSell && (order.Type == OrderTypeStop || order.Type == OrderTypeStopLimit) {
+			adjusted.StopPrice = max(order.StopPrice-a.CashAmount, 0)
+			if order.Type == OrderTypeStopLimit {
+				adjusted.Price = max(order.Price-a.CashAmount, 0)
+			}
+		}
+	case CorporateActionSymbolChange:
+		adjusted.Symbol = a.NewSymbol
+	}
+	return adjusted
+}
+
+// PositionAdjustment records how one holding was changed by a corporate action, MovementUuid
+// is the cash ledger entry of a dividend
+type PositionAdjustment struct {
+	UserID       uint64  `json:"user_id"`
+	Symbol       string  `json:"symbol"`
+	NewSymbol    string  `json:"new_symbol,omitempty"`
+	OldQuantity  float64 `json:"old_quantity"`
+	NewQuantity  float64 `json:"new_quantity"`
+	CashAmount   float64 `json:"cash_amount,omitempty"`
+	MovementUuid string  `json:"movement_uuid,omitempty"`
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"simple-securities/pkg/eventstore"
+	"simple-securities/pkg/fee"
+	"simple-securities/pkg/uuid"
+	"time"
+)
+
+// ErrVersionConflict is returned when a stream was appended to since it was loaded
+var ErrVersionConflict = eventstore.ErrVersionConflict
+
+type EventType string
+
+const (
+	EventOrderPlaced        EventType = "order.placed"
+	EventOrderFilled        EventType = "order.filled"
+	EventOrderCancelled     EventType = "order.cancelled"
+	EventOrderExpired       EventType = "order.expired"
+	EventOrderAdjusted      EventType = "order.adjusted"
+	EventCashMovementPosted EventType = "ledger.cash_movement_posted"
+
+	EventCorporateActionApplied EventType = "corporate_action.applied"
+)
+
+// EventSchemaVersions is the current schema version of every event type. A payload
+// change that old readers cannot decode bumps the version of its type.
+var EventSchemaVersions = map[EventType]uint32{
+	EventOrderPlaced:        1,
+	EventOrderFilled:        1,
+	EventOrderCancelled:     1,
+	EventOrderExpired:       1,
+	EventOrderAdjusted:      1,
+	EventCashMovementPosted: 1,
+
+	EventCorporateActionApplied: 1,
+}
+
+// Event is an append-only fact of an aggregate stream. Version orders the events of
+// the stream from 1 and Position orders all the events of the store.
+type Event struct {
+	Position      uint64    `db:"position"`
+	Uuid          string    `db:"uuid"`
+	StreamID      string    `db:"stream_id"`
+	Version       uint64    `db:"version"`
+	Type          EventType `db:"type"`
+	SchemaVersion uint32    `db:"schema_version"`
+	Payload       []byte    `db:"payload"`
+	OccurredAt    time.Time `db:"occurred_at"`
+}
+
+func (e Event) TableName() string {
+	return "stock_events"
+}
+
+// Snapshot is the state of an aggregate after the event at Version
+type Snapshot struct {
+	StreamID      string
+	Version       uint64
+	SchemaVersion uint32
+	State         []byte
+	TakenAt       time.Time
+}
+
+// NewEvent encodes the payload of an event of the stream at the given version
+func NewEvent(streamID string, version uint64, eventType EventType, payload any) (*Event, error) {
+	schemaVersion, ok := EventSchemaVersions[eventType]
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %q", eventType)
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", eventType, err)
+	}
+	return &Event{
+		Uuid:          uuid.NewGoogleUUID(),
+		StreamID:      streamID,
+		Version:       version,
+		Type:          eventType,
+		SchemaVersion: schemaVersion,
+		Payload:       data,
+		OccurredAt:    time.Now(),
+	}, nil
+}
+
+// Decode reads the payload into v, events written by a newer schema are rejected
+func (e *Event) Decode(v any) error {
+	current, ok := EventSchemaVersions[e.Type]
+	if !ok {
+		return fmt.Errorf("unknown event type %q", e.Type)
+	}
+	if e.SchemaVersion > current {
+		return fmt.Errorf("%s schema version %d is newer than %d", e.Type, e.SchemaVersion, current)
+	}
+	if err := json.Unmarshal(e.Payload, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", e.Type, err)
+	}
+	return nil
+}
+
+type OrderPlaced struct {
+	UserID        uint64      `json:"user_id"`
+	Symbol        string      `json:"symbol"`
+	Side          OrderSide   `json:"side"`
+	Type          OrderType   `json:"type"`
+	TimeInForce   TimeInForce `json:"time_in_force"`
+	ExtendedHours bool        `json:"extended_hours"`
+	Price         float64     `json:"price"`
+	StopPrice     float64     `json:"stop_price"`
+	Quantity      float64     `json:"quantity"`
+	ExpiresAt     *time.Time  `json:"expires_at,omitempty"`
+}
+
+type OrderFilled struct {
+	FillUuid       string        `json:"fill_uuid"`
+	Quantity       float64       `json:"quantity"`
+	Price          float64       `json:"price"`
+	Liquidity      fee.Liquidity `json:"liquidity"`
+	Commission     float64       `json:"commission"`
+	PassThroughFee float64       `json:"pass_through_fee"`
+	Fee            float64       `json:"fee"`
+	ExecutedAt     time.Time     `json:"executed_at"`
+}
+
+type OrderCancelled struct {
+	Reason string `json:"reason"`
+}
+
+type OrderExpired struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// OrderAdjusted carries the new terms of an open order over a corporate action of its symbol
+type OrderAdjusted struct {
+	ActionUuid     string  `json:"action_uuid"`
+	Symbol         string  `json:"symbol"`
+	Quantity       float64 `json:"quantity"`
+	FilledQuantity float64 `json:"filled_quantity"`
+	Price          float64 `json:"price"`
+	StopPrice      float64 `json:"stop_price"`
+}
+
+type CashMovementPosted struct {
+	MovementUuid string  `json:"movement_uuid"`
+	UserID       uint64  `json:"user_id"`
+	Symbol       string  `json:"symbol"`
+	Type         string  `json:"type"`
+	Amount       float64 `json:"amount"`
+	Reference    string  `json:"reference"`
+}
+
+// CorporateActionApplied books a corporate action on the holdings of the symbol, the open
+// orders listed are adjusted by an OrderAdjusted event on their own streams
+type CorporateActionApplied struct {
+	ActionUuid  string                `json:"action_uuid"`
+	Type        CorporateActionType   `json:"type"`
+	Symbol      string                `json:"symbol"`
+	NewSymbol   string                `json:"new_symbol,omitempty"`
+	Adjustments []*PositionAdjustment `json:"adjustments"`
+	Orders      []string              `json:"orders"`
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"simple-securities/pkg/fee"
+	"time"
+)
+
+// Fill is one execution of a stock order, RealizedPnL is booked on fills that reduce a position.
+// Fee is the Commission net of discounts plus the PassThroughFee charged by the exchange and regulators.
+type Fill struct {
+	ID             uint64        `db:"id"`
+	Uuid           string        `db:"uuid"`
+	OrderUuid      string        `db:"order_uuid"`
+	UserID         uint64        `db:"user_id"`
+	Symbol         string        `db:"symbol"`
+	Side           OrderSide     `db:"side"`
+	Quantity       float64       `db:"quantity"`
+	Price          float64       `db:"price"`
+	Liquidity      fee.Liquidity `db:"liquidity"`
+	Commission     float64       `db:"commission"`
+	PassThroughFee float64       `db:"pass_through_fee"`
+	Fee            float64       `db:"fee"`
+	RealizedPnL    float64       `db:"realized_pnl"`
+	ExecutedAt     time.Time     `db:"executed_at"`
+}
+
+func (f Fill) TableName() string {
+	return "stock_fills"
+}
+
+// Notional is the traded value before fees
+func (f *Fill) Notional() float64 {
+	return f.Quantity * f.Price
+}
+
+// ApplyFee attaches the computed fee to the fill
+func (f *Fill) ApplyFee(b fee.Breakdown) {
+	f.Commission = b.Commission - b.Discount
+	f.PassThroughFee = b.PassThrough
+	f.Fee = b.Total
+}
+
+// FeeMovements returns the cash ledger debits of the fill fee, one entry per non-zero component
+func (f *Fill) FeeMovements() []*CashMovement {
+	var movements []*CashMovement
+	if f.Commission > 0 {
+		movements = append(movements, NewCashMovement(f.UserID, f.Symbol, CashMovementFee, -f.Commission, f.Uuid))
+	}
+	if f.PassThroughFee > 0 {
+		movements = append(movements, NewCashMovement(f.UserID, f.Symbol, CashMovementPassThroughFee, -f.PassThroughFee, f.Uuid))
+	}
+	return movements
+}
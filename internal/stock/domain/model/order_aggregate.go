@@ -0,0 +1,276 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// quantityEpsilon absorbs the float noise of summed fill quantities
+const quantityEpsilon = 1e-9
+
+// OrderSnapshotSchemaVersion is bumped when StockOrder changes in a way that older
+// snapshots no longer decode into, those snapshots are then skipped and the stream replayed
+const OrderSnapshotSchemaVersion uint32 = 1
+
+// OrderStreamID is the event stream of the order with the given uuid
+func OrderStreamID(uuid string) string {
+	return "order-" + uuid
+}
+
+// OrderAggregate is the write model of a stock order. It is rebuilt by replaying the
+// events of the order stream, commands check the rebuilt state and raise new events
+// that are kept as changes until the aggregate is saved.
+type OrderAggregate struct {
+	Order   StockOrder
+	Version uint64
+	changes []*Event
+}
+
+func NewOrderAggregate(uuid string) *OrderAggregate {
+	return &OrderAggregate{Order: StockOrder{Uuid: uuid}}
+}
+
+// Exists reports whether the order was placed
+func (a *OrderAggregate) Exists() bool {
+	return a.Version > 0
+}
+
+// Load replays the events of the stream in version order
+func (a *OrderAggregate) Load(events []*Event) error {
+	for _, e := range events {
+		if e.Version != a.Version+1 {
+			return fmt.Errorf("order %s: event version %d follows %d", a.Order.Uuid, e.Version, a.Version)
+		}
+		if err := a.apply(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshot captures the state of the order at its current version, unsaved changes included
+func (a *OrderAggregate) Snapshot() (*Snapshot, error) {
+	state, err := json.Marshal(a.Order)
+	if err != nil {
+		return nil, fmt.Errorf("order %s: failed to encode snapshot: %w", a.Order.Uuid, err)
+	}
+	return &Snapshot{
+		StreamID:      OrderStreamID(a.Order.Uuid),
+		Version:       a.Version,
+		SchemaVersion: OrderSnapshotSchemaVersion,
+		State:         state,
+		TakenAt:       time.Now(),
+	}, nil
+}
+
+// Restore starts the aggregate from a snapshot, the events after it are loaded on top
+func (a *OrderAggregate) Restore(snapshot *Snapshot) error {
+	if a.Exists() {
+		return fmt.Errorf("order %s: snapshot restored over version %d", a.Order.Uuid, a.Version)
+	}
+	if snapshot.SchemaVersion != OrderSnapshotSchemaVersion {
+		return fmt.Errorf("order %s: snapshot schema version %d, expected %d",
+			a.Order.Uuid, snapshot.SchemaVersion, OrderSnapshotSchemaVersion)
+	}
+
+	var order StockOrder
+	if err := json.Unmarshal(snapshot.State, &order); err != nil {
+		return fmt.Errorf("order %s: failed to decode snapshot: %w", a.Order.Uuid, err)
+	}
+	if order.Uuid != a.Order.Uuid {
+		return fmt.Errorf("order %s: snapshot belongs to order %s", a.Order.Uuid, order.Uuid)
+	}
+	a.Order = order
+	a.Version = snapshot.Version
+	return nil
+}
+
+// Changes returns the events raised since the aggregate was loaded
+func (a *OrderAggregate) Changes() []*Event {
+	return a.changes
+}
+
+// ClearChanges is called once the changes are stored
+func (a *OrderAggregate) ClearChanges() {
+	a.changes = nil
+}
+
+// Place accepts a new order, the order is expected to be validated against the market hours
+func (a *OrderAggregate) Place(order *StockOrder) error {
+	if a.Exists() {
+		return fmt.Errorf("order %s is already placed", a.Order.Uuid)
+	}
+	if err := order.Validate(); err != nil {
+		return err
+	}
+	return a.raise(EventOrderPlaced, &OrderPlaced{
+		UserID:        order.UserID,
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Type:          order.Type,
+		TimeInForce:   order.TimeInForce,
+		ExtendedHours: order.ExtendedHours,
+		Price:         order.Price,
+		StopPrice:     order.StopPrice,
+		Quantity:      order.Quantity,
+		ExpiresAt:     order.ExpiresAt,
+	})
+}
+
+// Fill records an execution of the order and posts its fee on the cash ledger
+func (a *OrderAggregate) Fill(fill *Fill) error {
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if fill.Quantity <= 0 || fill.Price <= 0 {
+		return fmt.Errorf("fill quantity and price must be greater than 0")
+	}
+	if fill.Quantity > a.Order.RemainingQuantity()+quantityEpsilon {
+		return fmt.Errorf("fill of %g exceeds the remaining %g of order %s",
+			fill.Quantity, a.Order.RemainingQuantity(), a.Order.Uuid)
+	}
+
+	err := a.raise(EventOrderFilled, &OrderFilled{
+		FillUuid:       fill.Uuid,
+		Quantity:       fill.Quantity,
+		Price:          fill.Price,
+		Liquidity:      fill.Liquidity,
+		Commission:     fill.Commission,
+		PassThroughFee: fill.PassThroughFee,
+		Fee:            fill.Fee,
+		ExecutedAt:     fill.ExecutedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, m := range fill.FeeMovements() {
+		err := a.raise(EventCashMovementPosted, &CashMovementPosted{
+			MovementUuid: m.Uuid,
+			UserID:       m.UserID,
+			Symbol:       m.Symbol,
+			Type:         m.Type,
+			Amount:       m.Amount,
+			Reference:    m.Reference,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Cancel withdraws the rest of the order
+func (a *OrderAggregate) Cancel(reason string) error {
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	return a.raise(EventOrderCancelled, &OrderCancelled{Reason: reason})
+}
+
+// Expire withdraws the rest of a DAY order once its expiry has passed
+func (a *OrderAggregate) Expire(now time.Time) error {
+	if err := a.checkOpen(); err != nil {
+		return err
+	}
+	if a.Order.ExpiresAt == nil || now.Before(*a.Order.ExpiresAt) {
+		return fmt.Errorf("order %s is not due to expire", a.Order.Uuid)
+	}
+	return a.raise(EventOrderExpired, &OrderExpired{ExpiresAt: *a.Order.ExpiresAt})
+}
+
+// Adjust carries the order over a corporate action of its symbol. An order closed before the
+// action reached it keeps its terms, and an action is only applied once.
+func (a *OrderAggregate) Adjust(action *CorporateAction) error {
+	if !a.Exists() {
+		return fmt.Errorf("order %s is not placed", a.Order.Uuid)
+	}
+	if !a.Order.Status.IsOpen() || slices.Contains(a.Order.CorporateActions, action.Uuid) {
+		return nil
+	}
+	if a.Order.Symbol != action.Symbol {
+		return fmt.Errorf("order %s is in %s, not %s", a.Order.Uuid, a.Order.Symbol, action.Symbol)
+	}
+	return a.raise(EventOrderAdjusted, action.AdjustOrder(&a.Order))
+}
+
+func (a *OrderAggregate) checkOpen() error {
+	if !a.Exists() {
+		return fmt.Errorf("order %s is not placed", a.Order.Uuid)
+	}
+	if !a.Order.Status.IsOpen() {
+		return fmt.Errorf("order %s is %s", a.Order.Uuid, a.Order.Status)
+	}
+	return nil
+}
+
+func (a *OrderAggregate) raise(eventType EventType, payload any) error {
+	e, err := NewEvent(OrderStreamID(a.Order.Uuid), a.Version+1, eventType, payload)
+	if err != nil {
+		return err
+	}
+	if err := a.apply(e); err != nil {
+		return err
+	}
+	a.changes = append(a.changes, e)
+	return nil
+}
+
+func (a *OrderAggregate) apply(e *Event) error {
+	switch e.Type {
+	case EventOrderPlaced:
+		var placed OrderPlaced
+		if err := e.Decode(&placed); err != nil {
+			return err
+		}
+		a.Order = StockOrder{
+			Uuid:          a.Order.Uuid,
+			UserID:        placed.UserID,
+			Symbol:        placed.Symbol,
+			Side:          placed.Side,
+			Type:          placed.Type,
+			TimeInForce:   placed.TimeInForce,
+			ExtendedHours: placed.ExtendedHours,
+			Price:         placed.Price,
+			StopPrice:     placed.StopPrice,
+			Quantity:      placed.Quantity,
+			Status:        OrderStatusNew,
+			ExpiresAt:     placed.ExpiresAt,
+			CreatedAt:     e.OccurredAt,
+		}
+	case EventOrderFilled:
+		var filled OrderFilled
+		if err := e.Decode(&filled); err != nil {
+			return err
+		}
+		a.Order.FilledQuantity += filled.Quantity
+		a.Order.Status = OrderStatusPartiallyFilled
+		if a.Order.RemainingQuantity() <= quantityEpsilon {
+			a.Order.Status = OrderStatusFilled
+		}
+	case EventOrderCancelled:
+		a.Order.Status = OrderStatusCancelled
+	case EventOrderExpired:
+		a.Order.Status = OrderStatusExpired
+	case EventOrderAdjusted:
+		var adjusted OrderAdjusted
+		if err := e.Decode(&adjusted); err != nil {
+			return err
+		}
+		a.Order.Symbol = adjusted.Symbol
+		a.Order.Quantity = adjusted.Quantity
+		a.Order.FilledQuantity = adjusted.FilledQuantity
+		a.Order.Price = adjusted.Price
+		a.Order.StopPrice = adjusted.StopPrice
+		a.Order.CorporateActions = append(a.Order.CorporateActions, adjusted.ActionUuid)
+	case EventCashMovementPosted:
+		// Ledger entries caused by the order do not change its state
+	default:
+		return fmt.Errorf("order %s: unexpected event %q", a.Order.Uuid, e.Type)
+	}
+	a.Version = e.Version
+	a.Order.UpdatedAt = e.OccurredAt
+	return nil
+}
@@ -0,0 +1,145 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func placedOrder(t *testing.T) *OrderAggregate {
+	t.Helper()
+	aggregate := NewOrderAggregate("order-1")
+	err := aggregate.Place(&StockOrder{Uuid: "order-1", UserID: 1, Symbol: "AAPL", Side: OrderSideBuy,
+		Type: OrderTypeLimit, TimeInForce: TimeInForceGTC, Price: 200, Quantity: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return aggregate
+}
+
+func fill(uuid string, quantity float64) *Fill {
+	return &Fill{Uuid: uuid, OrderUuid: "order-1", UserID: 1, Symbol: "AAPL", Side: OrderSideBuy,
+		Quantity: quantity, Price: 200, Commission: 1, ExecutedAt: time.Now()}
+}
+
+// render prints the state a replay has to agree on
+func render(a *OrderAggregate) string {
+	return fmt.Sprint(a.Version, a.Order.Symbol, a.Order.Quantity, a.Order.FilledQuantity, a.Order.Price,
+		a.Order.Status, a.Order.CorporateActions)
+}
+
+func TestFillRejectsOverfill(t *testing.T) {
+	aggregate := placedOrder(t)
+	if err := aggregate.Fill(fill("fill-1", 6)); err != nil {
+		t.Fatal(err)
+	}
+	version, changes := aggregate.Version, len(aggregate.Changes())
+	if err := aggregate.Fill(fill("fill-2", 5)); err == nil {
+		t.Fatal("Fill() past the remaining quantity succeeded")
+	}
+	if aggregate.Version != version || len(aggregate.Changes()) != changes || aggregate.Order.FilledQuantity != 6 {
+		t.Errorf("rejected fill changed the order to %s", render(aggregate))
+	}
+
+	if err := aggregate.Fill(fill("fill-2", 4)); err != nil {
+		t.Fatal(err)
+	}
+	if aggregate.Order.Status != OrderStatusFilled {
+		t.Errorf("order is %s once filled, want %s", aggregate.Order.Status, OrderStatusFilled)
+	}
+}
+
+func TestClosedOrderRejectsCommands(t *testing.T) {
+	aggregate := placedOrder(t)
+	if err := aggregate.Cancel("user"); err != nil {
+		t.Fatal(err)
+	}
+	version := aggregate.Version
+
+	if err := aggregate.Fill(fill("fill-1", 1)); err == nil {
+		t.Error("Fill() of a cancelled order succeeded")
+	}
+	if err := aggregate.Cancel("user"); err == nil {
+		t.Error("Cancel() of a cancelled order succeeded")
+	}
+	if err := aggregate.Expire(time.Now()); err == nil {
+		t.Error("Expire() of a cancelled order succeeded")
+	}
+	// A closed order keeps the terms it closed on
+	split := NewCorporateAction("AAPL", CorporateActionSplit, "2024-03-01", "", 1, 2, 0, "")
+	if err := aggregate.Adjust(split); err != nil {
+		t.Errorf("Adjust() of a cancelled order = %v", err)
+	}
+	if aggregate.Version != version {
+		t.Errorf("closed order moved to version %d, want %d", aggregate.Version, version)
+	}
+
+	if err := NewOrderAggregate("order-2").Cancel("user"); err == nil {
+		t.Error("Cancel() of an order never placed succeeded")
+	}
+}
+
+func TestAdjustIsAppliedOnce(t *testing.T) {
+	aggregate := placedOrder(t)
+	if err := aggregate.Fill(fill("fill-1", 4)); err != nil {
+		t.Fatal(err)
+	}
+	split := NewCorporateAction("AAPL", CorporateActionSplit, "2024-03-01", "", 1, 2, 0, "")
+	for i := 0; i < 2; i++ {
+		if err := aggregate.Adjust(split); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := render(aggregate), fmt.Sprint(4, "AAPL", 20, 8, 100, OrderStatusPartiallyFilled, []string{split.Uuid}); got != want {
+		t.Errorf("adjusted order = %s, want %s", got, want)
+	}
+
+	other := NewCorporateAction("MSFT", CorporateActionSplit, "2024-03-01", "", 1, 2, 0, "")
+	if err := aggregate.Adjust(other); err == nil {
+		t.Error("Adjust() by an action of another symbol succeeded")
+	}
+}
+
+func TestSnapshotAndReplayAgree(t *testing.T) {
+	aggregate := placedOrder(t)
+	if err := aggregate.Fill(fill("fill-1", 4)); err != nil {
+		t.Fatal(err)
+	}
+	snapshot, err := aggregate.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := aggregate.Adjust(NewCorporateAction("AAPL", CorporateActionSplit, "2024-03-01", "", 1, 2, 0, "")); err != nil {
+		t.Fatal(err)
+	}
+	if err := aggregate.Fill(fill("fill-2", 12)); err != nil {
+		t.Fatal(err)
+	}
+	events := aggregate.Changes()
+
+	replayed := NewOrderAggregate("order-1")
+	if err := replayed.Load(events); err != nil {
+		t.Fatal(err)
+	}
+	restored := NewOrderAggregate("order-1")
+	if err := restored.Restore(snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if err := restored.Load(events[snapshot.Version:]); err != nil {
+		t.Fatal(err)
+	}
+	for name, a := range map[string]*OrderAggregate{"replayed": replayed, "restored": restored} {
+		if got, want := render(a), render(aggregate); got != want {
+			t.Errorf("%s order = %s, want %s", name, got, want)
+		}
+	}
+
+	// A gap in the stream and a snapshot of another schema are refused
+	if err := NewOrderAggregate("order-1").Load(events[1:]); err == nil {
+		t.Error("Load() of a stream missing its first event succeeded")
+	}
+	snapshot.SchemaVersion++
+	if err := NewOrderAggregate("order-1").Restore(snapshot); err == nil {
+		t.Error("Restore() of a snapshot of another schema version succeeded")
+	}
+}
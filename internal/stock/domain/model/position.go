@@ -0,0 +1,49 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+// Position is the holding of one user in one symbol, CostBasis is the total cost paid
+type Position struct {
+	ID        uint64    `db:"id"`
+	UserID    uint64    `db:"user_id"`
+	Symbol    string    `db:"symbol"`
+	Quantity  float64   `db:"quantity"`
+	CostBasis float64   `db:"cost_basis"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (p Position) TableName() string {
+	return "positions"
+}
+
+// ApplyFill books a fill at average cost and returns the P&L it realizes before fees.
+// A fill that reduces the position realizes the difference with the average cost of the
+// closed quantity, the part of a fill that flips the position opens it at the fill price.
+func (p *Position) ApplyFill(side OrderSide, quantity, price float64) float64 {
+	signed := quantity
+	if side == OrderSideSell {
+		signed = -quantity
+	}
+
+	var realized float64
+	if p.Quantity != 0 && (p.Quantity > 0) != (signed > 0) {
+		closed := math.Min(math.Abs(signed), math.Abs(p.Quantity))
+		avgCost := p.CostBasis / p.Quantity
+		direction := math.Copysign(1, p.Quantity)
+		realized = closed * (price - avgCost) * direction
+		p.CostBasis -= avgCost * closed * direction
+		p.Quantity -= closed * direction
+		signed += closed * direction
+	}
+
+	p.Quantity += signed
+	p.CostBasis += signed * price
+	if math.Abs(p.Quantity) <= quantityEpsilon {
+		p.Quantity, p.CostBasis = 0, 0
+	}
+	return realized
+}
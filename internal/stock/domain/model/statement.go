@@ -0,0 +1,101 @@
+package model
+
+import (
+	"simple-securities/pkg/uuid"
+	"time"
+)
+
+type StatementType string
+
+const (
+	// StatementTypeConfirmation lists the fills of one trading day
+	StatementTypeConfirmation StatementType = "CONFIRMATION"
+	// StatementTypeMonthly covers holdings, cash movements, fees and P&L of a calendar month
+	StatementTypeMonthly StatementType = "MONTHLY"
+)
+
+type StatementFormat string
+
+const (
+	StatementFormatHTML StatementFormat = "HTML"
+	StatementFormatCSV  StatementFormat = "CSV"
+)
+
+// ContentType is the MIME type of the rendered format
+func (f StatementFormat) ContentType() string {
+	if f == StatementFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "text/html; charset=utf-8"
+}
+
+// Statement is a rendered statement of one user, the period is given as dates in the exchange time zone
+type Statement struct {
+	ID          uint64        `db:"id"`
+	Uuid        string        `db:"uuid"`
+	UserID      uint64        `db:"user_id"`
+	Type        StatementType `db:"type"`
+	PeriodStart string        `db:"period_start"`
+	PeriodEnd   string        `db:"period_end"`
+	HTML        []byte        `db:"html"`
+	CSV         []byte        `db:"csv"`
+	CreatedAt   time.Time     `db:"created_at"`
+}
+
+func (s Statement) TableName() string {
+	return "statements"
+}
+
+func NewStatement(userID uint64, statementType StatementType, periodStart, periodEnd string, html, csv []byte) *Statement {
+	return &Statement{
+		Uuid:        uuid.NewGoogleUUID(),
+		UserID:      userID,
+		Type:        statementType,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		HTML:        html,
+		CSV:         csv,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Content returns the statement rendered in format
+func (s *Statement) Content(format StatementFormat) []byte {
+	if format == StatementFormatCSV {
+		return s.CSV
+	}
+	return s.HTML
+}
+
+// StatementHolding is a position valued at the end of the statement period
+type StatementHolding struct {
+	Symbol        string
+	Quantity      float64
+	CostBasis     float64
+	ClosePrice    float64
+	MarketValue   float64
+	UnrealizedPnL float64
+}
+
+// StatementSummary totals the statement period
+type StatementSummary struct {
+	MarketValue   float64
+	CostBasis     float64
+	UnrealizedPnL float64
+	RealizedPnL   float64
+	Fees          float64
+	NetCash       float64
+}
+
+// StatementData is the content of a statement before it is rendered
+type StatementData struct {
+	UserID        uint64
+	Type          StatementType
+	PeriodStart   string
+	PeriodEnd     string
+	GeneratedAt   time.Time
+	Holdings      []*StatementHolding
+	Fills         []*Fill
+	CashMovements []*CashMovement
+	Summary       StatementSummary
+}
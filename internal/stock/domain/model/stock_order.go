@@ -0,0 +1,121 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type OrderSide string
+
+const (
+	OrderSideBuy  OrderSide = "BUY"
+	OrderSideSell OrderSide = "SELL"
+)
+
+type OrderType string
+
+const (
+	OrderTypeMarket    OrderType = "MARKET"
+	OrderTypeLimit     OrderType = "LIMIT"
+	OrderTypeStop      OrderType = "STOP"
+	OrderTypeStopLimit OrderType = "STOP_LIMIT"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCancelled       OrderStatus = "CANCELLED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+)
+
+// IsOpen reports whether the order can still be filled
+func (s OrderStatus) IsOpen() bool {
+	return s == OrderStatusNew || s == OrderStatusPartiallyFilled
+}
+
+// TimeInForce says how long an order rests on the book
+type TimeInForce string
+
+const (
+	// TimeInForceDay orders expire at the close of the regular session they were accepted for
+	TimeInForceDay TimeInForce = "DAY"
+	// TimeInForceGTC orders rest until they are filled or cancelled
+	TimeInForceGTC TimeInForce = "GTC"
+)
+
+// StockOrder is an order of one user in one symbol, ExpiresAt is only set for DAY orders
+type StockOrder struct {
+	ID             uint64      `db:"id"`
+	Uuid           string      `db:"uuid"`
+	UserID         uint64      `db:"user_id"`
+	Symbol         string      `db:"symbol"`
+	Side           OrderSide   `db:"side"`
+	Type           OrderType   `db:"type"`
+	TimeInForce    TimeInForce `db:"time_in_force"`
+	ExtendedHours  bool        `db:"extended_hours"`
+	Price          float64     `db:"price"`
+	StopPrice      float64     `db:"stop_price"`
+	Quantity       float64     `db:"quantity"`
+	FilledQuantity float64     `db:"filled_quantity"`
+	Status         OrderStatus `db:"status"`
+	ExpiresAt      *time.Time  `db:"expires_at"`
+	CreatedAt      time.Time   `db:"created_at"`
+	UpdatedAt      time.Time   `db:"updated_at"`
+	// CorporateActions lists the actions the order was adjusted for, it is only kept by the aggregate
+	CorporateActions []string `db:"-"`
+}
+
+func (o StockOrder) TableName() string {
+	return "stock_orders"
+}
+
+// RemainingQuantity is the quantity still to be filled
+func (o *StockOrder) RemainingQuantity() float64 {
+	return o.Quantity - o.FilledQuantity
+}
+
+// Validate checks the fields of the order that do not depend on the market state
+func (o *StockOrder) Validate() error {
+	if o.UserID == 0 || o.Symbol == "" {
+		return fmt.Errorf("user_id and symbol are required")
+	}
+	if o.Side != OrderSideBuy && o.Side != OrderSideSell {
+		return fmt.Errorf("side must be BUY or SELL")
+	}
+	if o.Quantity <= 0 {
+		return fmt.Errorf("quantity must be greater than 0")
+	}
+
+	switch o.Type {
+	case OrderTypeMarket:
+	case OrderTypeLimit:
+		if o.Price <= 0 {
+			return fmt.Errorf("limit order needs price > 0")
+		}
+	case OrderTypeStop:
+		if o.StopPrice <= 0 {
+			return fmt.Errorf("stop order needs stop_price > 0")
+		}
+	case OrderTypeStopLimit:
+		if o.Price <= 0 || o.StopPrice <= 0 {
+			return fmt.Errorf("stop limit order needs price and stop_price > 0")
+		}
+	default:
+		return fmt.Errorf("unsupported order type %q", o.Type)
+	}
+
+	switch o.TimeInForce {
+	case TimeInForceDay, TimeInForceGTC:
+	default:
+		return fmt.Errorf("unsupported time in force %q", o.TimeInForce)
+	}
+	// Extended hours sessions only take limit orders that do not outlive the day
+	if o.ExtendedHours && (o.Type != OrderTypeLimit || o.TimeInForce != TimeInForceDay) {
+		return fmt.Errorf("extended hours orders must be DAY limit orders")
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+)
+
+type IBarRepo interface {
+	// GetRange returns the bars of every symbol with open time in [from, to), ordered by symbol and open time
+	GetRange(ctx context.Context, interval string, from, to int64) ([]*model.Bar, error)
+	// Upsert stores the bars, a bar already recorded for the same symbol, interval and open time is replaced
+	Upsert(ctx context.Context, bars []*model.Bar) error
+}
@@ -0,0 +1,22 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+)
+
+type ICorporateActionRepo interface {
+	// Create stores a new action, it returns false when the same symbol, type and ex-date is already on the calendar
+	Create(ctx context.Context, action *model.CorporateAction) (bool, error)
+	Update(ctx context.Context, action *model.CorporateAction) error
+	GetDue(ctx context.Context, asOf string) ([]*model.CorporateAction, error)
+	List(ctx context.Context, symbol string, limit, offset uint32) ([]*model.CorporateAction, error)
+	// Complete adjusts the bars of the symbol and marks the action processed in one transaction,
+	// the holdings and open orders are booked by the events of the action
+	Complete(ctx context.Context, action *model.CorporateAction) error
+}
+
+// INotificationClient delivers messages to users through the notification service
+type INotificationClient interface {
+	Send(ctx context.Context, userID uint64, nType, title, body string) error
+}
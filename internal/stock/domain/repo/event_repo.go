@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+)
+
+// IEventRepo is the append-only store of the stock domain events
+type IEventRepo interface {
+	// Append adds the events to the stream, it fails with model.ErrVersionConflict when
+	// the stream is no longer at expectedVersion
+	Append(ctx context.Context, streamID string, expectedVersion uint64, events []*model.Event) error
+	// Load returns the events of a stream after the given version in version order
+	Load(ctx context.Context, streamID string, afterVersion uint64) ([]*model.Event, error)
+	// Head returns the position of the last event stored, zero when there is none
+	Head(ctx context.Context) (uint64, error)
+	// LoadSnapshot returns the latest snapshot of a stream, nil when there is none
+	LoadSnapshot(ctx context.Context, streamID string) (*model.Snapshot, error)
+	// SaveSnapshot stores the snapshot unless a more recent one is stored already
+	SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error
+	// Subscribe hands the events of every stream stored after the given position to the
+	// handler in position order, following new events until ctx is done or the handler fails
+	Subscribe(ctx context.Context, afterPosition uint64, handler func(context.Context, *model.Event) error) error
+}
+
+// IReadModelRepo maintains the query tables built from the domain events
+type IReadModelRepo interface {
+	// Checkpoint returns the position of the last event applied by the projection
+	Checkpoint(ctx context.Context, projection string) (uint64, error)
+	// Apply updates the read models with the event and advances the checkpoint of the projection in one transaction
+	Apply(ctx context.Context, projection string, event *model.Event) error
+}
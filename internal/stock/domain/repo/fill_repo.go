@@ -0,0 +1,15 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"time"
+)
+
+// IFillRepo reads the stock_fills read model
+type IFillRepo interface {
+	// ListByOrder returns the fills of an order, oldest first
+	ListByOrder(ctx context.Context, orderUuid string) ([]*model.Fill, error)
+	// GetVolume returns the notional traded by a user since the given time
+	GetVolume(ctx context.Context, userID uint64, since time.Time) (float64, error)
+}
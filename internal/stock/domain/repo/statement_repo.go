@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"time"
+)
+
+type IStatementRepo interface {
+	// Create stores a statement, it returns false when the user already has one of the same type and period
+	Create(ctx context.Context, statement *model.Statement) (bool, error)
+	Exists(ctx context.Context, userID uint64, statementType model.StatementType, periodStart string) (bool, error)
+	// LastPeriodStart returns the latest period start of the statements of a type, empty when there is none
+	LastPeriodStart(ctx context.Context, statementType model.StatementType) (string, error)
+	GetByUuid(ctx context.Context, uuid string) (*model.Statement, error)
+	// List returns the statements of a user without their content, latest period first
+	List(ctx context.Context, userID uint64, statementType model.StatementType, limit, offset uint32) ([]*model.Statement, error)
+}
+
+// IAccountRepo reads the activity of the accounts from the fills store and the cash ledger
+type IAccountRepo interface {
+	// ListTradingUsers returns the users with fills executed in [from, to)
+	ListTradingUsers(ctx context.Context, from, to time.Time) ([]uint64, error)
+	// GetFirstFillTime returns the execution time of the first fill, nil when there is none
+	GetFirstFillTime(ctx context.Context) (*time.Time, error)
+	// ListAccountUsers returns the users holding a position or with fills or cash movements in [from, to)
+	ListAccountUsers(ctx context.Context, from, to time.Time) ([]uint64, error)
+	ListFills(ctx context.Context, userID uint64, from, to time.Time) ([]*model.Fill, error)
+	ListCashMovements(ctx context.Context, userID uint64, from, to time.Time) ([]*model.CashMovement, error)
+	ListPositions(ctx context.Context, userID uint64) ([]*model.Position, error)
+	// ListHolders returns the users holding a position in symbol with an id above afterUserID, by id
+	ListHolders(ctx context.Context, symbol string, afterUserID uint64, limit uint32) ([]uint64, error)
+	CountHolders(ctx context.Context, symbol string) (uint64, error)
+	// ListHoldings returns the open positions in symbol, by user
+	ListHoldings(ctx context.Context, symbol string) ([]*model.Position, error)
+	// GetClosePrice returns the close of the last daily bar of symbol opened before t, zero when there is none
+	GetClosePrice(ctx context.Context, symbol string, t time.Time) (float64, error)
+}
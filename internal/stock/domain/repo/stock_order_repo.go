@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"time"
+)
+
+// IStockOrderRepo reads the stock_orders read model
+type IStockOrderRepo interface {
+	GetByUuid(ctx context.Context, uuid string) (*model.StockOrder, error)
+	// List returns the orders of a user, latest first, status filters when not empty
+	List(ctx context.Context, userID uint64, status model.OrderStatus, limit, offset uint32) ([]*model.StockOrder, error)
+	// ListDue returns the uuids of the open orders whose expiry is on or before now
+	ListDue(ctx context.Context, now time.Time) ([]string, error)
+	// ListOpen returns the uuids of the open orders in symbol
+	ListOpen(ctx context.Context, symbol string) ([]string, error)
+}
@@ -0,0 +1,175 @@
+package grpc
+
+import (
+	"context"
+	stock "simple-securities/gen/stock/v1"
+	"simple-securities/internal/stock/application/mapper"
+	"simple-securities/internal/stock/application/service"
+)
+
+type StockGrpcHandler struct {
+	stock.UnimplementedStockServiceServer
+	importCorporateActionsSvc service.ImportCorporateActionsSvc
+	listCorporateActionsSvc   service.ListCorporateActionsSvc
+	listStatementsSvc         service.ListStatementsSvc
+	getStatementSvc           service.GetStatementSvc
+	placeOrderSvc             service.PlaceOrderSvc
+	cancelOrderSvc            service.CancelOrderSvc
+	reportExecutionSvc        service.ReportExecutionSvc
+	getOrderSvc               service.GetOrderSvc
+	listOrdersSvc             service.ListOrdersSvc
+	getFeeScheduleSvc         service.GetFeeScheduleSvc
+	listHoldersSvc            service.ListHoldersSvc
+}
+
+func NewStockGrpcHandler(
+	importCorporateActionsSvc service.ImportCorporateActionsSvc,
+	listCorporateActionsSvc service.ListCorporateActionsSvc,
+	listStatementsSvc service.ListStatementsSvc,
+	getStatementSvc service.GetStatementSvc,
+	placeOrderSvc service.PlaceOrderSvc,
+	cancelOrderSvc service.CancelOrderSvc,
+	reportExecutionSvc service.ReportExecutionSvc,
+	getOrderSvc service.GetOrderSvc,
+	listOrdersSvc service.ListOrdersSvc,
+	getFeeScheduleSvc service.GetFeeScheduleSvc,
+	listHoldersSvc service.ListHoldersSvc,
+) stock.StockServiceServer {
+	return &StockGrpcHandler{
+		importCorporateActionsSvc: importCorporateActionsSvc,
+		listCorporateActionsSvc:   listCorporateActionsSvc,
+		listStatementsSvc:         listStatementsSvc,
+		getStatementSvc:           getStatementSvc,
+		placeOrderSvc:             placeOrderSvc,
+		cancelOrderSvc:            cancelOrderSvc,
+		reportExecutionSvc:        reportExecutionSvc,
+		getOrderSvc:               getOrderSvc,
+		listOrdersSvc:             listOrdersSvc,
+		getFeeScheduleSvc:         getFeeScheduleSvc,
+		listHoldersSvc:            listHoldersSvc,
+	}
+}
+
+// Ping implements the StockService.Ping RPC
+func (h *StockGrpcHandler) Ping(ctx context.Context, req *stock.PingRequest) (*stock.PingResponse, error) {
+	return &stock.PingResponse{Message: "pong 🏓 from Stock Service"}, nil
+}
+
+func (h *StockGrpcHandler) ImportCorporateActions(ctx context.Context, req *stock.ImportCorporateActionsRequest) (*stock.ImportCorporateActionsResponse, error) {
+	result, err := h.importCorporateActionsSvc.Handle(ctx, mapper.ToImportCorporateActionsReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.ImportCorporateActionsResponse{
+		Imported: result.Imported,
+		Skipped:  result.Skipped,
+	}, nil
+}
+
+func (h *StockGrpcHandler) ListCorporateActions(ctx context.Context, req *stock.ListCorporateActionsRequest) (*stock.ListCorporateActionsResponse, error) {
+	result, err := h.listCorporateActionsSvc.Handle(ctx, mapper.ToListCorporateActionsReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.ListCorporateActionsResponse{
+		CorporateActions: mapper.ToCorporateActions(result),
+	}, nil
+}
+
+func (h *StockGrpcHandler) ListStatements(ctx context.Context, req *stock.ListStatementsRequest) (*stock.ListStatementsResponse, error) {
+	result, err := h.listStatementsSvc.Handle(ctx, mapper.ToListStatementsReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.ListStatementsResponse{
+		Statements: mapper.ToStatements(result),
+	}, nil
+}
+
+func (h *StockGrpcHandler) GetStatement(ctx context.Context, req *stock.GetStatementRequest) (*stock.GetStatementResponse, error) {
+	result, err := h.getStatementSvc.Handle(ctx, mapper.ToGetStatementReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.GetStatementResponse{
+		Statement:   mapper.ToStatement(result.Statement),
+		ContentType: result.ContentType,
+		Content:     result.Content,
+	}, nil
+}
+
+func (h *StockGrpcHandler) PlaceOrder(ctx context.Context, req *stock.PlaceOrderRequest) (*stock.PlaceOrderResponse, error) {
+	result, err := h.placeOrderSvc.Handle(ctx, mapper.ToPlaceOrderReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.PlaceOrderResponse{
+		Order: mapper.ToStockOrder(result),
+	}, nil
+}
+
+func (h *StockGrpcHandler) CancelOrder(ctx context.Context, req *stock.CancelOrderRequest) (*stock.CancelOrderResponse, error) {
+	result, err := h.cancelOrderSvc.Handle(ctx, mapper.ToCancelOrderReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.CancelOrderResponse{
+		Order: mapper.ToStockOrder(result),
+	}, nil
+}
+
+func (h *StockGrpcHandler) ReportExecution(ctx context.Context, req *stock.ReportExecutionRequest) (*stock.ReportExecutionResponse, error) {
+	result, err := h.reportExecutionSvc.Handle(ctx, mapper.ToReportExecutionReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.ReportExecutionResponse{
+		Order: mapper.ToStockOrder(result.Order),
+		Fill:  mapper.ToFill(result.Fill),
+	}, nil
+}
+
+func (h *StockGrpcHandler) GetOrder(ctx context.Context, req *stock.GetOrderRequest) (*stock.GetOrderResponse, error) {
+	result, err := h.getOrderSvc.Handle(ctx, req.Uuid)
+	if err != nil {
+		return nil, err
+	}
+	return &stock.GetOrderResponse{
+		Order: mapper.ToStockOrder(result.Order),
+		Fills: mapper.ToFills(result.Fills),
+	}, nil
+}
+
+func (h *StockGrpcHandler) ListOrders(ctx context.Context, req *stock.ListOrdersRequest) (*stock.ListOrdersResponse, error) {
+	result, err := h.listOrdersSvc.Handle(ctx, mapper.ToListOrdersReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.ListOrdersResponse{
+		Orders: mapper.ToStockOrders(result),
+	}, nil
+}
+
+func (h *StockGrpcHandler) GetFeeSchedule(ctx context.Context, req *stock.GetFeeScheduleRequest) (*stock.GetFeeScheduleResponse, error) {
+	result, err := h.getFeeScheduleSvc.Handle(ctx, mapper.ToGetFeeScheduleReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.GetFeeScheduleResponse{
+		Schedule:   mapper.ToFeeSchedule(result.Schedule),
+		Volume_30D: result.Volume30d,
+		Tier:       result.Tier,
+		Preview:    mapper.ToFeePreview(result.Preview),
+	}, nil
+}
+
+func (h *StockGrpcHandler) ListHolders(ctx context.Context, req *stock.ListHoldersRequest) (*stock.ListHoldersResponse, error) {
+	result, err := h.listHoldersSvc.Handle(ctx, mapper.ToListHoldersReq(req))
+	if err != nil {
+		return nil, err
+	}
+	return &stock.ListHoldersResponse{
+		UserIds: result.UserIDs,
+		Total:   result.Total,
+	}, nil
+}
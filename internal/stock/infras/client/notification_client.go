@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	noti "simple-securities/gen/notification/v1"
+	"simple-securities/internal/stock/domain/repo"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type NotificationClient struct {
+	conn   *grpc.ClientConn
+	client noti.NotificationServiceClient
+}
+
+// NewNotificationClient connects to the notification service at addr
+func NewNotificationClient(addr string) (*NotificationClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification client: %w", err)
+	}
+	return &NotificationClient{
+		conn:   conn,
+		client: noti.NewNotificationServiceClient(conn),
+	}, nil
+}
+
+var _ repo.INotificationClient = (*NotificationClient)(nil)
+
+func (c *NotificationClient) Send(ctx context.Context, userID uint64, nType, title, body string) error {
+	resp, err := c.client.Send(ctx, &noti.SendRequest{
+		UserId: userID,
+		Type:   nType,
+		Title:  title,
+		Body:   body,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("notification service rejected message for user %d", userID)
+	}
+	return nil
+}
+
+func (c *NotificationClient) Close() error {
+	return c.conn.Close()
+}
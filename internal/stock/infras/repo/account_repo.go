@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AccountRepo reads stock_fills, cash_movements and positions, timestamps are compared in UTC
+type AccountRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountRepo(db *sqlx.DB) repo.IAccountRepo {
+	return &AccountRepo{db: db}
+}
+
+// ListTradingUsers fetches the users with fills in [from, to)
+func (r *AccountRepo) ListTradingUsers(ctx context.Context, from, to time.Time) ([]uint64, error) {
+	query := `
+		SELECT DISTINCT user_id FROM stock_fills
+		WHERE executed_at >= $1 AND executed_at < $2
+		ORDER BY user_id
+	`
+
+	var userIDs []uint64
+	if err := r.db.SelectContext(ctx, &userIDs, query, from.UTC(), to.UTC()); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// GetFirstFillTime fetches the execution time of the first fill
+func (r *AccountRepo) GetFirstFillTime(ctx context.Context) (*time.Time, error) {
+	var executedAt time.Time
+	err := r.db.GetContext(ctx, &executedAt, `SELECT executed_at FROM stock_fills ORDER BY executed_at LIMIT 1`)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &executedAt, nil
+}
+
+// ListAccountUsers fetches the users with holdings or with activity in [from, to)
+func (r *AccountRepo) ListAccountUsers(ctx context.Context, from, to time.Time) ([]uint64, error) {
+	query := `
+		SELECT user_id FROM positions WHERE quantity <> 0
+		UNION
+		SELECT user_id FROM stock_fills WHERE executed_at >= $1 AND executed_at < $2
+		UNION
+		SELECT user_id FROM cash_movements WHERE created_at >= $1 AND created_at < $2
+		ORDER BY user_id
+	`
+
+	var userIDs []uint64
+	if err := r.db.SelectContext(ctx, &userIDs, query, from.UTC(), to.UTC()); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// ListFills fetches the fills of a user in [from, to), oldest first
+func (r *AccountRepo) ListFills(ctx context.Context, userID uint64, from, to time.Time) ([]*model.Fill, error) {
+	query := `
+		SELECT id, uuid, order_uuid, user_id, symbol, side, quantity, price,
+			liquidity, commission, pass_through_fee, fee, realized_pnl, executed_at
+		FROM stock_fills
+		WHERE user_id = $1 AND executed_at >= $2 AND executed_at < $3
+		ORDER BY executed_at, id
+	`
+
+	var fills []*model.Fill
+	if err := r.db.SelectContext(ctx, &fills, query, userID, from.UTC(), to.UTC()); err != nil {
+		return nil, err
+	}
+	return fills, nil
+}
+
+// ListCashMovements fetches the cash ledger entries of a user in [from, to), oldest first
+func (r *AccountRepo) ListCashMovements(ctx context.Context, userID uint64, from, to time.Time) ([]*model.CashMovement, error) {
+	query := `
+		SELECT id, uuid, user_id, symbol, type, amount, reference, created_at
+		FROM cash_movements
+		WHERE user_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at, id
+	`
+
+	var movements []*model.CashMovement
+	if err := r.db.SelectContext(ctx, &movements, query, userID, from.UTC(), to.UTC()); err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// ListPositions fetches the open positions of a user
+func (r *AccountRepo) ListPositions(ctx context.Context, userID uint64) ([]*model.Position, error) {
+	query := `
+		SELECT id, user_id, symbol, quantity, cost_basis, created_at, updated_at
+		FROM positions
+		WHERE user_id = $1 AND quantity <> 0
+		ORDER BY symbol
+	`
+
+	var positions []*model.Position
+	if err := r.db.SelectContext(ctx, &positions, query, userID); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// ListHolders fetches a page of the users holding a position in symbol
+func (r *AccountRepo) ListHolders(ctx context.Context, symbol string, afterUserID uint64, limit uint32) ([]uint64, error) {
+	query := `
+		SELECT user_id FROM positions
+		WHERE symbol = $1 AND quantity <> 0 AND user_id > $2
+		ORDER BY user_id
+		LIMIT $3
+	`
+
+	var userIDs []uint64
+	if err := r.db.SelectContext(ctx, &userIDs, query, symbol, afterUserID, limit); err != nil {
+		return nil, err
+	}
+	return userIDs, nil
+}
+
+// CountHolders counts the users holding a position in symbol
+func (r *AccountRepo) CountHolders(ctx context.Context, symbol string) (uint64, error) {
+	var count uint64
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM positions WHERE symbol = $1 AND quantity <> 0`, symbol)
+	return count, err
+}
+
+// ListHoldings fetches the open positions in symbol
+func (r *AccountRepo) ListHoldings(ctx context.Context, symbol string) ([]*model.Position, error) {
+	query := `
+		SELECT id, user_id, symbol, quantity, cost_basis, created_at, updated_at
+		FROM positions
+		WHERE symbol = $1 AND quantity <> 0
+		ORDER BY user_id
+	`
+
+	var positions []*model.Position
+	if err := r.db.SelectContext(ctx, &positions, query, symbol); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
+
+// GetClosePrice looks up the last daily close of symbol opened before t
+func (r *AccountRepo) GetClosePrice(ctx context.Context, symbol string, t time.Time) (float64, error) {
+	var price float64
+	err := r.db.GetContext(ctx, &price, `
+		SELECT close FROM bars
+		WHERE symbol = $1 AND interval = $2 AND open_time < $3
+		ORDER BY open_time DESC
+		LIMIT 1
+	`, symbol, dailyInterval, t.UnixMilli())
+	if err != nil && err != sql.ErrNoRows {
+		return 0, err
+	}
+	return price, nil
+}
@@ -0,0 +1,68 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type BarRepo struct {
+	db *sqlx.DB
+}
+
+func NewBarRepo(db *sqlx.DB) repo.IBarRepo {
+	return &BarRepo{db: db}
+}
+
+// GetRange fetches the bars of one interval opened in [from, to)
+func (r *BarRepo) GetRange(ctx context.Context, interval string, from, to int64) ([]*model.Bar, error) {
+	query := `
+		SELECT id, symbol, interval, open_time, open, high, low, close, volume
+		FROM bars
+		WHERE interval = $1 AND open_time >= $2 AND open_time < $3
+		ORDER BY symbol, open_time
+	`
+
+	var bars []*model.Bar
+	if err := r.db.SelectContext(ctx, &bars, query, interval, from, to); err != nil {
+		return nil, err
+	}
+	return bars, nil
+}
+
+// Upsert stores the bars in one transaction, re-running an aggregation overwrites its previous output
+func (r *BarRepo) Upsert(ctx context.Context, bars []*model.Bar) error {
+	if len(bars) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareNamedContext(ctx, `
+		INSERT INTO bars (symbol, interval, open_time, open, high, low, close, volume)
+		VALUES (:symbol, :interval, :open_time, :open, :high, :low, :close, :volume)
+		ON CONFLICT (symbol, interval, open_time) DO UPDATE SET
+			open   = excluded.open,
+			high   = excluded.high,
+			low    = excluded.low,
+			close  = excluded.close,
+			volume = excluded.volume
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, bar := range bars {
+		if _, err := stmt.ExecContext(ctx, bar); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
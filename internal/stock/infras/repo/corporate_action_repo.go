@@ -0,0 +1,213 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// dailyInterval is the bar interval used to look up the close before a cash dividend
+const dailyInterval = "1d"
+
+type CorporateActionRepo struct {
+	db *sqlx.DB
+}
+
+func NewCorporateActionRepo(db *sqlx.DB) repo.ICorporateActionRepo {
+	return &CorporateActionRepo{db: db}
+}
+
+// withTransaction runs fn inside a transaction with proper commit/rollback handling
+func (r *CorporateActionRepo) withTransaction(ctx context.Context, fn func(*sqlx.Tx) error) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// rollback/commit handler
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p) // rethrow panic after rollback
+		} else if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = fn(tx)
+	return err
+}
+
+const corporateActionColumns = `
+	id, uuid, symbol, type, ex_date, pay_date,
+	ratio_from, ratio_to, cash_amount, new_symbol,
+	status, reason, processed_at, created_at, updated_at
+`
+
+// Create persists a new calendar entry, duplicates of an existing entry are ignored
+func (r *CorporateActionRepo) Create(ctx context.Context, action *model.CorporateAction) (bool, error) {
+	query := `
+		INSERT INTO corporate_actions (
+			uuid, symbol, type, ex_date, pay_date,
+			ratio_from, ratio_to, cash_amount, new_symbol,
+			status, reason, processed_at, created_at, updated_at
+		) VALUES (
+			:uuid, :symbol, :type, :ex_date, :pay_date,
+			:ratio_from, :ratio_to, :cash_amount, :new_symbol,
+			:status, :reason, :processed_at, :created_at, :updated_at
+		)
+		ON CONFLICT (symbol, type, ex_date) DO NOTHING
+		RETURNING id
+	`
+
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &action.ID, action); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Update saves the processing status of an action
+func (r *CorporateActionRepo) Update(ctx context.Context, action *model.CorporateAction) error {
+	action.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE corporate_actions
+		SET
+			status       = :status,
+			reason       = :reason,
+			processed_at = :processed_at,
+			updated_at   = :updated_at
+		WHERE id = :id
+	`
+	_, err := r.db.NamedExecContext(ctx, query, action)
+	return err
+}
+
+// GetDue fetches the pending actions whose ex-date is on or before asOf, oldest first
+func (r *CorporateActionRepo) GetDue(ctx context.Context, asOf string) ([]*model.CorporateAction, error) {
+	query := `
+		SELECT ` + corporateActionColumns + `
+		FROM corporate_actions
+		WHERE status = $1 AND ex_date <= $2
+		ORDER BY ex_date, id
+	`
+
+	var actions []*model.CorporateAction
+	if err := r.db.SelectContext(ctx, &actions, query, model.CorporateActionStatusPending, asOf); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// List fetches the calendar, optionally for one symbol, latest ex-date first
+func (r *CorporateActionRepo) List(ctx context.Context, symbol string, limit, offset uint32) ([]*model.CorporateAction, error) {
+	query := `
+		SELECT ` + corporateActionColumns + `
+		FROM corporate_actions
+		WHERE $1 = '' OR symbol = $1
+		ORDER BY ex_date DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`
+
+	var actions []*model.CorporateAction
+	if err := r.db.SelectContext(ctx, &actions, query, symbol, limit, offset); err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// Complete back-adjusts the bars of the symbol to the action and marks it processed
+func (r *CorporateActionRepo) Complete(ctx context.Context, action *model.CorporateAction) error {
+	exDate, err := action.ExDateTime()
+	if err != nil {
+		return err
+	}
+
+	return r.withTransaction(ctx, func(tx *sqlx.Tx) error {
+		var err error
+		switch action.Type {
+		case model.CorporateActionSplit, model.CorporateActionReverseSplit, model.CorporateActionStockDividend:
+			factor := action.ShareFactor()
+			err = adjustBars(ctx, tx, action.Symbol, exDate, 1/factor, factor)
+		case model.CorporateActionCashDividend:
+			err = adjustBarsForDividend(ctx, tx, action, exDate)
+		case model.CorporateActionSymbolChange:
+			err = renameBars(ctx, tx, action)
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		action.Status = model.CorporateActionStatusProcessed
+		action.Reason = ""
+		action.ProcessedAt = &now
+		action.UpdatedAt = now
+		_, err = tx.NamedExecContext(ctx, `
+			UPDATE corporate_actions
+			SET status = :status, reason = :reason, processed_at = :processed_at, updated_at = :updated_at
+			WHERE id = :id
+		`, action)
+		return err
+	})
+}
+
+// adjustBarsForDividend back-adjusts the bars by the dividend yield on the previous close
+func adjustBarsForDividend(ctx context.Context, tx *sqlx.Tx, action *model.CorporateAction, exDate time.Time) error {
+	var prevClose float64
+	err := tx.GetContext(ctx, &prevClose, `
+		SELECT close FROM bars
+		WHERE symbol = $1 AND interval = $2 AND open_time < $3
+		ORDER BY open_time DESC
+		LIMIT 1
+	`, action.Symbol, dailyInterval, exDate.UnixMilli())
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if prevClose <= action.CashAmount {
+		return nil
+	}
+	return adjustBars(ctx, tx, action.Symbol, exDate, 1-action.CashAmount/prevClose, 1)
+}
+
+// renameBars moves the history to the new symbol, bars already recorded under the new symbol
+// win over the old ones
+func renameBars(ctx context.Context, tx *sqlx.Tx, action *model.CorporateAction) error {
+	if _, err := tx.ExecContext(ctx, `UPDATE OR IGNORE bars SET symbol = $1 WHERE symbol = $2`,
+		action.NewSymbol, action.Symbol); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM bars WHERE symbol = $1`, action.Symbol)
+	return err
+}
+
+// adjustBars rescales every bar that opened before the ex-date so that history is comparable
+// with the prices after it
+func adjustBars(ctx context.Context, tx *sqlx.Tx, symbol string, exDate time.Time, priceFactor, volumeFactor float64) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE bars
+		SET
+			open   = open * $1,
+			high   = high * $1,
+			low    = low * $1,
+			close  = close * $1,
+			volume = volume * $2
+		WHERE symbol = $3 AND open_time < $4
+	`, priceFactor, volumeFactor, symbol, exDate.UnixMilli())
+	return err
+}
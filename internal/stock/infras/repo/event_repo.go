@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"simple-securities/pkg/eventstore"
+)
+
+// EventRepo keeps the stock domain events in the event store, stock_events and
+// stock_snapshots in the stock database
+type EventRepo struct {
+	store eventstore.Store
+}
+
+func NewEventRepo(store eventstore.Store) repo.IEventRepo {
+	return &EventRepo{store: store}
+}
+
+// Append stores the events at the versions raised by the aggregate and fills in their positions
+func (r *EventRepo) Append(ctx context.Context, streamID string, expectedVersion uint64, events []*model.Event) error {
+	stored := make([]*eventstore.Event, len(events))
+	for i, e := range events {
+		stored[i] = toStoreEvent(e)
+	}
+	if err := r.store.Append(ctx, streamID, int64(expectedVersion), stored...); err != nil {
+		return err
+	}
+	for i, e := range stored {
+		events[i].Position = e.Position
+		events[i].OccurredAt = e.OccurredAt
+	}
+	return nil
+}
+
+func (r *EventRepo) Load(ctx context.Context, streamID string, afterVersion uint64) ([]*model.Event, error) {
+	stored, err := r.store.Load(ctx, streamID, afterVersion)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*model.Event, len(stored))
+	for i, e := range stored {
+		events[i] = toModelEvent(e)
+	}
+	return events, nil
+}
+
+func (r *EventRepo) Head(ctx context.Context) (uint64, error) {
+	return r.store.Head(ctx)
+}
+
+func (r *EventRepo) LoadSnapshot(ctx context.Context, streamID string) (*model.Snapshot, error) {
+	snapshot, err := r.store.LoadSnapshot(ctx, streamID)
+	if err != nil || snapshot == nil {
+		return nil, err
+	}
+	return &model.Snapshot{
+		StreamID:      snapshot.StreamID,
+		Version:       snapshot.Version,
+		SchemaVersion: snapshot.SchemaVersion,
+		State:         snapshot.State,
+		TakenAt:       snapshot.TakenAt,
+	}, nil
+}
+
+func (r *EventRepo) SaveSnapshot(ctx context.Context, snapshot *model.Snapshot) error {
+	return r.store.SaveSnapshot(ctx, &eventstore.Snapshot{
+		StreamID:      snapshot.StreamID,
+		Version:       snapshot.Version,
+		SchemaVersion: snapshot.SchemaVersion,
+		State:         snapshot.State,
+		TakenAt:       snapshot.TakenAt,
+	})
+}
+
+func (r *EventRepo) Subscribe(ctx context.Context, afterPosition uint64, handler func(context.Context, *model.Event) error) error {
+	return r.store.Subscribe(ctx, afterPosition, func(ctx context.Context, e *eventstore.Event) error {
+		return handler(ctx, toModelEvent(e))
+	})
+}
+
+func toStoreEvent(e *model.Event) *eventstore.Event {
+	return &eventstore.Event{
+		Uuid:          e.Uuid,
+		StreamID:      e.StreamID,
+		Version:       e.Version,
+		Type:          string(e.Type),
+		SchemaVersion: e.SchemaVersion,
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt,
+	}
+}
+
+func toModelEvent(e *eventstore.Event) *model.Event {
+	return &model.Event{
+		Position:      e.Position,
+		Uuid:          e.Uuid,
+		StreamID:      e.StreamID,
+		Version:       e.Version,
+		Type:          model.EventType(e.Type),
+		SchemaVersion: e.SchemaVersion,
+		Payload:       e.Payload,
+		OccurredAt:    e.OccurredAt,
+	}
+}
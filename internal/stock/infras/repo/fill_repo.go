@@ -0,0 +1,46 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// FillRepo reads stock_fills, timestamps are stored in UTC
+type FillRepo struct {
+	db *sqlx.DB
+}
+
+func NewFillRepo(db *sqlx.DB) repo.IFillRepo {
+	return &FillRepo{db: db}
+}
+
+// ListByOrder fetches the fills of an order in execution order
+func (r *FillRepo) ListByOrder(ctx context.Context, orderUuid string) ([]*model.Fill, error) {
+	query := `
+		SELECT id, uuid, order_uuid, user_id, symbol, side, quantity, price,
+			liquidity, commission, pass_through_fee, fee, realized_pnl, executed_at
+		FROM stock_fills
+		WHERE order_uuid = $1
+		ORDER BY executed_at, id
+	`
+
+	var fills []*model.Fill
+	if err := r.db.SelectContext(ctx, &fills, query, orderUuid); err != nil {
+		return nil, err
+	}
+	return fills, nil
+}
+
+// GetVolume sums the notional of the fills of a user executed at or after since
+func (r *FillRepo) GetVolume(ctx context.Context, userID uint64, since time.Time) (float64, error) {
+	var volume float64
+	err := r.db.GetContext(ctx, &volume, `
+		SELECT COALESCE(SUM(quantity * price), 0) FROM stock_fills
+		WHERE user_id = $1 AND executed_at >= $2
+	`, userID, since.UTC())
+	return volume, err
+}
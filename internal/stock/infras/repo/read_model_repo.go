@@ -0,0 +1,269 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// ReadModelRepo projects the order and corporate action events onto stock_orders, stock_fills,
+// positions and cash_movements. Fills are booked under the symbol of the order row so that an
+// order moved by a symbol change books its later fills under the new symbol.
+type ReadModelRepo struct {
+	db *sqlx.DB
+}
+
+func NewReadModelRepo(db *sqlx.DB) repo.IReadModelRepo {
+	return &ReadModelRepo{db: db}
+}
+
+// withTransaction runs fn inside a transaction with proper commit/rollback handling
+func (r *ReadModelRepo) withTransaction(ctx context.Context, fn func(*sqlx.Tx) error) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// rollback/commit handler
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p) // rethrow panic after rollback
+		} else if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = fn(tx)
+	return err
+}
+
+// Checkpoint fetches the position of the last event applied, zero before the first one
+func (r *ReadModelRepo) Checkpoint(ctx context.Context, projection string) (uint64, error) {
+	var position uint64
+	err := r.db.GetContext(ctx, &position,
+		`SELECT position FROM projection_checkpoints WHERE name = $1`, projection)
+	if err != nil && !stderrors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	return position, nil
+}
+
+// Apply projects the event unless the checkpoint is already past it, so that a replay is harmless
+func (r *ReadModelRepo) Apply(ctx context.Context, projection string, event *model.Event) error {
+	return r.withTransaction(ctx, func(tx *sqlx.Tx) error {
+		var position uint64
+		err := tx.GetContext(ctx, &position,
+			`SELECT position FROM projection_checkpoints WHERE name = $1`, projection)
+		if err != nil && !stderrors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if event.Position <= position {
+			return nil
+		}
+
+		orderUuid := strings.TrimPrefix(event.StreamID, model.OrderStreamID(""))
+		occurredAt := event.OccurredAt.UTC()
+		switch event.Type {
+		case model.EventOrderPlaced:
+			err = projectOrderPlaced(ctx, tx, orderUuid, event, occurredAt)
+		case model.EventOrderFilled:
+			err = projectOrderFilled(ctx, tx, orderUuid, event, occurredAt)
+		case model.EventOrderCancelled:
+			err = projectOrderStatus(ctx, tx, orderUuid, model.OrderStatusCancelled, occurredAt)
+		case model.EventOrderExpired:
+			err = projectOrderStatus(ctx, tx, orderUuid, model.OrderStatusExpired, occurredAt)
+		case model.EventOrderAdjusted:
+			err = projectOrderAdjusted(ctx, tx, orderUuid, event, occurredAt)
+		case model.EventCashMovementPosted:
+			err = projectCashMovement(ctx, tx, event, occurredAt)
+		case model.EventCorporateActionApplied:
+			err = projectCorporateAction(ctx, tx, event, occurredAt)
+		default:
+			err = fmt.Errorf("unexpected event %q", event.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to project %s at position %d: %w", event.Type, event.Position, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO projection_checkpoints (name, position, updated_at) VALUES ($1, $2, $3)
+			ON CONFLICT (name) DO UPDATE SET position = excluded.position, updated_at = excluded.updated_at
+		`, projection, event.Position, time.Now().UTC())
+		return err
+	})
+}
+
+func projectOrderPlaced(ctx context.Context, tx *sqlx.Tx, orderUuid string, event *model.Event, occurredAt time.Time) error {
+	var placed model.OrderPlaced
+	if err := event.Decode(&placed); err != nil {
+		return err
+	}
+
+	var expiresAt *time.Time
+	if placed.ExpiresAt != nil {
+		utc := placed.ExpiresAt.UTC()
+		expiresAt = &utc
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO stock_orders (
+			uuid, user_id, symbol, side, type, time_in_force, extended_hours,
+			price, stop_price, quantity, filled_quantity, status, expires_at, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 0, $11, $12, $13, $13)
+		ON CONFLICT (uuid) DO NOTHING
+	`, orderUuid, placed.UserID, placed.Symbol, placed.Side, placed.Type, placed.TimeInForce, placed.ExtendedHours,
+		placed.Price, placed.StopPrice, placed.Quantity, model.OrderStatusNew, expiresAt, occurredAt)
+	return err
+}
+
+// projectOrderFilled moves the order forward, books the fill on the position at average cost and
+// records the fill with the P&L it realized
+func projectOrderFilled(ctx context.Context, tx *sqlx.Tx, orderUuid string, event *model.Event, occurredAt time.Time) error {
+	var filled model.OrderFilled
+	if err := event.Decode(&filled); err != nil {
+		return err
+	}
+
+	var order model.StockOrder
+	if err := tx.GetContext(ctx, &order, `
+		SELECT id, uuid, user_id, symbol, side, quantity, filled_quantity FROM stock_orders WHERE uuid = $1
+	`, orderUuid); err != nil {
+		return err
+	}
+
+	order.FilledQuantity += filled.Quantity
+	status := model.OrderStatusPartiallyFilled
+	if order.RemainingQuantity() <= 1e-9 {
+		status = model.OrderStatusFilled
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE stock_orders SET filled_quantity = $1, status = $2, updated_at = $3 WHERE id = $4
+	`, order.FilledQuantity, status, occurredAt, order.ID); err != nil {
+		return err
+	}
+
+	position := model.Position{UserID: order.UserID, Symbol: order.Symbol}
+	err := tx.GetContext(ctx, &position, `
+		SELECT id, user_id, symbol, quantity, cost_basis, created_at, updated_at
+		FROM positions WHERE user_id = $1 AND symbol = $2
+	`, order.UserID, order.Symbol)
+	if err != nil && !stderrors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	realizedPnL := position.ApplyFill(order.Side, filled.Quantity, filled.Price)
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO positions (user_id, symbol, quantity, cost_basis, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $5)
+		ON CONFLICT (user_id, symbol) DO UPDATE SET
+			quantity   = excluded.quantity,
+			cost_basis = excluded.cost_basis,
+			updated_at = excluded.updated_at
+	`, order.UserID, order.Symbol, position.Quantity, position.CostBasis, occurredAt); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO stock_fills (
+			uuid, order_uuid, user_id, symbol, side, quantity, price,
+			liquidity, commission, pass_through_fee, fee, realized_pnl, executed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (uuid) DO NOTHING
+	`, filled.FillUuid, orderUuid, order.UserID, order.Symbol, order.Side, filled.Quantity, filled.Price,
+		filled.Liquidity, filled.Commission, filled.PassThroughFee, filled.Fee, realizedPnL, filled.ExecutedAt.UTC())
+	return err
+}
+
+func projectOrderStatus(ctx context.Context, tx *sqlx.Tx, orderUuid string, status model.OrderStatus, occurredAt time.Time) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE stock_orders SET status = $1, updated_at = $2 WHERE uuid = $3
+	`, status, occurredAt, orderUuid)
+	return err
+}
+
+func projectOrderAdjusted(ctx context.Context, tx *sqlx.Tx, orderUuid string, event *model.Event, occurredAt time.Time) error {
+	var adjusted model.OrderAdjusted
+	if err := event.Decode(&adjusted); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE stock_orders
+		SET symbol = $1, quantity = $2, filled_quantity = $3, price = $4, stop_price = $5, updated_at = $6
+		WHERE uuid = $7
+	`, adjusted.Symbol, adjusted.Quantity, adjusted.FilledQuantity, adjusted.Price, adjusted.StopPrice,
+		occurredAt, orderUuid)
+	return err
+}
+
+func projectCashMovement(ctx context.Context, tx *sqlx.Tx, event *model.Event, occurredAt time.Time) error {
+	var posted model.CashMovementPosted
+	if err := event.Decode(&posted); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO cash_movements (uuid, user_id, symbol, type, amount, reference, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (uuid) DO NOTHING
+	`, posted.MovementUuid, posted.UserID, posted.Symbol, posted.Type, posted.Amount, posted.Reference, occurredAt)
+	return err
+}
+
+// projectCorporateAction books the adjustments of the holders: share counts are rescaled with an
+// unchanged total cost basis, dividends are posted on the cash ledger and a symbol change moves
+// the position, merging it with a holding already recorded under the new symbol
+func projectCorporateAction(ctx context.Context, tx *sqlx.Tx, event *model.Event, occurredAt time.Time) error {
+	var applied model.CorporateActionApplied
+	if err := event.Decode(&applied); err != nil {
+		return err
+	}
+
+	for _, adj := range applied.Adjustments {
+		var err error
+		switch applied.Type {
+		case model.CorporateActionSplit, model.CorporateActionReverseSplit, model.CorporateActionStockDividend:
+			_, err = tx.ExecContext(ctx, `
+				UPDATE positions SET quantity = $1, updated_at = $2 WHERE user_id = $3 AND symbol = $4
+			`, adj.NewQuantity, occurredAt, adj.UserID, adj.Symbol)
+		case model.CorporateActionCashDividend:
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO cash_movements (uuid, user_id, symbol, type, amount, reference, created_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				ON CONFLICT (uuid) DO NOTHING
+			`, adj.MovementUuid, adj.UserID, adj.Symbol, model.CashMovementDividend, adj.CashAmount,
+				applied.ActionUuid, occurredAt)
+		case model.CorporateActionSymbolChange:
+			err = movePosition(ctx, tx, adj, occurredAt)
+		default:
+			err = fmt.Errorf("unsupported corporate action type %q", applied.Type)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func movePosition(ctx context.Context, tx *sqlx.Tx, adj *model.PositionAdjustment, occurredAt time.Time) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO positions (user_id, symbol, quantity, cost_basis, created_at, updated_at)
+		SELECT user_id, $1, quantity, cost_basis, created_at, $2 FROM positions WHERE user_id = $3 AND symbol = $4
+		ON CONFLICT (user_id, symbol) DO UPDATE SET
+			quantity   = quantity + excluded.quantity,
+			cost_basis = cost_basis + excluded.cost_basis,
+			updated_at = excluded.updated_at
+	`, adj.NewSymbol, occurredAt, adj.UserID, adj.Symbol); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM positions WHERE user_id = $1 AND symbol = $2`, adj.UserID, adj.Symbol)
+	return err
+}
@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type StatementRepo struct {
+	db *sqlx.DB
+}
+
+func NewStatementRepo(db *sqlx.DB) repo.IStatementRepo {
+	return &StatementRepo{db: db}
+}
+
+// Create persists a rendered statement, a statement already generated for the period is kept
+func (r *StatementRepo) Create(ctx context.Context, statement *model.Statement) (bool, error) {
+	query := `
+		INSERT INTO statements (uuid, user_id, type, period_start, period_end, html, csv, created_at)
+		VALUES (:uuid, :user_id, :type, :period_start, :period_end, :html, :csv, :created_at)
+		ON CONFLICT (user_id, type, period_start) DO NOTHING
+		RETURNING id
+	`
+
+	stmt, err := r.db.PrepareNamedContext(ctx, query)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	if err := stmt.GetContext(ctx, &statement.ID, statement); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Exists checks whether the statement of a period was already generated
+func (r *StatementRepo) Exists(
+	ctx context.Context,
+	userID uint64,
+	statementType model.StatementType,
+	periodStart string,
+) (bool, error) {
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, `
+		SELECT EXISTS (
+			SELECT 1 FROM statements WHERE user_id = $1 AND type = $2 AND period_start = $3
+		)
+	`, userID, statementType, periodStart)
+	return exists, err
+}
+
+// LastPeriodStart fetches the latest period start of the statements of a type
+func (r *StatementRepo) LastPeriodStart(ctx context.Context, statementType model.StatementType) (string, error) {
+	var periodStart string
+	err := r.db.GetContext(ctx, &periodStart, `
+		SELECT COALESCE(MAX(period_start), '') FROM statements WHERE type = $1
+	`, statementType)
+	return periodStart, err
+}
+
+// GetByUuid fetches a statement with its rendered content
+func (r *StatementRepo) GetByUuid(ctx context.Context, uuid string) (*model.Statement, error) {
+	query := `
+		SELECT id, uuid, user_id, type, period_start, period_end, html, csv, created_at
+		FROM statements
+		WHERE uuid = $1
+	`
+
+	var statement model.Statement
+	if err := r.db.GetContext(ctx, &statement, query, uuid); err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// List fetches the statements of a user, optionally of one type, latest period first
+func (r *StatementRepo) List(
+	ctx context.Context,
+	userID uint64,
+	statementType model.StatementType,
+	limit, offset uint32,
+) ([]*model.Statement, error) {
+	query := `
+		SELECT id, uuid, user_id, type, period_start, period_end, created_at
+		FROM statements
+		WHERE user_id = $1 AND ($2 = '' OR type = $2)
+		ORDER BY period_start DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	var statements []*model.Statement
+	if err := r.db.SelectContext(ctx, &statements, query, userID, statementType, limit, offset); err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
@@ -0,0 +1,83 @@
+package repo
+
+import (
+	"context"
+	"simple-securities/internal/stock/domain/model"
+	"simple-securities/internal/stock/domain/repo"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type StockOrderRepo struct {
+	db *sqlx.DB
+}
+
+func NewStockOrderRepo(db *sqlx.DB) repo.IStockOrderRepo {
+	return &StockOrderRepo{db: db}
+}
+
+const stockOrderColumns = `
+	id, uuid, user_id, symbol, side, type, time_in_force, extended_hours,
+	price, stop_price, quantity, filled_quantity, status, expires_at, created_at, updated_at
+`
+
+// GetByUuid fetches an order by uuid
+func (r *StockOrderRepo) GetByUuid(ctx context.Context, uuid string) (*model.StockOrder, error) {
+	query := `SELECT ` + stockOrderColumns + ` FROM stock_orders WHERE uuid = $1`
+
+	var order model.StockOrder
+	if err := r.db.GetContext(ctx, &order, query, uuid); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// List fetches the orders of a user, latest first
+func (r *StockOrderRepo) List(ctx context.Context, userID uint64, status model.OrderStatus, limit, offset uint32) ([]*model.StockOrder, error) {
+	query := `
+		SELECT ` + stockOrderColumns + ` FROM stock_orders
+		WHERE user_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+
+	var orders []*model.StockOrder
+	if err := r.db.SelectContext(ctx, &orders, query, userID, status, limit, offset); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// ListDue fetches the open orders past their expiry, expires_at is stored in UTC so that
+// the timestamps compare in order
+func (r *StockOrderRepo) ListDue(ctx context.Context, now time.Time) ([]string, error) {
+	query := `
+		SELECT uuid FROM stock_orders
+		WHERE status IN ($1, $2) AND expires_at IS NOT NULL AND expires_at <= $3
+		ORDER BY expires_at, id
+	`
+
+	var uuids []string
+	if err := r.db.SelectContext(ctx, &uuids, query,
+		model.OrderStatusNew, model.OrderStatusPartiallyFilled, now.UTC()); err != nil {
+		return nil, err
+	}
+	return uuids, nil
+}
+
+// ListOpen fetches the open orders in symbol, oldest first
+func (r *StockOrderRepo) ListOpen(ctx context.Context, symbol string) ([]string, error) {
+	query := `
+		SELECT uuid FROM stock_orders
+		WHERE symbol = $1 AND status IN ($2, $3)
+		ORDER BY id
+	`
+
+	var uuids []string
+	if err := r.db.SelectContext(ctx, &uuids, query,
+		symbol, model.OrderStatusNew, model.OrderStatusPartiallyFilled); err != nil {
+		return nil, err
+	}
+	return uuids, nil
+}
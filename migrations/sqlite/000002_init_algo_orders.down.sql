@@ -0,0 +1,12 @@
+BEGIN TRANSACTION;
+
+-- Drop indexes first (to avoid orphaned indexes)
+DROP INDEX IF EXISTS idx_algo_child_orders_algo_order_id;
+DROP INDEX IF EXISTS idx_algo_orders_user_id;
+DROP INDEX IF EXISTS idx_algo_orders_status;
+
+-- Then drop the tables
+DROP TABLE IF EXISTS algo_child_orders;
+DROP TABLE IF EXISTS algo_orders;
+
+COMMIT;
@@ -0,0 +1,50 @@
+BEGIN TRANSACTION;
+
+-- Create algo_orders table (parent orders owned by the algo scheduler)
+CREATE TABLE IF NOT EXISTS algo_orders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    quantity REAL NOT NULL,
+    filled_quantity REAL NOT NULL DEFAULT 0,
+    params TEXT NOT NULL DEFAULT '{}',
+    state TEXT NOT NULL DEFAULT '{}',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index used by the scheduler to reload working orders on start
+CREATE INDEX IF NOT EXISTS idx_algo_orders_status
+    ON algo_orders(status);
+
+CREATE INDEX IF NOT EXISTS idx_algo_orders_user_id
+    ON algo_orders(user_id);
+
+-- Create algo_child_orders table (slices sent to the market)
+CREATE TABLE IF NOT EXISTS algo_child_orders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    algo_order_id INTEGER NOT NULL REFERENCES algo_orders(id),
+    seq INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    type TEXT NOT NULL,
+    price REAL NOT NULL DEFAULT 0,
+    quantity REAL NOT NULL,
+    filled_quantity REAL NOT NULL DEFAULT 0,
+    avg_fill_price REAL NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (algo_order_id, seq)
+);
+
+CREATE INDEX IF NOT EXISTS idx_algo_child_orders_algo_order_id
+    ON algo_child_orders(algo_order_id);
+
+COMMIT;
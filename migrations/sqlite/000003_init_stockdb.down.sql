@@ -0,0 +1,17 @@
+BEGIN TRANSACTION;
+
+-- Drop indexes first (to avoid orphaned indexes)
+DROP INDEX IF EXISTS idx_cash_movements_user_id;
+DROP INDEX IF EXISTS idx_corporate_actions_pending;
+DROP INDEX IF EXISTS idx_stock_orders_user_id;
+DROP INDEX IF EXISTS idx_stock_orders_symbol_open;
+DROP INDEX IF EXISTS idx_positions_symbol;
+
+-- Then drop the tables
+DROP TABLE IF EXISTS cash_movements;
+DROP TABLE IF EXISTS corporate_actions;
+DROP TABLE IF EXISTS bars;
+DROP TABLE IF EXISTS stock_orders;
+DROP TABLE IF EXISTS positions;
+
+COMMIT;
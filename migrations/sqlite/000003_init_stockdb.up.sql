@@ -0,0 +1,95 @@
+BEGIN TRANSACTION;
+
+-- Create positions table (holdings per user and symbol)
+CREATE TABLE IF NOT EXISTS positions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    quantity REAL NOT NULL DEFAULT 0,
+    cost_basis REAL NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, symbol)
+);
+
+CREATE INDEX IF NOT EXISTS idx_positions_symbol
+    ON positions(symbol);
+
+-- Create stock_orders table
+CREATE TABLE IF NOT EXISTS stock_orders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    type TEXT NOT NULL,
+    price REAL NOT NULL DEFAULT 0,
+    stop_price REAL NOT NULL DEFAULT 0,
+    quantity REAL NOT NULL,
+    filled_quantity REAL NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Open orders of a symbol are adjusted by corporate actions
+CREATE INDEX IF NOT EXISTS idx_stock_orders_symbol_open
+    ON stock_orders(symbol) WHERE status IN ('NEW', 'PARTIALLY_FILLED');
+
+CREATE INDEX IF NOT EXISTS idx_stock_orders_user_id
+    ON stock_orders(user_id);
+
+-- Create bars table (historical OHLCV, open_time in unix milliseconds)
+CREATE TABLE IF NOT EXISTS bars (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    symbol TEXT NOT NULL,
+    interval TEXT NOT NULL,
+    open_time INTEGER NOT NULL,
+    open REAL NOT NULL,
+    high REAL NOT NULL,
+    low REAL NOT NULL,
+    close REAL NOT NULL,
+    volume REAL NOT NULL,
+    UNIQUE (symbol, interval, open_time)
+);
+
+-- Create corporate_actions table (the corporate action calendar)
+CREATE TABLE IF NOT EXISTS corporate_actions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    symbol TEXT NOT NULL,
+    type TEXT NOT NULL,
+    ex_date TEXT NOT NULL,
+    pay_date TEXT NOT NULL DEFAULT '',
+    ratio_from REAL NOT NULL DEFAULT 0,
+    ratio_to REAL NOT NULL DEFAULT 0,
+    cash_amount REAL NOT NULL DEFAULT 0,
+    new_symbol TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    processed_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (symbol, type, ex_date)
+);
+
+-- Index used by the processor to find due actions
+CREATE INDEX IF NOT EXISTS idx_corporate_actions_pending
+    ON corporate_actions(ex_date) WHERE status = 'PENDING';
+
+-- Create cash_movements table (dividends and other cash credited to users)
+CREATE TABLE IF NOT EXISTS cash_movements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    type TEXT NOT NULL,
+    amount REAL NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cash_movements_user_id
+    ON cash_movements(user_id);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN TRANSACTION;
+
+-- Drop indexes first (to avoid orphaned indexes)
+DROP INDEX IF EXISTS idx_statements_user_id;
+DROP INDEX IF EXISTS idx_cash_movements_created_at;
+DROP INDEX IF EXISTS idx_stock_fills_executed_at;
+DROP INDEX IF EXISTS idx_stock_fills_user_id_executed_at;
+
+-- Then drop the tables
+DROP TABLE IF EXISTS statements;
+DROP TABLE IF EXISTS stock_fills;
+
+COMMIT;
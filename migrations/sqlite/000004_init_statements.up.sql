@@ -0,0 +1,44 @@
+BEGIN TRANSACTION;
+
+-- Create stock_fills table (executions of stock_orders)
+CREATE TABLE IF NOT EXISTS stock_fills (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    order_uuid TEXT NOT NULL,
+    user_id INTEGER NOT NULL,
+    symbol TEXT NOT NULL,
+    side TEXT NOT NULL,
+    quantity REAL NOT NULL,
+    price REAL NOT NULL,
+    fee REAL NOT NULL DEFAULT 0,
+    realized_pnl REAL NOT NULL DEFAULT 0,
+    executed_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_fills_user_id_executed_at
+    ON stock_fills(user_id, executed_at);
+
+CREATE INDEX IF NOT EXISTS idx_stock_fills_executed_at
+    ON stock_fills(executed_at);
+
+CREATE INDEX IF NOT EXISTS idx_cash_movements_created_at
+    ON cash_movements(created_at);
+
+-- Create statements table (rendered trade confirmations and monthly statements)
+CREATE TABLE IF NOT EXISTS statements (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    period_start TEXT NOT NULL,
+    period_end TEXT NOT NULL,
+    html BLOB NOT NULL,
+    csv BLOB NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, type, period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statements_user_id
+    ON statements(user_id, period_start);
+
+COMMIT;
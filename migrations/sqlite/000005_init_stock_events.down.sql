@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+-- Drop indexes first (to avoid orphaned indexes)
+DROP INDEX IF EXISTS idx_stock_fills_order_uuid;
+
+-- Then drop the tables
+DROP TABLE IF EXISTS projection_checkpoints;
+DROP TABLE IF EXISTS stock_events;
+
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN TRANSACTION;
+
+-- Create stock_events table (append-only event store of the order streams)
+CREATE TABLE IF NOT EXISTS stock_events (
+    position INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    stream_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    schema_version INTEGER NOT NULL,
+    payload BLOB NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    UNIQUE (stream_id, version)
+);
+
+-- Create projection_checkpoints table (last event position applied by each projection)
+CREATE TABLE IF NOT EXISTS projection_checkpoints (
+    name TEXT PRIMARY KEY,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_fills_order_uuid
+    ON stock_fills(order_uuid);
+
+COMMIT;
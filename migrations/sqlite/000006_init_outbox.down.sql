@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+-- Drop indexes first (to avoid orphaned indexes)
+DROP INDEX IF EXISTS idx_outbox_messages_pending;
+
+-- Then drop the tables
+DROP TABLE IF EXISTS outbox_messages;
+
+COMMIT;
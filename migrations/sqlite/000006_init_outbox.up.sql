@@ -0,0 +1,20 @@
+BEGIN TRANSACTION;
+
+-- Create outbox_messages table (events written with the state change, published by the relay)
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    topic TEXT NOT NULL,
+    message_key TEXT NOT NULL DEFAULT '',
+    payload BLOB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL,
+    dispatched_at DATETIME NULL
+);
+
+-- Index for the relay to find the messages still to publish
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_pending
+    ON outbox_messages(id) WHERE dispatched_at IS NULL;
+
+COMMIT;
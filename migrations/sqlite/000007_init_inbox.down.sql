@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+-- Drop indexes first (to avoid orphaned indexes)
+DROP INDEX IF EXISTS idx_inbox_messages_processed_at;
+
+-- Then drop the tables
+DROP TABLE IF EXISTS inbox_messages;
+
+COMMIT;
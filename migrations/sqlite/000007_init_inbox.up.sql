@@ -0,0 +1,15 @@
+BEGIN TRANSACTION;
+
+-- Create inbox_messages table (Kafka messages each consumer group has processed)
+CREATE TABLE IF NOT EXISTS inbox_messages (
+    consumer_group TEXT NOT NULL,
+    message_id TEXT NOT NULL,
+    processed_at DATETIME NOT NULL,
+    PRIMARY KEY (consumer_group, message_id)
+);
+
+-- Index to purge the processed messages past the retention period
+CREATE INDEX IF NOT EXISTS idx_inbox_messages_processed_at
+    ON inbox_messages(processed_at);
+
+COMMIT;
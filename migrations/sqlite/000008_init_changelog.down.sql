@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+-- The capture triggers write to the changelog, drop them with cdc.DropTriggers first
+
+-- Drop the tables
+DROP TABLE IF EXISTS cdc_offsets;
+DROP TABLE IF EXISTS cdc_changelog;
+
+COMMIT;
@@ -0,0 +1,21 @@
+BEGIN TRANSACTION;
+
+-- Create cdc_changelog table (row changes captured by the triggers of the tracked tables)
+CREATE TABLE IF NOT EXISTS cdc_changelog (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    table_name TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    row_key TEXT NOT NULL,
+    before_image TEXT NULL,
+    after_image TEXT NULL,
+    changed_at TEXT NOT NULL
+);
+
+-- Create cdc_offsets table (last changelog position published by each tailer)
+CREATE TABLE IF NOT EXISTS cdc_offsets (
+    name TEXT PRIMARY KEY,
+    position INTEGER NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN TRANSACTION;
+
+-- Index serving the keyset pages of the notifications of a user, newest first. created_at
+-- holds times written with different offsets and precisions, julianday orders them by instant.
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created
+    ON notifications(user_id, julianday(created_at) DESC, id DESC);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN TRANSACTION;
+
+-- Create notification_preferences table (what each user mutes and when)
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER PRIMARY KEY,
+    muted_types TEXT NOT NULL DEFAULT '[]',
+    muted_channels TEXT NOT NULL DEFAULT '[]',
+    quiet_start TEXT NOT NULL DEFAULT '',
+    quiet_end TEXT NOT NULL DEFAULT '',
+    time_zone TEXT NOT NULL DEFAULT '',
+    digest_frequency TEXT NOT NULL DEFAULT 'off',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMIT;
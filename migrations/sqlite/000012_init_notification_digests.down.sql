@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+DROP INDEX IF EXISTS idx_notification_digest_items_digest;
+DROP INDEX IF EXISTS idx_notification_digests_due;
+DROP INDEX IF EXISTS idx_notification_digests_open;
+DROP TABLE IF EXISTS notification_digest_items;
+DROP TABLE IF EXISTS notification_digests;
+
+COMMIT;
@@ -0,0 +1,38 @@
+BEGIN TRANSACTION;
+
+-- Create notification_digests table (the digests collecting the notifications of each user)
+CREATE TABLE IF NOT EXISTS notification_digests (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id INTEGER NOT NULL,
+    frequency TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'open',
+    window_end DATETIME NOT NULL,
+    item_count INTEGER NOT NULL DEFAULT 0,
+    notification_id INTEGER NULL,
+    emitted_at DATETIME NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create notification_digest_items table (the notifications held in a digest)
+CREATE TABLE IF NOT EXISTS notification_digest_items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    digest_id INTEGER NOT NULL REFERENCES notification_digests(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index to find the open digest of a user and the digests due
+CREATE INDEX IF NOT EXISTS idx_notification_digests_open
+    ON notification_digests(user_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_notification_digests_due
+    ON notification_digests(julianday(window_end)) WHERE status = 'open';
+
+-- Index to fetch the items of a digest
+CREATE INDEX IF NOT EXISTS idx_notification_digest_items_digest
+    ON notification_digest_items(digest_id, id);
+
+COMMIT;
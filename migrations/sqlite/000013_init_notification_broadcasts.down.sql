@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP INDEX IF EXISTS idx_notification_broadcast_jobs_active;
+DROP TABLE IF EXISTS notification_broadcast_jobs;
+
+COMMIT;
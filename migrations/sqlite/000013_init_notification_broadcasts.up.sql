@@ -0,0 +1,33 @@
+BEGIN TRANSACTION;
+
+-- Create notification_broadcast_jobs table (notifications sent to every user of an audience)
+CREATE TABLE IF NOT EXISTS notification_broadcast_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    audience TEXT NOT NULL,
+    user_ids TEXT NOT NULL DEFAULT '[]', -- JSON array, for the users audience
+    symbol TEXT NOT NULL DEFAULT '', -- for the holders audience
+    type TEXT NOT NULL DEFAULT '',
+    title TEXT NOT NULL DEFAULT '',
+    body TEXT NOT NULL DEFAULT '',
+    template_id TEXT NOT NULL DEFAULT '',
+    locale TEXT NOT NULL DEFAULT '',
+    variables TEXT NOT NULL DEFAULT '{}', -- JSON object
+    status TEXT NOT NULL DEFAULT 'pending',
+    total INTEGER NOT NULL DEFAULT 0,
+    sent INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    cursor INTEGER NOT NULL DEFAULT 0, -- last user id handled
+    last_error TEXT NOT NULL DEFAULT '',
+    lease_id INTEGER NOT NULL DEFAULT 0, -- claim of the runner sending the job
+    locked_until DATETIME NULL, -- end of its lease
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME NULL,
+    finished_at DATETIME NULL,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Index to claim the jobs to send, oldest first
+CREATE INDEX IF NOT EXISTS idx_notification_broadcast_jobs_active
+    ON notification_broadcast_jobs(id) WHERE status IN ('pending', 'running');
+
+COMMIT;
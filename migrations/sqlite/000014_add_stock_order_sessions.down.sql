@@ -0,0 +1,10 @@
+BEGIN TRANSACTION;
+
+-- Drop the index first, the column it covers cannot be dropped otherwise
+DROP INDEX IF EXISTS idx_stock_orders_expires_at;
+
+ALTER TABLE stock_orders DROP COLUMN expires_at;
+ALTER TABLE stock_orders DROP COLUMN extended_hours;
+ALTER TABLE stock_orders DROP COLUMN time_in_force;
+
+COMMIT;
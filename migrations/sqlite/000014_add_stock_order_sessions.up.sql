@@ -0,0 +1,12 @@
+BEGIN TRANSACTION;
+
+-- Add the time in force and session of the stock orders (see the trading calendar)
+ALTER TABLE stock_orders ADD COLUMN time_in_force TEXT NOT NULL DEFAULT 'DAY';
+ALTER TABLE stock_orders ADD COLUMN extended_hours INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE stock_orders ADD COLUMN expires_at DATETIME NULL;
+
+-- DAY orders are expired at the session close
+CREATE INDEX IF NOT EXISTS idx_stock_orders_expires_at
+    ON stock_orders(expires_at) WHERE status IN ('NEW', 'PARTIALLY_FILLED');
+
+COMMIT;
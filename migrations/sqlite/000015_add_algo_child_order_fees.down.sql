@@ -0,0 +1,5 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE algo_child_orders DROP COLUMN fee;
+
+COMMIT;
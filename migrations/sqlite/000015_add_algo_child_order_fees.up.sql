@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+-- Add the fee accumulated by the fills of the algo child orders (see pkg/fee)
+ALTER TABLE algo_child_orders ADD COLUMN fee REAL NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN TRANSACTION;
+
+ALTER TABLE stock_fills DROP COLUMN pass_through_fee;
+ALTER TABLE stock_fills DROP COLUMN commission;
+ALTER TABLE stock_fills DROP COLUMN liquidity;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN TRANSACTION;
+
+-- Add the liquidity and the split of the fee of the stock fills (see pkg/fee), fee stays
+-- the total of the commission and the pass-through fees
+ALTER TABLE stock_fills ADD COLUMN liquidity TEXT NOT NULL DEFAULT 'TAKER';
+ALTER TABLE stock_fills ADD COLUMN commission REAL NOT NULL DEFAULT 0;
+ALTER TABLE stock_fills ADD COLUMN pass_through_fee REAL NOT NULL DEFAULT 0;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN TRANSACTION;
+
+DROP TABLE IF EXISTS stock_snapshots;
+
+-- Copy stock_events back into a table whose schema_version has no default
+CREATE TABLE stock_events_v1 (
+    position INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    stream_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    schema_version INTEGER NOT NULL,
+    payload BLOB NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    UNIQUE (stream_id, version)
+);
+
+INSERT INTO stock_events_v1 (position, uuid, stream_id, version, type, schema_version, payload, occurred_at)
+SELECT position, uuid, stream_id, version, type, schema_version, payload, occurred_at FROM stock_events;
+
+DROP TABLE stock_events;
+ALTER TABLE stock_events_v1 RENAME TO stock_events;
+
+COMMIT;
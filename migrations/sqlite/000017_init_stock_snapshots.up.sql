@@ -0,0 +1,32 @@
+BEGIN TRANSACTION;
+
+-- Create stock_snapshots table (latest state of the order streams, see pkg/eventstore)
+CREATE TABLE IF NOT EXISTS stock_snapshots (
+    stream_id TEXT PRIMARY KEY,
+    version INTEGER NOT NULL,
+    schema_version INTEGER NOT NULL DEFAULT 1,
+    state BLOB NOT NULL,
+    taken_at DATETIME NOT NULL
+);
+
+-- SQLite cannot change the default of a column, stock_events is copied into a table whose
+-- schema_version defaults to 1, keeping the positions of its events
+CREATE TABLE stock_events_v2 (
+    position INTEGER PRIMARY KEY AUTOINCREMENT,
+    uuid TEXT NOT NULL UNIQUE,
+    stream_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    schema_version INTEGER NOT NULL DEFAULT 1,
+    payload BLOB NOT NULL,
+    occurred_at DATETIME NOT NULL,
+    UNIQUE (stream_id, version)
+);
+
+INSERT INTO stock_events_v2 (position, uuid, stream_id, version, type, schema_version, payload, occurred_at)
+SELECT position, uuid, stream_id, version, type, schema_version, payload, occurred_at FROM stock_events;
+
+DROP TABLE stock_events;
+ALTER TABLE stock_events_v2 RENAME TO stock_events;
+
+COMMIT;
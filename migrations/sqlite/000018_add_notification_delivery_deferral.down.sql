@@ -0,0 +1,6 @@
+BEGIN TRANSACTION;
+
+DROP INDEX IF EXISTS idx_notification_deliveries_deliver_after;
+ALTER TABLE notification_deliveries DROP COLUMN deliver_after;
+
+COMMIT;
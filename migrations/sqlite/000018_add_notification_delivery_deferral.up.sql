@@ -0,0 +1,11 @@
+BEGIN TRANSACTION;
+
+-- Add when a deferred delivery is due, the deliveries held back by the quiet hours of their
+-- user wait until the quiet hours end
+ALTER TABLE notification_deliveries ADD COLUMN deliver_after DATETIME NULL;
+
+-- Index to find the deferred deliveries that are due
+CREATE INDEX IF NOT EXISTS idx_notification_deliveries_deliver_after
+    ON notification_deliveries(deliver_after) WHERE status = 'deferred';
+
+COMMIT;
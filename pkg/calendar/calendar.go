@@ -0,0 +1,257 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"simple-securities/pkg/datetime"
+	"time"
+)
+
+// DateLayout is the layout of holiday and half-day dates
+const DateLayout = datetime.YYYY_MM_DD
+
+// maxSearchDays bounds the look-ahead of NextOpen/NextClose
+const maxSearchDays = 366
+
+// ErrUnknownExchange is returned when an exchange code is not on the calendar
+var ErrUnknownExchange = errors.New("unknown exchange")
+
+// Phase is the part of the trading day a moment falls in
+type Phase string
+
+const (
+	PhaseClosed     Phase = "CLOSED"
+	PhasePreMarket  Phase = "PRE_MARKET"
+	PhaseRegular    Phase = "REGULAR"
+	PhasePostMarket Phase = "POST_MARKET"
+)
+
+// Session is a daily window given as offsets from local midnight, a zero session is disabled
+type Session struct {
+	Open  time.Duration
+	Close time.Duration
+}
+
+// IsZero reports whether the session is disabled
+func (s Session) IsZero() bool {
+	return s.Close <= s.Open
+}
+
+// Window is a session resolved on a calendar day
+type Window struct {
+	Open  time.Time
+	Close time.Time
+}
+
+// Contains reports whether t is in [Open, Close)
+func (w Window) Contains(t time.Time) bool {
+	return !w.Open.IsZero() && !t.Before(w.Open) && t.Before(w.Close)
+}
+
+// IsZero reports whether there is no such window on the day
+func (w Window) IsZero() bool {
+	return w.Open.IsZero()
+}
+
+// Day is the resolved trading day of an exchange
+type Day struct {
+	Date       string
+	PreMarket  Window
+	Regular    Window
+	PostMarket Window
+}
+
+// End is the close of the last session of the day, zero on a non-trading day
+func (d Day) End() time.Time {
+	if !d.PostMarket.IsZero() {
+		return d.PostMarket.Close
+	}
+	return d.Regular.Close
+}
+
+// Exchange describes the trading hours of one venue in its own time zone.
+//
+// HalfDays maps a date to the early close of the regular session, the
+// post-market window keeps its length and starts at the early close.
+type Exchange struct {
+	Code       string
+	Location   *time.Location
+	PreMarket  Session
+	Regular    Session
+	PostMarket Session
+	Weekend    []time.Weekday
+	Holidays   map[string]struct{}
+	HalfDays   map[string]time.Duration
+}
+
+// NewExchange builds an exchange with the given regular session, Saturday and Sunday off
+func NewExchange(code string, location *time.Location, regular Session) *Exchange {
+	if location == nil {
+		location = time.UTC
+	}
+	return &Exchange{
+		Code:     code,
+		Location: location,
+		Regular:  regular,
+		Weekend:  []time.Weekday{time.Saturday, time.Sunday},
+		Holidays: make(map[string]struct{}),
+		HalfDays: make(map[string]time.Duration),
+	}
+}
+
+// AddHoliday closes the exchange for the whole day
+func (e *Exchange) AddHoliday(date string) error {
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return fmt.Errorf("invalid holiday %q: %w", date, err)
+	}
+	e.Holidays[date] = struct{}{}
+	return nil
+}
+
+// AddHalfDay closes the regular session of date early at the given offset from midnight
+func (e *Exchange) AddHalfDay(date string, early time.Duration) error {
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return fmt.Errorf("invalid half day %q: %w", date, err)
+	}
+	if early <= e.Regular.Open || early > e.Regular.Close {
+		return fmt.Errorf("half day %s closes outside the regular session", date)
+	}
+	e.HalfDays[date] = early
+	return nil
+}
+
+// IsTradingDay reports whether the regular session runs on the calendar day of t
+func (e *Exchange) IsTradingDay(t time.Time) bool {
+	local := t.In(e.Location)
+	for _, wd := range e.Weekend {
+		if local.Weekday() == wd {
+			return false
+		}
+	}
+	_, holiday := e.Holidays[local.Format(DateLayout)]
+	return !holiday && !e.Regular.IsZero()
+}
+
+// Day resolves the sessions of the calendar day of t, all windows are zero on a non-trading day
+func (e *Exchange) Day(t time.Time) Day {
+	local := t.In(e.Location)
+	day := Day{Date: local.Format(DateLayout)}
+	if !e.IsTradingDay(t) {
+		return day
+	}
+
+	// Built from the wall clock so that sessions keep their local times across DST changes
+	at := func(offset time.Duration) time.Time {
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, int(offset), e.Location)
+	}
+
+	regular, post := e.Regular, e.PostMarket
+	if early, ok := e.HalfDays[day.Date]; ok {
+		if !post.IsZero() {
+			post = Session{Open: early, Close: early + (post.Close - post.Open)}
+		}
+		regular.Close = early
+	}
+
+	day.Regular = Window{Open: at(regular.Open), Close: at(regular.Close)}
+	if !e.PreMarket.IsZero() {
+		day.PreMarket = Window{Open: at(e.PreMarket.Open), Close: at(e.PreMarket.Close)}
+	}
+	if !post.IsZero() {
+		day.PostMarket = Window{Open: at(post.Open), Close: at(post.Close)}
+	}
+	return day
+}
+
+// Phase returns the session t falls in
+func (e *Exchange) Phase(t time.Time) Phase {
+	day := e.Day(t)
+	switch {
+	case day.Regular.Contains(t):
+		return PhaseRegular
+	case day.PreMarket.Contains(t):
+		return PhasePreMarket
+	case day.PostMarket.Contains(t):
+		return PhasePostMarket
+	default:
+		return PhaseClosed
+	}
+}
+
+// IsOpen reports whether the regular session is running at t
+func (e *Exchange) IsOpen(t time.Time) bool {
+	return e.Phase(t) == PhaseRegular
+}
+
+// IsOpenExtended reports whether t falls in the pre-market, regular or post-market session
+func (e *Exchange) IsOpenExtended(t time.Time) bool {
+	return e.Phase(t) != PhaseClosed
+}
+
+// NextOpen returns the first regular open strictly after t, zero when none is found within a year
+func (e *Exchange) NextOpen(t time.Time) time.Time {
+	return e.next(t, func(w Window) time.Time { return w.Open })
+}
+
+// NextClose returns the first regular close strictly after t, which is the close of the
+// running session when the market is open, zero when none is found within a year
+func (e *Exchange) NextClose(t time.Time) time.Time {
+	return e.next(t, func(w Window) time.Time { return w.Close })
+}
+
+func (e *Exchange) next(t time.Time, edge func(Window) time.Time) time.Time {
+	local := t.In(e.Location)
+	for i := 0; i <= maxSearchDays; i++ {
+		day := e.Day(local.AddDate(0, 0, i))
+		if day.Regular.IsZero() {
+			continue
+		}
+		if at := edge(day.Regular); at.After(t) {
+			return at
+		}
+	}
+	return time.Time{}
+}
+
+// BarOpenTime returns the open time of the bar of the given interval that t belongs to.
+// Bars are aligned to the regular open and the last bar of the day is cut short by the close,
+// false is returned when t is outside the regular session.
+func (e *Exchange) BarOpenTime(t time.Time, interval time.Duration) (time.Time, bool) {
+	day := e.Day(t)
+	if !day.Regular.Contains(t) || interval <= 0 {
+		return time.Time{}, false
+	}
+	return day.Regular.Open.Add(t.Sub(day.Regular.Open) / interval * interval), true
+}
+
+// Calendar holds the exchanges known to the system by code
+type Calendar struct {
+	exchanges map[string]*Exchange
+}
+
+// New builds a calendar from the given exchanges
+func New(exchanges ...*Exchange) *Calendar {
+	c := &Calendar{exchanges: make(map[string]*Exchange, len(exchanges))}
+	for _, e := range exchanges {
+		c.exchanges[e.Code] = e
+	}
+	return c
+}
+
+// Exchange returns the exchange with the given code
+func (c *Calendar) Exchange(code string) (*Exchange, error) {
+	e, ok := c.exchanges[code]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExchange, code)
+	}
+	return e, nil
+}
+
+// ParseClock parses a "15:04" wall clock into an offset from midnight
+func ParseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid clock %q: %w", value, err)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
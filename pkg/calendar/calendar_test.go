@@ -0,0 +1,136 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestExchange(t *testing.T) *Exchange {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database not available: %v", err)
+	}
+	e := NewExchange("XNYS", location, Session{Open: 9*time.Hour + 30*time.Minute, Close: 16 * time.Hour})
+	e.PreMarket = Session{Open: 4 * time.Hour, Close: 9*time.Hour + 30*time.Minute}
+	e.PostMarket = Session{Open: 16 * time.Hour, Close: 20 * time.Hour}
+	if err := e.AddHoliday("2026-11-26"); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.AddHalfDay("2026-11-27", 13*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestPhase(t *testing.T) {
+	e := newTestExchange(t)
+	at := func(value string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", value, e.Location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	var tests = []struct {
+		at       string
+		expected Phase
+	}{
+		{"2026-11-23 03:59", PhaseClosed},
+		{"2026-11-23 04:00", PhasePreMarket},
+		{"2026-11-23 09:30", PhaseRegular},
+		{"2026-11-23 15:59", PhaseRegular},
+		{"2026-11-23 16:00", PhasePostMarket},
+		{"2026-11-23 20:00", PhaseClosed},
+		{"2026-11-26 12:00", PhaseClosed},     // holiday
+		{"2026-11-27 13:00", PhasePostMarket}, // half day
+		{"2026-11-27 16:59", PhasePostMarket},
+		{"2026-11-27 17:00", PhaseClosed},
+		{"2026-11-28 12:00", PhaseClosed}, // weekend
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.at, func(t *testing.T) {
+			if phase := e.Phase(at(tt.at)); phase != tt.expected {
+				t.Errorf("Phase(%s) = %s; want %s", tt.at, phase, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextOpenAndClose(t *testing.T) {
+	e := newTestExchange(t)
+	at := func(value string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", value, e.Location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	var tests = []struct {
+		at        string
+		nextOpen  string
+		nextClose string
+	}{
+		{"2026-11-23 08:00", "2026-11-23 09:30", "2026-11-23 16:00"},
+		{"2026-11-23 10:00", "2026-11-24 09:30", "2026-11-23 16:00"},
+		{"2026-11-25 17:00", "2026-11-27 09:30", "2026-11-27 13:00"},
+		{"2026-11-27 14:00", "2026-11-30 09:30", "2026-11-30 16:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.at, func(t *testing.T) {
+			if open := e.NextOpen(at(tt.at)); !open.Equal(at(tt.nextOpen)) {
+				t.Errorf("NextOpen(%s) = %s; want %s", tt.at, open, tt.nextOpen)
+			}
+			if close := e.NextClose(at(tt.at)); !close.Equal(at(tt.nextClose)) {
+				t.Errorf("NextClose(%s) = %s; want %s", tt.at, close, tt.nextClose)
+			}
+		})
+	}
+}
+
+func TestSessionAcrossDST(t *testing.T) {
+	e := newTestExchange(t)
+
+	// New York moves from EDT (UTC-4) to EST (UTC-5) on 2026-11-01
+	before := e.Day(time.Date(2026, 10, 30, 12, 0, 0, 0, time.UTC))
+	after := e.Day(time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC))
+
+	if got := before.Regular.Open.UTC().Format("15:04"); got != "13:30" {
+		t.Errorf("open before DST change = %s UTC; want 13:30", got)
+	}
+	if got := after.Regular.Open.UTC().Format("15:04"); got != "14:30" {
+		t.Errorf("open after DST change = %s UTC; want 14:30", got)
+	}
+}
+
+func TestBarOpenTime(t *testing.T) {
+	e := newTestExchange(t)
+	at := func(value string) time.Time {
+		v, err := time.ParseInLocation("2006-01-02 15:04", value, e.Location)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	open, ok := e.BarOpenTime(at("2026-11-23 10:44"), time.Hour)
+	if !ok || !open.Equal(at("2026-11-23 10:30")) {
+		t.Errorf("BarOpenTime = %s, %v; want 10:30, true", open, ok)
+	}
+	if _, ok := e.BarOpenTime(at("2026-11-23 16:30"), time.Hour); ok {
+		t.Errorf("BarOpenTime in post-market should be outside the session")
+	}
+}
+
+func TestParseClock(t *testing.T) {
+	offset, err := ParseClock("09:30")
+	if err != nil || offset != 9*time.Hour+30*time.Minute {
+		t.Errorf("ParseClock(09:30) = %s, %v; want 9h30m", offset, err)
+	}
+	if _, err := ParseClock("9.30"); err == nil {
+		t.Errorf("ParseClock(9.30) should fail")
+	}
+}
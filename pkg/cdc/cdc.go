@@ -0,0 +1,192 @@
+// Package cdc captures the row changes of SQLite tables and publishes them to Kafka, in the
+// spirit of Debezium for the local setup.
+//
+// InstallTriggers adds triggers to the tracked tables that write every insert, update and
+// delete with the before and after images of the row to the changelog table, in the
+// transaction of the change. SQLite serializes the writers, so the changelog id is the commit
+// order of the changes and serves as their log position. The Tailer publishes the changelog
+// in position order, one topic per table keyed by primary key, and stores the position it
+// reached so that it resumes there. Delivery is at least once, consumers deduplicate on the
+// source position of the events.
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Tables of the service database, see migrations/sqlite/000008_init_changelog.up.sql
+const (
+	ChangelogTable = "cdc_changelog"
+	OffsetsTable   = "cdc_offsets"
+)
+
+// Operation is the kind of row change, named as by Debezium
+type Operation string
+
+const (
+	OperationCreate Operation = "c"
+	OperationUpdate Operation = "u"
+	OperationDelete Operation = "d"
+)
+
+// ChangeEvent is the value published for a row change. Before is null for a create and
+// After for a delete; both hold the columns of the row by name.
+type ChangeEvent struct {
+	Op     Operation       `json:"op"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+	Source Source          `json:"source"`
+	// TsMs is when the event was published, in unix milliseconds
+	TsMs int64 `json:"ts_ms"`
+}
+
+// Source locates the change in the changelog
+type Source struct {
+	// Name is the name of the tailer, usually the service
+	Name  string `json:"name"`
+	Table string `json:"table"`
+	// Position is the changelog position of the change, increasing in commit order
+	Position uint64 `json:"pos"`
+	// TsMs is when the change was committed, in unix milliseconds
+	TsMs int64 `json:"ts_ms"`
+}
+
+// Change is a row of the changelog
+type Change struct {
+	ID          uint64  `db:"id"`
+	Table       string  `db:"table_name"`
+	Operation   string  `db:"operation"`
+	RowKey      string  `db:"row_key"`
+	BeforeImage *string `db:"before_image"`
+	AfterImage  *string `db:"after_image"`
+	ChangedAt   string  `db:"changed_at"`
+}
+
+// changedAtLayout is the layout the triggers write changed_at with, it sorts as text
+const changedAtLayout = "2006-01-02T15:04:05.000Z"
+
+var identifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type column struct {
+	CID          int     `db:"cid"`
+	Name         string  `db:"name"`
+	Type         string  `db:"type"`
+	NotNull      bool    `db:"notnull"`
+	DefaultValue *string `db:"dflt_value"`
+	PK           int     `db:"pk"`
+}
+
+var triggerOperations = []struct {
+	event     string
+	operation Operation
+}{
+	{"INSERT", OperationCreate},
+	{"UPDATE", OperationUpdate},
+	{"DELETE", OperationDelete},
+}
+
+// InstallTriggers captures the changes of the tables into the changelog. The triggers are
+// built from the current columns of each table and replaced when installed again, which is
+// needed after a table is altered.
+func InstallTriggers(ctx context.Context, db *sqlx.DB, tables ...string) error {
+	for _, table := range tables {
+		if !identifier.MatchString(table) {
+			return fmt.Errorf("invalid table name %q", table)
+		}
+		var columns []column
+		if err := db.SelectContext(ctx, &columns, `PRAGMA table_info(`+table+`)`); err != nil {
+			return fmt.Errorf("failed to read the columns of %s: %w", table, err)
+		}
+		if len(columns) == 0 {
+			return fmt.Errorf("table %s not found", table)
+		}
+
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		for _, op := range triggerOperations {
+			if _, err := tx.ExecContext(ctx, `DROP TRIGGER IF EXISTS `+triggerName(table, op.event)); err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, triggerSQL(table, columns, op.event, op.operation)); err != nil {
+				_ = tx.Rollback()
+				return fmt.Errorf("failed to create the %s trigger of %s: %w", op.event, table, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DropTriggers stops capturing the changes of the tables
+func DropTriggers(ctx context.Context, db *sqlx.DB, tables ...string) error {
+	for _, table := range tables {
+		if !identifier.MatchString(table) {
+			return fmt.Errorf("invalid table name %q", table)
+		}
+		for _, op := range triggerOperations {
+			if _, err := db.ExecContext(ctx, `DROP TRIGGER IF EXISTS `+triggerName(table, op.event)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func triggerName(table, event string) string {
+	return "cdc_" + table + "_" + strings.ToLower(event)
+}
+
+// triggerSQL builds the trigger writing the changes of one kind to the changelog. The row
+// key is the primary key of the row, its rowid when it has none.
+func triggerSQL(table string, columns []column, event string, op Operation) string {
+	var keys []column
+	for _, c := range columns {
+		if c.PK > 0 {
+			keys = append(keys, c)
+		}
+	}
+	if len(keys) == 0 {
+		keys = []column{{Name: "rowid"}}
+	}
+
+	before, after, keyRow := "NULL", "NULL", "NEW"
+	switch op {
+	case OperationCreate:
+		after = image("NEW", columns)
+	case OperationUpdate:
+		before, after = image("OLD", columns), image("NEW", columns)
+	case OperationDelete:
+		before, keyRow = image("OLD", columns), "OLD"
+	}
+
+	return fmt.Sprintf(`CREATE TRIGGER %s AFTER %s ON %s
+BEGIN
+    INSERT INTO %s (table_name, operation, row_key, before_image, after_image, changed_at)
+    VALUES ('%s', '%s', %s, %s, %s, strftime('%%Y-%%m-%%dT%%H:%%M:%%fZ', 'now'));
+END`, triggerName(table, event), event, table, ChangelogTable, table, op, image(keyRow, keys), before, after)
+}
+
+// image is the json_object of the columns of the row, blobs are hex encoded as JSON has no
+// bytes
+func image(row string, columns []column) string {
+	args := make([]string, 0, 2*len(columns))
+	for _, c := range columns {
+		value := row + `."` + c.Name + `"`
+		if strings.Contains(strings.ToUpper(c.Type), "BLOB") {
+			value = "hex(" + value + ")"
+		}
+		args = append(args, "'"+c.Name+"'", value)
+	}
+	return "json_object(" + strings.Join(args, ", ") + ")"
+}
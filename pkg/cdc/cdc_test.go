@@ -0,0 +1,158 @@
+package cdc
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"simple-securities/pkg/kafka"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "cdc.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	for _, file := range []string{
+		"../../migrations/sqlite/000001_init_notificationdb.up.sql",
+		"../../migrations/sqlite/000008_init_changelog.up.sql",
+	} {
+		schema, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		db.MustExec(string(schema))
+	}
+	if err := InstallTriggers(context.Background(), db, "notifications"); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newTestTailer(t *testing.T, db *sqlx.DB, bus *kafka.MemoryBus) *Tailer {
+	tailer, err := NewTailer(db, bus, TailerConfig{Name: "notification", TopicPrefix: "cdc"}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tailer
+}
+
+func changeEvents(t *testing.T, bus *kafka.MemoryBus, topic string) []ChangeEvent {
+	var events []ChangeEvent
+	for _, m := range bus.Messages(topic) {
+		var event ChangeEvent
+		if err := json.Unmarshal(m.Value, &event); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestTailerPublishesRowChanges(t *testing.T) {
+	db := newTestDB(t)
+	db.MustExec(`INSERT INTO notifications (uuid, user_id, type, title, body, created_by, updated_by)
+		VALUES ('n-1', 7, 'order', 'Filled', 'Your order is filled', 1, 1)`)
+	db.MustExec(`UPDATE notifications SET read = 1 WHERE uuid = 'n-1'`)
+	db.MustExec(`DELETE FROM notifications WHERE uuid = 'n-1'`)
+
+	bus := kafka.NewMemoryBus(1, zap.NewNop())
+	defer bus.Close()
+	tailer := newTestTailer(t, db, bus)
+	if n, err := tailer.Poll(context.Background()); err != nil || n != 3 {
+		t.Fatalf("Poll() = %d, %v, want 3", n, err)
+	}
+
+	messages := bus.Messages("cdc.notifications")
+	events := changeEvents(t, bus, "cdc.notifications")
+	if len(events) != 3 {
+		t.Fatalf("published %d events, want 3", len(events))
+	}
+	for i, op := range []Operation{OperationCreate, OperationUpdate, OperationDelete} {
+		event := events[i]
+		if event.Op != op || event.Source.Table != "notifications" || event.Source.Name != "notification" {
+			t.Errorf("event %d = %+v, want op %s", i, event, op)
+		}
+		if event.Source.Position != uint64(i+1) || event.Source.TsMs == 0 {
+			t.Errorf("event %d source = %+v", i, event.Source)
+		}
+		if string(messages[i].Key) != `{"id":1}` {
+			t.Errorf("event %d key = %s", i, messages[i].Key)
+		}
+	}
+
+	var before, after struct {
+		Uuid string `json:"uuid"`
+		Read int    `json:"read"`
+	}
+	if events[0].Before != nil && string(events[0].Before) != "null" {
+		t.Errorf("create has a before image: %s", events[0].Before)
+	}
+	if err := json.Unmarshal(events[1].Before, &before); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(events[1].After, &after); err != nil {
+		t.Fatal(err)
+	}
+	if before.Uuid != "n-1" || before.Read != 0 || after.Read != 1 {
+		t.Errorf("update images before %+v after %+v", before, after)
+	}
+	if events[2].After != nil && string(events[2].After) != "null" {
+		t.Errorf("delete has an after image: %s", events[2].After)
+	}
+}
+
+func TestTailerResumesAtPosition(t *testing.T) {
+	db := newTestDB(t)
+	bus := kafka.NewMemoryBus(1, zap.NewNop())
+	defer bus.Close()
+	ctx := context.Background()
+
+	db.MustExec(`INSERT INTO notifications (uuid, user_id, type, title, body, created_by, updated_by)
+		VALUES ('n-1', 7, 'order', 'Filled', 'Your order is filled', 1, 1)`)
+	if n, err := newTestTailer(t, db, bus).Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() = %d, %v, want 1", n, err)
+	}
+
+	db.MustExec(`UPDATE notifications SET viewed = 1 WHERE uuid = 'n-1'`)
+	tailer := newTestTailer(t, db, bus)
+	if n, err := tailer.Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() after restart = %d, %v, want only the new change", n, err)
+	}
+	if position, err := tailer.Position(ctx); err != nil || position != 2 {
+		t.Fatalf("Position() = %d, %v, want 2", position, err)
+	}
+
+	// Published changes past the retention are purged, the position is kept
+	tailer.config.Retention = time.Nanosecond
+	time.Sleep(2 * time.Millisecond)
+	if purged, err := tailer.Purge(ctx); err != nil || purged != 2 {
+		t.Fatalf("Purge() = %d, %v, want 2", purged, err)
+	}
+	db.MustExec(`DELETE FROM notifications WHERE uuid = 'n-1'`)
+	if n, err := tailer.Poll(ctx); err != nil || n != 1 {
+		t.Fatalf("Poll() after purge = %d, %v, want 1", n, err)
+	}
+	if events := changeEvents(t, bus, "cdc.notifications"); events[2].Source.Position != 3 {
+		t.Fatalf("position after purge = %d, want 3", events[2].Source.Position)
+	}
+}
+
+func TestInstallTriggersRejectsUnknownTable(t *testing.T) {
+	db := newTestDB(t)
+	if err := InstallTriggers(context.Background(), db, "missing"); err == nil {
+		t.Fatal("InstallTriggers() on a missing table did not fail")
+	}
+	if err := InstallTriggers(context.Background(), db, "notifications; DROP TABLE x"); err == nil {
+		t.Fatal("InstallTriggers() accepted an invalid name")
+	}
+}
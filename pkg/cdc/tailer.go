@@ -0,0 +1,232 @@
+package cdc
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"simple-securities/pkg/kafka"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval      = 500 * time.Millisecond
+	defaultBatchSize     = 100
+	defaultPurgeInterval = time.Minute
+)
+
+// Publisher is the part of kafka.MessageBus the tailer needs
+type Publisher interface {
+	WriteMessage(ctx context.Context, topic string, key string, value []byte) error
+}
+
+type TailerConfig struct {
+	// Name identifies the position of the tailer and is the source name of its events
+	Name string
+	// TopicPrefix names the topics, the changes of a table go to <prefix>.<table>
+	TopicPrefix string
+	// Interval between two polls of the changelog
+	Interval time.Duration
+	// BatchSize is the number of changes read per query
+	BatchSize int
+	// Retention is how long published changes are kept, zero keeps them forever
+	Retention time.Duration
+}
+
+// Tailer publishes the changelog in position order. A change that fails to publish stops the
+// poll and is retried on the next one, so the changes of a row are never reordered.
+type Tailer struct {
+	db        *sqlx.DB
+	publisher Publisher
+	config    TailerConfig
+	logger    *zap.Logger
+
+	published atomic.Uint64
+	position  atomic.Uint64
+}
+
+func NewTailer(db *sqlx.DB, publisher Publisher, config TailerConfig, logger *zap.Logger) (*Tailer, error) {
+	if config.Name == "" {
+		return nil, fmt.Errorf("cdc tailer needs a name")
+	}
+	if config.TopicPrefix == "" {
+		config.TopicPrefix = "cdc." + config.Name
+	}
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	return &Tailer{
+		db:        db,
+		publisher: publisher,
+		config:    config,
+		logger:    logger,
+	}, nil
+}
+
+// Topic is the topic of the changes of the table
+func (t *Tailer) Topic(table string) string {
+	return t.config.TopicPrefix + "." + table
+}
+
+// Start runs the tailer until ctx is cancelled
+func (t *Tailer) Start(ctx context.Context) error {
+	t.logger.Info("🔁 cdc tailer started",
+		zap.String("name", t.config.Name),
+		zap.String("topic_prefix", t.config.TopicPrefix),
+		zap.Duration("interval", t.config.Interval))
+
+	ticker := time.NewTicker(t.config.Interval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(defaultPurgeInterval)
+	defer purgeTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			t.logger.Info("cdc tailer stopped", zap.Uint64("position", t.position.Load()))
+			return nil
+		case <-ticker.C:
+			if _, err := t.Poll(ctx); err != nil && ctx.Err() == nil {
+				t.logger.Error("failed to tail changelog", zap.Error(err))
+			}
+		case <-purgeTicker.C:
+			if _, err := t.Purge(ctx); err != nil && ctx.Err() == nil {
+				t.logger.Error("failed to purge changelog", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Poll publishes the changes after the stored position until the changelog is drained or a
+// publish fails, and returns the number of changes published
+func (t *Tailer) Poll(ctx context.Context) (int, error) {
+	position, err := t.Position(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for {
+		var changes []*Change
+		err := t.db.SelectContext(ctx, &changes, `
+			SELECT id, table_name, operation, row_key, before_image, after_image, changed_at
+			FROM `+ChangelogTable+`
+			WHERE id > $1
+			ORDER BY id
+			LIMIT $2
+		`, position, t.config.BatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		reached := position
+		for _, c := range changes {
+			if err = t.publish(ctx, c); err != nil {
+				break
+			}
+			reached = c.ID
+			published++
+		}
+		// Not storing the position publishes the changes again, which at least once allows
+		if reached > position {
+			if saveErr := t.savePosition(ctx, reached); saveErr != nil {
+				return published, saveErr
+			}
+			position = reached
+		}
+		if err != nil {
+			return published, err
+		}
+		if len(changes) < t.config.BatchSize {
+			return published, nil
+		}
+	}
+}
+
+func (t *Tailer) publish(ctx context.Context, c *Change) error {
+	event := ChangeEvent{
+		Op: Operation(c.Operation),
+		Source: Source{
+			Name:     t.config.Name,
+			Table:    c.Table,
+			Position: c.ID,
+		},
+		TsMs: time.Now().UnixMilli(),
+	}
+	if changedAt, err := time.Parse(changedAtLayout, c.ChangedAt); err == nil {
+		event.Source.TsMs = changedAt.UnixMilli()
+	}
+	if c.BeforeImage != nil {
+		event.Before = json.RawMessage(*c.BeforeImage)
+	}
+	if c.AfterImage != nil {
+		event.After = json.RawMessage(*c.AfterImage)
+	}
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal change %d: %w", c.ID, err)
+	}
+	requestID := fmt.Sprintf("%s-%d", t.config.Name, c.ID)
+	if err := t.publisher.WriteMessage(kafka.WithRequestID(ctx, requestID), t.Topic(c.Table), c.RowKey, value); err != nil {
+		return fmt.Errorf("failed to publish change %d of %s: %w", c.ID, c.Table, err)
+	}
+	t.published.Add(1)
+	return nil
+}
+
+// Position is the last changelog position the tailer published
+func (t *Tailer) Position(ctx context.Context) (uint64, error) {
+	var position uint64
+	err := t.db.GetContext(ctx, &position, `SELECT position FROM `+OffsetsTable+` WHERE name = $1`, t.config.Name)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+	t.position.Store(position)
+	return position, nil
+}
+
+func (t *Tailer) savePosition(ctx context.Context, position uint64) error {
+	_, err := t.db.ExecContext(ctx, `
+		INSERT INTO `+OffsetsTable+` (name, position, updated_at) VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO UPDATE SET position = excluded.position, updated_at = excluded.updated_at
+	`, t.config.Name, position, time.Now().UTC())
+	if err == nil {
+		t.position.Store(position)
+	}
+	return err
+}
+
+// Purge deletes the changes older than the retention that every tailer has published
+func (t *Tailer) Purge(ctx context.Context) (int64, error) {
+	if t.config.Retention <= 0 {
+		return 0, nil
+	}
+	cutoff := time.Now().UTC().Add(-t.config.Retention).Format(changedAtLayout)
+	result, err := t.db.ExecContext(ctx, `
+		DELETE FROM `+ChangelogTable+`
+		WHERE id <= (SELECT COALESCE(MIN(position), 0) FROM `+OffsetsTable+`) AND changed_at < $1
+	`, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	purged, err := result.RowsAffected()
+	if err == nil && purged > 0 {
+		t.logger.Info("cdc changelog purged", zap.Int64("changes", purged))
+	}
+	return purged, err
+}
+
+// Published counts the changes published since the tailer started
+func (t *Tailer) Published() uint64 {
+	return t.published.Load()
+}
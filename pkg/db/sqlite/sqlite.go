@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3" // SQLite driver
@@ -15,8 +17,19 @@ type SQLiteClient struct {
 }
 
 func NewSQLiteClient() (*SQLiteClient, error) {
-	dsn := ":memory:"
+	c, err := NewSQLiteClientWithDSN(":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// Every connection to :memory: opens a database of its own, background workers such as
+	// the outbox relay have to share the one the migrations ran on
+	c.DB.SetMaxOpenConns(1)
+	return c, nil
+}
 
+// NewSQLiteClientWithDSN opens a SQLite database at the given DSN, e.g. a file
+// path for state that has to survive restarts.
+func NewSQLiteClientWithDSN(dsn string) (*SQLiteClient, error) {
 	db, err := sqlx.Connect("sqlite3", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open SQLite connection: %w", err)
@@ -29,18 +42,56 @@ func NewSQLiteClient() (*SQLiteClient, error) {
 	return &SQLiteClient{DB: db}, nil
 }
 
+// AutoMigrate creates the notification schema and seeds it with demo notifications, for the
+// in-memory database
 func (c *SQLiteClient) AutoMigrate() {
-	files := []string{
+	c.Migrate()
+	c.MigrateFiles("migrations/sqlite/000001_seed_notifications.up.sql")
+}
+
+// Migrate creates the notification schema, it can run on every start of a database file
+func (c *SQLiteClient) Migrate() {
+	c.MigrateFiles(
 		"migrations/sqlite/000001_init_notificationdb.up.sql",
-		"migrations/sqlite/000001_seed_notifications.up.sql",
-	}
+		"migrations/sqlite/000006_init_outbox.up.sql",
+		"migrations/sqlite/000007_init_inbox.up.sql",
+		"migrations/sqlite/000008_init_changelog.up.sql",
+		"migrations/sqlite/000009_init_notifications_page_index.up.sql",
+		"migrations/sqlite/000010_init_notification_delivery.up.sql",
+		"migrations/sqlite/000011_init_notification_preferences.up.sql",
+		"migrations/sqlite/000012_init_notification_digests.up.sql",
+		"migrations/sqlite/000013_init_notification_broadcasts.up.sql",
+		"migrations/sqlite/000018_add_notification_delivery_deferral.up.sql",
+	)
+}
 
+// MigrateFiles executes the given migration files in order, each once per database. The
+// applied files are recorded in schema_migrations, so that a migration altering a table runs
+// on the databases created before it and is skipped on the next starts.
+func (c *SQLiteClient) MigrateFiles(files ...string) {
+	c.DB.MustExec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
 	for _, file := range files {
+		version := strings.TrimSuffix(filepath.Base(file), ".up.sql")
+		var applied bool
+		err := c.DB.Get(&applied, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, version)
+		if err != nil {
+			log.Fatalf("failed to check migration %s: %v", version, err)
+		}
+		if applied {
+			continue
+		}
+
 		sql, err := os.ReadFile(file)
 		if err != nil {
 			log.Fatalf("failed to read migration file %s: %v", file, err)
 		}
 		c.DB.MustExec(string(sql))
+		c.DB.MustExec(`INSERT INTO schema_migrations (version) VALUES ($1)`, version)
 	}
 }
 
@@ -0,0 +1,88 @@
+// Package eventstore is an append-only store of aggregate event streams on SQL.
+//
+// Every stream is keyed by the ID of its aggregate and numbers its events from 1. An
+// append names the version the writer expects the stream to be at and fails with
+// ErrVersionConflict when another writer got there first. Every event also gets a
+// position in the store that orders all streams, subscribers read the store in
+// position order and resume after the last position they processed.
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrVersionConflict is returned when the stream is not at the expected version
+	ErrVersionConflict = errors.New("eventstore: stream version conflict")
+	// ErrInvalidEvent is returned for events that cannot be appended to the stream as given
+	ErrInvalidEvent = errors.New("eventstore: invalid event")
+)
+
+// AnyVersion appends to the stream whatever its version
+const AnyVersion int64 = -1
+
+// NoStream expects the stream to be empty
+const NoStream int64 = 0
+
+// Event is a fact of an aggregate stream
+type Event struct {
+	Position      uint64    `db:"position"`
+	Uuid          string    `db:"uuid"`
+	StreamID      string    `db:"stream_id"`
+	Version       uint64    `db:"version"`
+	Type          string    `db:"type"`
+	SchemaVersion uint32    `db:"schema_version"`
+	Payload       []byte    `db:"payload"`
+	OccurredAt    time.Time `db:"occurred_at"`
+}
+
+// Snapshot is the state of an aggregate after the event at Version, loading the aggregate
+// starts from the snapshot and replays only the events after it
+type Snapshot struct {
+	StreamID      string    `db:"stream_id"`
+	Version       uint64    `db:"version"`
+	SchemaVersion uint32    `db:"schema_version"`
+	State         []byte    `db:"state"`
+	TakenAt       time.Time `db:"taken_at"`
+}
+
+// Handler processes the events delivered by a subscription. An error stops the subscription.
+type Handler func(ctx context.Context, event *Event) error
+
+type Store interface {
+	// Append adds the events to the stream and fills in their version and position. The
+	// stream has to be at expectedVersion unless it is AnyVersion.
+	Append(ctx context.Context, streamID string, expectedVersion int64, events ...*Event) error
+	// Load returns the events of the stream after the given version in version order
+	Load(ctx context.Context, streamID string, afterVersion uint64) ([]*Event, error)
+	// Version returns the version of the last event of the stream, zero for an empty stream
+	Version(ctx context.Context, streamID string) (uint64, error)
+	// ReadAll returns up to limit events of every stream after the given position in position order
+	ReadAll(ctx context.Context, afterPosition uint64, limit int) ([]*Event, error)
+	// Head returns the position of the last event in the store
+	Head(ctx context.Context) (uint64, error)
+	// SaveSnapshot stores the snapshot in place of the older snapshot of the stream
+	SaveSnapshot(ctx context.Context, snapshot *Snapshot) error
+	// LoadSnapshot returns the latest snapshot of the stream, nil when there is none
+	LoadSnapshot(ctx context.Context, streamID string) (*Snapshot, error)
+	// Subscribe delivers the events after the given position, first the stored ones and
+	// then the new ones as they are appended. It blocks until ctx is done or the handler
+	// fails; the caller resumes from the last position it processed.
+	Subscribe(ctx context.Context, afterPosition uint64, handler Handler) error
+}
+
+// SnapshotPolicy takes a snapshot of a stream every Every events, zero disables snapshots
+type SnapshotPolicy struct {
+	Every uint64 `yaml:"every" mapstructure:"every"`
+}
+
+// Due reports whether an append that moved the stream from one version to the other
+// crossed a snapshot boundary
+func (p SnapshotPolicy) Due(from, to uint64) bool {
+	if p.Every == 0 {
+		return false
+	}
+	return to/p.Every > from/p.Every
+}
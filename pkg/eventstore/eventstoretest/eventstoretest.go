@@ -0,0 +1,369 @@
+// Package eventstoretest is the conformance suite of the eventstore.Store implementations
+package eventstoretest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"simple-securities/pkg/eventstore"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Factory returns an empty store, it is called once per test
+type Factory func(t *testing.T) eventstore.Store
+
+// Run checks the store against the behaviour every implementation has to share
+func Run(t *testing.T, newStore Factory) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, s eventstore.Store)
+	}{
+		{"AppendAndLoad", testAppendAndLoad},
+		{"ExpectedVersion", testExpectedVersion},
+		{"AnyVersion", testAnyVersion},
+		{"InvalidEventsAreNotStored", testInvalidEvents},
+		{"ConcurrentAppends", testConcurrentAppends},
+		{"GlobalOrder", testGlobalOrder},
+		{"Snapshots", testSnapshots},
+		{"SubscribeCatchesUpAndFollows", testSubscribeFollows},
+		{"SubscribeFromPosition", testSubscribeFromPosition},
+		{"SubscribeHandlerError", testSubscribeHandlerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newStore(t))
+		})
+	}
+}
+
+func newEvents(n int, eventType string) []*eventstore.Event {
+	events := make([]*eventstore.Event, n)
+	for i := range events {
+		events[i] = &eventstore.Event{Type: eventType, Payload: []byte(fmt.Sprintf(`{"n":%d}`, i))}
+	}
+	return events
+}
+
+func testAppendAndLoad(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	events := newEvents(3, "created")
+	if err := s.Append(ctx, "a-1", eventstore.NoStream, events...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	for i, e := range events {
+		if e.Version != uint64(i+1) || e.StreamID != "a-1" || e.Uuid == "" || e.Position == 0 || e.SchemaVersion != 1 {
+			t.Fatalf("appended event %d = %+v", i, e)
+		}
+		if i > 0 && e.Position <= events[i-1].Position {
+			t.Fatalf("position %d does not follow %d", e.Position, events[i-1].Position)
+		}
+	}
+
+	loaded, err := s.Load(ctx, "a-1", 0)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded) != 3 {
+		t.Fatalf("Load() returned %d events, want 3", len(loaded))
+	}
+	for i, e := range loaded {
+		want := events[i]
+		if e.Uuid != want.Uuid || e.Version != want.Version || e.Position != want.Position ||
+			e.Type != want.Type || string(e.Payload) != string(want.Payload) ||
+			!e.OccurredAt.Equal(want.OccurredAt) {
+			t.Fatalf("loaded event %d = %+v, want %+v", i, e, want)
+		}
+	}
+
+	tail, err := s.Load(ctx, "a-1", 2)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(tail) != 1 || tail[0].Version != 3 {
+		t.Fatalf("Load(after 2) = %+v, want version 3 only", tail)
+	}
+
+	if version, err := s.Version(ctx, "a-1"); err != nil || version != 3 {
+		t.Fatalf("Version() = %d, %v, want 3", version, err)
+	}
+	if version, err := s.Version(ctx, "missing"); err != nil || version != 0 {
+		t.Fatalf("Version(missing) = %d, %v, want 0", version, err)
+	}
+	if missing, err := s.Load(ctx, "missing", 0); err != nil || len(missing) != 0 {
+		t.Fatalf("Load(missing) = %v, %v, want no events", missing, err)
+	}
+}
+
+func testExpectedVersion(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	if err := s.Append(ctx, "a-1", eventstore.NoStream, newEvents(2, "created")...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	for _, expected := range []int64{eventstore.NoStream, 1, 3} {
+		err := s.Append(ctx, "a-1", expected, newEvents(1, "changed")...)
+		if !errors.Is(err, eventstore.ErrVersionConflict) {
+			t.Fatalf("Append(expected %d) error = %v, want ErrVersionConflict", expected, err)
+		}
+	}
+	if err := s.Append(ctx, "a-1", 2, newEvents(1, "changed")...); err != nil {
+		t.Fatalf("Append(expected 2) error = %v", err)
+	}
+	if version, _ := s.Version(ctx, "a-1"); version != 3 {
+		t.Fatalf("Version() = %d, want 3", version)
+	}
+}
+
+func testAnyVersion(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := s.Append(ctx, "a-1", eventstore.AnyVersion, newEvents(2, "changed")...); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+	if version, _ := s.Version(ctx, "a-1"); version != 6 {
+		t.Fatalf("Version() = %d, want 6", version)
+	}
+}
+
+func testInvalidEvents(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	cases := map[string][]*eventstore.Event{
+		"wrong version": {{Type: "created"}, {Type: "changed", Version: 5}},
+		"other stream":  {{Type: "created"}, {Type: "changed", StreamID: "a-2"}},
+		"no type":       {{Type: "created"}, {}},
+	}
+	for name, events := range cases {
+		err := s.Append(ctx, "a-1", eventstore.NoStream, events...)
+		if !errors.Is(err, eventstore.ErrInvalidEvent) {
+			t.Fatalf("%s: Append() error = %v, want ErrInvalidEvent", name, err)
+		}
+		if events[0].Position != 0 {
+			t.Fatalf("%s: rejected event got position %d", name, events[0].Position)
+		}
+	}
+	if head, _ := s.Head(ctx); head != 0 {
+		t.Fatalf("Head() = %d after rejected appends, want 0", head)
+	}
+}
+
+func testConcurrentAppends(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	const writers = 8
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- s.Append(ctx, "a-1", eventstore.NoStream, newEvents(2, "created")...)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	won := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			won++
+		case !errors.Is(err, eventstore.ErrVersionConflict):
+			t.Fatalf("Append() error = %v, want nil or ErrVersionConflict", err)
+		}
+	}
+	if won != 1 {
+		t.Fatalf("%d concurrent appends at the same version succeeded, want 1", won)
+	}
+	if version, _ := s.Version(ctx, "a-1"); version != 2 {
+		t.Fatalf("Version() = %d, want 2", version)
+	}
+}
+
+func testGlobalOrder(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	var appended []*eventstore.Event
+	for i := 0; i < 5; i++ {
+		for _, stream := range []string{"a-1", "b-1", "c-1"} {
+			events := newEvents(1, "changed")
+			if err := s.Append(ctx, stream, eventstore.AnyVersion, events...); err != nil {
+				t.Fatalf("Append() error = %v", err)
+			}
+			appended = append(appended, events...)
+		}
+	}
+
+	var read []*eventstore.Event
+	var after uint64
+	for {
+		events, err := s.ReadAll(ctx, after, 4)
+		if err != nil {
+			t.Fatalf("ReadAll() error = %v", err)
+		}
+		if len(events) > 4 {
+			t.Fatalf("ReadAll() returned %d events, limit is 4", len(events))
+		}
+		if len(events) == 0 {
+			break
+		}
+		read = append(read, events...)
+		after = events[len(events)-1].Position
+	}
+
+	if len(read) != len(appended) {
+		t.Fatalf("ReadAll() returned %d events, want %d", len(read), len(appended))
+	}
+	for i, e := range read {
+		if e.Uuid != appended[i].Uuid || e.Position != appended[i].Position {
+			t.Fatalf("event %d = %s at %d, want %s at %d", i, e.Uuid, e.Position, appended[i].Uuid, appended[i].Position)
+		}
+	}
+	if head, err := s.Head(ctx); err != nil || head != appended[len(appended)-1].Position {
+		t.Fatalf("Head() = %d, %v, want %d", head, err, appended[len(appended)-1].Position)
+	}
+}
+
+func testSnapshots(t *testing.T, s eventstore.Store) {
+	ctx := context.Background()
+	if snapshot, err := s.LoadSnapshot(ctx, "a-1"); err != nil || snapshot != nil {
+		t.Fatalf("LoadSnapshot() = %+v, %v, want none", snapshot, err)
+	}
+
+	for _, version := range []uint64{2, 5, 3} {
+		err := s.SaveSnapshot(ctx, &eventstore.Snapshot{
+			StreamID: "a-1",
+			Version:  version,
+			State:    []byte(fmt.Sprintf(`{"v":%d}`, version)),
+		})
+		if err != nil {
+			t.Fatalf("SaveSnapshot(%d) error = %v", version, err)
+		}
+	}
+
+	snapshot, err := s.LoadSnapshot(ctx, "a-1")
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if snapshot == nil || snapshot.Version != 5 || string(snapshot.State) != `{"v":5}` || snapshot.SchemaVersion != 1 {
+		t.Fatalf("LoadSnapshot() = %+v, want the snapshot at version 5", snapshot)
+	}
+	if snapshot.TakenAt.IsZero() {
+		t.Fatalf("LoadSnapshot() has no time")
+	}
+
+	if err := s.SaveSnapshot(ctx, &eventstore.Snapshot{StreamID: "a-1"}); !errors.Is(err, eventstore.ErrInvalidEvent) {
+		t.Fatalf("SaveSnapshot(version 0) error = %v, want ErrInvalidEvent", err)
+	}
+}
+
+// collect subscribes in the background and hands over the delivered events
+func collect(ctx context.Context, s eventstore.Store, after uint64) (<-chan *eventstore.Event, <-chan error) {
+	events := make(chan *eventstore.Event, 100)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Subscribe(ctx, after, func(_ context.Context, e *eventstore.Event) error {
+			events <- e
+			return nil
+		})
+	}()
+	return events, done
+}
+
+func receive(t *testing.T, events <-chan *eventstore.Event, n int) []*eventstore.Event {
+	t.Helper()
+	var received []*eventstore.Event
+	timeout := time.After(5 * time.Second)
+	for len(received) < n {
+		select {
+		case e := <-events:
+			received = append(received, e)
+		case <-timeout:
+			t.Fatalf("received %d events, want %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func testSubscribeFollows(t *testing.T, s eventstore.Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := s.Append(ctx, "a-1", eventstore.NoStream, newEvents(3, "created")...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	events, done := collect(ctx, s, 0)
+	backlog := receive(t, events, 3)
+
+	if err := s.Append(ctx, "b-1", eventstore.NoStream, newEvents(2, "created")...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	live := receive(t, events, 2)
+
+	received := append(backlog, live...)
+	for i, e := range received {
+		if i > 0 && e.Position <= received[i-1].Position {
+			t.Fatalf("event %d at position %d does not follow %d", i, e.Position, received[i-1].Position)
+		}
+	}
+	if live[0].StreamID != "b-1" || live[1].Version != 2 {
+		t.Fatalf("live events = %+v, %+v", live[0], live[1])
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Subscribe() error = %v after cancel, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Subscribe() did not return after cancel")
+	}
+}
+
+func testSubscribeFromPosition(t *testing.T, s eventstore.Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := newEvents(4, "created")
+	if err := s.Append(ctx, "a-1", eventstore.NoStream, first...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	events, _ := collect(ctx, s, first[1].Position)
+	received := receive(t, events, 2)
+	if received[0].Uuid != first[2].Uuid || received[1].Uuid != first[3].Uuid {
+		t.Fatalf("Subscribe() started at %d, want %d", received[0].Position, first[2].Position)
+	}
+
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected event at position %d", e.Position)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func testSubscribeHandlerError(t *testing.T, s eventstore.Store) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.Append(ctx, "a-1", eventstore.NoStream, newEvents(3, "created")...); err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+
+	failure := errors.New("handler failed")
+	var handled []uint64
+	err := s.Subscribe(ctx, 0, func(_ context.Context, e *eventstore.Event) error {
+		if e.Version == 2 {
+			return failure
+		}
+		handled = append(handled, e.Version)
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("Subscribe() error = %v, want the handler error", err)
+	}
+	if len(handled) != 1 || handled[0] != 1 {
+		t.Fatalf("handled versions %v before the failure, want [1]", handled)
+	}
+}
@@ -0,0 +1,404 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"simple-securities/pkg/uuid"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Dialect string
+
+const (
+	DialectSQLite   Dialect = "sqlite"
+	DialectPostgres Dialect = "postgres"
+)
+
+const (
+	defaultEventsTable    = "events"
+	defaultSnapshotsTable = "snapshots"
+	defaultPollInterval   = time.Second
+	defaultBatchSize      = 500
+)
+
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type Config struct {
+	EventsTable    string
+	SnapshotsTable string
+	// PollInterval is how often subscriptions look for events appended by other processes,
+	// events appended through the same store are delivered right away
+	PollInterval time.Duration
+	// BatchSize is the number of events a subscription reads at once
+	BatchSize int
+}
+
+// SQLStore keeps the events in one table of a SQLite or Postgres database.
+//
+// On SQLite the database has to be opened with _txlock=immediate so that concurrent
+// appends queue on the write lock instead of failing with SQLITE_BUSY. On Postgres the
+// appends take a transaction level advisory lock so that positions become visible in
+// order and a subscriber never skips a position taken by a slower transaction.
+type SQLStore struct {
+	db      *sqlx.DB
+	dialect Dialect
+	config  Config
+	lockKey int64
+
+	mu       sync.Mutex
+	appended chan struct{}
+}
+
+func NewSQLStore(db *sqlx.DB, config Config) (*SQLStore, error) {
+	var dialect Dialect
+	switch db.DriverName() {
+	case "sqlite3", "sqlite":
+		dialect = DialectSQLite
+	case "postgres", "pgx", "pgx/v5":
+		dialect = DialectPostgres
+	default:
+		return nil, fmt.Errorf("eventstore: unsupported driver %q", db.DriverName())
+	}
+
+	if config.EventsTable == "" {
+		config.EventsTable = defaultEventsTable
+	}
+	if config.SnapshotsTable == "" {
+		config.SnapshotsTable = defaultSnapshotsTable
+	}
+	for _, table := range []string{config.EventsTable, config.SnapshotsTable} {
+		if !tableNamePattern.MatchString(table) {
+			return nil, fmt.Errorf("eventstore: invalid table name %q", table)
+		}
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(config.EventsTable))
+
+	return &SQLStore{
+		db:       db,
+		dialect:  dialect,
+		config:   config,
+		lockKey:  int64(h.Sum64()),
+		appended: make(chan struct{}),
+	}, nil
+}
+
+// Migrate creates the event and snapshot tables when they do not exist
+func (s *SQLStore) Migrate(ctx context.Context) error {
+	var statements []string
+	switch s.dialect {
+	case DialectSQLite:
+		statements = []string{
+			`CREATE TABLE IF NOT EXISTS ` + s.config.EventsTable + ` (
+				position INTEGER PRIMARY KEY AUTOINCREMENT,
+				uuid TEXT NOT NULL UNIQUE,
+				stream_id TEXT NOT NULL,
+				version INTEGER NOT NULL,
+				type TEXT NOT NULL,
+				schema_version INTEGER NOT NULL DEFAULT 1,
+				payload BLOB NOT NULL,
+				occurred_at DATETIME NOT NULL,
+				UNIQUE (stream_id, version)
+			)`,
+			`CREATE TABLE IF NOT EXISTS ` + s.config.SnapshotsTable + ` (
+				stream_id TEXT PRIMARY KEY,
+				version INTEGER NOT NULL,
+				schema_version INTEGER NOT NULL DEFAULT 1,
+				state BLOB NOT NULL,
+				taken_at DATETIME NOT NULL
+			)`,
+		}
+	case DialectPostgres:
+		statements = []string{
+			`CREATE TABLE IF NOT EXISTS ` + s.config.EventsTable + ` (
+				position BIGSERIAL PRIMARY KEY,
+				uuid TEXT NOT NULL UNIQUE,
+				stream_id TEXT NOT NULL,
+				version BIGINT NOT NULL,
+				type TEXT NOT NULL,
+				schema_version INTEGER NOT NULL DEFAULT 1,
+				payload BYTEA NOT NULL,
+				occurred_at TIMESTAMPTZ NOT NULL,
+				UNIQUE (stream_id, version)
+			)`,
+			`CREATE TABLE IF NOT EXISTS ` + s.config.SnapshotsTable + ` (
+				stream_id TEXT PRIMARY KEY,
+				version BIGINT NOT NULL,
+				schema_version INTEGER NOT NULL DEFAULT 1,
+				state BYTEA NOT NULL,
+				taken_at TIMESTAMPTZ NOT NULL
+			)`,
+		}
+	}
+
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("eventstore: failed to migrate: %w", err)
+		}
+	}
+	return nil
+}
+
+// withTransaction runs fn inside a transaction with proper commit/rollback handling
+func (s *SQLStore) withTransaction(ctx context.Context, fn func(*sqlx.Tx) error) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// rollback/commit handler
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p) // rethrow panic after rollback
+		} else if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = fn(tx)
+	return err
+}
+
+const eventColumns = `position, uuid, stream_id, version, type, schema_version, payload, occurred_at`
+
+// Append checks the version of the stream and inserts the events in one transaction. A writer
+// racing past the check hits the (stream_id, version) key and gets a conflict as well. Events
+// without a uuid, schema version or time get a new uuid, schema version 1 and the current time.
+func (s *SQLStore) Append(ctx context.Context, streamID string, expectedVersion int64, events ...*Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if streamID == "" {
+		return fmt.Errorf("%w: empty stream id", ErrInvalidEvent)
+	}
+	if expectedVersion < AnyVersion {
+		return fmt.Errorf("%w: expected version %d", ErrInvalidEvent, expectedVersion)
+	}
+
+	rows := make([]Event, len(events))
+	err := s.withTransaction(ctx, func(tx *sqlx.Tx) error {
+		if s.dialect == DialectPostgres {
+			if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, s.lockKey); err != nil {
+				return err
+			}
+		}
+
+		var current uint64
+		if err := tx.GetContext(ctx, &current,
+			`SELECT COALESCE(MAX(version), 0) FROM `+s.config.EventsTable+` WHERE stream_id = $1`, streamID); err != nil {
+			return err
+		}
+		if expectedVersion != AnyVersion && current != uint64(expectedVersion) {
+			return fmt.Errorf("%w: %s is at version %d, expected %d", ErrVersionConflict, streamID, current, expectedVersion)
+		}
+
+		now := time.Now().UTC()
+		for i, e := range events {
+			row := *e
+			row.Version = current + uint64(i) + 1
+			if e.StreamID != "" && e.StreamID != streamID {
+				return fmt.Errorf("%w: event %s belongs to stream %s, not %s", ErrInvalidEvent, e.Uuid, e.StreamID, streamID)
+			}
+			if e.Version != 0 && e.Version != row.Version {
+				return fmt.Errorf("%w: event %s has version %d, next version of %s is %d",
+					ErrInvalidEvent, e.Uuid, e.Version, streamID, row.Version)
+			}
+			if e.Type == "" {
+				return fmt.Errorf("%w: event %s has no type", ErrInvalidEvent, e.Uuid)
+			}
+			row.StreamID = streamID
+			if row.Uuid == "" {
+				row.Uuid = uuid.NewGoogleUUID()
+			}
+			if row.SchemaVersion == 0 {
+				row.SchemaVersion = 1
+			}
+			if row.OccurredAt.IsZero() {
+				row.OccurredAt = now
+			}
+			row.OccurredAt = row.OccurredAt.UTC()
+			if row.Payload == nil {
+				row.Payload = []byte{}
+			}
+			rows[i] = row
+		}
+
+		stmt, err := tx.PrepareNamedContext(ctx, `
+			INSERT INTO `+s.config.EventsTable+` (uuid, stream_id, version, type, schema_version, payload, occurred_at)
+			VALUES (:uuid, :stream_id, :version, :type, :schema_version, :payload, :occurred_at)
+			ON CONFLICT (stream_id, version) DO NOTHING
+			RETURNING position
+		`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for i := range rows {
+			if err := stmt.GetContext(ctx, &rows[i].Position, &rows[i]); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("%w: %s version %d already exists", ErrVersionConflict, streamID, rows[i].Version)
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, e := range events {
+		*e = rows[i]
+	}
+	s.notify()
+	return nil
+}
+
+func (s *SQLStore) Load(ctx context.Context, streamID string, afterVersion uint64) ([]*Event, error) {
+	query := `SELECT ` + eventColumns + ` FROM ` + s.config.EventsTable + `
+		WHERE stream_id = $1 AND version > $2 ORDER BY version`
+
+	var events []*Event
+	if err := s.db.SelectContext(ctx, &events, query, streamID, afterVersion); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *SQLStore) Version(ctx context.Context, streamID string) (uint64, error) {
+	var version uint64
+	err := s.db.GetContext(ctx, &version,
+		`SELECT COALESCE(MAX(version), 0) FROM `+s.config.EventsTable+` WHERE stream_id = $1`, streamID)
+	return version, err
+}
+
+func (s *SQLStore) ReadAll(ctx context.Context, afterPosition uint64, limit int) ([]*Event, error) {
+	if limit <= 0 {
+		limit = s.config.BatchSize
+	}
+	query := `SELECT ` + eventColumns + ` FROM ` + s.config.EventsTable + `
+		WHERE position > $1 ORDER BY position LIMIT $2`
+
+	var events []*Event
+	if err := s.db.SelectContext(ctx, &events, query, afterPosition, limit); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (s *SQLStore) Head(ctx context.Context) (uint64, error) {
+	var position uint64
+	err := s.db.GetContext(ctx, &position, `SELECT COALESCE(MAX(position), 0) FROM `+s.config.EventsTable)
+	return position, err
+}
+
+// SaveSnapshot replaces the snapshot of the stream unless the stored one is more recent
+func (s *SQLStore) SaveSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	if snapshot.StreamID == "" || snapshot.Version == 0 {
+		return fmt.Errorf("%w: snapshot needs a stream id and a version", ErrInvalidEvent)
+	}
+	row := *snapshot
+	if row.SchemaVersion == 0 {
+		row.SchemaVersion = 1
+	}
+	if row.TakenAt.IsZero() {
+		row.TakenAt = time.Now()
+	}
+	row.TakenAt = row.TakenAt.UTC()
+
+	_, err := s.db.NamedExecContext(ctx, `
+		INSERT INTO `+s.config.SnapshotsTable+` (stream_id, version, schema_version, state, taken_at)
+		VALUES (:stream_id, :version, :schema_version, :state, :taken_at)
+		ON CONFLICT (stream_id) DO UPDATE SET
+			version        = excluded.version,
+			schema_version = excluded.schema_version,
+			state          = excluded.state,
+			taken_at       = excluded.taken_at
+		WHERE `+s.config.SnapshotsTable+`.version <= excluded.version
+	`, &row)
+	return err
+}
+
+func (s *SQLStore) LoadSnapshot(ctx context.Context, streamID string) (*Snapshot, error) {
+	var snapshot Snapshot
+	err := s.db.GetContext(ctx, &snapshot, `
+		SELECT stream_id, version, schema_version, state, taken_at
+		FROM `+s.config.SnapshotsTable+` WHERE stream_id = $1
+	`, streamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+// Subscribe polls the store every PollInterval and right after every append made through it
+func (s *SQLStore) Subscribe(ctx context.Context, afterPosition uint64, handler Handler) error {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Taken before reading so that an append made while the batch is handled wakes the next read
+		appended := s.appendedSignal()
+
+		for {
+			events, err := s.ReadAll(ctx, afterPosition, s.config.BatchSize)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return err
+			}
+			for _, e := range events {
+				if err := handler(ctx, e); err != nil {
+					return err
+				}
+				afterPosition = e.Position
+			}
+			if len(events) < s.config.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-appended:
+		}
+	}
+}
+
+func (s *SQLStore) appendedSignal() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.appended
+}
+
+// notify wakes every subscription waiting for events
+func (s *SQLStore) notify() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	close(s.appended)
+	s.appended = make(chan struct{})
+}
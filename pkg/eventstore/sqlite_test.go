@@ -0,0 +1,69 @@
+package eventstore_test
+
+import (
+	"context"
+	"path/filepath"
+	"simple-securities/pkg/eventstore"
+	"simple-securities/pkg/eventstore/eventstoretest"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newSQLiteStore(t *testing.T) eventstore.Store {
+	dsn := "file:" + filepath.Join(t.TempDir(), "events.db") + "?_busy_timeout=5000&_txlock=immediate"
+	db, err := sqlx.Connect("sqlite3", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	s, err := eventstore.NewSQLStore(db, eventstore.Config{PollInterval: 20 * time.Millisecond, BatchSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSQLiteConformance(t *testing.T) {
+	eventstoretest.Run(t, newSQLiteStore)
+}
+
+func TestNewSQLStore(t *testing.T) {
+	db, err := sqlx.Connect("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := eventstore.NewSQLStore(db, eventstore.Config{EventsTable: "events; DROP TABLE x"}); err == nil {
+		t.Fatal("NewSQLStore() accepted an invalid table name")
+	}
+	if _, err := eventstore.NewSQLStore(sqlx.NewDb(db.DB, "mysql"), eventstore.Config{}); err == nil {
+		t.Fatal("NewSQLStore() accepted an unsupported driver")
+	}
+}
+
+func TestSnapshotPolicy(t *testing.T) {
+	tests := []struct {
+		every, from, to uint64
+		want            bool
+	}{
+		{0, 0, 100, false},
+		{10, 0, 9, false},
+		{10, 0, 10, true},
+		{10, 9, 12, true},
+		{10, 10, 19, false},
+		{10, 18, 31, true},
+	}
+	for _, tt := range tests {
+		if got := (eventstore.SnapshotPolicy{Every: tt.every}).Due(tt.from, tt.to); got != tt.want {
+			t.Errorf("SnapshotPolicy{%d}.Due(%d, %d) = %v, want %v", tt.every, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package fee
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// VolumeWindow is the look-back of the traded volume that sets the tier of an account
+const VolumeWindow = 30 * 24 * time.Hour
+
+// Liquidity says whether a fill added liquidity to the book or took it
+type Liquidity string
+
+const (
+	LiquidityMaker Liquidity = "MAKER"
+	LiquidityTaker Liquidity = "TAKER"
+)
+
+// Tier holds the commission rates that apply from MinVolume of 30-day traded notional,
+// rates are fractions of the notional (0.001 is 10 bps)
+type Tier struct {
+	Name      string  `yaml:"name" mapstructure:"name"`
+	MinVolume float64 `yaml:"min_volume" mapstructure:"min_volume"`
+	MakerRate float64 `yaml:"maker_rate" mapstructure:"maker_rate"`
+	TakerRate float64 `yaml:"taker_rate" mapstructure:"taker_rate"`
+}
+
+// Minimum is the smallest commission charged on a fill of Asset, an empty Asset applies to every other asset
+type Minimum struct {
+	Asset  string  `yaml:"asset" mapstructure:"asset"`
+	Amount float64 `yaml:"amount" mapstructure:"amount"`
+}
+
+// PassThrough is an exchange or regulatory fee charged at cost on top of the commission.
+// It is Rate of the notional plus PerUnit for every unit traded, capped at Max when set,
+// and only charged on Side when set.
+type PassThrough struct {
+	Name    string  `yaml:"name" mapstructure:"name"`
+	Side    string  `yaml:"side" mapstructure:"side"`
+	Rate    float64 `yaml:"rate" mapstructure:"rate"`
+	PerUnit float64 `yaml:"per_unit" mapstructure:"per_unit"`
+	Max     float64 `yaml:"max" mapstructure:"max"`
+}
+
+// AccountDiscount is the fraction taken off the commissions of one account, on top of the
+// discount of the schedule
+type AccountDiscount struct {
+	UserID   uint64  `yaml:"user_id" mapstructure:"user_id"`
+	Discount float64 `yaml:"discount" mapstructure:"discount"`
+}
+
+// Schedule is the cost model of a venue. Discount is the fraction taken off every commission,
+// pass-through fees are never discounted. Amounts are rounded to Precision decimals.
+type Schedule struct {
+	Name             string            `yaml:"name" mapstructure:"name"`
+	Precision        int               `yaml:"precision" mapstructure:"precision"`
+	Discount         float64           `yaml:"discount" mapstructure:"discount"`
+	AccountDiscounts []AccountDiscount `yaml:"account_discounts" mapstructure:"account_discounts"`
+	Tiers            []Tier            `yaml:"tiers" mapstructure:"tiers"`
+	Minimums         []Minimum         `yaml:"minimums" mapstructure:"minimums"`
+	PassThrough      []PassThrough     `yaml:"pass_through" mapstructure:"pass_through"`
+}
+
+// Trade is the fill to price, Volume30d is the notional the account traded over the last 30 days
+// and Discount the discount of the account (see Schedule.DiscountFor) applied on top of the
+// schedule one
+type Trade struct {
+	Asset     string
+	Side      string
+	Liquidity Liquidity
+	Quantity  float64
+	Price     float64
+	Volume30d float64
+	Discount  float64
+}
+
+// Breakdown is the fee of one trade, Total is Commission - Discount + PassThrough
+type Breakdown struct {
+	Tier        string
+	Rate        float64
+	Commission  float64
+	Discount    float64
+	PassThrough float64
+	Total       float64
+}
+
+// Validate checks the schedule and sorts its tiers by volume
+func (s *Schedule) Validate() error {
+	if len(s.Tiers) == 0 {
+		return fmt.Errorf("fee schedule %s has no tiers", s.Name)
+	}
+	if s.Discount < 0 || s.Discount > 1 {
+		return fmt.Errorf("fee schedule %s discount must be between 0 and 1", s.Name)
+	}
+	for _, d := range s.AccountDiscounts {
+		if d.Discount < 0 || d.Discount > 1 {
+			return fmt.Errorf("fee schedule %s discount of user %d must be between 0 and 1", s.Name, d.UserID)
+		}
+	}
+	if s.Precision < 0 {
+		return fmt.Errorf("fee schedule %s precision must not be negative", s.Name)
+	}
+	sort.SliceStable(s.Tiers, func(i, j int) bool { return s.Tiers[i].MinVolume < s.Tiers[j].MinVolume })
+	for _, t := range s.Tiers {
+		if t.MakerRate < 0 || t.TakerRate < 0 {
+			return fmt.Errorf("fee tier %s has a negative rate", t.Name)
+		}
+	}
+	return nil
+}
+
+// TierFor returns the highest tier reached by the 30-day volume, the first tier below all thresholds
+func (s *Schedule) TierFor(volume30d float64) Tier {
+	var tier Tier
+	for i, t := range s.Tiers {
+		if i == 0 || volume30d >= t.MinVolume {
+			tier = t
+		}
+	}
+	return tier
+}
+
+// MinimumFor returns the minimum commission of asset
+func (s *Schedule) MinimumFor(asset string) float64 {
+	var fallback float64
+	for _, m := range s.Minimums {
+		if m.Asset == asset {
+			return m.Amount
+		}
+		if m.Asset == "" {
+			fallback = m.Amount
+		}
+	}
+	return fallback
+}
+
+// DiscountFor returns the discount of the account of userID, 0 when it has none
+func (s *Schedule) DiscountFor(userID uint64) float64 {
+	for _, d := range s.AccountDiscounts {
+		if d.UserID == userID {
+			return d.Discount
+		}
+	}
+	return 0
+}
+
+// Compute prices the trade
+func (s *Schedule) Compute(t Trade) Breakdown {
+	notional := t.Quantity * t.Price
+	if notional <= 0 {
+		return Breakdown{}
+	}
+
+	tier := s.TierFor(t.Volume30d)
+	b := Breakdown{Tier: tier.Name, Rate: tier.TakerRate}
+	if t.Liquidity == LiquidityMaker {
+		b.Rate = tier.MakerRate
+	}
+
+	b.Commission = s.round(math.Max(notional*b.Rate, s.MinimumFor(t.Asset)))
+	discount := 1 - (1-s.Discount)*(1-clamp(t.Discount))
+	b.Discount = s.round(b.Commission * discount)
+
+	for _, p := range s.PassThrough {
+		if p.Side != "" && p.Side != t.Side {
+			continue
+		}
+		amount := notional*p.Rate + t.Quantity*p.PerUnit
+		if p.Max > 0 {
+			amount = math.Min(amount, p.Max)
+		}
+		// Regulators round their fees up to the next unit of precision
+		b.PassThrough += s.roundUp(amount)
+	}
+
+	b.Total = s.round(b.Commission - b.Discount + b.PassThrough)
+	return b
+}
+
+func (s *Schedule) round(v float64) float64 {
+	scale := math.Pow10(s.Precision)
+	return math.Round(v*scale) / scale
+}
+
+func (s *Schedule) roundUp(v float64) float64 {
+	scale := math.Pow10(s.Precision)
+	// Subtracting a tiny epsilon keeps exact amounts from being pushed up by float noise
+	return math.Ceil(v*scale-1e-9) / scale
+}
+
+func clamp(v float64) float64 {
+	return math.Min(math.Max(v, 0), 1)
+}
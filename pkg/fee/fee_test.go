@@ -0,0 +1,114 @@
+package fee
+
+import (
+	"math"
+	"testing"
+)
+
+func newTestSchedule(t *testing.T) *Schedule {
+	s := &Schedule{
+		Name:      "stock",
+		Precision: 2,
+		Tiers: []Tier{
+			{Name: "T2", MinVolume: 1_000_000, MakerRate: 0.0002, TakerRate: 0.0005},
+			{Name: "T1", MinVolume: 0, MakerRate: 0.0005, TakerRate: 0.001},
+		},
+		Minimums: []Minimum{{Amount: 1}, {Asset: "BRK.A", Amount: 5}},
+		PassThrough: []PassThrough{
+			{Name: "SEC", Side: "SELL", Rate: 0.0000278},
+			{Name: "TAF", Side: "SELL", PerUnit: 0.000166, Max: 8.30},
+		},
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestCompute(t *testing.T) {
+	s := newTestSchedule(t)
+
+	var tests = []struct {
+		name     string
+		trade    Trade
+		expected Breakdown
+	}{
+		{
+			name:     "taker buy in base tier",
+			trade:    Trade{Asset: "AAPL", Side: "BUY", Liquidity: LiquidityTaker, Quantity: 100, Price: 200},
+			expected: Breakdown{Tier: "T1", Rate: 0.001, Commission: 20, Total: 20},
+		},
+		{
+			name:     "maker buy in upper tier",
+			trade:    Trade{Asset: "AAPL", Side: "BUY", Liquidity: LiquidityMaker, Quantity: 100, Price: 200, Volume30d: 2_000_000},
+			expected: Breakdown{Tier: "T2", Rate: 0.0002, Commission: 4, Total: 4},
+		},
+		{
+			name:     "minimum commission",
+			trade:    Trade{Asset: "AAPL", Side: "BUY", Liquidity: LiquidityTaker, Quantity: 1, Price: 10},
+			expected: Breakdown{Tier: "T1", Rate: 0.001, Commission: 1, Total: 1},
+		},
+		{
+			name:     "asset minimum",
+			trade:    Trade{Asset: "BRK.A", Side: "BUY", Liquidity: LiquidityTaker, Quantity: 1, Price: 1000},
+			expected: Breakdown{Tier: "T1", Rate: 0.001, Commission: 5, Total: 5},
+		},
+		{
+			name:  "sell with pass-through and discount",
+			trade: Trade{Asset: "AAPL", Side: "SELL", Liquidity: LiquidityTaker, Quantity: 100, Price: 200, Discount: 0.5},
+			// SEC 20000 * 0.0000278 = 0.556 -> 0.56, TAF 100 * 0.000166 = 0.0166 -> 0.02
+			expected: Breakdown{Tier: "T1", Rate: 0.001, Commission: 20, Discount: 10, PassThrough: 0.58, Total: 10.58},
+		},
+		{
+			name:  "pass-through cap",
+			trade: Trade{Asset: "AAPL", Side: "SELL", Liquidity: LiquidityMaker, Quantity: 100_000, Price: 1},
+			// SEC 100000 * 0.0000278 = 2.78, TAF 16.6 capped at 8.30
+			expected: Breakdown{Tier: "T1", Rate: 0.0005, Commission: 50, PassThrough: 11.08, Total: 61.08},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Compute(tt.trade)
+			if got.Tier != tt.expected.Tier || !near(got.Rate, tt.expected.Rate) ||
+				!near(got.Commission, tt.expected.Commission) || !near(got.Discount, tt.expected.Discount) ||
+				!near(got.PassThrough, tt.expected.PassThrough) || !near(got.Total, tt.expected.Total) {
+				t.Errorf("Compute() = %+v; want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	if err := (&Schedule{Name: "empty"}).Validate(); err == nil {
+		t.Errorf("Validate() should reject a schedule without tiers")
+	}
+	if err := (&Schedule{Name: "discount", Discount: 2, Tiers: []Tier{{Name: "T1"}}}).Validate(); err == nil {
+		t.Errorf("Validate() should reject a discount above 1")
+	}
+	accountDiscount := &Schedule{Name: "account", Tiers: []Tier{{Name: "T1"}}, AccountDiscounts: []AccountDiscount{{UserID: 7, Discount: -0.1}}}
+	if err := accountDiscount.Validate(); err == nil {
+		t.Errorf("Validate() should reject a negative account discount")
+	}
+}
+
+func TestDiscountFor(t *testing.T) {
+	s := &Schedule{
+		Name:             "account",
+		Discount:         0.2,
+		AccountDiscounts: []AccountDiscount{{UserID: 7, Discount: 0.5}},
+		Tiers:            []Tier{{Name: "T1", TakerRate: 0.001}},
+	}
+	if got := s.DiscountFor(8); got != 0 {
+		t.Errorf("DiscountFor(8) = %v, want 0", got)
+	}
+	// 20% off the schedule then 50% off the account: 60% of the commission of 20
+	b := s.Compute(Trade{Asset: "AAPL", Side: "BUY", Liquidity: LiquidityTaker, Quantity: 100, Price: 200, Discount: s.DiscountFor(7)})
+	if !near(b.Discount, 12) || !near(b.Total, 8) {
+		t.Errorf("Compute() with the account discount = %+v, want 12 off 20", b)
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,97 @@
+// Package inbox records the Kafka messages a consumer group has processed so that a message
+// delivered again has no further effect.
+//
+// The record is inserted in a transaction that stays open while the handler runs and commits
+// only when it succeeds. Handlers that write through Tx(ctx) commit their changes with the
+// record, which makes them exactly-once effective; a handler writing elsewhere is at least
+// once for a crash between its write and the commit.
+package inbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Table holds the inbox of a service database, see migrations/sqlite/000007_init_inbox.up.sql
+const Table = "inbox_messages"
+
+type txKey struct{}
+
+// Tx returns the transaction of the message being processed
+func Tx(ctx context.Context) (*sqlx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx, ok
+}
+
+// Store is the SQL kafka.DedupStore
+type Store struct {
+	db *sqlx.DB
+}
+
+func NewStore(db *sqlx.DB) *Store {
+	return &Store{db: db}
+}
+
+// withTransaction runs fn inside a transaction with proper commit/rollback handling
+func (s *Store) withTransaction(ctx context.Context, fn func(*sqlx.Tx) error) (err error) {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// rollback/commit handler
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p) // rethrow panic after rollback
+		} else if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	err = fn(tx)
+	return err
+}
+
+// Process runs handle unless the group processed the message before and records the message
+// together with the writes handle makes through Tx
+func (s *Store) Process(ctx context.Context, group, messageID string, handle func(ctx context.Context) error) (bool, error) {
+	processed := false
+	err := s.withTransaction(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO `+Table+` (consumer_group, message_id, processed_at) VALUES ($1, $2, $3)
+			ON CONFLICT (consumer_group, message_id) DO NOTHING
+		`, group, messageID, time.Now().UTC())
+		if err != nil {
+			return fmt.Errorf("failed to record message %s: %w", messageID, err)
+		}
+		if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+			return err
+		}
+
+		if err := handle(context.WithValue(ctx, txKey{}, tx)); err != nil {
+			return err
+		}
+		processed = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return processed, nil
+}
+
+// Purge forgets the messages processed before the given time, a message redelivered after
+// that is processed again
+func (s *Store) Purge(ctx context.Context, before time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM `+Table+` WHERE processed_at < $1`, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
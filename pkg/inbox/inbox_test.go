@@ -0,0 +1,110 @@
+package inbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestStore(t *testing.T) (*Store, *sqlx.DB) {
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "inbox.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	schema, err := os.ReadFile("../../migrations/sqlite/000007_init_inbox.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.MustExec(string(schema))
+	db.MustExec(`CREATE TABLE effects (message_id TEXT NOT NULL)`)
+	return NewStore(db), db
+}
+
+// handler writes its effect with the inbox transaction
+func handler(messageID string, fail error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		tx, ok := Tx(ctx)
+		if !ok {
+			return errors.New("no inbox transaction")
+		}
+		if _, err := tx.ExecContext(ctx, `INSERT INTO effects (message_id) VALUES ($1)`, messageID); err != nil {
+			return err
+		}
+		return fail
+	}
+}
+
+func countEffects(t *testing.T, db *sqlx.DB) int {
+	var n int
+	if err := db.Get(&n, `SELECT COUNT(*) FROM effects`); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestProcessOnce(t *testing.T) {
+	s, db := newTestStore(t)
+	ctx := context.Background()
+
+	for i, want := range []bool{true, false, false} {
+		processed, err := s.Process(ctx, "group-a", "m-1", handler("m-1", nil))
+		if err != nil || processed != want {
+			t.Fatalf("delivery %d: Process() = %v, %v, want %v", i+1, processed, err, want)
+		}
+	}
+	if n := countEffects(t, db); n != 1 {
+		t.Fatalf("%d effects after redeliveries, want 1", n)
+	}
+
+	// Every group processes the message for itself
+	if processed, err := s.Process(ctx, "group-b", "m-1", handler("m-1", nil)); err != nil || !processed {
+		t.Fatalf("Process(group-b) = %v, %v, want processed", processed, err)
+	}
+}
+
+func TestProcessFailureIsNotRecorded(t *testing.T) {
+	s, db := newTestStore(t)
+	ctx := context.Background()
+	failure := errors.New("handler failed")
+
+	processed, err := s.Process(ctx, "group-a", "m-1", handler("m-1", failure))
+	if !errors.Is(err, failure) || processed {
+		t.Fatalf("Process() = %v, %v, want the handler error", processed, err)
+	}
+	if n := countEffects(t, db); n != 0 {
+		t.Fatalf("failed handler left %d effects, want 0", n)
+	}
+
+	if processed, err := s.Process(ctx, "group-a", "m-1", handler("m-1", nil)); err != nil || !processed {
+		t.Fatalf("retry Process() = %v, %v, want processed", processed, err)
+	}
+	if n := countEffects(t, db); n != 1 {
+		t.Fatalf("%d effects after the retry, want 1", n)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	s, _ := newTestStore(t)
+	ctx := context.Background()
+
+	if _, err := s.Process(ctx, "group-a", "m-1", handler("m-1", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := s.Purge(ctx, time.Now().Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("Purge(an hour ago) = %d, %v, want 0", n, err)
+	}
+	if n, err := s.Purge(ctx, time.Now().Add(time.Second)); err != nil || n != 1 {
+		t.Fatalf("Purge(now) = %d, %v, want 1", n, err)
+	}
+	if processed, err := s.Process(ctx, "group-a", "m-1", handler("m-1", nil)); err != nil || !processed {
+		t.Fatalf("Process() after purge = %v, %v, want processed", processed, err)
+	}
+}
@@ -0,0 +1,403 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// ErrTopicMismatch is returned for existing topics that differ from their declaration
+var ErrTopicMismatch = errors.New("kafka topic does not match its declaration")
+
+const defaultAdminTimeout = 10 * time.Second
+
+// TopicSpec declares a topic. Zero values keep the broker defaults; a negative retention
+// keeps messages forever. RetryTopics also declares the retry topics and the dead-letter
+// topic of a consumed topic, see ConsumerOptions.
+type TopicSpec struct {
+	Name              string        `yaml:"name" mapstructure:"name"`
+	Partitions        int           `yaml:"partitions" mapstructure:"partitions"`
+	ReplicationFactor int           `yaml:"replication_factor" mapstructure:"replication_factor"`
+	Retention         time.Duration `yaml:"retention" mapstructure:"retention"`
+	Compact           bool          `yaml:"compact" mapstructure:"compact"`
+	RetryTopics       int           `yaml:"retry_topics" mapstructure:"retry_topics"`
+}
+
+// expand adds the retry and dead-letter topics of the specs, like their topic but never
+// compacted as their messages are not keyed by entity
+func expand(specs []TopicSpec) []TopicSpec {
+	var expanded []TopicSpec
+	for _, s := range specs {
+		expanded = append(expanded, s)
+		if s.RetryTopics <= 0 {
+			continue
+		}
+		derived := s
+		derived.Compact, derived.RetryTopics = false, 0
+		for attempt := 1; attempt <= s.RetryTopics; attempt++ {
+			derived.Name = RetryTopic(s.Name, attempt)
+			expanded = append(expanded, derived)
+		}
+		derived.Name = DeadLetterTopic(s.Name)
+		expanded = append(expanded, derived)
+	}
+	return expanded
+}
+
+// configs are the topic configs set by the spec
+func (s TopicSpec) configs() map[string]string {
+	configs := make(map[string]string)
+	if s.Retention != 0 {
+		retention := int64(-1)
+		if s.Retention > 0 {
+			retention = s.Retention.Milliseconds()
+		}
+		configs["retention.ms"] = strconv.FormatInt(retention, 10)
+	}
+	if s.Compact {
+		configs["cleanup.policy"] = "compact"
+	}
+	return configs
+}
+
+// TopicInfo describes an existing topic
+type TopicInfo struct {
+	Name              string
+	Partitions        int
+	ReplicationFactor int
+	Configs           map[string]string
+}
+
+// PartitionLag is how far a consumer group is behind the end of a partition. Committed is -1
+// when the group has not committed on the partition, its lag is then the whole partition.
+type PartitionLag struct {
+	Partition int
+	Committed int64
+	End       int64
+	Lag       int64
+}
+
+type GroupLag struct {
+	GroupID    string
+	Topic      string
+	Partitions []PartitionLag
+	Total      int64
+}
+
+// Admin declares topics and inspects consumer groups
+type Admin struct {
+	client *kafka.Client
+	logger *zap.Logger
+}
+
+func NewAdmin(brokers []string, logger *zap.Logger) *Admin {
+	return &Admin{
+		client: &kafka.Client{Addr: kafka.TCP(brokers...), Timeout: defaultAdminTimeout},
+		logger: logger,
+	}
+}
+
+// Topics describes the topics, all of them but the internal ones when no name is given
+func (a *Admin) Topics(ctx context.Context, names ...string) ([]TopicInfo, error) {
+	metadata, err := a.client.Metadata(ctx, &kafka.MetadataRequest{Topics: names})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kafka metadata: %w", err)
+	}
+
+	var topics []TopicInfo
+	var resources []kafka.DescribeConfigRequestResource
+	for _, t := range metadata.Topics {
+		if t.Error != nil {
+			if errors.Is(t.Error, kafka.UnknownTopicOrPartition) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to describe topic %s: %w", t.Name, t.Error)
+		}
+		if t.Internal && len(names) == 0 {
+			continue
+		}
+		info := TopicInfo{Name: t.Name, Partitions: len(t.Partitions), Configs: map[string]string{}}
+		if len(t.Partitions) > 0 {
+			info.ReplicationFactor = len(t.Partitions[0].Replicas)
+		}
+		topics = append(topics, info)
+		resources = append(resources, kafka.DescribeConfigRequestResource{
+			ResourceType: kafka.ResourceTypeTopic,
+			ResourceName: t.Name,
+			ConfigNames:  []string{"retention.ms", "cleanup.policy"},
+		})
+	}
+	if len(topics) == 0 {
+		return nil, nil
+	}
+
+	described, err := a.client.DescribeConfigs(ctx, &kafka.DescribeConfigsRequest{Resources: resources})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe topic configs: %w", err)
+	}
+	configs := make(map[string]map[string]string, len(described.Resources))
+	for _, r := range described.Resources {
+		if r.Error != nil {
+			return nil, fmt.Errorf("failed to describe configs of topic %s: %w", r.ResourceName, r.Error)
+		}
+		entries := make(map[string]string, len(r.ConfigEntries))
+		for _, e := range r.ConfigEntries {
+			entries[e.ConfigName] = e.ConfigValue
+		}
+		configs[r.ResourceName] = entries
+	}
+	for i := range topics {
+		if c, ok := configs[topics[i].Name]; ok {
+			topics[i].Configs = c
+		}
+	}
+
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Name < topics[j].Name })
+	return topics, nil
+}
+
+// CreateTopics creates the topics, the topics that exist already are left as they are. It
+// returns the names of the topics created.
+func (a *Admin) CreateTopics(ctx context.Context, specs ...TopicSpec) ([]string, error) {
+	specs = expand(specs)
+	if len(specs) == 0 {
+		return nil, nil
+	}
+
+	configs := make([]kafka.TopicConfig, len(specs))
+	for i, s := range specs {
+		configs[i] = kafka.TopicConfig{Topic: s.Name, NumPartitions: -1, ReplicationFactor: -1}
+		if s.Partitions > 0 {
+			configs[i].NumPartitions = s.Partitions
+		}
+		if s.ReplicationFactor > 0 {
+			configs[i].ReplicationFactor = s.ReplicationFactor
+		}
+		for name, value := range s.configs() {
+			configs[i].ConfigEntries = append(configs[i].ConfigEntries, kafka.ConfigEntry{ConfigName: name, ConfigValue: value})
+		}
+	}
+
+	res, err := a.client.CreateTopics(ctx, &kafka.CreateTopicsRequest{Topics: configs})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka topics: %w", err)
+	}
+
+	var created []string
+	var errs []error
+	for _, s := range specs {
+		switch err := res.Errors[s.Name]; {
+		case err == nil:
+			created = append(created, s.Name)
+		case errors.Is(err, kafka.TopicAlreadyExists):
+		default:
+			errs = append(errs, fmt.Errorf("failed to create topic %s: %w", s.Name, err))
+		}
+	}
+	return created, errors.Join(errs...)
+}
+
+// EnsureTopics creates the missing topics and checks the existing ones against their
+// declaration. Differences are returned as ErrTopicMismatch errors and not corrected, as
+// partitions cannot be removed and changing retention may drop data.
+func (a *Admin) EnsureTopics(ctx context.Context, specs ...TopicSpec) error {
+	created, err := a.CreateTopics(ctx, specs...)
+	if err != nil {
+		return err
+	}
+	specs = expand(specs)
+	for _, name := range created {
+		a.logger.Info("🗂️ kafka topic created", zap.String("topic", name))
+	}
+
+	names := make([]string, len(specs))
+	for i, s := range specs {
+		names[i] = s.Name
+	}
+	topics, err := a.Topics(ctx, names...)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]TopicInfo, len(topics))
+	for _, t := range topics {
+		existing[t.Name] = t
+	}
+
+	var errs []error
+	for _, s := range specs {
+		t, ok := existing[s.Name]
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s is missing", ErrTopicMismatch, s.Name))
+			continue
+		}
+		if s.Partitions > 0 && t.Partitions != s.Partitions {
+			errs = append(errs, fmt.Errorf("%w: %s has %d partitions, %d declared",
+				ErrTopicMismatch, s.Name, t.Partitions, s.Partitions))
+		}
+		if s.ReplicationFactor > 0 && t.ReplicationFactor != s.ReplicationFactor {
+			errs = append(errs, fmt.Errorf("%w: %s has replication factor %d, %d declared",
+				ErrTopicMismatch, s.Name, t.ReplicationFactor, s.ReplicationFactor))
+		}
+		for name, value := range s.configs() {
+			if t.Configs[name] != value {
+				errs = append(errs, fmt.Errorf("%w: %s has %s=%s, %s declared",
+					ErrTopicMismatch, s.Name, name, t.Configs[name], value))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// partitions lists the partition ids of the topic
+func (a *Admin) partitions(ctx context.Context, topic string) ([]int, error) {
+	metadata, err := a.client.Metadata(ctx, &kafka.MetadataRequest{Topics: []string{topic}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kafka metadata: %w", err)
+	}
+	if len(metadata.Topics) != 1 {
+		return nil, fmt.Errorf("topic %s not found", topic)
+	}
+	if err := metadata.Topics[0].Error; err != nil {
+		return nil, fmt.Errorf("failed to describe topic %s: %w", topic, err)
+	}
+
+	ids := make([]int, len(metadata.Topics[0].Partitions))
+	for i, p := range metadata.Topics[0].Partitions {
+		ids[i] = p.ID
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// offsets lists the offsets of the partitions at the timestamp, kafka.FirstOffset or
+// kafka.LastOffset. Partitions without a message at or after the timestamp have no offset.
+func (a *Admin) offsets(ctx context.Context, topic string, partitions []int, timestamp int64) (map[int]int64, error) {
+	requests := make([]kafka.OffsetRequest, len(partitions))
+	for i, p := range partitions {
+		requests[i] = kafka.OffsetRequest{Partition: p, Timestamp: timestamp}
+	}
+	res, err := a.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: map[string][]kafka.OffsetRequest{topic: requests}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list offsets of %s: %w", topic, err)
+	}
+
+	offsets := make(map[int]int64, len(partitions))
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			return nil, fmt.Errorf("failed to list offsets of %s/%d: %w", topic, p.Partition, p.Error)
+		}
+		switch timestamp {
+		case kafka.FirstOffset:
+			offsets[p.Partition] = p.FirstOffset
+		case kafka.LastOffset:
+			offsets[p.Partition] = p.LastOffset
+		default:
+			for offset := range p.Offsets {
+				if offset >= 0 {
+					offsets[p.Partition] = offset
+				}
+			}
+		}
+	}
+	return offsets, nil
+}
+
+// Lag compares the offsets committed by the group with the end of each partition
+func (a *Admin) Lag(ctx context.Context, groupID, topic string) (GroupLag, error) {
+	partitions, err := a.partitions(ctx, topic)
+	if err != nil {
+		return GroupLag{}, err
+	}
+	ends, err := a.offsets(ctx, topic, partitions, kafka.LastOffset)
+	if err != nil {
+		return GroupLag{}, err
+	}
+	committed, err := a.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: groupID,
+		Topics:  map[string][]int{topic: partitions},
+	})
+	if err != nil {
+		return GroupLag{}, fmt.Errorf("failed to fetch offsets of group %s: %w", groupID, err)
+	}
+	if committed.Error != nil {
+		return GroupLag{}, fmt.Errorf("failed to fetch offsets of group %s: %w", groupID, committed.Error)
+	}
+
+	commits := make(map[int]int64, len(partitions))
+	for _, p := range committed.Topics[topic] {
+		if p.Error != nil {
+			return GroupLag{}, fmt.Errorf("failed to fetch offset of group %s on %s/%d: %w", groupID, topic, p.Partition, p.Error)
+		}
+		commits[p.Partition] = p.CommittedOffset
+	}
+
+	lag := GroupLag{GroupID: groupID, Topic: topic}
+	for _, p := range partitions {
+		committed, ok := commits[p]
+		if !ok {
+			committed = -1
+		}
+		partition := PartitionLag{Partition: p, Committed: committed, End: ends[p], Lag: ends[p] - max(committed, 0)}
+		lag.Partitions = append(lag.Partitions, partition)
+		lag.Total += partition.Lag
+	}
+	return lag, nil
+}
+
+// ResetOffsets moves the group to the first message of each partition at or after the
+// timestamp, or to kafka.FirstOffset or kafka.LastOffset. The group has to be inactive,
+// the broker rejects commits from outside the members of an active group.
+func (a *Admin) ResetOffsets(ctx context.Context, groupID, topic string, timestamp int64) (map[int]int64, error) {
+	partitions, err := a.partitions(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+	offsets, err := a.offsets(ctx, topic, partitions, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	if len(offsets) < len(partitions) {
+		// No message at or after the timestamp, the group starts at the end
+		ends, err := a.offsets(ctx, topic, partitions, kafka.LastOffset)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range partitions {
+			if _, ok := offsets[p]; !ok {
+				offsets[p] = ends[p]
+			}
+		}
+	}
+
+	commits := make([]kafka.OffsetCommit, 0, len(offsets))
+	for _, p := range partitions {
+		commits = append(commits, kafka.OffsetCommit{Partition: p, Offset: offsets[p]})
+	}
+	res, err := a.client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      groupID,
+		GenerationID: -1,
+		Topics:       map[string][]kafka.OffsetCommit{topic: commits},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset offsets of group %s: %w", groupID, err)
+	}
+
+	var errs []error
+	for _, p := range res.Topics[topic] {
+		if p.Error != nil {
+			errs = append(errs, fmt.Errorf("failed to reset offset of group %s on %s/%d: %w", groupID, topic, p.Partition, p.Error))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	a.logger.Info("⏪ kafka group offsets reset", zap.String("group_id", groupID), zap.String("topic", topic))
+	return offsets, nil
+}
@@ -0,0 +1,36 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// MessageBus sends and consumes messages, it is implemented by Manager on Kafka and by
+// MemoryBus in memory for tests. Services depend on the part of it they need.
+type MessageBus interface {
+	SendMessage(ctx context.Context, topic string, key string, partition int, event Event) error
+	WriteMessage(ctx context.Context, topic string, key string, value []byte) error
+
+	AddConsumer(topic, groupID string, handler EventHandler) error
+	AddConsumerWithOptions(topic, groupID string, handler EventHandler, options ConsumerOptions) error
+	RemoveConsumer(topic, groupID string) error
+	StartConsumer(ctx context.Context, topic, groupID string) error
+	StopConsumer(topic, groupID string) error
+	PauseConsumer(topic, groupID string) error
+	ResumeConsumer(topic, groupID string) error
+	StartAllConsumers(ctx context.Context)
+
+	SetCodec(codec *Codec)
+	Codec() *Codec
+	GetStatus() map[string]interface{}
+	Close() error
+
+	// writeMessage sends an encoded value with its headers, for Publish
+	writeMessage(ctx context.Context, topic string, key string, value []byte, headers []kafka.Header) error
+}
+
+var (
+	_ MessageBus = (*Manager)(nil)
+	_ MessageBus = (*MemoryBus)(nil)
+)
@@ -0,0 +1,161 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"simple-securities/pkg/kafka/schema"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers describing the schema of a typed payload
+const (
+	HeaderSchemaID    = "schema-id"
+	HeaderContentType = "content-type"
+)
+
+// Subject is the registry subject of the values of a topic
+func Subject(topic string) string {
+	return topic + "-value"
+}
+
+// Codec encodes the typed payloads of Publish and decodes those of Subscribe. Values are
+// written with the serializer of the codec under a schema registered for the topic, whose
+// id travels in the schema-id header; consumers read the writer schema back by id and pick
+// the serializer of its format. The content-type header is only written for the tools that
+// read the topic without the registry. Payloads without a schema id, such as the events of
+// SendMessage, are read as JSON.
+type Codec struct {
+	registry    schema.Registry
+	serializer  schema.Serializer
+	serializers map[schema.Format]schema.Serializer
+	schemas     sync.Map // subject and Go type → *schema.Schema
+}
+
+// NewCodec writes with serializer and reads the formats of serializer, readers and JSON
+func NewCodec(registry schema.Registry, serializer schema.Serializer, readers ...schema.Serializer) *Codec {
+	c := &Codec{
+		registry:    registry,
+		serializer:  serializer,
+		serializers: map[schema.Format]schema.Serializer{schema.FormatJSON: schema.NewJSONSerializer()},
+	}
+	for _, s := range append(readers, serializer) {
+		c.serializers[s.Format()] = s
+	}
+	return c
+}
+
+// jsonCodec reads the values of a manager without codec
+var jsonCodec = NewCodec(nil, schema.NewJSONSerializer())
+
+type schemaKey struct {
+	subject string
+	t       reflect.Type
+}
+
+func (c *Codec) encode(ctx context.Context, topic string, v any) ([]byte, []kafka.Header, error) {
+	subject := Subject(topic)
+	key := schemaKey{subject: subject, t: reflect.TypeOf(v)}
+
+	s, ok := c.schemas.Load(key)
+	if !ok {
+		definition, err := c.serializer.Definition(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		registered, err := c.registry.Register(ctx, subject, c.serializer.Format(), definition)
+		if err != nil {
+			return nil, nil, err
+		}
+		s, _ = c.schemas.LoadOrStore(key, registered)
+	}
+	writer := s.(*schema.Schema)
+
+	payload, err := c.serializer.Marshal(v, writer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode %T for %s: %w", v, topic, err)
+	}
+	headers := []kafka.Header{
+		{Key: HeaderSchemaID, Value: []byte(strconv.FormatUint(uint64(writer.ID), 10))},
+		{Key: HeaderContentType, Value: []byte(writer.Format.ContentType())},
+	}
+	return payload, headers, nil
+}
+
+// decode reads the payload into the pointer v
+func (c *Codec) decode(ctx context.Context, headers []kafka.Header, data []byte, v any) error {
+	var schemaID string
+	for _, h := range headers {
+		if h.Key == HeaderSchemaID {
+			schemaID = string(h.Value)
+		}
+	}
+	if schemaID == "" {
+		return c.serializers[schema.FormatJSON].Unmarshal(data, nil, v)
+	}
+
+	id, err := strconv.ParseUint(schemaID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid schema id %q: %w", schemaID, err)
+	}
+	if c.registry == nil {
+		return fmt.Errorf("no schema registry to read schema %d", id)
+	}
+	writer, err := c.registry.ByID(ctx, uint32(id))
+	if err != nil {
+		return err
+	}
+	serializer, ok := c.serializers[writer.Format]
+	if !ok {
+		return fmt.Errorf("%w: no %s serializer for schema %d", schema.ErrUnsupported, writer.Format, id)
+	}
+	if err := serializer.Unmarshal(data, writer, v); err != nil {
+		return fmt.Errorf("failed to decode %s version %d: %w", writer.Subject, writer.Version, err)
+	}
+	return nil
+}
+
+// Publish sends the value on the topic encoded by the codec of the bus
+func Publish[T any](ctx context.Context, m MessageBus, topic string, key string, value T) error {
+	codec := m.Codec()
+	if codec == nil {
+		return fmt.Errorf("kafka message bus has no codec to publish %T", value)
+	}
+	payload, headers, err := codec.encode(ctx, topic, value)
+	if err != nil {
+		return err
+	}
+	return m.writeMessage(ctx, topic, key, payload, headers)
+}
+
+// Subscribe registers a consumer of the topic that hands the decoded values to handler. A
+// value that cannot be decoded fails like a handler error and goes to the retry topics.
+func Subscribe[T any](
+	m MessageBus,
+	topic, groupID string,
+	handler func(ctx context.Context, msg *Message, value T) error,
+	options ConsumerOptions,
+) error {
+	return m.AddConsumerWithOptions(topic, groupID, func(ctx context.Context, msg *Message) error {
+		var value T
+		target := any(&value)
+		// Pointer types such as generated proto messages are decoded into a new value
+		if t := reflect.TypeOf(value); t != nil && t.Kind() == reflect.Pointer {
+			value = reflect.New(t.Elem()).Interface().(T)
+			target = value
+		}
+
+		codec := m.Codec()
+		if codec == nil {
+			codec = jsonCodec
+		}
+		if err := codec.decode(ctx, msg.Headers, msg.Value, target); err != nil {
+			return err
+		}
+		return handler(ctx, msg, value)
+	}, options)
+}
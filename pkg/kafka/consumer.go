@@ -3,53 +3,246 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
-type EventHandler func(ctx context.Context, key, event []byte) error
+// EventHandler handles a message, ctx carries the trace context and request id of the message
+type EventHandler func(ctx context.Context, msg *Message) error
 
+// Headers set on the messages moved to the retry and dead-letter topics
+const (
+	HeaderMessageID         = "message-id"
+	HeaderRetryAttempt      = "retry-attempt"
+	HeaderOriginalTopic     = "original-topic"
+	HeaderOriginalPartition = "original-partition"
+	HeaderOriginalOffset    = "original-offset"
+	HeaderError             = "error"
+	HeaderFailedAt          = "failed-at"
+)
+
+const (
+	minReadBackoff     = 100 * time.Millisecond
+	maxReadBackoff     = 30 * time.Second
+	maxErrorHeaderSize = 1000
+)
+
+// RetryTopic is the topic a message of topic goes to after failing attempt times
+func RetryTopic(topic string, attempt int) string {
+	return fmt.Sprintf("%s.retry.%d", topic, attempt)
+}
+
+// DeadLetterTopic receives the messages of topic that failed every retry
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// RetryPolicy moves a failed message through Attempts retry topics before the dead-letter
+// topic. The consumer of retry topic N handles a message Backoff * 2^(N-1) after it was
+// moved there, capped at MaxBackoff.
+type RetryPolicy struct {
+	Attempts   int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Delay is how long a message waits on the retry topic of the given attempt
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if attempt <= 0 || p.Backoff <= 0 {
+		return 0
+	}
+	delay := p.Backoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// DedupStore remembers the messages a consumer group has processed. Process runs handle
+// unless the message was processed before, and records the message only if handle
+// succeeds; it reports whether handle ran.
+type DedupStore interface {
+	Process(ctx context.Context, group, messageID string, handle func(ctx context.Context) error) (bool, error)
+}
+
+type ConsumerOptions struct {
+	Retry RetryPolicy
+	// Dedup skips the messages delivered again, e.g. after a crash before the offset commit
+	Dedup DedupStore
+	// Workers handle the messages of a topic concurrently when above one, see runConcurrent
+	Workers int
+	// CommitBatch is the number of settled messages that triggers a commit in concurrent
+	// mode, and the queue size of each worker
+	CommitBatch int
+	// CommitInterval commits the settled messages of a concurrent consumer at least this often
+	CommitInterval time.Duration
+	// DrainTimeout bounds how long the queued messages are still handled after ctx is done
+	DrainTimeout time.Duration
+}
+
+// Consumer reads a topic and its retry topics with one consumer group. Offsets are committed
+// only once a message is handled or moved on to the next retry or dead-letter topic, so a
+// message is never lost; it may be delivered again, which the dedup store absorbs.
 type Consumer struct {
-	reader  *kafka.Reader
-	logger  *zap.Logger
-	handler EventHandler
+	topic    string
+	groupID  string
+	readers  []messageReader // the topic, then retry topic 1 to Attempts
+	producer *Producer
+	options  ConsumerOptions
+	logger   *zap.Logger
+	handler  EventHandler
+
+	pauseMu sync.Mutex
+	resumed chan struct{} // closed by Resume, nil unless paused
+
+	handled       atomic.Int64
+	failed        atomic.Int64
+	lastMessageAt atomic.Int64 // unix nanoseconds
+}
+
+// ConsumerStats counts the messages settled by a consumer since it started
+type ConsumerStats struct {
+	Handled       int64      `json:"handled"`
+	Failed        int64      `json:"failed"`
+	LastMessageAt *time.Time `json:"last_message_at,omitempty"`
 }
 
 func NewConsumer(brokers []string, topic, groupID string, handler EventHandler, logger *zap.Logger) *Consumer {
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:  brokers,
-		Topic:    topic,
-		GroupID:  groupID,
-		MinBytes: 10e3, // 10KB
-		MaxBytes: 10e6, // 10MB
-	})
+	return NewConsumerWithOptions(brokers, topic, groupID, handler, nil, ConsumerOptions{}, logger)
+}
+
+// NewConsumerWithOptions creates a consumer that moves failed messages through the retry and
+// dead-letter topics with producer. Without a producer failed messages are logged and skipped.
+func NewConsumerWithOptions(
+	brokers []string,
+	topic, groupID string,
+	handler EventHandler,
+	producer *Producer,
+	options ConsumerOptions,
+	logger *zap.Logger,
+) *Consumer {
+	return newConsumer(kafkaReaders(brokers), topic, groupID, handler, producer, options, logger)
+}
+
+// newConsumer creates a consumer reading the topic and its retry topics with readers
+func newConsumer(
+	readers readerFactory,
+	topic, groupID string,
+	handler EventHandler,
+	producer *Producer,
+	options ConsumerOptions,
+	logger *zap.Logger,
+) *Consumer {
+	topics := []string{topic}
+	if producer != nil {
+		for attempt := 1; attempt <= options.Retry.Attempts; attempt++ {
+			topics = append(topics, RetryTopic(topic, attempt))
+		}
+	}
 
-	return &Consumer{
-		reader:  reader,
-		logger:  logger,
-		handler: handler,
+	c := &Consumer{
+		topic:    topic,
+		groupID:  groupID,
+		readers:  make([]messageReader, len(topics)),
+		producer: producer,
+		options:  options,
+		logger:   logger,
+		handler:  handler,
+	}
+	for i, t := range topics {
+		c.readers[i] = readers(t, groupID)
+	}
+	return c
+}
+
+// messageReader reads a topic with a consumer group, a kafka.Reader or a reader of the
+// memory broker
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Config() kafka.ReaderConfig
+	Close() error
+}
+
+// readerFactory opens the reader of a topic for a consumer group
+type readerFactory func(topic, groupID string) messageReader
+
+func kafkaReaders(brokers []string) readerFactory {
+	return func(topic, groupID string) messageReader {
+		return kafka.NewReader(kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    topic,
+			GroupID:  groupID,
+			MinBytes: 10e3, // 10KB
+			MaxBytes: 10e6, // 10MB
+		})
 	}
 }
 
 func (c *Consumer) Start(ctx context.Context) error {
 	c.logger.Info("🧩 connect kafka consumer",
-		zap.String("topic", c.reader.Config().Topic),
-		zap.String("group_id", c.reader.Config().GroupID),
+		zap.String("topic", c.topic),
+		zap.String("group_id", c.groupID),
+		zap.Int("retry_topics", len(c.readers)-1),
+		zap.Int("workers", max(c.options.Workers, 1)),
 	)
 
+	var wg sync.WaitGroup
+	for attempt, reader := range c.readers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.run(ctx, attempt, reader)
+		}()
+	}
+	wg.Wait()
+
+	c.logger.Info("Stopping kafka consumer", zap.String("topic", c.topic))
+	return c.Close()
+}
+
+// run consumes the topic of the given retry attempt, zero being the topic itself
+func (c *Consumer) run(ctx context.Context, attempt int, reader messageReader) {
+	if c.options.Workers > 1 {
+		c.runConcurrent(ctx, attempt, reader)
+		return
+	}
+
 	for {
-		select {
-		case <-ctx.Done():
-			c.logger.Info("Stopping kafka consumer")
-			return c.reader.Close()
-		default:
-			m, err := c.reader.ReadMessage(ctx)
-			if err != nil {
-				c.logger.Error("failed to read message", zap.Error(err))
-				continue
-			}
+		m, ok := c.fetch(ctx, reader)
+		if !ok || !c.process(ctx, ctx, attempt, m) {
+			return
+		}
+
+		if err := reader.CommitMessages(ctx, m); err != nil && ctx.Err() == nil {
+			c.logger.Error("failed to commit message",
+				zap.String("topic", m.Topic),
+				zap.Int64("offset", m.Offset),
+				zap.Error(err))
+		}
+	}
+}
 
+// fetch reads the next message, backing off on read errors; it fails once ctx is done
+func (c *Consumer) fetch(ctx context.Context, reader messageReader) (kafka.Message, bool) {
+	backoff := minReadBackoff
+	for {
+		if !c.waitResumed(ctx) {
+			return kafka.Message{}, false
+		}
+		m, err := reader.FetchMessage(ctx)
+		if err == nil {
 			c.logger.Debug("📥 kafka recieves",
 				zap.String("topic", m.Topic),
 				zap.Int("partition", m.Partition),
@@ -57,32 +250,270 @@ func (c *Consumer) Start(ctx context.Context) error {
 				zap.ByteString("key", m.Key),
 				zap.String("event", string(m.Value)),
 			)
+			return m, true
+		}
+		if ctx.Err() != nil {
+			return kafka.Message{}, false
+		}
+		c.logger.Error("failed to read message", zap.String("topic", reader.Config().Topic), zap.Error(err))
+		if !sleep(ctx, backoff) {
+			return kafka.Message{}, false
+		}
+		backoff = min(backoff*2, maxReadBackoff)
+	}
+}
 
-			if err := c.handler(ctx, m.Key, m.Value); err != nil {
-				c.logger.Error("failed to handle message", zap.Error(err))
-			}
+// process waits until a retried message is due, then settles it. The offset only moves on
+// once the message is settled, until then it is tried again. Waiting stops with waitCtx and
+// settling with settleCtx; process reports whether the message was settled.
+func (c *Consumer) process(waitCtx, settleCtx context.Context, attempt int, m kafka.Message) bool {
+	if attempt > 0 && !sleep(waitCtx, time.Until(m.Time.Add(c.options.Retry.Delay(attempt)))) {
+		return false
+	}
+
+	backoff := minReadBackoff
+	for {
+		err := c.settle(settleCtx, attempt, m)
+		if err == nil {
+			return true
 		}
+		c.logger.Error("failed to settle message",
+			zap.String("topic", m.Topic),
+			zap.Int64("offset", m.Offset),
+			zap.Error(err))
+		if !sleep(settleCtx, backoff) {
+			return false
+		}
+		backoff = min(backoff*2, maxReadBackoff)
+	}
+}
+
+// settle handles the message, or moves it on to the next retry topic or the dead-letter topic
+func (c *Consumer) settle(ctx context.Context, attempt int, m kafka.Message) error {
+	id := MessageID(m)
+	c.lastMessageAt.Store(time.Now().UnixNano())
+	err := c.handle(ctx, id, m)
+	if err == nil {
+		c.handled.Add(1)
+		return nil
+	}
+	if ctx.Err() != nil {
+		return err
+	}
+
+	c.logger.Warn("failed to handle message",
+		zap.String("topic", m.Topic),
+		zap.String("message_id", id),
+		zap.Int("attempt", attempt),
+		zap.Error(err))
+
+	if c.producer == nil {
+		c.logger.Error("dropping failed message", zap.String("topic", m.Topic), zap.String("message_id", id))
+		c.failed.Add(1)
+		return nil
+	}
+
+	next := DeadLetterTopic(c.topic)
+	if attempt < c.options.Retry.Attempts {
+		next = RetryTopic(c.topic, attempt+1)
+	}
+	if err := c.forward(ctx, next, attempt+1, id, m, err); err != nil {
+		return err
+	}
+	c.failed.Add(1)
+	return nil
+}
+
+// Pause stops fetching messages, the messages already fetched are still settled
+func (c *Consumer) Pause() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if c.resumed == nil {
+		c.resumed = make(chan struct{})
+	}
+}
+
+func (c *Consumer) Resume() {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	if c.resumed != nil {
+		close(c.resumed)
+		c.resumed = nil
+	}
+}
+
+func (c *Consumer) Paused() bool {
+	c.pauseMu.Lock()
+	defer c.pauseMu.Unlock()
+	return c.resumed != nil
+}
+
+// waitResumed blocks while the consumer is paused, it reports false once ctx is done
+func (c *Consumer) waitResumed(ctx context.Context) bool {
+	c.pauseMu.Lock()
+	resumed := c.resumed
+	c.pauseMu.Unlock()
+
+	if resumed == nil {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-resumed:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (c *Consumer) Stats() ConsumerStats {
+	stats := ConsumerStats{Handled: c.handled.Load(), Failed: c.failed.Load()}
+	if last := c.lastMessageAt.Load(); last > 0 {
+		t := time.Unix(0, last)
+		stats.LastMessageAt = &t
+	}
+	return stats
+}
+
+func (c *Consumer) handle(ctx context.Context, id string, m kafka.Message) error {
+	msg := newMessage(m)
+	ctx = extract(ctx, msg)
+	if c.options.Dedup == nil {
+		return c.handler(ctx, msg)
+	}
+
+	processed, err := c.options.Dedup.Process(ctx, c.groupID, id, func(ctx context.Context) error {
+		return c.handler(ctx, msg)
+	})
+	if err == nil && !processed {
+		c.logger.Debug("skipping duplicate message", zap.String("topic", m.Topic), zap.String("message_id", id))
+	}
+	return err
+}
+
+// forward writes the failed message to topic with the failure in its headers
+func (c *Consumer) forward(ctx context.Context, topic string, attempt int, id string, m kafka.Message, cause error) error {
+	reason := cause.Error()
+	if len(reason) > maxErrorHeaderSize {
+		reason = reason[:maxErrorHeaderSize]
+	}
+
+	originalTopic, originalPartition, originalOffset := m.Topic, strconv.Itoa(m.Partition), strconv.FormatInt(m.Offset, 10)
+	if v, ok := header(m, HeaderOriginalTopic); ok {
+		originalTopic = v
+		originalPartition, _ = header(m, HeaderOriginalPartition)
+		originalOffset, _ = header(m, HeaderOriginalOffset)
+	}
+
+	headers := []kafka.Header{
+		{Key: HeaderMessageID, Value: []byte(id)},
+		{Key: HeaderRetryAttempt, Value: []byte(strconv.Itoa(attempt))},
+		{Key: HeaderOriginalTopic, Value: []byte(originalTopic)},
+		{Key: HeaderOriginalPartition, Value: []byte(originalPartition)},
+		{Key: HeaderOriginalOffset, Value: []byte(originalOffset)},
+		{Key: HeaderError, Value: []byte(reason)},
+		{Key: HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	}
+	for _, h := range m.Headers {
+		if !isRetryHeader(h.Key) {
+			headers = append(headers, h)
+		}
+	}
+
+	if err := c.producer.syncWriter.WriteMessages(ctx, kafka.Message{
+		Topic:   topic,
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+		Time:    time.Now(),
+	}); err != nil {
+		return fmt.Errorf("failed to move message %s to %s: %w", id, topic, err)
+	}
+
+	c.logger.Info("↪️ kafka message moved",
+		zap.String("from", m.Topic),
+		zap.String("to", topic),
+		zap.String("message_id", id),
+		zap.String("reason", reason))
+	return nil
+}
+
+// MessageID identifies a message across redeliveries and retry topics: the message-id
+// header, else the request id of the event, else the position of the message in its topic
+func MessageID(m kafka.Message) string {
+	if id, ok := header(m, HeaderMessageID); ok && id != "" {
+		return id
+	}
+	if requestID := eventRequestID(m.Value); requestID != "" {
+		return requestID
+	}
+	return fmt.Sprintf("%s/%d/%d", m.Topic, m.Partition, m.Offset)
+}
+
+func header(m kafka.Message, key string) (string, bool) {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value), true
+		}
+	}
+	return "", false
+}
+
+func isRetryHeader(key string) bool {
+	switch key {
+	case HeaderMessageID, HeaderRetryAttempt, HeaderOriginalTopic, HeaderOriginalPartition,
+		HeaderOriginalOffset, HeaderError, HeaderFailedAt:
+		return true
+	}
+	return false
+}
+
+// sleep waits for d unless ctx is done first, it reports whether the wait completed
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
 }
 
 func (c *Consumer) Close() error {
-	return c.reader.Close()
+	var errs []error
+	for _, reader := range c.readers {
+		if err := reader.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close consumer of %s: %v", c.topic, errs)
+	}
+	return nil
 }
 
-func (c *Consumer) ConsumeMessages(ctx context.Context, handler func(key, event []byte) error) error {
+// ConsumeMessages reads the topic without the retry topics and commits every message once
+// handler returns, a failed message is logged and skipped
+func (c *Consumer) ConsumeMessages(ctx context.Context, handler EventHandler) error {
+	reader := c.readers[0]
 	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		default:
-			m, err := c.reader.ReadMessage(ctx)
-			if err != nil {
-				return fmt.Errorf("failed to read message: %w", err)
+		m, err := reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
 			}
+			return fmt.Errorf("failed to read message: %w", err)
+		}
 
-			if err := handler(m.Key, m.Value); err != nil {
-				c.logger.Error("failed to process message", zap.Error(err))
-			}
+		msg := newMessage(m)
+		if err := handler(extract(ctx, msg), msg); err != nil {
+			c.logger.Error("failed to process message", zap.Error(err))
+		}
+		if err := reader.CommitMessages(ctx, m); err != nil {
+			return fmt.Errorf("failed to commit message: %w", err)
 		}
 	}
 }
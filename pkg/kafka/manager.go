@@ -3,17 +3,22 @@ package kafka
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"time"
 
+	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
 type Manager struct {
 	producer  *Producer
-	consumers map[string]*Consumer
+	consumers map[consumerKey]*registration
+	codec     *Codec
 	logger    *zap.Logger
 	mu        sync.RWMutex
 	brokers   []string
+	readers   readerFactory
 
 	// Control flags
 	producerEnabled bool
@@ -24,9 +29,10 @@ type Manager struct {
 func NewManager(brokers []string, logger *zap.Logger) *Manager {
 	return &Manager{
 		producer:        NewProducer(brokers, logger),
-		consumers:       make(map[string]*Consumer),
+		consumers:       make(map[consumerKey]*registration),
 		logger:          logger,
 		brokers:         brokers,
+		readers:         kafkaReaders(brokers),
 		producerEnabled: true, // Producer enabled by default
 		consumerEnabled: true, // Consumer enabled by default
 	}
@@ -35,14 +41,34 @@ func NewManager(brokers []string, logger *zap.Logger) *Manager {
 func NewManagerWithConfig(brokers []string, logger *zap.Logger, producerEnabled, consumerEnabled bool) *Manager {
 	return &Manager{
 		producer:        NewProducer(brokers, logger),
-		consumers:       make(map[string]*Consumer),
+		consumers:       make(map[consumerKey]*registration),
 		logger:          logger,
 		brokers:         brokers,
+		readers:         kafkaReaders(brokers),
 		producerEnabled: producerEnabled,
 		consumerEnabled: consumerEnabled,
 	}
 }
 
+// NewManagerWithProducer creates a manager sending with producer, e.g. one built with
+// NewProducerWithConfig. Failed messages are moved to the retry topics with it too.
+func NewManagerWithProducer(brokers []string, producer *Producer, logger *zap.Logger) *Manager {
+	return &Manager{
+		producer:        producer,
+		consumers:       make(map[consumerKey]*registration),
+		logger:          logger,
+		brokers:         brokers,
+		readers:         kafkaReaders(brokers),
+		producerEnabled: true,
+		consumerEnabled: true,
+	}
+}
+
+// Producer returns the producer of the manager, for its delivery reports and stats
+func (m *Manager) Producer() *Producer {
+	return m.producer
+}
+
 func (m *Manager) SendMessage(ctx context.Context, topic string, key string, partition int, event Event) error {
 	m.controlMu.RLock()
 	defer m.controlMu.RUnlock()
@@ -54,27 +80,106 @@ func (m *Manager) SendMessage(ctx context.Context, topic string, key string, par
 	return m.producer.SendMessage(ctx, topic, key, partition, event) // partition -1 = let Kafka decide
 }
 
+// WriteMessage sends an already encoded event through the producer
+func (m *Manager) WriteMessage(ctx context.Context, topic string, key string, value []byte) error {
+	m.controlMu.RLock()
+	defer m.controlMu.RUnlock()
+
+	if !m.producerEnabled {
+		return fmt.Errorf("kafka producer is disabled")
+	}
+
+	return m.producer.WriteMessage(ctx, topic, key, value)
+}
+
+func (m *Manager) writeMessage(ctx context.Context, topic string, key string, value []byte, headers []kafka.Header) error {
+	m.controlMu.RLock()
+	defer m.controlMu.RUnlock()
+
+	if !m.producerEnabled {
+		return fmt.Errorf("kafka producer is disabled")
+	}
+
+	return m.producer.write(ctx, m.producer.writer, topic, key, -1, value, headers)
+}
+
+// SetCodec sets the codec of the values sent with Publish and read with Subscribe
+func (m *Manager) SetCodec(codec *Codec) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.codec = codec
+}
+
+func (m *Manager) Codec() *Codec {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.codec
+}
+
+// consumerKey identifies a consumer, a topic is consumed by any number of groups
+type consumerKey struct {
+	topic   string
+	groupID string
+}
+
+// registration is a consumer added to the manager. Its Consumer is created on start, as the
+// readers join the group, and is dropped once stopped.
+type registration struct {
+	handler   EventHandler
+	options   ConsumerOptions
+	consumer  *Consumer
+	startedAt time.Time
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// ConsumerStatus is the state of a consumer in GetStatus
+type ConsumerStatus struct {
+	Topic     string        `json:"topic"`
+	GroupID   string        `json:"group_id"`
+	State     string        `json:"state"` // stopped, running or paused
+	Workers   int           `json:"workers"`
+	Retries   int           `json:"retry_topics"`
+	Stats     ConsumerStats `json:"stats"`
+	StartedAt *time.Time    `json:"started_at,omitempty"`
+}
+
+// AddConsumer registers a consumer that moves failed messages straight to the dead-letter topic
 func (m *Manager) AddConsumer(topic, groupID string, handler EventHandler) error {
+	return m.AddConsumerWithOptions(topic, groupID, handler, ConsumerOptions{})
+}
+
+// AddConsumerWithOptions registers a consumer with retry topics and a dedup store, failed
+// messages are moved on with the producer of the manager. Each group of a topic receives
+// every message of the topic.
+func (m *Manager) AddConsumerWithOptions(topic, groupID string, handler EventHandler, options ConsumerOptions) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, exists := m.consumers[topic]; exists {
-		return fmt.Errorf("consumer for topic %s already exists", topic)
+	key := consumerKey{topic: topic, groupID: groupID}
+	if _, exists := m.consumers[key]; exists {
+		return fmt.Errorf("consumer for topic %s and group %s already exists", topic, groupID)
 	}
 
-	consumer := NewConsumer(
-		m.brokers,
-		topic,
-		groupID,
-		handler,
-		m.logger,
-	)
+	m.consumers[key] = &registration{handler: handler, options: options}
+	return nil
+}
+
+// RemoveConsumer stops the consumer and removes it from the manager
+func (m *Manager) RemoveConsumer(topic, groupID string) error {
+	if err := m.StopConsumer(topic, groupID); err != nil {
+		return err
+	}
 
-	m.consumers[topic] = consumer
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.consumers, consumerKey{topic: topic, groupID: groupID})
 	return nil
 }
 
-func (m *Manager) StartConsumer(ctx context.Context, topic string) error {
+// StartConsumer starts consuming the topic with the group until ctx is done or the consumer
+// is stopped. A stopped consumer can be started again.
+func (m *Manager) StartConsumer(ctx context.Context, topic, groupID string) error {
 	m.controlMu.RLock()
 	defer m.controlMu.RUnlock()
 
@@ -82,17 +187,104 @@ func (m *Manager) StartConsumer(ctx context.Context, topic string) error {
 		return fmt.Errorf("kafka consumer is disabled")
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := consumerKey{topic: topic, groupID: groupID}
+	r, exists := m.consumers[key]
+	if !exists {
+		return fmt.Errorf("consumer for topic %s and group %s not found", topic, groupID)
+	}
+	if r.consumer != nil {
+		return fmt.Errorf("consumer for topic %s and group %s is already running", topic, groupID)
+	}
+	m.start(ctx, key, r)
+	return nil
+}
+
+// start runs the consumer of the registration, m.mu is held
+func (m *Manager) start(ctx context.Context, key consumerKey, r *registration) {
+	consumer := newConsumer(m.readers, key.topic, key.groupID, r.handler, m.producer, r.options, m.logger)
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	r.consumer, r.startedAt, r.cancel, r.done = consumer, time.Now(), cancel, done
+
+	go func() {
+		defer close(done)
+		if err := consumer.Start(ctx); err != nil {
+			m.logger.Error("consumer stopped with error",
+				zap.String("topic", key.topic),
+				zap.String("group_id", key.groupID),
+				zap.Error(err))
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if r.consumer == consumer {
+			r.consumer, r.cancel, r.done = nil, nil, nil
+		}
+		cancel()
+	}()
+}
+
+// StopConsumer stops the consumer and waits until its messages in flight are settled
+func (m *Manager) StopConsumer(topic, groupID string) error {
 	m.mu.RLock()
-	consumer, exists := m.consumers[topic]
+	r, exists := m.consumers[consumerKey{topic: topic, groupID: groupID}]
+	var cancel context.CancelFunc
+	var done chan struct{}
+	if exists {
+		cancel, done = r.cancel, r.done
+	}
 	m.mu.RUnlock()
 
 	if !exists {
-		return fmt.Errorf("consumer for topic %s not found", topic)
+		return fmt.Errorf("consumer for topic %s and group %s not found", topic, groupID)
+	}
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return nil
+}
+
+// PauseConsumer stops fetching messages until the consumer is resumed, the group membership
+// and the messages in flight are kept
+func (m *Manager) PauseConsumer(topic, groupID string) error {
+	consumer, err := m.running(topic, groupID)
+	if err != nil {
+		return err
+	}
+	consumer.Pause()
+	m.logger.Info("kafka consumer paused", zap.String("topic", topic), zap.String("group_id", groupID))
+	return nil
+}
+
+func (m *Manager) ResumeConsumer(topic, groupID string) error {
+	consumer, err := m.running(topic, groupID)
+	if err != nil {
+		return err
 	}
+	consumer.Resume()
+	m.logger.Info("kafka consumer resumed", zap.String("topic", topic), zap.String("group_id", groupID))
+	return nil
+}
 
-	return consumer.Start(ctx)
+func (m *Manager) running(topic, groupID string) (*Consumer, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	r, exists := m.consumers[consumerKey{topic: topic, groupID: groupID}]
+	if !exists {
+		return nil, fmt.Errorf("consumer for topic %s and group %s not found", topic, groupID)
+	}
+	if r.consumer == nil {
+		return nil, fmt.Errorf("consumer for topic %s and group %s is not running", topic, groupID)
+	}
+	return r.consumer, nil
 }
 
+// StartAllConsumers starts the consumers that are not running
 func (m *Manager) StartAllConsumers(ctx context.Context) {
 	m.controlMu.RLock()
 	defer m.controlMu.RUnlock()
@@ -102,23 +294,27 @@ func (m *Manager) StartAllConsumers(ctx context.Context) {
 		return
 	}
 
-	for topic, consumer := range m.consumers {
-		go func(t string, c *Consumer) {
-			if err := c.Start(ctx); err != nil {
-				m.logger.Error("consumer stopped with error", zap.String("topic", t), zap.Error(err))
-			}
-		}(topic, consumer)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, r := range m.consumers {
+		if r.consumer == nil {
+			m.start(ctx, key, r)
+		}
 	}
 }
 
+// Close stops the consumers and closes the producer
 func (m *Manager) Close() error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+	m.mu.RLock()
+	keys := make([]consumerKey, 0, len(m.consumers))
+	for key := range m.consumers {
+		keys = append(keys, key)
+	}
+	m.mu.RUnlock()
 
-	// Close all consumers
-	for topic, consumer := range m.consumers {
-		if err := consumer.Close(); err != nil {
-			m.logger.Error("failed to close consumer", zap.String("topic", topic), zap.Error(err))
+	for _, key := range keys {
+		if err := m.StopConsumer(key.topic, key.groupID); err != nil {
+			m.logger.Error("failed to stop consumer", zap.String("topic", key.topic), zap.Error(err))
 		}
 	}
 
@@ -171,13 +367,41 @@ func (m *Manager) GetStatus() map[string]interface{} {
 	defer m.controlMu.RUnlock()
 
 	m.mu.RLock()
-	consumerCount := len(m.consumers)
+	consumers := make([]ConsumerStatus, 0, len(m.consumers))
+	for key, r := range m.consumers {
+		status := ConsumerStatus{
+			Topic:   key.topic,
+			GroupID: key.groupID,
+			State:   "stopped",
+			Workers: max(r.options.Workers, 1),
+			Retries: r.options.Retry.Attempts,
+		}
+		if c := r.consumer; c != nil {
+			status.State = "running"
+			if c.Paused() {
+				status.State = "paused"
+			}
+			status.Stats = c.Stats()
+			startedAt := r.startedAt
+			status.StartedAt = &startedAt
+		}
+		consumers = append(consumers, status)
+	}
 	m.mu.RUnlock()
 
+	sort.Slice(consumers, func(i, j int) bool {
+		if consumers[i].Topic != consumers[j].Topic {
+			return consumers[i].Topic < consumers[j].Topic
+		}
+		return consumers[i].GroupID < consumers[j].GroupID
+	})
+
 	return map[string]interface{}{
 		"producer_enabled": m.producerEnabled,
 		"consumer_enabled": m.consumerEnabled,
-		"consumer_count":   consumerCount,
+		"consumer_count":   len(consumers),
+		"consumers":        consumers,
+		"producer":         m.producer.Stats(),
 		"brokers":          m.brokers,
 	}
 }
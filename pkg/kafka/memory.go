@@ -0,0 +1,285 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const defaultMemoryPartitions = 3
+
+// MemoryBus is a Manager on an in-memory broker, so that producers and consumers are tested
+// without Kafka. Topics are created on their first write or read; messages are partitioned by
+// the balancer of the producer, so a keyed message goes to the same partition as on Kafka
+// for the same number of partitions and keyless messages are spread round robin. A consumer
+// group starts at the beginning of a topic and resumes at its committed offsets once
+// started again, so retries, dead-letter topics, worker pools and commits behave as on Kafka.
+type MemoryBus struct {
+	*Manager
+	broker *memoryBroker
+}
+
+// NewMemoryBus creates a bus whose topics have the given number of partitions, 3 when zero
+func NewMemoryBus(partitions int, logger *zap.Logger) *MemoryBus {
+	if partitions <= 0 {
+		partitions = defaultMemoryPartitions
+	}
+	broker := &memoryBroker{
+		partitions: partitions,
+		topics:     make(map[string][][]memoryRecord),
+		committed:  make(map[consumerKey][]int64),
+		changed:    make(chan struct{}),
+		balancer:   newBalancer(),
+	}
+
+	// The default config is always valid
+	producer, _ := NewProducerWithConfig(nil, ProducerConfig{}, logger)
+	producer.writer = &memoryWriter{broker: broker, completion: producer.complete}
+	producer.syncWriter = producer.writer
+
+	m := NewManagerWithProducer(nil, producer, logger)
+	m.readers = broker.reader
+	return &MemoryBus{Manager: m, broker: broker}
+}
+
+// CreateTopic creates the topic with its own number of partitions, it fails if the topic
+// exists already
+func (b *MemoryBus) CreateTopic(topic string, partitions int) error {
+	b.broker.mu.Lock()
+	defer b.broker.mu.Unlock()
+	if _, exists := b.broker.topics[topic]; exists {
+		return fmt.Errorf("topic %s already exists", topic)
+	}
+	b.broker.topics[topic] = make([][]memoryRecord, max(partitions, 1))
+	return nil
+}
+
+// Messages returns the messages written to the topic in the order they were written
+func (b *MemoryBus) Messages(topic string) []*Message {
+	b.broker.mu.Lock()
+	var records []memoryRecord
+	for _, partition := range b.broker.topics[topic] {
+		records = append(records, partition...)
+	}
+	b.broker.mu.Unlock()
+
+	sort.Slice(records, func(i, j int) bool { return records[i].seq < records[j].seq })
+	messages := make([]*Message, len(records))
+	for i, r := range records {
+		messages[i] = newMessage(r.message)
+	}
+	return messages
+}
+
+// Lag is the number of messages of the topic the group has not committed yet
+func (b *MemoryBus) Lag(groupID, topic string) int64 {
+	b.broker.mu.Lock()
+	defer b.broker.mu.Unlock()
+	return b.broker.lag(consumerKey{topic: topic, groupID: groupID})
+}
+
+// WaitCommitted waits until the group has committed every message written to the topic so
+// far, or ctx is done
+func (b *MemoryBus) WaitCommitted(ctx context.Context, groupID, topic string) error {
+	key := consumerKey{topic: topic, groupID: groupID}
+	for {
+		b.broker.mu.Lock()
+		lag, changed := b.broker.lag(key), b.broker.changed
+		b.broker.mu.Unlock()
+		if lag == 0 {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// memoryBroker holds the partitions of the topics and the committed offsets of the groups
+type memoryBroker struct {
+	mu         sync.Mutex
+	partitions int
+	topics     map[string][][]memoryRecord
+	// committed is the next offset of each partition a group reads
+	committed map[consumerKey][]int64
+	// changed is closed and replaced on every write and commit
+	changed chan struct{}
+	seq     uint64
+	// balancer is the one of the producers, so that keys land on the partitions they do on Kafka
+	balancer kafka.Balancer
+}
+
+type memoryRecord struct {
+	message kafka.Message
+	seq     uint64
+}
+
+// indexes lists the partitions of a topic of n partitions for the balancer
+func (b *memoryBroker) indexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// topic returns the partitions of the topic, creating it if needed; b.mu is held
+func (b *memoryBroker) topic(name string) [][]memoryRecord {
+	partitions, exists := b.topics[name]
+	if !exists {
+		partitions = make([][]memoryRecord, b.partitions)
+		b.topics[name] = partitions
+	}
+	return partitions
+}
+
+// notify wakes the readers and waiters up; b.mu is held
+func (b *memoryBroker) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func (b *memoryBroker) write(msgs []kafka.Message) []kafka.Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	written := make([]kafka.Message, len(msgs))
+	for i, m := range msgs {
+		partitions := b.topic(m.Topic)
+		m.Partition = b.balancer.Balance(m, b.indexes(len(partitions))...)
+		m.Offset = int64(len(partitions[m.Partition]))
+		if m.Time.IsZero() {
+			m.Time = time.Now()
+		}
+		b.seq++
+		partitions[m.Partition] = append(partitions[m.Partition], memoryRecord{message: m, seq: b.seq})
+		written[i] = m
+	}
+	b.notify()
+	return written
+}
+
+// committedOf returns the committed offsets of the group, one per partition; b.mu is held
+func (b *memoryBroker) committedOf(key consumerKey) []int64 {
+	committed := b.committed[key]
+	for len(committed) < len(b.topics[key.topic]) {
+		committed = append(committed, 0)
+	}
+	b.committed[key] = committed
+	return committed
+}
+
+// lag is the number of messages after the committed offsets of the group; b.mu is held
+func (b *memoryBroker) lag(key consumerKey) int64 {
+	var lag int64
+	committed := b.committedOf(key)
+	for p, partition := range b.topics[key.topic] {
+		lag += int64(len(partition)) - committed[p]
+	}
+	return lag
+}
+
+func (b *memoryBroker) reader(topic, groupID string) messageReader {
+	return &memoryReader{
+		broker: b,
+		key:    consumerKey{topic: topic, groupID: groupID},
+		closed: make(chan struct{}),
+	}
+}
+
+// memoryWriter writes to the memory broker and reports the messages as delivered at once
+type memoryWriter struct {
+	broker     *memoryBroker
+	completion func(messages []kafka.Message, err error)
+}
+
+func (w *memoryWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	written := w.broker.write(msgs)
+	if w.completion != nil && len(written) > 0 {
+		w.completion(written, nil)
+	}
+	return nil
+}
+
+func (w *memoryWriter) Close() error {
+	return nil
+}
+
+// memoryReader is the single member of its group, it reads every partition of the topic
+// starting at the committed offsets of the group
+type memoryReader struct {
+	broker *memoryBroker
+	key    consumerKey
+	// positions is the next offset to fetch of each partition
+	positions []int64
+	next      int
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func (r *memoryReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	for {
+		r.broker.mu.Lock()
+		partitions := r.broker.topic(r.key.topic)
+		committed := r.broker.committedOf(r.key)
+		for len(r.positions) < len(partitions) {
+			r.positions = append(r.positions, committed[len(r.positions)])
+		}
+		for i := range partitions {
+			p := (r.next + i) % len(partitions)
+			if r.positions[p] < int64(len(partitions[p])) {
+				m := partitions[p][r.positions[p]].message
+				r.positions[p]++
+				r.next = p + 1
+				r.broker.mu.Unlock()
+				return m, nil
+			}
+		}
+		changed := r.broker.changed
+		r.broker.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-r.closed:
+			return kafka.Message{}, io.EOF
+		case <-ctx.Done():
+			return kafka.Message{}, ctx.Err()
+		}
+	}
+}
+
+func (r *memoryReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.broker.mu.Lock()
+	defer r.broker.mu.Unlock()
+	committed := r.broker.committedOf(r.key)
+	for _, m := range msgs {
+		if m.Partition < len(committed) && committed[m.Partition] <= m.Offset {
+			committed[m.Partition] = m.Offset + 1
+		}
+	}
+	r.broker.notify()
+	return nil
+}
+
+func (r *memoryReader) Config() kafka.ReaderConfig {
+	return kafka.ReaderConfig{Topic: r.key.topic, GroupID: r.key.groupID}
+}
+
+func (r *memoryReader) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return nil
+}
@@ -0,0 +1,230 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"simple-securities/pkg/kafka/schema"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+func newTestBus(t *testing.T) *MemoryBus {
+	t.Helper()
+	bus := NewMemoryBus(3, zap.NewNop())
+	t.Cleanup(func() { _ = bus.Close() })
+	return bus
+}
+
+func waitCommitted(t *testing.T, bus *MemoryBus, groupID, topic string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bus.WaitCommitted(ctx, groupID, topic); err != nil {
+		t.Fatalf("group %s did not commit %s: %v", groupID, topic, err)
+	}
+}
+
+// received collects the keys and values handled by a consumer
+type received struct {
+	mu     sync.Mutex
+	values map[string][]string
+}
+
+func (r *received) handler(_ context.Context, msg *Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.values == nil {
+		r.values = make(map[string][]string)
+	}
+	r.values[string(msg.Key)] = append(r.values[string(msg.Key)], string(msg.Value))
+	return nil
+}
+
+func (r *received) get(key string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.values[key]
+}
+
+func TestMemoryBusGroups(t *testing.T) {
+	bus := newTestBus(t)
+	ctx := context.Background()
+
+	var billing, audit received
+	options := ConsumerOptions{Workers: 4, CommitInterval: 10 * time.Millisecond}
+	if err := bus.AddConsumerWithOptions("orders", "billing", billing.handler, options); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.AddConsumer("orders", "audit", audit.handler); err != nil {
+		t.Fatal(err)
+	}
+	bus.StartAllConsumers(ctx)
+
+	keys := []string{"a", "b", "c", "d"}
+	for i := 0; i < 20; i++ {
+		key := keys[i%len(keys)]
+		if err := bus.WriteMessage(ctx, "orders", key, []byte(fmt.Sprint(i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	waitCommitted(t, bus, "billing", "orders")
+	waitCommitted(t, bus, "audit", "orders")
+
+	// Every group receives every message, in order per key
+	for i, key := range keys {
+		var want []string
+		for n := i; n < 20; n += len(keys) {
+			want = append(want, fmt.Sprint(n))
+		}
+		for name, r := range map[string]*received{"billing": &billing, "audit": &audit} {
+			if got := r.get(key); fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("%s received %v for key %s, want %v", name, got, key, want)
+			}
+		}
+	}
+	if lag := bus.Lag("billing", "orders"); lag != 0 {
+		t.Errorf("lag = %d, want 0", lag)
+	}
+	if got := len(bus.Messages("orders")); got != 20 {
+		t.Errorf("topic holds %d messages, want 20", got)
+	}
+}
+
+func TestMemoryBusResumesAtCommittedOffsets(t *testing.T) {
+	bus := newTestBus(t)
+	ctx := context.Background()
+
+	var r received
+	if err := bus.AddConsumer("orders", "billing", r.handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.WriteMessage(ctx, "orders", "a", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.StartConsumer(ctx, "orders", "billing"); err != nil {
+		t.Fatal(err)
+	}
+	waitCommitted(t, bus, "billing", "orders")
+	if err := bus.StopConsumer("orders", "billing"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.WriteMessage(ctx, "orders", "a", []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if lag := bus.Lag("billing", "orders"); lag != 1 {
+		t.Errorf("lag of the stopped group = %d, want 1", lag)
+	}
+	if err := bus.StartConsumer(ctx, "orders", "billing"); err != nil {
+		t.Fatal(err)
+	}
+	waitCommitted(t, bus, "billing", "orders")
+
+	if got := r.get("a"); fmt.Sprint(got) != "[1 2]" {
+		t.Errorf("received %v, want [1 2]", got)
+	}
+}
+
+func TestMemoryBusRetriesToDeadLetter(t *testing.T) {
+	bus := newTestBus(t)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var attempts []int
+	err := bus.AddConsumerWithOptions("orders", "billing", func(_ context.Context, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, msg.Attempt())
+		return errors.New("insufficient funds")
+	}, ConsumerOptions{Retry: RetryPolicy{Attempts: 2, Backoff: time.Millisecond}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus.StartAllConsumers(ctx)
+
+	if err := bus.SendMessage(ctx, "orders", "a", -1, Event{Meta: Meta{RequestID: "req-1", Message: "order.placed"}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, topic := range []string{"orders", RetryTopic("orders", 1), RetryTopic("orders", 2)} {
+		waitCommitted(t, bus, "billing", topic)
+	}
+
+	dead := bus.Messages(DeadLetterTopic("orders"))
+	if len(dead) != 1 {
+		t.Fatalf("dead-letter topic holds %d messages, want 1", len(dead))
+	}
+	if reason, _ := dead[0].Header(HeaderError); reason != "insufficient funds" {
+		t.Errorf("error header = %q", reason)
+	}
+	if origin, _ := dead[0].Header(HeaderOriginalTopic); origin != "orders" {
+		t.Errorf("original topic = %q, want orders", origin)
+	}
+	if dead[0].RequestID() != "req-1" || dead[0].EventType() != "order.placed" {
+		t.Errorf("headers not kept: request id %q, event type %q", dead[0].RequestID(), dead[0].EventType())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(attempts) != "[0 1 2]" {
+		t.Errorf("attempts = %v, want [0 1 2]", attempts)
+	}
+}
+
+func TestMemoryBusPublishSubscribe(t *testing.T) {
+	type placed struct {
+		OrderID string  `json:"order_id"`
+		Price   float64 `json:"price"`
+	}
+
+	bus := newTestBus(t)
+	bus.SetCodec(NewCodec(schema.NewMemoryRegistry(schema.CompatibilityBackward), schema.NewJSONSerializer()))
+	ctx := WithRequestID(context.Background(), "req-1")
+
+	got := make(chan placed, 1)
+	var requestID string
+	err := Subscribe(bus, "orders", "billing", func(ctx context.Context, _ *Message, value placed) error {
+		requestID = RequestIDFrom(ctx)
+		got <- value
+		return nil
+	}, ConsumerOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bus.StartAllConsumers(ctx)
+
+	if err := Publish(ctx, bus, "orders", "o-1", placed{OrderID: "o-1", Price: 9.5}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case value := <-got:
+		if value != (placed{OrderID: "o-1", Price: 9.5}) {
+			t.Errorf("received %+v", value)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("value not received")
+	}
+	waitCommitted(t, bus, "billing", "orders")
+	if requestID != "req-1" {
+		t.Errorf("request id = %q, want req-1", requestID)
+	}
+	if stats := bus.Producer().Stats(); stats.Delivered != 1 {
+		t.Errorf("delivered = %d, want 1", stats.Delivered)
+	}
+}
+
+func TestMemoryBusPartitionsLikeKafka(t *testing.T) {
+	bus := newTestBus(t)
+	balancer := newBalancer()
+	for _, key := range []string{"a", "b", "order-42", "user-7"} {
+		msg := kafka.Message{Topic: "orders", Key: []byte(key)}
+		want := balancer.Balance(msg, 0, 1, 2)
+		if written := bus.broker.write([]kafka.Message{msg}); written[0].Partition != want {
+			t.Errorf("key %s written to partition %d, want %d as on Kafka", key, written[0].Partition, want)
+		}
+	}
+}
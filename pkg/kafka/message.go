@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Message is a message read by a consumer, with its position in the topic and its headers
+type Message struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []kafka.Header
+	Time      time.Time
+}
+
+func newMessage(m kafka.Message) *Message {
+	return &Message{
+		Topic:     m.Topic,
+		Partition: m.Partition,
+		Offset:    m.Offset,
+		Key:       m.Key,
+		Value:     m.Value,
+		Headers:   m.Headers,
+		Time:      m.Time,
+	}
+}
+
+// Header returns the last value of the header
+func (m *Message) Header(key string) (string, bool) {
+	value, found := "", false
+	for _, h := range m.Headers {
+		if h.Key == key {
+			value, found = string(h.Value), true
+		}
+	}
+	return value, found
+}
+
+// RequestID is the request-id header, else the request id of the event in the value
+func (m *Message) RequestID() string {
+	if requestID, ok := m.Header(HeaderRequestID); ok && requestID != "" {
+		return requestID
+	}
+	return eventRequestID(m.Value)
+}
+
+// EventType is the event-type header, the message of the event meta for SendMessage
+func (m *Message) EventType() string {
+	eventType, _ := m.Header(HeaderEventType)
+	return eventType
+}
+
+// Attempt is the retry attempt of the message, zero when it was read from the topic itself
+func (m *Message) Attempt() int {
+	value, _ := m.Header(HeaderRetryAttempt)
+	attempt, _ := strconv.Atoi(value)
+	return attempt
+}
+
+// TraceContext is the trace context of the traceparent header
+func (m *Message) TraceContext() (TraceContext, bool) {
+	traceparent, ok := m.Header(HeaderTraceparent)
+	if !ok {
+		return TraceContext{}, false
+	}
+	tc, err := ParseTraceparent(traceparent)
+	return tc, err == nil
+}
@@ -0,0 +1,219 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCommitBatch    = 100
+	defaultCommitInterval = time.Second
+	defaultDrainTimeout   = 30 * time.Second
+)
+
+// runConcurrent consumes the topic of the given retry attempt with a pool of workers. The
+// messages of a key always go to the same worker, so they are handled in order, while
+// messages of other keys are handled concurrently.
+//
+// Settled messages are committed in batches, per partition up to the first message still in
+// flight, so a commit never skips an unprocessed message. Once ctx is done fetching stops
+// and the workers drain their queues for up to DrainTimeout before the last commit; a worker
+// that leaves a message unsettled drops the rest of its queue, which is delivered again.
+func (c *Consumer) runConcurrent(ctx context.Context, attempt int, reader messageReader) {
+	workers := c.options.Workers
+	commitBatch := c.options.CommitBatch
+	if commitBatch <= 0 {
+		commitBatch = defaultCommitBatch
+	}
+	drainTimeout := c.options.DrainTimeout
+	if drainTimeout <= 0 {
+		drainTimeout = defaultDrainTimeout
+	}
+
+	// Handling and committing outlive ctx by the drain timeout
+	drainCtx, stopDrain := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopDrain()
+	stopAfter := context.AfterFunc(ctx, func() {
+		time.AfterFunc(drainTimeout, stopDrain)
+	})
+	defer stopAfter()
+
+	tracker := newOffsetTracker(commitBatch)
+	// Bounds the messages fetched but not yet settled
+	inFlight := make(chan struct{}, workers*commitBatch)
+
+	var wg sync.WaitGroup
+	queues := make([]chan kafka.Message, workers)
+	for i := range queues {
+		queues[i] = make(chan kafka.Message, commitBatch)
+		wg.Add(1)
+		go func(queue <-chan kafka.Message) {
+			defer wg.Done()
+			abandoned := false
+			for m := range queue {
+				// Handling a later message of a key before an abandoned one would break its order
+				if !abandoned && c.process(ctx, drainCtx, attempt, m) {
+					tracker.settle(m)
+				} else {
+					abandoned = true
+				}
+				<-inFlight
+			}
+		}(queues[i])
+	}
+
+	drained := make(chan struct{})
+	committed := make(chan struct{})
+	go func() {
+		defer close(committed)
+		c.commitLoop(drainCtx, reader, tracker, drained)
+	}()
+
+	for {
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		m, ok := c.fetch(ctx, reader)
+		if !ok {
+			<-inFlight
+			break
+		}
+		tracker.add(m)
+		queues[workerOf(m, workers)] <- m
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+	close(drained)
+	<-committed
+}
+
+// commitLoop commits the settled messages every commit interval, when a batch is settled and
+// a last time once the workers are drained
+func (c *Consumer) commitLoop(ctx context.Context, reader messageReader, tracker *offsetTracker, drained <-chan struct{}) {
+	interval := c.options.CommitInterval
+	if interval <= 0 {
+		interval = defaultCommitInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-tracker.ready:
+		case <-drained:
+			c.commit(ctx, reader, tracker)
+			return
+		case <-ctx.Done():
+			return
+		}
+		c.commit(ctx, reader, tracker)
+	}
+}
+
+func (c *Consumer) commit(ctx context.Context, reader messageReader, tracker *offsetTracker) {
+	messages := tracker.committable()
+	if len(messages) == 0 {
+		return
+	}
+	if err := reader.CommitMessages(ctx, messages...); err != nil {
+		// The offsets are committed again with the next batch of the partition, or the
+		// messages are delivered again, e.g. when the partition was reassigned
+		c.logger.Error("failed to commit messages",
+			zap.String("topic", reader.Config().Topic),
+			zap.Int("partitions", len(messages)),
+			zap.Error(err))
+	}
+}
+
+// workerOf hashes the key of the message onto a worker, keyless messages are spread by offset
+func workerOf(m kafka.Message, workers int) int {
+	if len(m.Key) == 0 {
+		return int(m.Offset % int64(workers))
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(m.Key)
+	return int(h.Sum32() % uint32(workers))
+}
+
+// offsetTracker follows the messages in flight of each partition in fetch order, which is
+// offset order
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int][]*trackedMessage
+	settled    int
+	batch      int
+	// ready signals that a batch of messages was settled since the last commit
+	ready chan struct{}
+}
+
+type trackedMessage struct {
+	message kafka.Message
+	settled bool
+}
+
+func newOffsetTracker(batch int) *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[int][]*trackedMessage),
+		batch:      batch,
+		ready:      make(chan struct{}, 1),
+	}
+}
+
+func (t *offsetTracker) add(m kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.partitions[m.Partition] = append(t.partitions[m.Partition], &trackedMessage{message: m})
+}
+
+func (t *offsetTracker) settle(m kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, tracked := range t.partitions[m.Partition] {
+		if tracked.message.Offset == m.Offset {
+			tracked.settled = true
+			break
+		}
+	}
+	t.settled++
+	if t.settled >= t.batch {
+		select {
+		case t.ready <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// committable removes the settled messages at the head of each partition and returns the
+// last of them, committing it commits the partition up to there
+func (t *offsetTracker) committable() []kafka.Message {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var messages []kafka.Message
+	for partition, tracked := range t.partitions {
+		n := 0
+		for n < len(tracked) && tracked[n].settled {
+			n++
+		}
+		if n == 0 {
+			continue
+		}
+		messages = append(messages, tracked[n-1].message)
+		t.partitions[partition] = tracked[n:]
+	}
+	t.settled = 0
+	return messages
+}
@@ -4,51 +4,246 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync/atomic"
 	"time"
 
+	"simple-securities/pkg/uuid"
+
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	defaultLogFirst      = 10
+	defaultLogThereafter = 100
 )
 
+// ProducerConfig tunes the writer of a producer, zero values keep the kafka-go defaults
+type ProducerConfig struct {
+	// Async returns from SendMessage once the message is queued, the outcome is reported to
+	// OnDelivery and Deliveries instead
+	Async bool
+	// A batch is sent once it holds BatchSize messages or BatchBytes bytes, or Linger after
+	// its first message
+	BatchSize  int
+	BatchBytes int64
+	Linger     time.Duration
+	// Compression is none, gzip, snappy, lz4 or zstd
+	Compression string
+	// Acks is none, leader or all, leader by default
+	Acks        string
+	MaxAttempts int
+	// Idempotent waits for all in-sync replicas and stamps every message with a message-id
+	// header, so the retries of a write are dropped by the dedup store of the consumers.
+	// kafka-go has no idempotent producer, the broker itself may store a retried batch twice.
+	Idempotent bool
+	// OnDelivery is called with the outcome of every message from the writer goroutines, it
+	// has to return quickly
+	OnDelivery func(DeliveryReport)
+	// DeliveryReports is the buffer of the Deliveries channel, reports are dropped when it is
+	// full. Zero disables the channel.
+	DeliveryReports int
+	// Each second the first LogFirst sends are logged, then one in LogThereafter
+	LogFirst      int
+	LogThereafter int
+}
+
+// DeliveryReport is the outcome of writing a message
+type DeliveryReport struct {
+	Topic     string
+	Partition int
+	Offset    int64
+	Key       []byte
+	Headers   []kafka.Header
+	Time      time.Time
+	Err       error
+}
+
+// ProducerStats counts the delivery reports of a producer
+type ProducerStats struct {
+	Delivered      int64
+	Failed         int64
+	DroppedReports int64
+}
+
+// messageWriter writes messages to the brokers, a kafka.Writer or a writer of the memory broker
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
 type Producer struct {
-	writer *kafka.Writer
-	logger *zap.Logger
+	writer messageWriter
+	// syncWriter is the writer itself unless it is async, moving messages to retry topics and
+	// relaying an outbox need to know the message was written
+	syncWriter messageWriter
+	config     ProducerConfig
+	deliveries chan DeliveryReport
+	logger     *zap.Logger
+	sampled    *zap.Logger
+
+	delivered      atomic.Int64
+	failed         atomic.Int64
+	droppedReports atomic.Int64
 }
 
 func NewProducer(brokers []string, logger *zap.Logger) *Producer {
-	writer := &kafka.Writer{
-		Addr:         kafka.TCP(brokers...),
-		Balancer:     &kafka.LeastBytes{},
-		RequiredAcks: kafka.RequireOne,
-		Async:        false,
+	// The default config is always valid
+	p, _ := NewProducerWithConfig(brokers, ProducerConfig{}, logger)
+	return p
+}
+
+func NewProducerWithConfig(brokers []string, config ProducerConfig, logger *zap.Logger) (*Producer, error) {
+	compression, err := parseCompression(config.Compression)
+	if err != nil {
+		return nil, err
+	}
+	acks, err := parseAcks(config.Acks)
+	if err != nil {
+		return nil, err
+	}
+	if config.Idempotent {
+		if config.Acks != "" && acks != kafka.RequireAll {
+			return nil, fmt.Errorf("idempotent kafka producer needs acks all, not %s", config.Acks)
+		}
+		acks = kafka.RequireAll
+	}
+	if config.LogFirst <= 0 {
+		config.LogFirst = defaultLogFirst
+	}
+	if config.LogThereafter <= 0 {
+		config.LogThereafter = defaultLogThereafter
 	}
 
-	return &Producer{
-		writer: writer,
+	p := &Producer{
+		config: config,
 		logger: logger,
+		sampled: logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSamplerWithOptions(core, time.Second, config.LogFirst, config.LogThereafter)
+		})),
+	}
+	if config.DeliveryReports > 0 {
+		p.deliveries = make(chan DeliveryReport, config.DeliveryReports)
+	}
+
+	newWriter := func(async bool) *kafka.Writer {
+		return &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     newBalancer(),
+			RequiredAcks: acks,
+			Async:        async,
+			BatchSize:    config.BatchSize,
+			BatchBytes:   config.BatchBytes,
+			BatchTimeout: config.Linger,
+			MaxAttempts:  config.MaxAttempts,
+			Compression:  compression,
+			Completion:   p.complete,
+		}
+	}
+	p.writer = newWriter(config.Async)
+	p.syncWriter = p.writer
+	if config.Async {
+		p.syncWriter = newWriter(false)
+	}
+	return p, nil
+}
+
+// newBalancer picks the partition of a message by the FNV-1a hash of its key, like sarama and
+// the memory broker, and spreads keyless messages round robin
+func newBalancer() kafka.Balancer {
+	return &kafka.Hash{}
+}
+
+func parseCompression(name string) (kafka.Compression, error) {
+	switch name {
+	case "", "none":
+		return 0, nil
+	case "gzip":
+		return kafka.Gzip, nil
+	case "snappy":
+		return kafka.Snappy, nil
+	case "lz4":
+		return kafka.Lz4, nil
+	case "zstd":
+		return kafka.Zstd, nil
+	default:
+		return 0, fmt.Errorf("unknown kafka compression %q", name)
+	}
+}
+
+func parseAcks(name string) (kafka.RequiredAcks, error) {
+	switch name {
+	case "", "leader":
+		return kafka.RequireOne, nil
+	case "none":
+		return kafka.RequireNone, nil
+	case "all":
+		return kafka.RequireAll, nil
+	default:
+		return 0, fmt.Errorf("unknown kafka acks %q", name)
 	}
 }
 
 // SendMessage sends an event to Kafka.
-// - If key is provided → Kafka hashes key → same key = same partition.
-// - If key is empty → Kafka balances messages across partitions.
-// - If partition >= 0 → overrides Kafka partitioner.
+// - If key is provided → the key is hashed → same key = same partition.
+// - If key is empty → messages are spread round robin across partitions.
+// - partition is not honoured: the kafka-go writer always picks the partition with its balancer.
+// The request id, event type and service name of the meta go in the headers along with the
+// trace context of ctx; an event without request id takes the one of ctx.
 func (p *Producer) SendMessage(
 	ctx context.Context,
 	topic string,
 	key string,
-	partition int, // set -1 to let Kafka decide
+	partition int, // ignored by the writer, pass -1
 	event Event,
 ) error {
+	if event.Meta.RequestID == "" {
+		event.Meta.RequestID = RequestIDFrom(ctx)
+	}
 	eventBytes, err := json.Marshal(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
+	var headers []kafka.Header
+	if event.Meta.RequestID != "" {
+		headers = append(headers, kafka.Header{Key: HeaderRequestID, Value: []byte(event.Meta.RequestID)})
+	}
+	if event.Meta.Message != "" {
+		headers = append(headers, kafka.Header{Key: HeaderEventType, Value: []byte(event.Meta.Message)})
+	}
+	if event.Meta.ServiceName != "" {
+		headers = append(headers, kafka.Header{Key: HeaderServiceName, Value: []byte(event.Meta.ServiceName)})
+	}
+	return p.write(ctx, p.writer, topic, key, partition, eventBytes, headers)
+}
+
+// WriteMessage sends an already encoded event, e.g. one stored in an outbox, keyed like
+// SendMessage. It returns once the message is written, also when the producer is async.
+func (p *Producer) WriteMessage(ctx context.Context, topic string, key string, value []byte) error {
+	return p.write(ctx, p.syncWriter, topic, key, -1, value, nil)
+}
+
+func (p *Producer) write(
+	ctx context.Context,
+	writer messageWriter,
+	topic string,
+	key string,
+	partition int,
+	value []byte,
+	headers []kafka.Header,
+) error {
+	headers = propagate(ctx, headers)
+	if p.config.Idempotent && !hasHeader(headers, HeaderMessageID) {
+		headers = append(headers, kafka.Header{Key: HeaderMessageID, Value: []byte(uuid.NewGoogleUUID())})
+	}
+
 	msg := kafka.Message{
-		Topic: topic,
-		Value: eventBytes,
-		Time:  time.Now(),
+		Topic:   topic,
+		Value:   value,
+		Headers: headers,
+		Time:    time.Now(),
 	}
 
 	// Add key if provided
@@ -61,20 +256,83 @@ func (p *Producer) SendMessage(
 		msg.Partition = partition
 	}
 
-	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+	if err := writer.WriteMessages(ctx, msg); err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
-	p.logger.Info("📤 kafka sends",
+	p.sampled.Info("📤 kafka sends",
 		zap.String("topic", topic),
 		zap.String("key", key),
 		zap.Int("partition", msg.Partition),
-		zap.ByteString("event", eventBytes),
+		zap.Int("bytes", len(value)),
+		zap.Bool("async", writer != p.syncWriter),
 	)
 
 	return nil
 }
 
+// complete reports the outcome of a batch written to a partition
+func (p *Producer) complete(messages []kafka.Message, err error) {
+	if err != nil {
+		p.failed.Add(int64(len(messages)))
+		p.sampled.Error("failed to deliver kafka messages",
+			zap.String("topic", messages[0].Topic),
+			zap.Int("messages", len(messages)),
+			zap.Error(err))
+	} else {
+		p.delivered.Add(int64(len(messages)))
+	}
+
+	if p.config.OnDelivery == nil && p.deliveries == nil {
+		return
+	}
+	for _, m := range messages {
+		report := DeliveryReport{
+			Topic:     m.Topic,
+			Partition: m.Partition,
+			Offset:    m.Offset,
+			Key:       m.Key,
+			Headers:   m.Headers,
+			Time:      m.Time,
+			Err:       err,
+		}
+		if p.config.OnDelivery != nil {
+			p.config.OnDelivery(report)
+		}
+		if p.deliveries != nil {
+			select {
+			case p.deliveries <- report:
+			default:
+				p.droppedReports.Add(1)
+			}
+		}
+	}
+}
+
+// Deliveries receives the delivery reports when ProducerConfig.DeliveryReports is set, it is
+// closed by Close
+func (p *Producer) Deliveries() <-chan DeliveryReport {
+	return p.deliveries
+}
+
+func (p *Producer) Stats() ProducerStats {
+	return ProducerStats{
+		Delivered:      p.delivered.Load(),
+		Failed:         p.failed.Load(),
+		DroppedReports: p.droppedReports.Load(),
+	}
+}
+
+// Close flushes the pending batches, reporting their delivery, and closes Deliveries
 func (p *Producer) Close() error {
-	return p.writer.Close()
+	err := p.writer.Close()
+	if p.syncWriter != p.writer {
+		if syncErr := p.syncWriter.Close(); err == nil {
+			err = syncErr
+		}
+	}
+	if p.deliveries != nil {
+		close(p.deliveries)
+	}
+	return err
 }
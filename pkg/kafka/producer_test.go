@@ -0,0 +1,27 @@
+package kafka
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+func TestWritersHashKeys(t *testing.T) {
+	p, err := NewProducerWithConfig([]string{"localhost:9092"}, ProducerConfig{Async: true}, zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = p.Close() }()
+
+	// Both the async writer and the one relaying outboxes keep the messages of a key in order
+	for name, writer := range map[string]messageWriter{"writer": p.writer, "sync writer": p.syncWriter} {
+		w, ok := writer.(*kafka.Writer)
+		if !ok {
+			t.Fatalf("%s is a %T", name, writer)
+		}
+		if _, ok := w.Balancer.(*kafka.Hash); !ok {
+			t.Errorf("%s balances with %T, want the key hash", name, w.Balancer)
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var avroNamePattern = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// avroType is a parsed Avro schema. Named types referenced again share the same node.
+type avroType struct {
+	Type        string
+	Name        string
+	LogicalType string
+	Fields      []*avroField
+	Items       *avroType
+	Values      *avroType
+	Branches    []*avroType
+	Symbols     []string
+}
+
+type avroField struct {
+	Name       string
+	Type       *avroType
+	HasDefault bool
+}
+
+// AvroSerializer encodes Go structs in the Avro binary encoding. Schemas are derived from
+// the struct fields named by their avro or json tag: pointers become unions with null,
+// time.Time a timestamp-millis long, and every field defaults to its zero value so that
+// adding a field keeps the schema backward compatible. Interfaces are not supported.
+type AvroSerializer struct {
+	definitions sync.Map // reflect.Type → string
+	parsed      sync.Map // definition → *avroType
+}
+
+func NewAvroSerializer() *AvroSerializer {
+	return &AvroSerializer{}
+}
+
+func (s *AvroSerializer) Format() Format {
+	return FormatAvro
+}
+
+func (s *AvroSerializer) Definition(v any) (string, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return "", fmt.Errorf("%w: nil value", ErrUnsupported)
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if definition, ok := s.definitions.Load(t); ok {
+		return definition.(string), nil
+	}
+
+	schema, _, _, err := deriveAvro(t, map[reflect.Type]string{})
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	s.definitions.Store(t, string(data))
+	return string(data), nil
+}
+
+func (s *AvroSerializer) Marshal(v any, schema *Schema) ([]byte, error) {
+	t, err := s.parse(schema.Definition)
+	if err != nil {
+		return nil, err
+	}
+	var buf []byte
+	if err := avroEncode(&buf, t, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func (s *AvroSerializer) Unmarshal(data []byte, schema *Schema, v any) error {
+	t, err := s.parse(schema.Definition)
+	if err != nil {
+		return err
+	}
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("%w: decoding into %T, a non-nil pointer is needed", ErrUnsupported, v)
+	}
+
+	d := &avroDecoder{data: data}
+	value, err := d.decode(t)
+	if err != nil {
+		return err
+	}
+	return avroAssign(target.Elem(), value)
+}
+
+func (s *AvroSerializer) CanRead(reader, writer string) error {
+	r, err := s.parse(reader)
+	if err != nil {
+		return fmt.Errorf("invalid reader schema: %w", err)
+	}
+	w, err := s.parse(writer)
+	if err != nil {
+		return fmt.Errorf("invalid writer schema: %w", err)
+	}
+	return avroCanRead(r, w, "$", map[[2]*avroType]bool{})
+}
+
+func (s *AvroSerializer) parse(definition string) (*avroType, error) {
+	if t, ok := s.parsed.Load(definition); ok {
+		return t.(*avroType), nil
+	}
+
+	var raw any
+	if err := json.Unmarshal([]byte(definition), &raw); err != nil {
+		return nil, err
+	}
+	t, err := parseAvro(raw, map[string]*avroType{})
+	if err != nil {
+		return nil, err
+	}
+	s.parsed.Store(definition, t)
+	return t, nil
+}
+
+// deriveAvro returns the schema of the type, and its default when it has one
+func deriveAvro(t reflect.Type, named map[reflect.Type]string) (schema any, def any, hasDefault bool, err error) {
+	if t == timeType {
+		return map[string]any{"type": "long", "logicalType": "timestamp-millis"}, 0, true, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		inner, _, _, err := deriveAvro(t.Elem(), named)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return []any{"null", inner}, nil, true, nil
+	case reflect.Bool:
+		return "boolean", false, true, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return "int", 0, true, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "long", 0, true, nil
+	case reflect.Float32:
+		return "float", 0, true, nil
+	case reflect.Float64:
+		return "double", 0, true, nil
+	case reflect.String:
+		return "string", "", true, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "bytes", "", true, nil
+		}
+		items, _, _, err := deriveAvro(t.Elem(), named)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return map[string]any{"type": "array", "items": items}, []any{}, true, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, nil, false, fmt.Errorf("%w: avro map keys are strings, not %s", ErrUnsupported, t.Key())
+		}
+		values, _, _, err := deriveAvro(t.Elem(), named)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		return map[string]any{"type": "map", "values": values}, map[string]any{}, true, nil
+	case reflect.Struct:
+		if name, ok := named[t]; ok {
+			return name, nil, false, nil
+		}
+		name := avroNamePattern.ReplaceAllString(t.Name(), "_")
+		if name == "" {
+			name = fmt.Sprintf("record%d", len(named)+1)
+		}
+		if namespace := avroNamespace(t.PkgPath()); namespace != "" {
+			name = namespace + "." + name
+		}
+		named[t] = name
+
+		fields := []any{}
+		for _, f := range structFields(t, "avro", "json") {
+			schema, def, hasDefault, err := deriveAvro(t.FieldByIndex(f.index).Type, named)
+			if err != nil {
+				return nil, nil, false, fmt.Errorf("%s.%s: %w", t.Name(), f.name, err)
+			}
+			field := map[string]any{"name": avroNamePattern.ReplaceAllString(f.name, "_"), "type": schema}
+			if hasDefault {
+				field["default"] = def
+			}
+			fields = append(fields, field)
+		}
+		return map[string]any{"type": "record", "name": name, "fields": fields}, nil, false, nil
+	default:
+		return nil, nil, false, fmt.Errorf("%w: %s has no avro type", ErrUnsupported, t)
+	}
+}
+
+func avroNamespace(pkgPath string) string {
+	if pkgPath == "" {
+		return ""
+	}
+	parts := strings.Split(pkgPath, "/")
+	for i, part := range parts {
+		parts[i] = avroNamePattern.ReplaceAllString(part, "_")
+	}
+	return strings.Join(parts, ".")
+}
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true,
+	"float": true, "double": true, "bytes": true, "string": true,
+}
+
+func parseAvro(raw any, named map[string]*avroType) (*avroType, error) {
+	switch v := raw.(type) {
+	case string:
+		if avroPrimitives[v] {
+			return &avroType{Type: v}, nil
+		}
+		if t, ok := named[v]; ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("unknown avro type %q", v)
+	case []any:
+		union := &avroType{Type: "union"}
+		for _, branch := range v {
+			t, err := parseAvro(branch, named)
+			if err != nil {
+				return nil, err
+			}
+			union.Branches = append(union.Branches, t)
+		}
+		return union, nil
+	case map[string]any:
+		typeName, _ := v["type"].(string)
+		switch typeName {
+		case "record":
+			name, _ := v["name"].(string)
+			if namespace, _ := v["namespace"].(string); namespace != "" && !strings.Contains(name, ".") {
+				name = namespace + "." + name
+			}
+			t := &avroType{Type: "record", Name: name}
+			named[name] = t
+			rawFields, _ := v["fields"].([]any)
+			for _, rf := range rawFields {
+				f, ok := rf.(map[string]any)
+				if !ok {
+					return nil, fmt.Errorf("record %s: invalid field %v", name, rf)
+				}
+				fieldName, _ := f["name"].(string)
+				ft, err := parseAvro(f["type"], named)
+				if err != nil {
+					return nil, fmt.Errorf("record %s field %s: %w", name, fieldName, err)
+				}
+				_, hasDefault := f["default"]
+				t.Fields = append(t.Fields, &avroField{Name: fieldName, Type: ft, HasDefault: hasDefault})
+			}
+			return t, nil
+		case "enum":
+			name, _ := v["name"].(string)
+			t := &avroType{Type: "enum", Name: name}
+			symbols, _ := v["symbols"].([]any)
+			for _, symbol := range symbols {
+				s, _ := symbol.(string)
+				t.Symbols = append(t.Symbols, s)
+			}
+			named[name] = t
+			return t, nil
+		case "array":
+			items, err := parseAvro(v["items"], named)
+			if err != nil {
+				return nil, err
+			}
+			return &avroType{Type: "array", Items: items}, nil
+		case "map":
+			values, err := parseAvro(v["values"], named)
+			if err != nil {
+				return nil, err
+			}
+			return &avroType{Type: "map", Values: values}, nil
+		default:
+			t, err := parseAvro(typeName, named)
+			if err != nil {
+				return nil, err
+			}
+			if logicalType, _ := v["logicalType"].(string); logicalType != "" {
+				copied := *t
+				copied.LogicalType = logicalType
+				return &copied, nil
+			}
+			return t, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid avro schema %v", raw)
+	}
+}
+
+// avroPromotions lists the writer types each reader type also reads
+var avroPromotions = map[string][]string{
+	"long":   {"int"},
+	"float":  {"int", "long"},
+	"double": {"int", "long", "float"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+// avroCanRead follows the Avro schema resolution rules
+func avroCanRead(r, w *avroType, path string, visiting map[[2]*avroType]bool) error {
+	pair := [2]*avroType{r, w}
+	if visiting[pair] {
+		return nil
+	}
+	visiting[pair] = true
+
+	if w.Type == "union" {
+		for _, branch := range w.Branches {
+			if err := avroCanRead(r, branch, path, visiting); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if r.Type == "union" {
+		for _, branch := range r.Branches {
+			if avroCanRead(branch, w, path, visiting) == nil {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: no branch of the reader union reads %s", path, w.Type)
+	}
+
+	if r.Type != w.Type {
+		for _, promoted := range avroPromotions[r.Type] {
+			if promoted == w.Type {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: %s is written, %s is read", path, w.Type, r.Type)
+	}
+
+	switch r.Type {
+	case "record":
+		if shortName(r.Name) != shortName(w.Name) {
+			return fmt.Errorf("%s: record %s is written, %s is read", path, w.Name, r.Name)
+		}
+		written := make(map[string]*avroField, len(w.Fields))
+		for _, f := range w.Fields {
+			written[f.Name] = f
+		}
+		for _, rf := range r.Fields {
+			wf, ok := written[rf.Name]
+			if !ok {
+				if !rf.HasDefault {
+					return fmt.Errorf("%s.%s: not written and without default", path, rf.Name)
+				}
+				continue
+			}
+			if err := avroCanRead(rf.Type, wf.Type, path+"."+rf.Name, visiting); err != nil {
+				return err
+			}
+		}
+	case "enum":
+		symbols := make(map[string]bool, len(r.Symbols))
+		for _, s := range r.Symbols {
+			symbols[s] = true
+		}
+		for _, s := range w.Symbols {
+			if !symbols[s] {
+				return fmt.Errorf("%s: symbol %s is written, not read", path, s)
+			}
+		}
+	case "array":
+		return avroCanRead(r.Items, w.Items, path+"[]", visiting)
+	case "map":
+		return avroCanRead(r.Values, w.Values, path+"[*]", visiting)
+	}
+	return nil
+}
+
+func shortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -0,0 +1,421 @@
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// avroEncode appends v in the Avro binary encoding of t
+func avroEncode(buf *[]byte, t *avroType, v reflect.Value) error {
+	if !v.IsValid() && t.Type != "null" && t.Type != "union" {
+		return fmt.Errorf("%w: nil value for avro %s", ErrUnsupported, t.Type)
+	}
+	if t.Type != "union" {
+		for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+			if v.IsNil() {
+				return fmt.Errorf("%w: nil value for avro %s", ErrUnsupported, t.Type)
+			}
+			v = v.Elem()
+		}
+	}
+
+	switch t.Type {
+	case "null":
+		return nil
+	case "boolean":
+		if v.Bool() {
+			*buf = append(*buf, 1)
+		} else {
+			*buf = append(*buf, 0)
+		}
+	case "int", "long":
+		if t.LogicalType == "timestamp-millis" && v.Type() == timeType {
+			appendLong(buf, v.Interface().(time.Time).UnixMilli())
+			return nil
+		}
+		switch {
+		case v.CanInt():
+			appendLong(buf, v.Int())
+		case v.CanUint():
+			appendLong(buf, int64(v.Uint()))
+		default:
+			return fmt.Errorf("%w: %s for avro %s", ErrUnsupported, v.Type(), t.Type)
+		}
+	case "float":
+		*buf = binary.LittleEndian.AppendUint32(*buf, math.Float32bits(float32(v.Float())))
+	case "double":
+		*buf = binary.LittleEndian.AppendUint64(*buf, math.Float64bits(v.Float()))
+	case "string", "bytes":
+		var data []byte
+		if v.Kind() == reflect.String {
+			data = []byte(v.String())
+		} else {
+			data = v.Bytes()
+		}
+		appendLong(buf, int64(len(data)))
+		*buf = append(*buf, data...)
+	case "enum":
+		for i, symbol := range t.Symbols {
+			if symbol == v.String() {
+				appendLong(buf, int64(i))
+				return nil
+			}
+		}
+		return fmt.Errorf("%w: %q is not a symbol of %s", ErrUnsupported, v.String(), t.Name)
+	case "record":
+		fields := make(map[string][]int)
+		for _, f := range structFields(v.Type(), "avro", "json") {
+			fields[avroNamePattern.ReplaceAllString(f.name, "_")] = f.index
+		}
+		for _, f := range t.Fields {
+			index, ok := fields[f.Name]
+			if !ok {
+				return fmt.Errorf("%w: %s has no field %s", ErrUnsupported, v.Type(), f.Name)
+			}
+			if err := avroEncode(buf, f.Type, v.FieldByIndex(index)); err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Name, f.Name, err)
+			}
+		}
+	case "array":
+		if v.Len() > 0 {
+			appendLong(buf, int64(v.Len()))
+			for i := 0; i < v.Len(); i++ {
+				if err := avroEncode(buf, t.Items, v.Index(i)); err != nil {
+					return err
+				}
+			}
+		}
+		appendLong(buf, 0)
+	case "map":
+		if v.Len() > 0 {
+			keys := v.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+			appendLong(buf, int64(len(keys)))
+			for _, key := range keys {
+				appendLong(buf, int64(len(key.String())))
+				*buf = append(*buf, key.String()...)
+				if err := avroEncode(buf, t.Values, v.MapIndex(key)); err != nil {
+					return err
+				}
+			}
+		}
+		appendLong(buf, 0)
+	case "union":
+		isNil := !v.IsValid() || ((v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil())
+		for i, branch := range t.Branches {
+			if (branch.Type == "null") == isNil {
+				appendLong(buf, int64(i))
+				if isNil {
+					return nil
+				}
+				return avroEncode(buf, branch, v)
+			}
+		}
+		return fmt.Errorf("%w: no union branch for %s", ErrUnsupported, v.Type())
+	default:
+		return fmt.Errorf("%w: avro %s", ErrUnsupported, t.Type)
+	}
+	return nil
+}
+
+func appendLong(buf *[]byte, n int64) {
+	*buf = binary.AppendUvarint(*buf, uint64(n<<1)^uint64(n>>63))
+}
+
+// avroDecoder reads values written with a schema into generic values: nil, bool, int64,
+// float64, string, []byte, time.Time, []any and map[string]any for records and maps
+type avroDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *avroDecoder) long() (int64, error) {
+	u, n := binary.Uvarint(d.data[d.pos:])
+	if n <= 0 {
+		return 0, fmt.Errorf("invalid avro long at byte %d", d.pos)
+	}
+	d.pos += n
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (d *avroDecoder) bytes() ([]byte, error) {
+	n, err := d.long()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > int64(len(d.data)-d.pos) {
+		return nil, fmt.Errorf("invalid avro length %d at byte %d", n, d.pos)
+	}
+	data := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return data, nil
+}
+
+func (d *avroDecoder) fixed(n int) ([]byte, error) {
+	if n > len(d.data)-d.pos {
+		return nil, fmt.Errorf("avro data ends at byte %d", len(d.data))
+	}
+	data := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return data, nil
+}
+
+// blockCount reads the item count of the next array or map block
+func (d *avroDecoder) blockCount() (int64, error) {
+	n, err := d.long()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		// A negative count is followed by the size of the block in bytes
+		if _, err := d.long(); err != nil {
+			return 0, err
+		}
+		n = -n
+	}
+	if n > int64(len(d.data)-d.pos) {
+		return 0, fmt.Errorf("invalid avro block count %d at byte %d", n, d.pos)
+	}
+	return n, nil
+}
+
+func (d *avroDecoder) decode(t *avroType) (any, error) {
+	switch t.Type {
+	case "null":
+		return nil, nil
+	case "boolean":
+		b, err := d.fixed(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case "int", "long":
+		n, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if t.LogicalType == "timestamp-millis" {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return n, nil
+	case "float":
+		b, err := d.fixed(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case "double":
+		b, err := d.fixed(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case "string":
+		b, err := d.bytes()
+		return string(b), err
+	case "bytes":
+		b, err := d.bytes()
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case "enum":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(t.Symbols)) {
+			return nil, fmt.Errorf("invalid symbol %d of %s", i, t.Name)
+		}
+		return t.Symbols[i], nil
+	case "record":
+		record := make(map[string]any, len(t.Fields))
+		for _, f := range t.Fields {
+			value, err := d.decode(f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.Name, f.Name, err)
+			}
+			record[f.Name] = value
+		}
+		return record, nil
+	case "array":
+		items := []any{}
+		for {
+			n, err := d.blockCount()
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return items, nil
+			}
+			for ; n > 0; n-- {
+				item, err := d.decode(t.Items)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+	case "map":
+		values := map[string]any{}
+		for {
+			n, err := d.blockCount()
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return values, nil
+			}
+			for ; n > 0; n-- {
+				key, err := d.bytes()
+				if err != nil {
+					return nil, err
+				}
+				value, err := d.decode(t.Values)
+				if err != nil {
+					return nil, err
+				}
+				values[string(key)] = value
+			}
+		}
+	case "union":
+		i, err := d.long()
+		if err != nil {
+			return nil, err
+		}
+		if i < 0 || i >= int64(len(t.Branches)) {
+			return nil, fmt.Errorf("invalid union branch %d", i)
+		}
+		return d.decode(t.Branches[i])
+	default:
+		return nil, fmt.Errorf("%w: avro %s", ErrUnsupported, t.Type)
+	}
+}
+
+// avroAssign stores a decoded value in dst, record fields missing from the data keep their zero value
+func avroAssign(dst reflect.Value, value any) error {
+	if dst.Kind() == reflect.Pointer {
+		if value == nil {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return avroAssign(dst.Elem(), value)
+	}
+	if value == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	if dst.Type() == timeType {
+		switch v := value.(type) {
+		case time.Time:
+			dst.Set(reflect.ValueOf(v))
+		case int64:
+			dst.Set(reflect.ValueOf(time.UnixMilli(v).UTC()))
+		default:
+			return fmt.Errorf("cannot read %T into time.Time", value)
+		}
+		return nil
+	}
+
+	mismatch := fmt.Errorf("cannot read %T into %s", value, dst.Type())
+	switch dst.Kind() {
+	case reflect.Bool:
+		v, ok := value.(bool)
+		if !ok {
+			return mismatch
+		}
+		dst.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, ok := value.(int64)
+		if !ok {
+			return mismatch
+		}
+		dst.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, ok := value.(int64)
+		if !ok {
+			return mismatch
+		}
+		dst.SetUint(uint64(v))
+	case reflect.Float32, reflect.Float64:
+		switch v := value.(type) {
+		case float64:
+			dst.SetFloat(v)
+		case int64:
+			dst.SetFloat(float64(v))
+		default:
+			return mismatch
+		}
+	case reflect.String:
+		switch v := value.(type) {
+		case string:
+			dst.SetString(v)
+		case []byte:
+			dst.SetString(string(v))
+		default:
+			return mismatch
+		}
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() == reflect.Uint8 {
+			switch v := value.(type) {
+			case []byte:
+				dst.SetBytes(v)
+			case string:
+				dst.SetBytes([]byte(v))
+			default:
+				return mismatch
+			}
+			return nil
+		}
+		items, ok := value.([]any)
+		if !ok {
+			return mismatch
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := avroAssign(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+	case reflect.Map:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return mismatch
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(values))
+		for key, item := range values {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := avroAssign(elem, item); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(m)
+	case reflect.Struct:
+		record, ok := value.(map[string]any)
+		if !ok {
+			return mismatch
+		}
+		for _, f := range structFields(dst.Type(), "avro", "json") {
+			item, ok := record[avroNamePattern.ReplaceAllString(f.name, "_")]
+			if !ok {
+				continue
+			}
+			if err := avroAssign(dst.FieldByIndex(f.index), item); err != nil {
+				return fmt.Errorf("%s: %w", f.name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupported, dst.Type())
+	}
+	return nil
+}
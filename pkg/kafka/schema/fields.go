@@ -0,0 +1,66 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+type structField struct {
+	name      string
+	index     []int
+	omitempty bool
+}
+
+// structFields lists the exported fields of the struct under the name of the first tag
+// that sets one, embedded structs without a name are flattened like encoding/json does
+func structFields(t reflect.Type, tags ...string) []structField {
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+
+		name, omitempty, named := "", false, false
+		for _, tag := range tags {
+			value, ok := f.Tag.Lookup(tag)
+			if !ok {
+				continue
+			}
+			parts := strings.Split(value, ",")
+			if parts[0] == "-" && len(parts) == 1 {
+				name = "-"
+				break
+			}
+			for _, option := range parts[1:] {
+				omitempty = omitempty || option == "omitempty"
+			}
+			if parts[0] != "" {
+				name, named = parts[0], true
+				break
+			}
+		}
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && !named && f.Type.Kind() == reflect.Struct {
+			for _, inner := range structFields(f.Type, tags...) {
+				inner.index = append([]int{i}, inner.index...)
+				fields = append(fields, inner)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if !named {
+			name = f.Name
+		}
+		fields = append(fields, structField{name: name, index: []int{i}, omitempty: omitempty})
+	}
+	return fields
+}
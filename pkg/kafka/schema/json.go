@@ -0,0 +1,160 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// jsonSchema is the subset of JSON Schema derived from Go types
+type jsonSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema            `json:"additionalProperties,omitempty"`
+}
+
+// JSONSerializer encodes with encoding/json and describes the types with JSON Schema.
+// Fields that are neither pointers nor omitempty are required.
+type JSONSerializer struct {
+	definitions sync.Map // reflect.Type → string
+}
+
+func NewJSONSerializer() *JSONSerializer {
+	return &JSONSerializer{}
+}
+
+func (s *JSONSerializer) Format() Format {
+	return FormatJSON
+}
+
+func (s *JSONSerializer) Definition(v any) (string, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return "", fmt.Errorf("%w: nil value", ErrUnsupported)
+	}
+	if definition, ok := s.definitions.Load(t); ok {
+		return definition.(string), nil
+	}
+
+	data, err := json.Marshal(deriveJSON(t, map[reflect.Type]bool{}))
+	if err != nil {
+		return "", err
+	}
+	s.definitions.Store(t, string(data))
+	return string(data), nil
+}
+
+func (s *JSONSerializer) Marshal(v any, _ *Schema) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (s *JSONSerializer) Unmarshal(data []byte, _ *Schema, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (s *JSONSerializer) CanRead(reader, writer string) error {
+	var r, w jsonSchema
+	if err := json.Unmarshal([]byte(reader), &r); err != nil {
+		return fmt.Errorf("invalid reader schema: %w", err)
+	}
+	if err := json.Unmarshal([]byte(writer), &w); err != nil {
+		return fmt.Errorf("invalid writer schema: %w", err)
+	}
+	return jsonCanRead(&r, &w, "$")
+}
+
+func deriveJSON(t reflect.Type, visiting map[reflect.Type]bool) *jsonSchema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.String:
+		return &jsonSchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &jsonSchema{Type: "string", Format: "byte"}
+		}
+		return &jsonSchema{Type: "array", Items: deriveJSON(t.Elem(), visiting)}
+	case reflect.Map:
+		return &jsonSchema{Type: "object", AdditionalProperties: deriveJSON(t.Elem(), visiting)}
+	case reflect.Struct:
+		if visiting[t] {
+			// Recursive types are not described past their first level
+			return &jsonSchema{Type: "object", Title: t.Name()}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		schema := &jsonSchema{Type: "object", Title: t.Name(), Properties: map[string]*jsonSchema{}}
+		for _, f := range structFields(t, "json") {
+			ft := t.FieldByIndex(f.index).Type
+			schema.Properties[f.name] = deriveJSON(ft, visiting)
+			if !f.omitempty && ft.Kind() != reflect.Pointer && ft.Kind() != reflect.Interface {
+				schema.Required = append(schema.Required, f.name)
+			}
+		}
+		sort.Strings(schema.Required)
+		return schema
+	default:
+		// Interfaces and other kinds accept any value
+		return &jsonSchema{}
+	}
+}
+
+// jsonCanRead checks that every value valid against the writer schema is valid against the reader schema
+func jsonCanRead(r, w *jsonSchema, path string) error {
+	if r.Type == "" {
+		return nil
+	}
+	if w.Type == "" {
+		return fmt.Errorf("%s: any value is written, %s is read", path, r.Type)
+	}
+	if r.Type != w.Type && !(r.Type == "number" && w.Type == "integer") {
+		return fmt.Errorf("%s: %s is written, %s is read", path, w.Type, r.Type)
+	}
+
+	switch r.Type {
+	case "object":
+		writerRequired := make(map[string]bool, len(w.Required))
+		for _, name := range w.Required {
+			writerRequired[name] = true
+		}
+		for _, name := range r.Required {
+			if !writerRequired[name] {
+				return fmt.Errorf("%s.%s: required by the reader but optional or missing in the writer", path, name)
+			}
+		}
+		for name, rp := range r.Properties {
+			if wp, ok := w.Properties[name]; ok {
+				if err := jsonCanRead(rp, wp, path+"."+name); err != nil {
+					return err
+				}
+			}
+		}
+		if r.AdditionalProperties != nil && w.AdditionalProperties != nil {
+			return jsonCanRead(r.AdditionalProperties, w.AdditionalProperties, path+"[*]")
+		}
+	case "array":
+		if r.Items != nil && w.Items != nil {
+			return jsonCanRead(r.Items, w.Items, path+"[]")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// protobufDefinition is the descriptor of the message, encoded deterministically
+type protobufDefinition struct {
+	Message    string `json:"message"`
+	Descriptor string `json:"descriptor"`
+}
+
+// ProtobufSerializer encodes the messages generated from the proto/ tree. Compatibility is
+// checked on the top level fields by number: a field number kept by both versions has to
+// keep its type and cardinality, fields may be added and removed freely.
+type ProtobufSerializer struct{}
+
+func NewProtobufSerializer() *ProtobufSerializer {
+	return &ProtobufSerializer{}
+}
+
+func (s *ProtobufSerializer) Format() Format {
+	return FormatProtobuf
+}
+
+func (s *ProtobufSerializer) Definition(v any) (string, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("%w: %T is not a proto.Message", ErrUnsupported, v)
+	}
+	descriptor := msg.ProtoReflect().Descriptor()
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(protodesc.ToDescriptorProto(descriptor))
+	if err != nil {
+		return "", err
+	}
+	definition, err := json.Marshal(protobufDefinition{
+		Message:    string(descriptor.FullName()),
+		Descriptor: base64.StdEncoding.EncodeToString(data),
+	})
+	return string(definition), err
+}
+
+func (s *ProtobufSerializer) Marshal(v any, _ *Schema) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a proto.Message", ErrUnsupported, v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (s *ProtobufSerializer) Unmarshal(data []byte, _ *Schema, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: %T is not a proto.Message", ErrUnsupported, v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (s *ProtobufSerializer) CanRead(reader, writer string) error {
+	r, err := parseProtobufDefinition(reader)
+	if err != nil {
+		return fmt.Errorf("invalid reader schema: %w", err)
+	}
+	w, err := parseProtobufDefinition(writer)
+	if err != nil {
+		return fmt.Errorf("invalid writer schema: %w", err)
+	}
+
+	written := make(map[int32]*descriptorpb.FieldDescriptorProto, len(w.GetField()))
+	for _, f := range w.GetField() {
+		written[f.GetNumber()] = f
+	}
+	for _, rf := range r.GetField() {
+		wf, ok := written[rf.GetNumber()]
+		if !ok {
+			continue
+		}
+		if rf.GetType() != wf.GetType() || rf.GetTypeName() != wf.GetTypeName() {
+			return fmt.Errorf("field %d: %s %s is written as %s %s",
+				rf.GetNumber(), rf.GetName(), protobufType(rf), wf.GetName(), protobufType(wf))
+		}
+		if (rf.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) !=
+			(wf.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED) {
+			return fmt.Errorf("field %d: %s changes between repeated and singular", rf.GetNumber(), rf.GetName())
+		}
+	}
+	return nil
+}
+
+func parseProtobufDefinition(definition string) (*descriptorpb.DescriptorProto, error) {
+	var d protobufDefinition
+	if err := json.Unmarshal([]byte(definition), &d); err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(d.Descriptor)
+	if err != nil {
+		return nil, err
+	}
+	var descriptor descriptorpb.DescriptorProto
+	if err := proto.Unmarshal(data, &descriptor); err != nil {
+		return nil, err
+	}
+	return &descriptor, nil
+}
+
+func protobufType(f *descriptorpb.FieldDescriptorProto) string {
+	if f.GetTypeName() != "" {
+		return f.GetTypeName()
+	}
+	return f.GetType().String()
+}
@@ -0,0 +1,122 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Registry stores the schema versions of the subjects
+type Registry interface {
+	// Register returns the version of the subject with the definition, adding it when it is
+	// new and compatible with the latest version
+	Register(ctx context.Context, subject string, format Format, definition string) (*Schema, error)
+	// ByID returns the schema with the id
+	ByID(ctx context.Context, id uint32) (*Schema, error)
+	// Latest returns the latest version of the subject
+	Latest(ctx context.Context, subject string) (*Schema, error)
+}
+
+// MemoryRegistry is a Registry local to the process. Ids are only meaningful within the
+// process, so producers and consumers of a topic have to share the registry, which makes it
+// fit for tests and single service topics.
+type MemoryRegistry struct {
+	mu            sync.RWMutex
+	compatibility Compatibility
+	serializers   map[Format]Serializer
+	byID          map[uint32]*Schema
+	subjects      map[string][]*Schema
+	nextID        uint32
+}
+
+// NewMemoryRegistry checks the compatibility of new versions with the serializer of their format
+func NewMemoryRegistry(compatibility Compatibility, serializers ...Serializer) *MemoryRegistry {
+	r := &MemoryRegistry{
+		compatibility: compatibility,
+		serializers:   make(map[Format]Serializer, len(serializers)),
+		byID:          make(map[uint32]*Schema),
+		subjects:      make(map[string][]*Schema),
+	}
+	for _, s := range serializers {
+		r.serializers[s.Format()] = s
+	}
+	return r
+}
+
+func (r *MemoryRegistry) Register(_ context.Context, subject string, format Format, definition string) (*Schema, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := r.subjects[subject]
+	for _, s := range versions {
+		if s.Format == format && s.Definition == definition {
+			return s, nil
+		}
+	}
+	if len(versions) > 0 {
+		if err := r.checkCompatibility(versions[len(versions)-1], format, definition); err != nil {
+			return nil, err
+		}
+	}
+
+	r.nextID++
+	s := &Schema{
+		ID:         r.nextID,
+		Subject:    subject,
+		Version:    len(versions) + 1,
+		Format:     format,
+		Definition: definition,
+	}
+	r.byID[s.ID] = s
+	r.subjects[subject] = append(versions, s)
+	return s, nil
+}
+
+func (r *MemoryRegistry) checkCompatibility(latest *Schema, format Format, definition string) error {
+	if r.compatibility == CompatibilityNone || r.compatibility == "" {
+		return nil
+	}
+	if latest.Format != format {
+		return fmt.Errorf("%w: %s is %s, not %s", ErrIncompatible, latest.Subject, latest.Format, format)
+	}
+	serializer, ok := r.serializers[format]
+	if !ok {
+		return fmt.Errorf("%w: no %s serializer to check %s", ErrUnsupported, format, latest.Subject)
+	}
+
+	if r.compatibility == CompatibilityBackward || r.compatibility == CompatibilityFull {
+		if err := serializer.CanRead(definition, latest.Definition); err != nil {
+			return fmt.Errorf("%w: %s version %d cannot be read with the new schema: %v",
+				ErrIncompatible, latest.Subject, latest.Version, err)
+		}
+	}
+	if r.compatibility == CompatibilityForward || r.compatibility == CompatibilityFull {
+		if err := serializer.CanRead(latest.Definition, definition); err != nil {
+			return fmt.Errorf("%w: %s version %d cannot read the new schema: %v",
+				ErrIncompatible, latest.Subject, latest.Version, err)
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRegistry) ByID(_ context.Context, id uint32) (*Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.byID[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: schema id %d", ErrNotFound, id)
+	}
+	return s, nil
+}
+
+func (r *MemoryRegistry) Latest(_ context.Context, subject string) (*Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := r.subjects[subject]
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: subject %s", ErrNotFound, subject)
+	}
+	return versions[len(versions)-1], nil
+}
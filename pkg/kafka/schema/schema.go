@@ -0,0 +1,84 @@
+// Package schema serializes Kafka payloads against versioned schemas.
+//
+// A Serializer derives the schema of a Go type in its format (JSON Schema, a Protobuf
+// descriptor or an Avro record), encodes values with it and checks whether data written
+// with one schema can be read with another. Schemas are registered per subject in a
+// Registry, which assigns the ids carried in the message headers and rejects versions that
+// break the compatibility mode of the registry.
+package schema
+
+import "errors"
+
+var (
+	// ErrIncompatible is returned when a schema breaks the compatibility mode of the registry
+	ErrIncompatible = errors.New("schema: incompatible")
+	// ErrNotFound is returned for unknown schema ids and subjects
+	ErrNotFound = errors.New("schema: not found")
+	// ErrUnsupported is returned for values the serializer cannot describe or encode
+	ErrUnsupported = errors.New("schema: unsupported")
+)
+
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatProtobuf Format = "protobuf"
+	FormatAvro     Format = "avro"
+)
+
+// ContentType is the content-type header of the payloads in the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatProtobuf:
+		return "application/x-protobuf"
+	case FormatAvro:
+		return "application/avro"
+	default:
+		return "application/json"
+	}
+}
+
+// FormatOf is the format of a content-type header, JSON when it is unknown
+func FormatOf(contentType string) Format {
+	for _, f := range []Format{FormatProtobuf, FormatAvro} {
+		if f.ContentType() == contentType {
+			return f
+		}
+	}
+	return FormatJSON
+}
+
+// Schema is a registered version of the schema of a subject
+type Schema struct {
+	ID         uint32
+	Subject    string
+	Version    int
+	Format     Format
+	Definition string
+}
+
+type Serializer interface {
+	Format() Format
+	// Definition describes the schema of v, the same type always gives the same definition
+	Definition(v any) (string, error)
+	// Marshal encodes v with the schema
+	Marshal(v any, s *Schema) ([]byte, error)
+	// Unmarshal decodes data written with the schema into the pointer v
+	Unmarshal(data []byte, s *Schema, v any) error
+	// CanRead returns why data written with the writer definition cannot be read with the
+	// reader definition, nil when it can
+	CanRead(reader, writer string) error
+}
+
+// Compatibility is the rule a new schema version has to follow against the latest one
+type Compatibility string
+
+const (
+	CompatibilityNone Compatibility = "NONE"
+	// CompatibilityBackward lets consumers on the new schema read data written with the latest one
+	CompatibilityBackward Compatibility = "BACKWARD"
+	// CompatibilityForward lets consumers on the latest schema read data written with the new one
+	CompatibilityForward Compatibility = "FORWARD"
+	// CompatibilityFull is backward and forward at once
+	CompatibilityFull Compatibility = "FULL"
+)
@@ -0,0 +1,243 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	notificationv1 "simple-securities/gen/notification/v1"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type orderV1 struct {
+	ID       uint64    `json:"id"`
+	Symbol   string    `json:"symbol"`
+	Quantity int32     `json:"quantity"`
+	Price    float32   `json:"price"`
+	Tags     []string  `json:"tags"`
+	Fills    []fill    `json:"fills"`
+	Note     *string   `json:"note"`
+	Meta     meta      `json:"meta"`
+	PlacedAt time.Time `json:"placed_at"`
+}
+
+type fill struct {
+	Quantity int64   `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+type meta struct {
+	Source string            `json:"source"`
+	Labels map[string]string `json:"labels"`
+}
+
+// orderV2 widens quantity and price, drops tags and adds a venue
+type orderV2 struct {
+	ID       uint64    `json:"id"`
+	Symbol   string    `json:"symbol"`
+	Quantity int64     `json:"quantity"`
+	Price    float64   `json:"price"`
+	Fills    []fill    `json:"fills"`
+	Note     *string   `json:"note"`
+	Meta     meta      `json:"meta"`
+	PlacedAt time.Time `json:"placed_at"`
+	Venue    string    `json:"venue"`
+}
+
+func sampleOrder() orderV1 {
+	note := "iceberg"
+	return orderV1{
+		ID:       42,
+		Symbol:   "AAPL",
+		Quantity: -7,
+		Price:    189.5,
+		Tags:     []string{"limit", "day"},
+		Fills:    []fill{{Quantity: 3, Price: 189.25}, {Quantity: 4, Price: 189.5}},
+		Note:     &note,
+		Meta:     meta{Source: "api", Labels: map[string]string{"desk": "eq", "region": "us"}},
+		PlacedAt: time.Date(2026, 3, 2, 14, 30, 0, 123e6, time.UTC),
+	}
+}
+
+func register(t *testing.T, r Registry, s Serializer, subject string, v any) *Schema {
+	t.Helper()
+	definition, err := s.Definition(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered, err := r.Register(context.Background(), subject, s.Format(), definition)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return registered
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, s := range []Serializer{NewJSONSerializer(), NewAvroSerializer()} {
+		t.Run(string(s.Format()), func(t *testing.T) {
+			r := NewMemoryRegistry(CompatibilityBackward, s)
+			writer := register(t, r, s, "orders-value", orderV1{})
+
+			want := sampleOrder()
+			data, err := s.Marshal(want, writer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got orderV1
+			if err := s.Unmarshal(data, writer, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("got %+v, want %+v", got, want)
+			}
+
+			var empty orderV1
+			data, err = s.Marshal(orderV1{}, writer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Unmarshal(data, writer, &empty); err != nil {
+				t.Fatal(err)
+			}
+			if empty.Note != nil || empty.ID != 0 {
+				t.Fatalf("zero order read as %+v", empty)
+			}
+		})
+	}
+}
+
+func TestProtobufRoundTrip(t *testing.T) {
+	s := NewProtobufSerializer()
+	r := NewMemoryRegistry(CompatibilityFull, s)
+	writer := register(t, r, s, "notifications-value", &notificationv1.SendRequest{})
+
+	want := &notificationv1.SendRequest{UserId: 7, Type: "fill", Title: "Order filled", Body: "3 AAPL at 189.25"}
+	data, err := s.Marshal(want, writer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &notificationv1.SendRequest{}
+	if err := s.Unmarshal(data, writer, got); err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := s.Definition(orderV1{}); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("definition of a plain struct: %v", err)
+	}
+	// Another message reusing field 1 with another type
+	other, err := s.Definition(&notificationv1.GetResponse{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Register(context.Background(), "notifications-value", FormatProtobuf, other); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("registering a conflicting message: %v", err)
+	}
+}
+
+// orderVersion returns the given version of a type named order, as the record names of the
+// versions of a subject have to match
+func orderVersion(version int) any {
+	if version == 1 {
+		type order orderV1
+		return order(sampleOrder())
+	}
+	type order orderV2
+	return order{}
+}
+
+func TestAvroSchemaEvolution(t *testing.T) {
+	s := NewAvroSerializer()
+	r := NewMemoryRegistry(CompatibilityBackward, s)
+	v1 := register(t, r, s, "orders-value", orderVersion(1))
+	v2 := register(t, r, s, "orders-value", orderVersion(2))
+	if v2.Version != 2 || v2.ID == v1.ID {
+		t.Fatalf("second version registered as %+v", v2)
+	}
+
+	data, err := s.Marshal(orderVersion(1), v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Data written with version 1 is read into the version 2 type
+	var got orderV2
+	if err := s.Unmarshal(data, v1, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Quantity != -7 || got.Price != 189.5 || len(got.Fills) != 2 || got.Venue != "" || *got.Note != "iceberg" {
+		t.Fatalf("version 1 read as %+v", got)
+	}
+
+	// Narrowing quantity back cannot read the long written by version 2
+	if err := s.CanRead(v1.Definition, v2.Definition); err == nil {
+		t.Fatal("int reads long")
+	}
+	// Records of another name are not versions of each other
+	if err := s.CanRead(v1.Definition, register(t, r, s, "fills-value", fill{}).Definition); err == nil {
+		t.Fatal("order reads fill")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	s := NewJSONSerializer()
+	r := NewMemoryRegistry(CompatibilityBackward, s)
+
+	first := register(t, r, s, "orders-value", orderV1{})
+	again := register(t, r, s, "orders-value", orderV1{})
+	if again.ID != first.ID || again.Version != 1 {
+		t.Fatalf("same definition registered again as %+v", again)
+	}
+
+	type renamed struct {
+		Symbol string `json:"ticker"`
+		Count  string `json:"quantity"`
+	}
+	definition, err := s.Definition(renamed{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Register(ctx, "orders-value", FormatJSON, definition); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("registering an incompatible version: %v", err)
+	}
+	if _, err := r.Register(ctx, "orders-value", FormatAvro, `"string"`); !errors.Is(err, ErrIncompatible) {
+		t.Fatalf("registering another format: %v", err)
+	}
+
+	// Another subject is checked on its own
+	other := register(t, r, s, "fills-value", renamed{})
+	if other.Version != 1 {
+		t.Fatalf("first version of another subject is %d", other.Version)
+	}
+
+	latest, err := r.Latest(ctx, "orders-value")
+	if err != nil || latest.ID != first.ID {
+		t.Fatalf("latest is %+v, %v", latest, err)
+	}
+	byID, err := r.ByID(ctx, other.ID)
+	if err != nil || byID.Subject != "fills-value" {
+		t.Fatalf("schema %d is %+v, %v", other.ID, byID, err)
+	}
+	if _, err := r.ByID(ctx, 99); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("unknown id: %v", err)
+	}
+	if _, err := r.Latest(ctx, "unknown-value"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("unknown subject: %v", err)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	for _, f := range []Format{FormatJSON, FormatProtobuf, FormatAvro} {
+		if got := FormatOf(f.ContentType()); got != f {
+			t.Fatalf("format of %s is %s", f.ContentType(), got)
+		}
+	}
+	if got := FormatOf("text/plain"); got != FormatJSON {
+		t.Fatalf("format of text/plain is %s", got)
+	}
+}
@@ -0,0 +1,167 @@
+package kafka
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers propagating the request across services
+const (
+	HeaderTraceparent = "traceparent"
+	HeaderRequestID   = "request-id"
+	HeaderEventType   = "event-type"
+	HeaderServiceName = "service-name"
+)
+
+// TraceContext is the W3C trace context of a message, see https://www.w3.org/TR/trace-context/
+type TraceContext struct {
+	TraceID string // 32 lowercase hex digits
+	SpanID  string // 16 lowercase hex digits
+	Flags   byte
+}
+
+const traceFlagSampled byte = 0x01
+
+// NewTraceContext starts a sampled trace
+func NewTraceContext() TraceContext {
+	return TraceContext{TraceID: randomHex(16), SpanID: randomHex(8), Flags: traceFlagSampled}
+}
+
+// ParseTraceparent reads a traceparent header of version 00, later versions are read by
+// their first four fields as the specification asks
+func ParseTraceparent(traceparent string) (TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceparent), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", traceparent)
+	}
+	if !isHex(parts[0]) || !isHex(parts[1]) || len(parts[1]) != 32 || !isHex(parts[2]) || len(parts[2]) != 16 ||
+		!isHex(parts[3]) || len(parts[3]) != 2 {
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", traceparent)
+	}
+	flags, _ := hex.DecodeString(parts[3])
+	tc := TraceContext{TraceID: parts[1], SpanID: parts[2], Flags: flags[0]}
+	if !tc.IsValid() {
+		return TraceContext{}, fmt.Errorf("invalid traceparent %q", traceparent)
+	}
+	return tc, nil
+}
+
+// IsValid reports whether the ids are set, all zero ids are invalid
+func (t TraceContext) IsValid() bool {
+	return len(t.TraceID) == 32 && t.TraceID != strings.Repeat("0", 32) &&
+		len(t.SpanID) == 16 && t.SpanID != strings.Repeat("0", 16)
+}
+
+func (t TraceContext) Sampled() bool {
+	return t.Flags&traceFlagSampled != 0
+}
+
+// Child is a new span of the same trace
+func (t TraceContext) Child() TraceContext {
+	return TraceContext{TraceID: t.TraceID, SpanID: randomHex(8), Flags: t.Flags}
+}
+
+// Traceparent formats the trace context as a version 00 traceparent header
+func (t TraceContext) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-%02x", t.TraceID, t.SpanID, t.Flags)
+}
+
+type traceKey struct{}
+
+type requestIDKey struct{}
+
+func WithTraceContext(ctx context.Context, tc TraceContext) context.Context {
+	return context.WithValue(ctx, traceKey{}, tc)
+}
+
+// TraceContextFrom returns the trace context of the message being handled, or the one set
+// with WithTraceContext
+func TraceContextFrom(ctx context.Context) (TraceContext, bool) {
+	tc, ok := ctx.Value(traceKey{}).(TraceContext)
+	return tc, ok && tc.IsValid()
+}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFrom returns the request id of the message being handled, or the one set with
+// WithRequestID
+func RequestIDFrom(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// propagate adds the trace context and request id of ctx to headers, unless they are set
+// already. Messages sent outside of a trace start a new one.
+func propagate(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	if !hasHeader(headers, HeaderTraceparent) {
+		tc, ok := TraceContextFrom(ctx)
+		if ok {
+			tc = tc.Child()
+		} else {
+			tc = NewTraceContext()
+		}
+		headers = append(headers, kafka.Header{Key: HeaderTraceparent, Value: []byte(tc.Traceparent())})
+	}
+	if requestID := RequestIDFrom(ctx); requestID != "" && !hasHeader(headers, HeaderRequestID) {
+		headers = append(headers, kafka.Header{Key: HeaderRequestID, Value: []byte(requestID)})
+	}
+	return headers
+}
+
+// extract returns ctx with the trace context and request id of the message
+func extract(ctx context.Context, m *Message) context.Context {
+	if tc, ok := m.TraceContext(); ok {
+		ctx = WithTraceContext(ctx, tc)
+	}
+	if requestID := m.RequestID(); requestID != "" {
+		ctx = WithRequestID(ctx, requestID)
+	}
+	return ctx
+}
+
+// eventRequestID is the meta.request_id of a JSON encoded Event
+func eventRequestID(value []byte) string {
+	var event struct {
+		Meta struct {
+			RequestID string `json:"request_id"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(value, &event); err != nil {
+		return ""
+	}
+	return event.Meta.RequestID
+}
+
+func hasHeader(headers []kafka.Header, key string) bool {
+	for _, h := range headers {
+		if h.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("kafka: failed to generate trace id: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+// Package outbox publishes Kafka events written in the same SQL transaction as the state
+// change they describe.
+//
+// A repository enqueues the event with the transaction of its write, so the event is stored
+// if and only if the change commits. The Relay then publishes the stored events in order and
+// marks them dispatched. A crash between publishing and marking publishes the event again,
+// delivery is at least once and consumers deduplicate on the message uuid, which is also the
+// request id of the event.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"simple-securities/pkg/kafka"
+	"simple-securities/pkg/uuid"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Table holds the outbox of a service database, see migrations/sqlite/000006_init_outbox.up.sql
+const Table = "outbox_messages"
+
+type Message struct {
+	ID           uint64     `db:"id"`
+	Uuid         string     `db:"uuid"`
+	Topic        string     `db:"topic"`
+	Key          string     `db:"message_key"`
+	Payload      []byte     `db:"payload"`
+	Attempts     uint32     `db:"attempts"`
+	LastError    string     `db:"last_error"`
+	CreatedAt    time.Time  `db:"created_at"`
+	DispatchedAt *time.Time `db:"dispatched_at"`
+}
+
+func (m Message) TableName() string {
+	return Table
+}
+
+// NewMessage encodes the event for the topic. The request id of the event identifies the
+// message, one is generated when the event has none.
+func NewMessage(topic, key string, event kafka.Event) (*Message, error) {
+	if topic == "" {
+		return nil, fmt.Errorf("outbox message needs a topic")
+	}
+	if event.Meta.RequestID == "" {
+		event.Meta.RequestID = uuid.NewGoogleUUID()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal outbox event: %w", err)
+	}
+	return &Message{
+		Uuid:      event.Meta.RequestID,
+		Topic:     topic,
+		Key:       key,
+		Payload:   payload,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// NewEvent wraps the data in the event envelope the services publish
+func NewEvent(serviceName, message string, data any) kafka.Event {
+	now := time.Now()
+	return kafka.Event{
+		Meta: kafka.Meta{
+			ServiceName: serviceName,
+			RequestID:   uuid.NewGoogleUUID(),
+			Code:        200,
+			Message:     message,
+			Timestamp:   now.Unix(),
+			Datetime:    now.Format("2006-01-02 15:04:05"),
+		},
+		Data: data,
+	}
+}
+
+// Enqueue stores the message with tx, the transaction of the write the event belongs to
+func Enqueue(ctx context.Context, tx sqlx.ExtContext, msg *Message) error {
+	msg.CreatedAt = msg.CreatedAt.UTC()
+	_, err := sqlx.NamedExecContext(ctx, tx, `
+		INSERT INTO `+Table+` (uuid, topic, message_key, payload, created_at)
+		VALUES (:uuid, :topic, :message_key, :payload, :created_at)
+	`, msg)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue outbox message: %w", err)
+	}
+	return nil
+}
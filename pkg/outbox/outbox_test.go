@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"simple-securities/pkg/kafka"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"go.uber.org/zap"
+)
+
+type published struct {
+	topic, key string
+	value      []byte
+}
+
+type fakePublisher struct {
+	messages []published
+	failOn   int // fails the n-th call from 1, zero never fails
+	calls    int
+}
+
+func (p *fakePublisher) WriteMessage(_ context.Context, topic string, key string, value []byte) error {
+	p.calls++
+	if p.calls == p.failOn {
+		return errors.New("broker unavailable")
+	}
+	p.messages = append(p.messages, published{topic, key, value})
+	return nil
+}
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	db, err := sqlx.Connect("sqlite3", "file:"+filepath.Join(t.TempDir(), "outbox.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	schema, err := os.ReadFile("../../migrations/sqlite/000006_init_outbox.up.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.MustExec(string(schema))
+	return db
+}
+
+func enqueue(t *testing.T, db *sqlx.DB, key string, commit bool) *Message {
+	msg, err := NewMessage("events", key, NewEvent("test-service", "created", map[string]string{"key": key}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := db.MustBegin()
+	if err := Enqueue(context.Background(), tx, msg); err != nil {
+		t.Fatal(err)
+	}
+	if commit {
+		err = tx.Commit()
+	} else {
+		err = tx.Rollback()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func TestEnqueueFollowsTransaction(t *testing.T) {
+	db := newTestDB(t)
+	committed := enqueue(t, db, "a", true)
+	enqueue(t, db, "b", false)
+
+	var uuids []string
+	if err := db.Select(&uuids, `SELECT uuid FROM `+Table); err != nil {
+		t.Fatal(err)
+	}
+	if len(uuids) != 1 || uuids[0] != committed.Uuid {
+		t.Fatalf("outbox holds %v, want only %s", uuids, committed.Uuid)
+	}
+}
+
+func TestDispatchInOrder(t *testing.T) {
+	db := newTestDB(t)
+	var want []string
+	for _, key := range []string{"a", "b", "a", "c", "b"} {
+		want = append(want, enqueue(t, db, key, true).Uuid)
+	}
+
+	publisher := &fakePublisher{}
+	relay := NewRelay(db, publisher, RelayConfig{BatchSize: 2}, zap.NewNop())
+	n, err := relay.Dispatch(context.Background())
+	if err != nil || n != 5 {
+		t.Fatalf("Dispatch() = %d, %v, want 5", n, err)
+	}
+	for i, m := range publisher.messages {
+		if m.topic != "events" || !strings.Contains(string(m.value), `"request_id":"`+want[i]+`"`) {
+			t.Fatalf("message %d = %s, want %s", i, m.value, want[i])
+		}
+	}
+
+	if n, err := relay.Dispatch(context.Background()); err != nil || n != 0 {
+		t.Fatalf("second Dispatch() = %d, %v, want nothing left", n, err)
+	}
+	stats, err := relay.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Pending != 0 || stats.Lag != 0 || stats.Dispatched != 5 || stats.LastDispatchedAt.IsZero() {
+		t.Fatalf("Stats() = %+v", stats)
+	}
+}
+
+func TestDispatchStopsAtFailure(t *testing.T) {
+	db := newTestDB(t)
+	for _, key := range []string{"a", "b", "c"} {
+		enqueue(t, db, key, true)
+	}
+
+	publisher := &fakePublisher{failOn: 2}
+	relay := NewRelay(db, publisher, RelayConfig{}, zap.NewNop())
+	n, err := relay.Dispatch(context.Background())
+	if err == nil || n != 1 {
+		t.Fatalf("Dispatch() = %d, %v, want 1 and the publish error", n, err)
+	}
+
+	var failed Message
+	if err := db.Get(&failed, `SELECT * FROM `+Table+` WHERE message_key = 'b'`); err != nil {
+		t.Fatal(err)
+	}
+	if failed.Attempts != 1 || failed.LastError == "" || failed.DispatchedAt != nil {
+		t.Fatalf("failed message = %+v", failed)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	stats, err := relay.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Pending != 2 || stats.Lag <= 0 || stats.Failures != 1 {
+		t.Fatalf("Stats() = %+v, want 2 pending with lag", stats)
+	}
+
+	if n, err := relay.Dispatch(context.Background()); err != nil || n != 2 {
+		t.Fatalf("retry Dispatch() = %d, %v, want 2", n, err)
+	}
+	keys := ""
+	for _, m := range publisher.messages {
+		keys += m.key
+	}
+	if keys != "abc" {
+		t.Fatalf("published keys %q, want %q", keys, "abc")
+	}
+}
+
+func TestRelayToConsumer(t *testing.T) {
+	db := newTestDB(t)
+	var want []string
+	for _, key := range []string{"a", "b", "a"} {
+		want = append(want, enqueue(t, db, key, true).Uuid)
+	}
+
+	bus := kafka.NewMemoryBus(1, zap.NewNop())
+	defer bus.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	if err := bus.AddConsumer("events", "test-group", func(ctx context.Context, msg *kafka.Message) error {
+		got = append(got, kafka.RequestIDFrom(ctx))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bus.StartAllConsumers(ctx)
+
+	relay := NewRelay(db, bus, RelayConfig{}, zap.NewNop())
+	if n, err := relay.Dispatch(ctx); err != nil || n != 3 {
+		t.Fatalf("Dispatch() = %d, %v, want 3", n, err)
+	}
+	if err := bus.WaitCommitted(ctx, "test-group", "events"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("consumed request ids %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,223 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"simple-securities/pkg/kafka"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval      = 500 * time.Millisecond
+	defaultBatchSize     = 100
+	defaultStatsInterval = 30 * time.Second
+	maxLastErrorLength   = 500
+)
+
+// Publisher is the part of kafka.Producer the relay needs, every kafka.MessageBus has it as well
+type Publisher interface {
+	WriteMessage(ctx context.Context, topic string, key string, value []byte) error
+}
+
+type RelayConfig struct {
+	// Interval between two polls of the outbox
+	Interval time.Duration
+	// BatchSize is the number of messages published per poll
+	BatchSize int
+	// Retention is how long dispatched messages are kept, zero keeps them forever
+	Retention time.Duration
+	// StatsInterval is how often the relay lag is logged and old messages purged
+	StatsInterval time.Duration
+}
+
+// Stats describes how far the relay is behind the outbox
+type Stats struct {
+	// Pending is the number of messages not dispatched yet
+	Pending uint64
+	// Lag is the age of the oldest pending message, zero when the outbox is drained
+	Lag time.Duration
+	// Dispatched and Failures count the publish attempts since the relay started
+	Dispatched uint64
+	Failures   uint64
+	// LastDispatchedAt is the time of the last successful publish
+	LastDispatchedAt time.Time
+}
+
+// Relay publishes the outbox in insertion order. A message that fails to publish stops the
+// poll, so a later message with the same key never overtakes it; it is retried on the next
+// poll with its attempts and last error recorded.
+type Relay struct {
+	db        *sqlx.DB
+	publisher Publisher
+	config    RelayConfig
+	logger    *zap.Logger
+
+	dispatched       atomic.Uint64
+	failures         atomic.Uint64
+	lastDispatchedAt atomic.Int64
+}
+
+func NewRelay(db *sqlx.DB, publisher Publisher, config RelayConfig, logger *zap.Logger) *Relay {
+	if config.Interval <= 0 {
+		config.Interval = defaultInterval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultBatchSize
+	}
+	if config.StatsInterval <= 0 {
+		config.StatsInterval = defaultStatsInterval
+	}
+	return &Relay{
+		db:        db,
+		publisher: publisher,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// Start runs the relay until ctx is cancelled
+func (r *Relay) Start(ctx context.Context) error {
+	r.logger.Info("📮 outbox relay started",
+		zap.Duration("interval", r.config.Interval),
+		zap.Int("batch_size", r.config.BatchSize))
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	statsTicker := time.NewTicker(r.config.StatsInterval)
+	defer statsTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Info("outbox relay stopped")
+			return nil
+		case <-ticker.C:
+			if _, err := r.Dispatch(ctx); err != nil && ctx.Err() == nil {
+				r.logger.Error("failed to relay outbox", zap.Error(err))
+			}
+		case <-statsTicker.C:
+			r.report(ctx)
+		}
+	}
+}
+
+// Dispatch publishes the pending messages until the outbox is drained or a publish fails and
+// returns the number of messages dispatched
+func (r *Relay) Dispatch(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		var messages []*Message
+		err := r.db.SelectContext(ctx, &messages, `
+			SELECT id, uuid, topic, message_key, payload, attempts, last_error, created_at, dispatched_at
+			FROM `+Table+`
+			WHERE dispatched_at IS NULL
+			ORDER BY id
+			LIMIT $1
+		`, r.config.BatchSize)
+		if err != nil {
+			return dispatched, err
+		}
+
+		for _, m := range messages {
+			if err := r.publisher.WriteMessage(kafka.WithRequestID(ctx, m.Uuid), m.Topic, m.Key, m.Payload); err != nil {
+				r.failures.Add(1)
+				r.markFailed(ctx, m, err)
+				return dispatched, err
+			}
+			r.dispatched.Add(1)
+			r.lastDispatchedAt.Store(time.Now().UnixNano())
+
+			// Not marking the message publishes it again on the next poll, which at least once allows
+			if _, err := r.db.ExecContext(ctx, `
+				UPDATE `+Table+` SET dispatched_at = $1, attempts = attempts + 1 WHERE id = $2
+			`, time.Now().UTC(), m.ID); err != nil {
+				return dispatched, err
+			}
+			dispatched++
+		}
+
+		if len(messages) < r.config.BatchSize {
+			return dispatched, nil
+		}
+	}
+}
+
+func (r *Relay) markFailed(ctx context.Context, m *Message, cause error) {
+	lastError := cause.Error()
+	if len(lastError) > maxLastErrorLength {
+		lastError = lastError[:maxLastErrorLength]
+	}
+	if _, err := r.db.ExecContext(ctx, `
+		UPDATE `+Table+` SET attempts = attempts + 1, last_error = $1 WHERE id = $2
+	`, lastError, m.ID); err != nil {
+		r.logger.Error("failed to record outbox failure", zap.String("uuid", m.Uuid), zap.Error(err))
+	}
+	r.logger.Warn("failed to publish outbox message",
+		zap.String("uuid", m.Uuid),
+		zap.String("topic", m.Topic),
+		zap.Uint32("attempts", m.Attempts+1),
+		zap.Error(cause))
+}
+
+// Stats measures the relay lag from the pending messages in the outbox
+func (r *Relay) Stats(ctx context.Context) (Stats, error) {
+	stats := Stats{
+		Dispatched: r.dispatched.Load(),
+		Failures:   r.failures.Load(),
+	}
+	if at := r.lastDispatchedAt.Load(); at > 0 {
+		stats.LastDispatchedAt = time.Unix(0, at)
+	}
+
+	if err := r.db.GetContext(ctx, &stats.Pending,
+		`SELECT COUNT(*) FROM `+Table+` WHERE dispatched_at IS NULL`); err != nil {
+		return stats, err
+	}
+
+	var oldest time.Time
+	err := r.db.GetContext(ctx, &oldest, `
+		SELECT created_at FROM `+Table+` WHERE dispatched_at IS NULL ORDER BY id LIMIT 1
+	`)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return stats, err
+	}
+	if err == nil {
+		stats.Lag = max(time.Since(oldest), 0)
+	}
+	return stats, nil
+}
+
+// report logs the relay lag and purges the messages dispatched before the retention period
+func (r *Relay) report(ctx context.Context) {
+	stats, err := r.Stats(ctx)
+	if err != nil {
+		r.logger.Error("failed to measure outbox lag", zap.Error(err))
+	} else {
+		r.logger.Info("📊 outbox relay",
+			zap.Uint64("pending", stats.Pending),
+			zap.Duration("lag", stats.Lag),
+			zap.Uint64("dispatched", stats.Dispatched),
+			zap.Uint64("failures", stats.Failures),
+			zap.Time("last_dispatched_at", stats.LastDispatchedAt))
+	}
+
+	if r.config.Retention <= 0 {
+		return
+	}
+	result, err := r.db.ExecContext(ctx, `
+		DELETE FROM `+Table+` WHERE dispatched_at IS NOT NULL AND dispatched_at < $1
+	`, time.Now().Add(-r.config.Retention).UTC())
+	if err != nil {
+		r.logger.Error("failed to purge outbox", zap.Error(err))
+		return
+	}
+	if purged, _ := result.RowsAffected(); purged > 0 {
+		r.logger.Debug("purged dispatched outbox messages", zap.Int64("count", purged))
+	}
+}
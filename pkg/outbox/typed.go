@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"simple-securities/pkg/kafka"
+)
+
+// typedPublisher relays the messages of one topic as values of T, see NewTypedPublisher
+type typedPublisher[T any] struct {
+	bus   kafka.MessageBus
+	topic string
+}
+
+// NewTypedPublisher relays the messages of topic through kafka.Publish as values of T, so that
+// the schema of T is registered for the topic by the codec of the bus and the consumers read
+// them with kafka.Subscribe[T]. The outbox keeps the events as JSON, they are decoded into T
+// on the way out; the messages of the other topics are written as stored.
+func NewTypedPublisher[T any](bus kafka.MessageBus, topic string) Publisher {
+	return &typedPublisher[T]{bus: bus, topic: topic}
+}
+
+func (p *typedPublisher[T]) WriteMessage(ctx context.Context, topic string, key string, value []byte) error {
+	if topic != p.topic {
+		return p.bus.WriteMessage(ctx, topic, key, value)
+	}
+
+	var event T
+	if err := json.Unmarshal(value, &event); err != nil {
+		return fmt.Errorf("failed to decode outbox event of %s as %T: %w", topic, event, err)
+	}
+	return kafka.Publish(ctx, p.bus, topic, key, event)
+}